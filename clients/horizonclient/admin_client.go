@@ -112,5 +112,45 @@ func (c *AdminClient) SetIngestionAccountFilter(filter hProtocol.AccountFilterCo
 	return c.sendHTTPRequest(req, nil)
 }
 
+func (c *AdminClient) GetIngestionContractFilter() (hProtocol.ContractFilterConfig, error) {
+	var filter hProtocol.ContractFilterConfig
+	err := c.sendGetRequest(c.getIngestionFiltersURL("contract"), &filter)
+	return filter, err
+}
+
+func (c *AdminClient) GetIngestionOperationFilter() (hProtocol.OperationFilterConfig, error) {
+	var filter hProtocol.OperationFilterConfig
+	err := c.sendGetRequest(c.getIngestionFiltersURL("operation"), &filter)
+	return filter, err
+}
+
+func (c *AdminClient) SetIngestionContractFilter(filter hProtocol.ContractFilterConfig) error {
+	buf := bytes.NewBuffer(nil)
+	err := json.NewEncoder(buf).Encode(filter)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPut, c.getIngestionFiltersURL("contract"), buf)
+	if err != nil {
+		return errors.Wrap(err, "error creating HTTP request")
+	}
+	req.Header.Add("Content-Type", "application/json")
+	return c.sendHTTPRequest(req, nil)
+}
+
+func (c *AdminClient) SetIngestionOperationFilter(filter hProtocol.OperationFilterConfig) error {
+	buf := bytes.NewBuffer(nil)
+	err := json.NewEncoder(buf).Encode(filter)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPut, c.getIngestionFiltersURL("operation"), buf)
+	if err != nil {
+		return errors.Wrap(err, "error creating HTTP request")
+	}
+	req.Header.Add("Content-Type", "application/json")
+	return c.sendHTTPRequest(req, nil)
+}
+
 // ensure that the horizon admin client implements AdminClientInterface
 var _ AdminClientInterface = &AdminClient{}
@@ -173,6 +173,10 @@ type AdminClientInterface interface {
 	GetIngestionAssetFilter() (hProtocol.AssetFilterConfig, error)
 	SetIngestionAccountFilter(hProtocol.AccountFilterConfig) error
 	SetIngestionAssetFilter(hProtocol.AssetFilterConfig) error
+	GetIngestionContractFilter() (hProtocol.ContractFilterConfig, error)
+	GetIngestionOperationFilter() (hProtocol.OperationFilterConfig, error)
+	SetIngestionContractFilter(hProtocol.ContractFilterConfig) error
+	SetIngestionOperationFilter(hProtocol.OperationFilterConfig) error
 }
 
 // ClientInterface contains methods implemented by the horizon client
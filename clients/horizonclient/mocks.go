@@ -403,6 +403,26 @@ func (m *MockAdminClient) SetIngestionAssetFilter(resource hProtocol.AssetFilter
 	return a.Error(0)
 }
 
+func (m *MockAdminClient) GetIngestionContractFilter() (hProtocol.ContractFilterConfig, error) {
+	a := m.Called()
+	return a.Get(0).(hProtocol.ContractFilterConfig), a.Error(1)
+}
+
+func (m *MockAdminClient) GetIngestionOperationFilter() (hProtocol.OperationFilterConfig, error) {
+	a := m.Called()
+	return a.Get(0).(hProtocol.OperationFilterConfig), a.Error(1)
+}
+
+func (m *MockAdminClient) SetIngestionContractFilter(resource hProtocol.ContractFilterConfig) error {
+	a := m.Called(resource)
+	return a.Error(0)
+}
+
+func (m *MockAdminClient) SetIngestionOperationFilter(resource hProtocol.OperationFilterConfig) error {
+	a := m.Called(resource)
+	return a.Error(0)
+}
+
 // ensure that the MockClient implements ClientInterface
 var _ ClientInterface = &MockClient{}
 
@@ -0,0 +1,332 @@
+package cdp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stellar/go/ingest/ledgerbackend"
+	"github.com/stellar/go/support/datastore"
+	"github.com/stellar/go/support/log"
+	"github.com/stellar/go/xdr"
+)
+
+// defaultReorderBufferSize is the default number of ledgers each worker may read
+// ahead of the ordered delivery.
+const defaultReorderBufferSize = 100
+
+// Checkpointer persists the progress of ApplyLedgerMetadataParallel, so that a job
+// interrupted by a crash resumes without processing the completed partitions again.
+// The methods may be called concurrently.
+type Checkpointer interface {
+	// CompletedPartitions returns the partitions completed by previous runs of the job.
+	CompletedPartitions(ctx context.Context) ([]ledgerbackend.Range, error)
+	// PartitionCompleted is called once all the ledgers of the partition were
+	// delivered to the callback without error.
+	PartitionCompleted(ctx context.Context, partition ledgerbackend.Range) error
+}
+
+type ParallelConfig struct {
+	// Workers, required, is the number of BufferedStorageBackend instances
+	// processing the partitions of the range concurrently.
+	Workers uint32
+	// PartitionSize, optional, is the number of ledgers of each partition handed to the
+	// workers, rounded up to a multiple of the datastore's ledgers per file.
+	// Defaults to splitting the range evenly between the workers. Partitions must
+	// keep the same size across runs for the Checkpointer to skip the completed ones.
+	PartitionSize uint32
+	// Ordered, optional, delivers the ledgers to the callback in sequence order from a
+	// single goroutine. Otherwise, each worker invokes the callback concurrently with the
+	// ledgers of its partitions, which are delivered in sequence order per partition.
+	Ordered bool
+	// ReorderBufferSize, optional, is the number of ledgers each worker may read ahead of the
+	// ordered delivery, defaults to 100. Only used if Ordered is set.
+	ReorderBufferSize uint32
+	// Checkpointer, optional, records the completed partitions and skips those completed by previous runs.
+	Checkpointer Checkpointer
+}
+
+// splitRange splits the bounded ledgerRange into partitions of partitionSize ledgers,
+// rounded up to a multiple of ledgersPerFile and aligned to the files of the datastore.
+func splitRange(ledgerRange ledgerbackend.Range, partitionSize, ledgersPerFile uint32) []ledgerbackend.Range {
+	schema := datastore.DataStoreSchema{LedgersPerFile: max(1, ledgersPerFile)}
+	partitionSize = max(1, (partitionSize+schema.LedgersPerFile-1)/schema.LedgersPerFile) * schema.LedgersPerFile
+
+	var partitions []ledgerbackend.Range
+	for from := ledgerRange.From(); from <= ledgerRange.To(); {
+		to := min(ledgerRange.To(), schema.GetSequenceNumberStartBoundary(from)+partitionSize-1)
+		partitions = append(partitions, ledgerbackend.BoundedRange(from, to))
+		if to == ledgerRange.To() {
+			break
+		}
+		from = to + 1
+	}
+	return partitions
+}
+
+// ApplyLedgerMetadataParallel - partitions the requested bounded range between
+// parallelConfig.Workers workers, each one with its own instance of BufferedStorageBackend,
+// and emits the ledger metadata of the range by invoking the provided callback once per ledger.
+//
+// The function is blocking, it will only return when the range is completed,
+// the ctx is canceled, or an error occurs.
+//
+// ledgerRange - the requested range, must be bounded.
+//
+// publisherConfig - PublisherConfig. Provide configuration settings for DataStore
+// and BufferedStorageBackend, the latter is used by every worker. If a Registry is set,
+// the metrics of the workers are distinguished with a "worker" label.
+//
+// parallelConfig - ParallelConfig. Number of workers, partition size, delivery order and checkpointing.
+//
+// ctx - the context. Caller uses this to cancel the internal ledger processing,
+// when canceled, the function will return asap with that error.
+//
+// callback - function. Invoked for every LedgerCloseMeta along with the index of the worker
+// which read it. Invoked concurrently by the workers, unless parallelConfig.Ordered is set.
+// If callback invocation returns an error, the processing will stop and return an error asap.
+//
+// return - error, nil will be returned only if the range completed processing with no errors.
+func ApplyLedgerMetadataParallel(ledgerRange ledgerbackend.Range,
+	publisherConfig PublisherConfig,
+	parallelConfig ParallelConfig,
+	ctx context.Context,
+	callback func(worker uint32, lcm xdr.LedgerCloseMeta) error) error {
+
+	logger := publisherConfig.Log
+	if logger == nil {
+		logger = log.DefaultLogger
+	}
+
+	if !ledgerRange.Bounded() || ledgerRange.To() <= ledgerRange.From() {
+		return fmt.Errorf("invalid range, must be bounded with end greater than start")
+	}
+	if parallelConfig.Workers == 0 {
+		return fmt.Errorf("invalid number of workers, must be greater than zero")
+	}
+
+	dataStore, err := datastoreFactory(ctx, publisherConfig.DataStoreConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create datastore: %w", err)
+	}
+
+	schema, err := datastore.LoadSchema(ctx, dataStore, publisherConfig.DataStoreConfig)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve datastore schema: %w", err)
+	}
+
+	ledgerRange = ledgerbackend.BoundedRange(max(2, ledgerRange.From()), ledgerRange.To())
+	partitionSize := parallelConfig.PartitionSize
+	if partitionSize == 0 {
+		count := ledgerRange.To() - ledgerRange.From() + 1
+		partitionSize = (count + parallelConfig.Workers - 1) / parallelConfig.Workers
+	}
+	partitions := splitRange(ledgerRange, partitionSize, schema.LedgersPerFile)
+
+	if parallelConfig.Checkpointer != nil {
+		completed, checkpointErr := parallelConfig.Checkpointer.CompletedPartitions(ctx)
+		if checkpointErr != nil {
+			return fmt.Errorf("failed to retrieve completed partitions: %w", checkpointErr)
+		}
+		partitions = withoutCompletedPartitions(partitions, completed)
+		logger.Infof("Resuming with %d partitions remaining, %d partitions completed", len(partitions), len(completed))
+	}
+
+	backends := make([]ledgerbackend.LedgerBackend, parallelConfig.Workers)
+	defer func() {
+		for _, backend := range backends {
+			if backend != nil {
+				backend.Close()
+			}
+		}
+	}()
+	for i := range backends {
+		var backend ledgerbackend.LedgerBackend
+		backend, err = ledgerbackend.NewBufferedStorageBackend(publisherConfig.BufferedStorageConfig, dataStore, schema)
+		if err != nil {
+			return fmt.Errorf("failed to create buffered storage backend: %w", err)
+		}
+		if publisherConfig.Registry != nil {
+			registerer := prometheus.WrapRegistererWith(prometheus.Labels{"worker": fmt.Sprint(i)}, publisherConfig.Registry)
+			backend = ledgerbackend.WithMetrics(backend, registerer, publisherConfig.RegistryNamespace)
+		}
+		backends[i] = backend
+	}
+
+	runner := &parallelRunner{
+		config:    parallelConfig,
+		logger:    logger,
+		callback:  callback,
+		backends:  backends,
+		remaining: partitions,
+	}
+	return runner.run(ctx)
+}
+
+func withoutCompletedPartitions(partitions, completed []ledgerbackend.Range) []ledgerbackend.Range {
+	done := map[ledgerbackend.Range]bool{}
+	for _, partition := range completed {
+		done[partition] = true
+	}
+	var remaining []ledgerbackend.Range
+	for _, partition := range partitions {
+		if !done[partition] {
+			remaining = append(remaining, partition)
+		}
+	}
+	return remaining
+}
+
+// partitionTask is a partition of the range handed to a worker.
+type partitionTask struct {
+	ledgerRange ledgerbackend.Range
+	// ledgers relays the ledgers of the partition to the ordered delivery, closed once
+	// the partition is completely read. nil if the delivery is unordered.
+	ledgers chan orderedLedger
+}
+
+type orderedLedger struct {
+	worker uint32
+	lcm    xdr.LedgerCloseMeta
+}
+
+type parallelRunner struct {
+	config    ParallelConfig
+	logger    *log.Entry
+	callback  func(worker uint32, lcm xdr.LedgerCloseMeta) error
+	backends  []ledgerbackend.LedgerBackend
+	remaining []ledgerbackend.Range
+}
+
+func (r *parallelRunner) run(ctx context.Context) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	tasks := make([]*partitionTask, len(r.remaining))
+	for i, partition := range r.remaining {
+		tasks[i] = &partitionTask{ledgerRange: partition}
+		if r.config.Ordered {
+			bufferSize := r.config.ReorderBufferSize
+			if bufferSize == 0 {
+				bufferSize = defaultReorderBufferSize
+			}
+			tasks[i].ledgers = make(chan orderedLedger, bufferSize)
+		}
+	}
+
+	// the partitions are handed out in sequence order, so the ordered delivery
+	// is always waiting on a partition which is being read
+	queue := make(chan *partitionTask)
+	go func() {
+		defer close(queue)
+		for _, task := range tasks {
+			select {
+			case queue <- task:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i, backend := range r.backends {
+		wg.Add(1)
+		go func(worker uint32, backend ledgerbackend.LedgerBackend) {
+			defer wg.Done()
+			for task := range queue {
+				if err := r.processPartition(ctx, worker, backend, task); err != nil {
+					cancel(err)
+					return
+				}
+			}
+		}(uint32(i), backend)
+	}
+
+	if r.config.Ordered {
+		if err := r.deliverInOrder(ctx, tasks); err != nil {
+			cancel(err)
+		}
+	}
+	wg.Wait()
+
+	if err := context.Cause(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *parallelRunner) processPartition(ctx context.Context, worker uint32, backend ledgerbackend.LedgerBackend, task *partitionTask) error {
+	partition := task.ledgerRange
+	r.logger.WithField("worker", worker).Infof("Processing partition %s", partition)
+	startTime := time.Now()
+
+	if err := backend.PrepareRange(ctx, partition); err != nil {
+		return fmt.Errorf("error preparing partition %s, %w", partition, err)
+	}
+	for ledgerSeq := partition.From(); ledgerSeq <= partition.To(); ledgerSeq++ {
+		ledgerCloseMeta, err := backend.GetLedger(ctx, ledgerSeq)
+		if err != nil {
+			return fmt.Errorf("error getting ledger, %w", err)
+		}
+
+		if task.ledgers != nil {
+			select {
+			case task.ledgers <- orderedLedger{worker: worker, lcm: ledgerCloseMeta}:
+			case <-ctx.Done():
+				return context.Cause(ctx)
+			}
+			continue
+		}
+		if err = r.callback(worker, ledgerCloseMeta); err != nil {
+			return fmt.Errorf("received an error from callback invocation: %w", err)
+		}
+	}
+
+	if task.ledgers != nil {
+		close(task.ledgers)
+		return nil
+	}
+	r.logger.WithFields(log.F{
+		"worker":   worker,
+		"duration": time.Since(startTime).Seconds(),
+	}).Infof("Processed partition %s", partition)
+	return r.partitionCompleted(ctx, partition)
+}
+
+// deliverInOrder invokes the callback with the ledgers of the partitions in sequence order.
+func (r *parallelRunner) deliverInOrder(ctx context.Context, tasks []*partitionTask) error {
+	for _, task := range tasks {
+		for {
+			var ledger orderedLedger
+			var ok bool
+			select {
+			case ledger, ok = <-task.ledgers:
+			case <-ctx.Done():
+				return context.Cause(ctx)
+			}
+			if !ok {
+				break
+			}
+			if err := r.callback(ledger.worker, ledger.lcm); err != nil {
+				return fmt.Errorf("received an error from callback invocation: %w", err)
+			}
+		}
+		if err := r.partitionCompleted(ctx, task.ledgerRange); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *parallelRunner) partitionCompleted(ctx context.Context, partition ledgerbackend.Range) error {
+	if r.config.Checkpointer == nil {
+		return nil
+	}
+	if err := r.config.Checkpointer.PartitionCompleted(ctx, partition); err != nil {
+		return fmt.Errorf("failed to checkpoint partition %s: %w", partition, err)
+	}
+	return nil
+}
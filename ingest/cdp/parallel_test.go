@@ -0,0 +1,241 @@
+package cdp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/ingest/ledgerbackend"
+	"github.com/stellar/go/support/datastore"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+type memoryCheckpointer struct {
+	lock      sync.Mutex
+	completed []ledgerbackend.Range
+}
+
+func (c *memoryCheckpointer) CompletedPartitions(ctx context.Context) ([]ledgerbackend.Range, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return append([]ledgerbackend.Range{}, c.completed...), nil
+}
+
+func (c *memoryCheckpointer) PartitionCompleted(ctx context.Context, partition ledgerbackend.Range) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.completed = append(c.completed, partition)
+	return nil
+}
+
+// createParallelMockDataStore mocks a datastore with one ledger per file containing
+// the given ledgers, which must each be downloaded once.
+func createParallelMockDataStore(t *testing.T, ledgers ...uint32) *datastore.MockDataStore {
+	mockDataStore := new(datastore.MockDataStore)
+	mockDataStore.On("GetFile", mock.Anything, ".config.json").
+		Return(createManifestReader(t, 64000), nil).Once()
+	mockDataStore.On("ListFilePaths", mock.Anything, "", 0).Return(nil, nil)
+
+	for _, seq := range ledgers {
+		objectName := fmt.Sprintf("FFFFFFFF--0-63999/%08X--%d.xdr.zst", math.MaxUint32-seq, seq)
+		mockDataStore.On("GetFile", mock.Anything, objectName).Return(makeSingleLCMBatch(seq), nil).Once()
+	}
+
+	t.Cleanup(func() {
+		mockDataStore.AssertExpectations(t)
+	})
+	return mockDataStore
+}
+
+func createManifestReader(t *testing.T, filesPerPartition uint32) io.ReadCloser {
+	configJSON, err := json.Marshal(datastore.DatastoreManifest{
+		NetworkPassphrase: "passphrase",
+		Version:           "1.0",
+		Compression:       "xyz",
+		LedgersPerFile:    1,
+		FilesPerPartition: filesPerPartition,
+	})
+	require.NoError(t, err)
+	return io.NopCloser(bytes.NewReader(configJSON))
+}
+
+func ledgerSequences(from, to uint32) []uint32 {
+	var sequences []uint32
+	for seq := from; seq <= to; seq++ {
+		sequences = append(sequences, seq)
+	}
+	return sequences
+}
+
+func useDataStore(dataStore datastore.DataStore) {
+	datastoreFactory = func(_ context.Context, _ datastore.DataStoreConfig) (datastore.DataStore, error) {
+		return dataStore, nil
+	}
+}
+
+func TestSplitRange(t *testing.T) {
+	assert.Equal(t, []ledgerbackend.Range{
+		ledgerbackend.BoundedRange(2, 5),
+		ledgerbackend.BoundedRange(6, 9),
+		ledgerbackend.BoundedRange(10, 10),
+	}, splitRange(ledgerbackend.BoundedRange(2, 10), 4, 1))
+
+	// partitions are rounded up to whole files
+	assert.Equal(t, []ledgerbackend.Range{
+		ledgerbackend.BoundedRange(2, 127),
+		ledgerbackend.BoundedRange(128, 255),
+		ledgerbackend.BoundedRange(256, 300),
+	}, splitRange(ledgerbackend.BoundedRange(2, 300), 100, 64))
+
+	assert.Equal(t, []ledgerbackend.Range{
+		ledgerbackend.BoundedRange(70, 100),
+	}, splitRange(ledgerbackend.BoundedRange(70, 100), 640, 64))
+}
+
+func TestApplyLedgerMetadataParallelUnordered(t *testing.T) {
+	useDataStore(createParallelMockDataStore(t, ledgerSequences(2, 21)...))
+	pubConfig := PublisherConfig{
+		DataStoreConfig:       datastore.DataStoreConfig{},
+		BufferedStorageConfig: DefaultBufferedStorageBackendConfig(1),
+	}
+
+	var lock sync.Mutex
+	var delivered []uint32
+	lastByWorker := map[uint32]uint32{}
+	callback := func(worker uint32, lcm xdr.LedgerCloseMeta) error {
+		lock.Lock()
+		defer lock.Unlock()
+		seq := lcm.LedgerSequence()
+		assert.Less(t, worker, uint32(3))
+		// each worker reads its partitions in sequence order
+		assert.Greater(t, seq, lastByWorker[worker])
+		lastByWorker[worker] = seq
+		delivered = append(delivered, seq)
+		return nil
+	}
+
+	require.NoError(t, ApplyLedgerMetadataParallel(ledgerbackend.BoundedRange(2, 21), pubConfig,
+		ParallelConfig{Workers: 3, PartitionSize: 4}, context.Background(), callback))
+
+	sort.Slice(delivered, func(i, j int) bool { return delivered[i] < delivered[j] })
+	assert.Equal(t, ledgerSequences(2, 21), delivered)
+}
+
+func TestApplyLedgerMetadataParallelOrdered(t *testing.T) {
+	useDataStore(createParallelMockDataStore(t, ledgerSequences(2, 21)...))
+	pubConfig := PublisherConfig{
+		DataStoreConfig:       datastore.DataStoreConfig{},
+		BufferedStorageConfig: DefaultBufferedStorageBackendConfig(1),
+	}
+	checkpointer := &memoryCheckpointer{}
+
+	var delivered []uint32
+	callback := func(worker uint32, lcm xdr.LedgerCloseMeta) error {
+		delivered = append(delivered, lcm.LedgerSequence())
+		return nil
+	}
+
+	require.NoError(t, ApplyLedgerMetadataParallel(ledgerbackend.BoundedRange(2, 21), pubConfig,
+		ParallelConfig{Workers: 3, PartitionSize: 3, Ordered: true, ReorderBufferSize: 2, Checkpointer: checkpointer},
+		context.Background(), callback))
+
+	assert.Equal(t, ledgerSequences(2, 21), delivered)
+	// partitions are checkpointed in order as they are delivered
+	assert.Equal(t, splitRange(ledgerbackend.BoundedRange(2, 21), 3, 1), checkpointer.completed)
+}
+
+func TestApplyLedgerMetadataParallelResume(t *testing.T) {
+	useDataStore(createParallelMockDataStore(t, append(ledgerSequences(6, 9), ledgerSequences(14, 17)...)...))
+	pubConfig := PublisherConfig{
+		DataStoreConfig:       datastore.DataStoreConfig{},
+		BufferedStorageConfig: DefaultBufferedStorageBackendConfig(1),
+	}
+	checkpointer := &memoryCheckpointer{completed: []ledgerbackend.Range{
+		ledgerbackend.BoundedRange(2, 5),
+		ledgerbackend.BoundedRange(10, 13),
+	}}
+
+	var lock sync.Mutex
+	var delivered []uint32
+	callback := func(worker uint32, lcm xdr.LedgerCloseMeta) error {
+		lock.Lock()
+		defer lock.Unlock()
+		delivered = append(delivered, lcm.LedgerSequence())
+		return nil
+	}
+
+	require.NoError(t, ApplyLedgerMetadataParallel(ledgerbackend.BoundedRange(2, 17), pubConfig,
+		ParallelConfig{Workers: 2, PartitionSize: 4, Checkpointer: checkpointer}, context.Background(), callback))
+
+	sort.Slice(delivered, func(i, j int) bool { return delivered[i] < delivered[j] })
+	assert.Equal(t, append(ledgerSequences(6, 9), ledgerSequences(14, 17)...), delivered)
+	assert.ElementsMatch(t, []ledgerbackend.Range{
+		ledgerbackend.BoundedRange(2, 5),
+		ledgerbackend.BoundedRange(10, 13),
+		ledgerbackend.BoundedRange(6, 9),
+		ledgerbackend.BoundedRange(14, 17),
+	}, checkpointer.completed)
+}
+
+func TestApplyLedgerMetadataParallelCallbackError(t *testing.T) {
+	for _, ordered := range []bool{false, true} {
+		mockDataStore := new(datastore.MockDataStore)
+		mockDataStore.On("GetFile", mock.Anything, ".config.json").
+			Return(createManifestReader(t, 64000), nil).Once()
+		mockDataStore.On("ListFilePaths", mock.Anything, "", 0).Return(nil, nil)
+		for seq := uint32(2); seq <= 21; seq++ {
+			objectName := fmt.Sprintf("FFFFFFFF--0-63999/%08X--%d.xdr.zst", math.MaxUint32-seq, seq)
+			mockDataStore.On("GetFile", mock.Anything, objectName).Return(makeSingleLCMBatch(seq), nil).Maybe()
+		}
+		useDataStore(mockDataStore)
+		pubConfig := PublisherConfig{
+			DataStoreConfig:       datastore.DataStoreConfig{},
+			BufferedStorageConfig: DefaultBufferedStorageBackendConfig(1),
+		}
+		checkpointer := &memoryCheckpointer{}
+
+		callback := func(worker uint32, lcm xdr.LedgerCloseMeta) error {
+			if lcm.LedgerSequence() == 3 {
+				return errors.New("uhoh")
+			}
+			return nil
+		}
+
+		err := ApplyLedgerMetadataParallel(ledgerbackend.BoundedRange(2, 21), pubConfig,
+			ParallelConfig{Workers: 2, PartitionSize: 10, Ordered: ordered, Checkpointer: checkpointer},
+			context.Background(), callback)
+		assert.ErrorContains(t, err, "received an error from callback invocation: uhoh")
+		assert.NotContains(t, checkpointer.completed, ledgerbackend.BoundedRange(2, 11))
+		mockDataStore.AssertExpectations(t)
+	}
+}
+
+func TestApplyLedgerMetadataParallelInvalidConfig(t *testing.T) {
+	pubConfig := PublisherConfig{
+		DataStoreConfig:       datastore.DataStoreConfig{},
+		BufferedStorageConfig: DefaultBufferedStorageBackendConfig(1),
+	}
+	callback := func(worker uint32, lcm xdr.LedgerCloseMeta) error {
+		return nil
+	}
+
+	assert.EqualError(t,
+		ApplyLedgerMetadataParallel(ledgerbackend.UnboundedRange(2), pubConfig, ParallelConfig{Workers: 2},
+			context.Background(), callback),
+		"invalid range, must be bounded with end greater than start")
+	assert.EqualError(t,
+		ApplyLedgerMetadataParallel(ledgerbackend.BoundedRange(2, 10), pubConfig, ParallelConfig{},
+			context.Background(), callback),
+		"invalid number of workers, must be greater than zero")
+}
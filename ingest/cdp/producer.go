@@ -7,6 +7,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/stellar/go/ingest"
 	"github.com/stellar/go/ingest/ledgerbackend"
 	"github.com/stellar/go/support/datastore"
 	"github.com/stellar/go/support/log"
@@ -57,6 +58,9 @@ type PublisherConfig struct {
 	DataStoreConfig datastore.DataStoreConfig
 	// Log, optional, if nil uses go default logger
 	Log *log.Entry
+	// CursorStore, optional, include to commit the cursor of every ledger once
+	// the callback processed it, and to resume after the committed cursor
+	CursorStore ingest.CursorStore
 }
 
 // ApplyLedgerMetadata - creates an internal instance
@@ -79,6 +83,13 @@ type PublisherConfig struct {
 // callback - function. Invoked for every LedgerCloseMeta. If callback invocation
 // returns an error, the processing will stop and return an error asap.
 //
+// If publisherConfig.CursorStore is set, the cursor of every ledger is committed
+// to it after the callback returns successfully. When the store holds a cursor
+// within or past the start of the requested range, processing resumes with the
+// ledger following the cursor, which must be built on the cursor ledger,
+// otherwise an error is returned. A bounded range already covered by the cursor
+// returns nil without invoking the callback.
+//
 // return - error, function only returns if requested range is bounded or an error occured.
 // nil will be returned only if bounded range requested and completed processing with no errors.
 // otherwise return will always be an error.
@@ -120,10 +131,18 @@ func ApplyLedgerMetadata(ledgerRange ledgerbackend.Range,
 		return fmt.Errorf("invalid end value for unbounded range, must be zero")
 	}
 
-	from := max(2, ledgerRange.From())
+	resumption, err := ResumeRange(ctx, publisherConfig.CursorStore, ledgerRange, logger)
+	if err != nil {
+		return err
+	}
+	if resumption.Done {
+		return nil
+	}
+	ledgerRange = resumption.Range
+
 	ledgerBackend.PrepareRange(ctx, ledgerRange)
 
-	for ledgerSeq := from; ledgerSeq <= ledgerRange.To() || !ledgerRange.Bounded(); ledgerSeq++ {
+	for ledgerSeq := ledgerRange.From(); ledgerSeq <= ledgerRange.To() || !ledgerRange.Bounded(); ledgerSeq++ {
 		var ledgerCloseMeta xdr.LedgerCloseMeta
 
 		logger.WithField("sequence", ledgerSeq).Info("Requesting ledger from the backend...")
@@ -134,6 +153,10 @@ func ApplyLedgerMetadata(ledgerRange ledgerbackend.Range,
 			return fmt.Errorf("error getting ledger, %w", err)
 		}
 
+		if err = resumption.Verify(ledgerCloseMeta); err != nil {
+			return err
+		}
+
 		log.WithFields(log.F{
 			"sequence": ledgerSeq,
 			"duration": time.Since(startTime).Seconds(),
@@ -143,6 +166,12 @@ func ApplyLedgerMetadata(ledgerRange ledgerbackend.Range,
 		if err != nil {
 			return fmt.Errorf("received an error from callback invocation: %w", err)
 		}
+
+		if publisherConfig.CursorStore != nil {
+			if err = publisherConfig.CursorStore.Commit(ctx, ingest.CursorOf(ledgerCloseMeta)); err != nil {
+				return fmt.Errorf("failed to commit cursor: %w", err)
+			}
+		}
 	}
 	return nil
 }
@@ -8,6 +8,7 @@ import (
 	"io"
 	"math"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/stellar/go/ingest"
 	"github.com/stellar/go/ingest/ledgerbackend"
 	"github.com/stellar/go/support/compressxdr"
 	"github.com/stellar/go/support/datastore"
@@ -165,6 +167,7 @@ func TestBSBProducerFnGetLedgerError(t *testing.T) {
 		Return(io.NopCloser(bytes.NewReader(configManifestJSON(t))), nil).Once()
 
 	mockDataStore.On("GetFile", mock.Anything, "FFFFFFFD--2.xdr.zst").Return(nil, os.ErrNotExist).Once()
+	mockDataStore.On("ListFilePaths", mock.Anything, "FFFFFFFD--2.xdr.", 1).Return(nil, nil).Once()
 	// since buffer is multi-worker async, it may get to this on other worker, but not deterministic,
 	// don't assert on it
 	mockDataStore.On("GetFile", mock.Anything, "FFFFFFFC--3.xdr.zst").Return(makeSingleLCMBatch(3), nil).Maybe()
@@ -217,6 +220,76 @@ func TestBSBProducerFnCallbackError(t *testing.T) {
 		"received an error from callback invocation")
 }
 
+func TestBSBProducerFnResumesFromCursor(t *testing.T) {
+	ctx := context.Background()
+	cursorStore := ingest.NewFileCursorStore(filepath.Join(t.TempDir(), "cursor.json"))
+	require.NoError(t, cursorStore.Commit(ctx, ingest.Cursor{Ledger: 2}))
+
+	pubConfig := PublisherConfig{
+		DataStoreConfig:       datastore.DataStoreConfig{},
+		BufferedStorageConfig: DefaultBufferedStorageBackendConfig(1),
+		CursorStore:           cursorStore,
+	}
+	// ledger 2 was processed before the restart, only ledgers 3 and 4 are fetched
+	mockDataStore := createMockdataStore(t, 3, 4, 64000)
+	datastoreFactory = func(_ context.Context, _ datastore.DataStoreConfig) (datastore.DataStore, error) {
+		return mockDataStore, nil
+	}
+
+	var published []uint32
+	appCallback := func(lcm xdr.LedgerCloseMeta) error {
+		published = append(published, lcm.LedgerSequence())
+		return nil
+	}
+
+	require.NoError(t, ApplyLedgerMetadata(ledgerbackend.BoundedRange(2, 4), pubConfig, ctx, appCallback))
+	assert.Equal(t, []uint32{3, 4}, published)
+
+	cursor, found, err := cursorStore.Load(ctx)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, uint32(4), cursor.Ledger)
+
+	// the range is already covered by the cursor
+	mockDataStore = new(datastore.MockDataStore)
+	mockDataStore.On("GetFile", mock.Anything, ".config.json").
+		Return(io.NopCloser(bytes.NewReader(configManifestJSON(t))), nil).Once()
+	mockDataStore.On("ListFilePaths", mock.Anything, "", 0).Return(nil, nil).Maybe()
+	require.NoError(t, ApplyLedgerMetadata(ledgerbackend.BoundedRange(2, 4), pubConfig, ctx, appCallback))
+	assert.Equal(t, []uint32{3, 4}, published)
+}
+
+func TestBSBProducerFnCursorMismatch(t *testing.T) {
+	ctx := context.Background()
+	cursorStore := ingest.NewFileCursorStore(filepath.Join(t.TempDir(), "cursor.json"))
+	require.NoError(t, cursorStore.Commit(ctx, ingest.Cursor{Ledger: 2, LedgerHash: xdr.Hash{1}}))
+
+	pubConfig := PublisherConfig{
+		DataStoreConfig:       datastore.DataStoreConfig{},
+		BufferedStorageConfig: DefaultBufferedStorageBackendConfig(1),
+		CursorStore:           cursorStore,
+	}
+	mockDataStore := new(datastore.MockDataStore)
+	mockDataStore.On("GetFile", mock.Anything, ".config.json").
+		Return(io.NopCloser(bytes.NewReader(configManifestJSON(t))), nil).Once()
+	mockDataStore.On("GetFile", mock.Anything, "FFFFFFFC--3.xdr.zst").Return(makeSingleLCMBatch(3), nil).Once()
+	mockDataStore.On("GetFile", mock.Anything, "FFFFFFFB--4.xdr.zst").Return(makeSingleLCMBatch(4), nil).Maybe()
+	mockDataStore.On("ListFilePaths", mock.Anything, "", 0).Return(nil, nil)
+	datastoreFactory = func(_ context.Context, _ datastore.DataStoreConfig) (datastore.DataStore, error) {
+		return mockDataStore, nil
+	}
+
+	appCallback := func(lcm xdr.LedgerCloseMeta) error {
+		assert.Fail(t, "callback invoked for a ledger not following the cursor")
+		return nil
+	}
+
+	assert.ErrorContains(t,
+		ApplyLedgerMetadata(ledgerbackend.BoundedRange(2, 4), pubConfig, ctx, appCallback),
+		"ledger does not follow the committed cursor")
+	mockDataStore.AssertExpectations(t)
+}
+
 func createMockdataStore(t *testing.T, start, end, partitionSize uint32) *datastore.MockDataStore {
 	mockDataStore := new(datastore.MockDataStore)
 
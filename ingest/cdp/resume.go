@@ -0,0 +1,64 @@
+package cdp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stellar/go/ingest"
+	"github.com/stellar/go/ingest/ledgerbackend"
+	"github.com/stellar/go/support/log"
+	"github.com/stellar/go/xdr"
+)
+
+// Resumption is the part of a ledger range left to process after the cursor
+// committed to a CursorStore.
+type Resumption struct {
+	// Range is the range left to process, starting at ledger 2 at the earliest.
+	Range ledgerbackend.Range
+	// Done is true if the committed cursor is at or past the end of a bounded range.
+	Done bool
+
+	cursor  ingest.Cursor
+	resumed bool
+}
+
+// ResumeRange returns the part of ledgerRange following the cursor committed to
+// cursorStore, if the cursor is within or past the start of the range. cursorStore
+// may be nil, in which case the whole range is left to process.
+func ResumeRange(ctx context.Context, cursorStore ingest.CursorStore, ledgerRange ledgerbackend.Range, logger *log.Entry) (Resumption, error) {
+	from := max(2, ledgerRange.From())
+	resumption := Resumption{}
+	if cursorStore != nil {
+		cursor, found, err := cursorStore.Load(ctx)
+		if err != nil {
+			return Resumption{}, fmt.Errorf("failed to load cursor: %w", err)
+		}
+		if found && cursor.Ledger >= from {
+			from = cursor.Ledger + 1
+			resumption.cursor = cursor
+			resumption.resumed = true
+			logger.WithField("cursor", cursor.Ledger).Info("Resuming after committed cursor")
+		}
+	}
+
+	if !ledgerRange.Bounded() {
+		resumption.Range = ledgerbackend.UnboundedRange(from)
+	} else if from > ledgerRange.To() {
+		resumption.Done = true
+	} else {
+		resumption.Range = ledgerbackend.BoundedRange(from, ledgerRange.To())
+	}
+	return resumption, nil
+}
+
+// Verify returns an error if ledger is the first ledger of Range and is not built
+// on the committed cursor ledger.
+func (r Resumption) Verify(ledger xdr.LedgerCloseMeta) error {
+	if !r.resumed || ledger.LedgerSequence() != r.Range.From() {
+		return nil
+	}
+	if err := r.cursor.Verify(ledger); err != nil {
+		return fmt.Errorf("ledger does not follow the committed cursor: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package cdp
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/ingest"
+	"github.com/stellar/go/ingest/ledgerbackend"
+	"github.com/stellar/go/support/log"
+	"github.com/stellar/go/xdr"
+)
+
+func TestResumeRange(t *testing.T) {
+	ctx := context.Background()
+	cursorStore := ingest.NewFileCursorStore(filepath.Join(t.TempDir(), "cursor.json"))
+
+	resumption, err := ResumeRange(ctx, nil, ledgerbackend.BoundedRange(1, 4), log.DefaultLogger)
+	require.NoError(t, err)
+	assert.Equal(t, Resumption{Range: ledgerbackend.BoundedRange(2, 4)}, resumption)
+
+	resumption, err = ResumeRange(ctx, cursorStore, ledgerbackend.UnboundedRange(3), log.DefaultLogger)
+	require.NoError(t, err)
+	assert.Equal(t, ledgerbackend.UnboundedRange(3), resumption.Range)
+
+	ledger := xdr.LedgerCloseMeta{
+		V: 0,
+		V0: &xdr.LedgerCloseMetaV0{
+			LedgerHeader: xdr.LedgerHeaderHistoryEntry{
+				Header: xdr.LedgerHeader{LedgerSeq: 4, PreviousLedgerHash: xdr.Hash{1}},
+			},
+		},
+	}
+	require.NoError(t, cursorStore.Commit(ctx, ingest.Cursor{Ledger: 3, LedgerHash: xdr.Hash{1}}))
+	for _, testCase := range []struct {
+		ledgerRange ledgerbackend.Range
+		expected    ledgerbackend.Range
+		done        bool
+	}{
+		// the cursor is before the range
+		{ledgerbackend.BoundedRange(4, 6), ledgerbackend.BoundedRange(4, 6), false},
+		{ledgerbackend.BoundedRange(2, 6), ledgerbackend.BoundedRange(4, 6), false},
+		{ledgerbackend.UnboundedRange(2), ledgerbackend.UnboundedRange(4), false},
+		{ledgerbackend.BoundedRange(2, 3), ledgerbackend.Range{}, true},
+	} {
+		resumption, err = ResumeRange(ctx, cursorStore, testCase.ledgerRange, log.DefaultLogger)
+		require.NoError(t, err)
+		assert.Equal(t, testCase.expected, resumption.Range, testCase.ledgerRange)
+		assert.Equal(t, testCase.done, resumption.Done, testCase.ledgerRange)
+	}
+
+	resumption, err = ResumeRange(ctx, cursorStore, ledgerbackend.BoundedRange(2, 6), log.DefaultLogger)
+	require.NoError(t, err)
+	require.NoError(t, resumption.Verify(ledger))
+	ledger.V0.LedgerHeader.Header.PreviousLedgerHash = xdr.Hash{2}
+	assert.ErrorContains(t, resumption.Verify(ledger), "ledger does not follow the committed cursor")
+}
@@ -0,0 +1,248 @@
+package ingest
+
+import (
+	"encoding/binary"
+
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/support/collections/set"
+	"github.com/stellar/go/xdr"
+)
+
+// ChangeFilter selects the changes returned by a ChangeReader. A change is
+// selected if it matches every criteria configured, and a criteria configured
+// with several values matches any of them. The zero value selects all changes.
+type ChangeFilter struct {
+	entryTypes   set.Set[xdr.LedgerEntryType]
+	accounts     set.Set[string]
+	contracts    set.Set[string]
+	assetIssuers set.Set[string]
+	predicates   []func(Change) bool
+}
+
+// ChangeFilterOption configures a ChangeFilter.
+type ChangeFilterOption func(*ChangeFilter)
+
+// NewChangeFilter returns a ChangeFilter configured with the given options,
+// or nil if there are none.
+func NewChangeFilter(options ...ChangeFilterOption) *ChangeFilter {
+	if len(options) == 0 {
+		return nil
+	}
+	filter := &ChangeFilter{}
+	for _, option := range options {
+		option(filter)
+	}
+	return filter
+}
+
+// WithEntryTypes selects the changes of ledger entries of the given types.
+// CheckpointChangeReader skips the bucket entries of other types before
+// decoding them.
+func WithEntryTypes(entryTypes ...xdr.LedgerEntryType) ChangeFilterOption {
+	return func(filter *ChangeFilter) {
+		if filter.entryTypes == nil {
+			filter.entryTypes = set.Set[xdr.LedgerEntryType]{}
+		}
+		for _, entryType := range entryTypes {
+			filter.entryTypes.Add(entryType)
+		}
+	}
+}
+
+// WithAccounts selects the changes of ledger entries owned by the given
+// accounts: their account, trust lines, offers and data entries, and the
+// claimable balances they can claim.
+func WithAccounts(accountIDs ...string) ChangeFilterOption {
+	return func(filter *ChangeFilter) {
+		if filter.accounts == nil {
+			filter.accounts = set.Set[string]{}
+		}
+		for _, accountID := range accountIDs {
+			filter.accounts.Add(accountID)
+		}
+	}
+}
+
+// WithContracts selects the changes of the contract data entries of the given
+// contracts, identified by their strkey encoded IDs (C...).
+func WithContracts(contractIDs ...string) ChangeFilterOption {
+	return func(filter *ChangeFilter) {
+		if filter.contracts == nil {
+			filter.contracts = set.Set[string]{}
+		}
+		for _, contractID := range contractIDs {
+			filter.contracts.Add(contractID)
+		}
+	}
+}
+
+// WithAssetIssuers selects the changes of ledger entries holding or exchanging
+// assets issued by the given accounts: trust lines, offers, claimable balances
+// and liquidity pools.
+func WithAssetIssuers(issuers ...string) ChangeFilterOption {
+	return func(filter *ChangeFilter) {
+		if filter.assetIssuers == nil {
+			filter.assetIssuers = set.Set[string]{}
+		}
+		for _, issuer := range issuers {
+			filter.assetIssuers.Add(issuer)
+		}
+	}
+}
+
+// WithPredicate selects the changes for which predicate returns true.
+func WithPredicate(predicate func(Change) bool) ChangeFilterOption {
+	return func(filter *ChangeFilter) {
+		filter.predicates = append(filter.predicates, predicate)
+	}
+}
+
+// Matches returns true if the change is selected by the filter. A nil filter
+// selects all changes.
+func (f *ChangeFilter) Matches(change Change) bool {
+	if f == nil {
+		return true
+	}
+	if f.entryTypes != nil && !f.entryTypes.Contains(change.Type) {
+		return false
+	}
+
+	entry := change.Post
+	if entry == nil {
+		entry = change.Pre
+	}
+	if f.accounts != nil && (entry == nil || !f.matchesAccount(entry.Data)) {
+		return false
+	}
+	if f.contracts != nil && (entry == nil || !f.matchesContract(entry.Data)) {
+		return false
+	}
+	if f.assetIssuers != nil && (entry == nil || !f.matchesAssetIssuer(entry.Data)) {
+		return false
+	}
+
+	for _, predicate := range f.predicates {
+		if !predicate(change) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesEntryType returns true if the filter may select the changes of
+// ledger entries of the given type.
+func (f *ChangeFilter) matchesEntryType(entryType xdr.LedgerEntryType) bool {
+	return f == nil || f.entryTypes == nil || f.entryTypes.Contains(entryType)
+}
+
+// matchesBucketEntry returns true if the filter may select the bucket entry
+// encoded in frame, only considering the type of its ledger entry so the
+// frame doesn't need to be decoded.
+func (f *ChangeFilter) matchesBucketEntry(frame []byte) bool {
+	if len(frame) < 4 {
+		return true
+	}
+	// the offset of the ledger entry type in the XDR encoding of the bucket entry
+	var offset int
+	switch xdr.BucketEntryType(int32(binary.BigEndian.Uint32(frame))) {
+	case xdr.BucketEntryTypeLiveentry, xdr.BucketEntryTypeInitentry:
+		// LedgerEntry.LastModifiedLedgerSeq precedes LedgerEntry.Data
+		offset = 8
+	case xdr.BucketEntryTypeDeadentry:
+		offset = 4
+	default:
+		return true
+	}
+	if len(frame) < offset+4 {
+		return true
+	}
+	return f.matchesEntryType(xdr.LedgerEntryType(int32(binary.BigEndian.Uint32(frame[offset:]))))
+}
+
+func (f *ChangeFilter) matchesAccount(data xdr.LedgerEntryData) bool {
+	switch data.Type {
+	case xdr.LedgerEntryTypeAccount:
+		return f.accounts.Contains(data.Account.AccountId.Address())
+	case xdr.LedgerEntryTypeTrustline:
+		return f.accounts.Contains(data.TrustLine.AccountId.Address())
+	case xdr.LedgerEntryTypeOffer:
+		return f.accounts.Contains(data.Offer.SellerId.Address())
+	case xdr.LedgerEntryTypeData:
+		return f.accounts.Contains(data.Data.AccountId.Address())
+	case xdr.LedgerEntryTypeClaimableBalance:
+		for _, claimant := range data.ClaimableBalance.Claimants {
+			if f.accounts.Contains(claimant.MustV0().Destination.Address()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (f *ChangeFilter) matchesContract(data xdr.LedgerEntryData) bool {
+	if data.Type != xdr.LedgerEntryTypeContractData {
+		return false
+	}
+	contractID, ok := data.ContractData.Contract.GetContractId()
+	if !ok {
+		return false
+	}
+	address, err := strkey.Encode(strkey.VersionByteContract, contractID[:])
+	return err == nil && f.contracts.Contains(address)
+}
+
+func (f *ChangeFilter) matchesAssetIssuer(data xdr.LedgerEntryData) bool {
+	var assets []xdr.Asset
+	switch data.Type {
+	case xdr.LedgerEntryTypeTrustline:
+		if data.TrustLine.Asset.Type == xdr.AssetTypeAssetTypePoolShare {
+			return false
+		}
+		assets = append(assets, data.TrustLine.Asset.ToAsset())
+	case xdr.LedgerEntryTypeOffer:
+		assets = append(assets, data.Offer.Selling, data.Offer.Buying)
+	case xdr.LedgerEntryTypeClaimableBalance:
+		assets = append(assets, data.ClaimableBalance.Asset)
+	case xdr.LedgerEntryTypeLiquidityPool:
+		params := data.LiquidityPool.Body.MustConstantProduct().Params
+		assets = append(assets, params.AssetA, params.AssetB)
+	}
+	for _, asset := range assets {
+		var assetType, code, issuer string
+		if err := asset.Extract(&assetType, &code, &issuer); err == nil && f.assetIssuers.Contains(issuer) {
+			return true
+		}
+	}
+	return false
+}
+
+type filteredChangeReader struct {
+	input  ChangeReader
+	filter *ChangeFilter
+}
+
+func (r *filteredChangeReader) Read() (Change, error) {
+	for {
+		change, err := r.input.Read()
+		if err != nil {
+			return change, err
+		}
+		if r.filter.Matches(change) {
+			return change, nil
+		}
+	}
+}
+
+func (r *filteredChangeReader) Close() error {
+	return r.input.Close()
+}
+
+// NewFilteredChangeReader wraps a given ChangeReader and returns a ChangeReader
+// which only returns the Changes selected by the filter options. Prefer passing
+// the options to the constructor of the input reader, which applies them earlier.
+func NewFilteredChangeReader(input ChangeReader, options ...ChangeFilterOption) ChangeReader {
+	return &filteredChangeReader{
+		input:  input,
+		filter: NewChangeFilter(options...),
+	}
+}
@@ -0,0 +1,248 @@
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/historyarchive"
+	"github.com/stellar/go/ingest/ledgerbackend"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/xdr"
+)
+
+const (
+	filterAccount = "GBXGQJWVLWOYHFLVTKWV5FGHA3LNYY2JQKM7OAJAUEQFU6LPCSEFVXON"
+	filterIssuer  = "GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU"
+	otherAccount  = "GC3C4AKRBQLHOJ45U4XG35ESVWRDECWO5XLDGYADO6DPR3L7KIDVUMML"
+)
+
+func trustLineLedgerEntry(account, issuer string) xdr.LedgerEntry {
+	return xdr.LedgerEntry{
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeTrustline,
+			TrustLine: &xdr.TrustLineEntry{
+				AccountId: xdr.MustAddress(account),
+				Asset:     xdr.MustNewCreditAsset("USD", issuer).ToTrustLineAsset(),
+			},
+		},
+	}
+}
+
+func offerLedgerEntry(seller, issuer string) xdr.LedgerEntry {
+	return xdr.LedgerEntry{
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeOffer,
+			Offer: &xdr.OfferEntry{
+				SellerId: xdr.MustAddress(seller),
+				OfferId:  1,
+				Selling:  xdr.MustNewNativeAsset(),
+				Buying:   xdr.MustNewCreditAsset("USD", issuer),
+			},
+		},
+	}
+}
+
+func readAllChanges(t *testing.T, reader ChangeReader) []Change {
+	var changes []Change
+	for {
+		change, err := reader.Read()
+		if err == io.EOF {
+			return changes
+		}
+		require.NoError(t, err)
+		changes = append(changes, change)
+	}
+}
+
+func createdChangeOf(entry xdr.LedgerEntry) Change {
+	return Change{Type: entry.Data.Type, Post: &entry}
+}
+
+func TestChangeFilterMatches(t *testing.T) {
+	contract := xdr.ContractId{1, 2, 3}
+	contractAddress, err := strkey.Encode(strkey.VersionByteContract, contract[:])
+	require.NoError(t, err)
+
+	account := createdChangeOf(accountLedgerEntry(filterAccount, 100))
+	removedAccount := Change{Type: xdr.LedgerEntryTypeAccount, Pre: account.Post}
+	trustLine := createdChangeOf(trustLineLedgerEntry(otherAccount, filterIssuer))
+	offer := createdChangeOf(offerLedgerEntry(filterAccount, filterIssuer))
+	contractData := createdChangeOf(contractDataEntry(contract, "balance", xdr.ContractDataDurabilityPersistent))
+	otherContractData := createdChangeOf(contractDataEntry(xdr.ContractId{4}, "balance", xdr.ContractDataDurabilityPersistent))
+	changes := []Change{account, removedAccount, trustLine, offer, contractData, otherContractData}
+
+	for _, testCase := range []struct {
+		name     string
+		options  []ChangeFilterOption
+		expected []Change
+	}{
+		{"no options", nil, changes},
+		{"entry types", []ChangeFilterOption{
+			WithEntryTypes(xdr.LedgerEntryTypeAccount),
+			WithEntryTypes(xdr.LedgerEntryTypeOffer),
+		}, []Change{account, removedAccount, offer}},
+		{"accounts", []ChangeFilterOption{WithAccounts(filterAccount)}, []Change{account, removedAccount, offer}},
+		{"contracts", []ChangeFilterOption{WithContracts(contractAddress)}, []Change{contractData}},
+		{"asset issuers", []ChangeFilterOption{WithAssetIssuers(filterIssuer)}, []Change{trustLine, offer}},
+		{"predicate", []ChangeFilterOption{WithPredicate(func(change Change) bool {
+			return change.Post == nil
+		})}, []Change{removedAccount}},
+		{"combined", []ChangeFilterOption{
+			WithAccounts(filterAccount, otherAccount),
+			WithAssetIssuers(filterIssuer),
+			WithEntryTypes(xdr.LedgerEntryTypeTrustline),
+		}, []Change{trustLine}},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			filter := NewChangeFilter(testCase.options...)
+			var matched []Change
+			for _, change := range changes {
+				if filter.Matches(change) {
+					matched = append(matched, change)
+				}
+			}
+			assert.Equal(t, testCase.expected, matched)
+		})
+	}
+}
+
+func TestChangeFilterMatchesBucketEntry(t *testing.T) {
+	filter := NewChangeFilter(WithEntryTypes(xdr.LedgerEntryTypeTrustline))
+	trustLine := trustLineLedgerEntry(filterAccount, filterIssuer)
+	trustLineKey := mustLedgerKey(t, trustLine)
+	account := accountLedgerEntry(filterAccount, 100)
+	accountKey := mustLedgerKey(t, account)
+
+	for _, testCase := range []struct {
+		entry    xdr.BucketEntry
+		expected bool
+	}{
+		{liveBucketEntry(trustLine), true},
+		{xdr.BucketEntry{Type: xdr.BucketEntryTypeInitentry, LiveEntry: &trustLine}, true},
+		{xdr.BucketEntry{Type: xdr.BucketEntryTypeDeadentry, DeadEntry: &trustLineKey}, true},
+		{liveBucketEntry(account), false},
+		{xdr.BucketEntry{Type: xdr.BucketEntryTypeDeadentry, DeadEntry: &accountKey}, false},
+		{xdr.BucketEntry{Type: xdr.BucketEntryTypeMetaentry, MetaEntry: &xdr.BucketMetadata{LedgerVersion: 23}}, true},
+	} {
+		frame, err := testCase.entry.MarshalBinary()
+		require.NoError(t, err)
+		assert.Equal(t, testCase.expected, filter.matchesBucketEntry(frame), testCase.entry.Type.String())
+	}
+
+	var nilFilter *ChangeFilter
+	frame, err := liveBucketEntry(account).MarshalBinary()
+	require.NoError(t, err)
+	assert.True(t, nilFilter.matchesBucketEntry(frame))
+}
+
+func TestCheckpointChangeReaderFilter(t *testing.T) {
+	newBucket, newHash := bucket(t,
+		liveBucketEntry(accountLedgerEntry(filterAccount, 200)),
+		liveBucketEntry(trustLineLedgerEntry(filterAccount, filterIssuer)),
+	)
+	oldBucket, oldHash := bucket(t,
+		liveBucketEntry(accountLedgerEntry(filterAccount, 100)),
+		liveBucketEntry(accountLedgerEntry(otherAccount, 100)),
+		liveBucketEntry(trustLineLedgerEntry(otherAccount, filterIssuer)),
+		liveBucketEntry(offerLedgerEntry(otherAccount, filterIssuer)),
+	)
+	has := historyarchive.HistoryArchiveState{}
+	for i := range has.CurrentBuckets {
+		has.CurrentBuckets[i].Curr, has.CurrentBuckets[i].Snap = zeroBucketHash, zeroBucketHash
+	}
+	has.CurrentBuckets[0].Curr, has.CurrentBuckets[0].Snap = newHash.String(), oldHash.String()
+
+	read := func(options ...ChangeFilterOption) []Change {
+		archive := &historyarchive.MockArchive{}
+		defer archive.AssertExpectations(t)
+		archive.On("GetCheckpointManager").
+			Return(historyarchive.NewCheckpointManager(historyarchive.DefaultCheckpointFrequency))
+		archive.On("GetCheckpointHAS", uint32(63)).Return(has, nil).Once()
+		for hash, contents := range map[historyarchive.Hash][]byte{newHash: newBucket, oldHash: oldBucket} {
+			archive.On("BucketExists", hash).Return(true, nil).Once()
+			archive.On("BucketSize", hash).Return(int64(len(contents)), nil).Once()
+			archive.On("GetXdrStreamForHash", hash).
+				Return(xdr.NewStream(io.NopCloser(bytes.NewReader(contents))), nil).Once()
+		}
+
+		reader, err := NewCheckpointChangeReader(context.Background(), archive, 63, options...)
+		require.NoError(t, err)
+		defer reader.Close()
+		return readAllChanges(t, reader)
+	}
+
+	assert.Equal(t, []Change{
+		createdChangeOf(accountLedgerEntry(filterAccount, 200)),
+		createdChangeOf(accountLedgerEntry(otherAccount, 100)),
+	}, read(WithEntryTypes(xdr.LedgerEntryTypeAccount)))
+
+	assert.Equal(t, []Change{
+		createdChangeOf(trustLineLedgerEntry(filterAccount, filterIssuer)),
+		createdChangeOf(trustLineLedgerEntry(otherAccount, filterIssuer)),
+		createdChangeOf(offerLedgerEntry(otherAccount, filterIssuer)),
+	}, read(WithAssetIssuers(filterIssuer)))
+
+	// the older version of an entry isn't returned when its latest version is filtered out
+	assert.Equal(t, []Change{
+		createdChangeOf(accountLedgerEntry(otherAccount, 100)),
+	}, read(WithEntryTypes(xdr.LedgerEntryTypeAccount), WithPredicate(func(change Change) bool {
+		return change.Post.Data.MustAccount().Balance == 100
+	})))
+}
+
+func TestLedgerChangeReaderFilter(t *testing.T) {
+	ledger := emptyLedger(64)
+	ledger.V0.UpgradesProcessing = []xdr.UpgradeEntryMeta{
+		{Changes: xdr.LedgerEntryChanges{buildChange(otherAccount, 1), buildChange(filterAccount, 2)}},
+		{Changes: xdr.LedgerEntryChanges{buildChange(otherAccount, 3)}},
+		{Changes: xdr.LedgerEntryChanges{buildChange(filterAccount, 4)}},
+	}
+
+	ctx := context.Background()
+	backend := &ledgerbackend.MockDatabaseBackend{}
+	backend.On("GetLedger", ctx, uint32(64)).Return(ledger, nil).Once()
+	reader, err := NewLedgerChangeReader(ctx, backend, network.TestNetworkPassphrase, 64, WithAccounts(filterAccount))
+	require.NoError(t, err)
+	backend.AssertExpectations(t)
+	changes := readAllChanges(t, reader)
+	require.Len(t, changes, 2)
+	isBalance(filterAccount, 2)(t, 0, changes[0])
+	isBalance(filterAccount, 4)(t, 1, changes[1])
+
+	reader, err = NewLedgerChangeReaderFromLedgerCloseMeta(network.TestNetworkPassphrase, ledger,
+		WithPredicate(func(change Change) bool {
+			return change.Post.Data.MustAccount().Balance > 2
+		}))
+	require.NoError(t, err)
+	changes = readAllChanges(t, reader)
+	require.Len(t, changes, 2)
+	isBalance(otherAccount, 3)(t, 0, changes[0])
+	isBalance(filterAccount, 4)(t, 1, changes[1])
+}
+
+func TestFilteredChangeReader(t *testing.T) {
+	account := createdChangeOf(accountLedgerEntry(filterAccount, 100))
+	otherAccountChange := createdChangeOf(accountLedgerEntry(otherAccount, 100))
+
+	input := &MockChangeReader{}
+	input.On("Read").Return(otherAccountChange, nil).Once()
+	input.On("Read").Return(account, nil).Once()
+	input.On("Read").Return(otherAccountChange, nil).Once()
+	input.On("Read").Return(Change{}, io.EOF).Once()
+	input.On("Close").Return(nil).Once()
+
+	reader := NewFilteredChangeReader(input, WithAccounts(filterAccount))
+	change, err := reader.Read()
+	require.NoError(t, err)
+	assert.Equal(t, account, change)
+	_, err = reader.Read()
+	assert.Equal(t, io.EOF, err)
+	require.NoError(t, reader.Close())
+	input.AssertExpectations(t)
+}
@@ -39,6 +39,7 @@ type CheckpointChangeReader struct {
 	totalSize      int64
 
 	encodingBuffer *xdr.EncodingBuffer
+	filter         *ChangeFilter
 
 	// This should be set to true in tests only
 	disableBucketListHashValidation bool
@@ -61,10 +62,15 @@ const (
 // `historyarchive.ConnectOptions.CheckpointFrequency` for configuring this),
 // its next sequence number would have to be a multiple of 64, e.g.
 // sequence=100031 is a checkpoint ledger, since: (100031+1) mod 64 == 0
+//
+// The options select the changes returned by the reader. The bucket entries of
+// ledger entry types not selected by WithEntryTypes are skipped without being
+// decoded.
 func NewCheckpointChangeReader(
 	ctx context.Context,
 	archive historyarchive.ArchiveInterface,
 	sequence uint32,
+	options ...ChangeFilterOption,
 ) (*CheckpointChangeReader, error) {
 	manager := archive.GetCheckpointManager()
 
@@ -95,6 +101,7 @@ func NewCheckpointChangeReader(
 		closeOnce:         sync.Once{},
 		done:              make(chan bool),
 		encodingBuffer:    xdr.NewEncodingBuffer(),
+		filter:            NewChangeFilter(options...),
 		sleep:             time.Sleep,
 	}, nil
 }
@@ -225,13 +232,29 @@ func (r *CheckpointChangeReader) readBucketEntry(stream *xdr.Stream, hash histor
 	currentPosition := stream.BytesRead()
 	gzipCurrentPosition := stream.CompressedBytesRead()
 
+	var accept func([]byte) bool
+	if r.filter != nil {
+		accept = r.filter.matchesBucketEntry
+	}
+
 	for attempts := 0; ; attempts++ {
 		if r.ctx.Err() != nil {
 			err = r.ctx.Err()
 			break
 		}
 		if err == nil {
-			err = stream.ReadOne(&entry)
+			var decoded bool
+			decoded, err = stream.ReadOneIf(&entry, accept)
+			if err == nil && !decoded {
+				// the entry was filtered out, read the next one
+				r.readBytesMutex.Lock()
+				r.totalRead += stream.CompressedBytesRead() - gzipCurrentPosition
+				r.readBytesMutex.Unlock()
+				currentPosition = stream.BytesRead()
+				gzipCurrentPosition = stream.CompressedBytesRead()
+				attempts = -1
+				continue
+			}
 			if err == nil || err == io.EOF {
 				r.readBytesMutex.Lock()
 				r.totalRead += stream.CompressedBytesRead() - gzipCurrentPosition
@@ -394,11 +417,13 @@ func (r *CheckpointChangeReader) streamBucketContents(hash historyarchive.Hash,
 			if !r.visitedLedgerKeys.Contains(h) {
 				// Return LEDGER_ENTRY_STATE changes only now.
 				liveEntry := entry.MustLiveEntry()
-				entryChange := xdr.LedgerEntryChange{
-					Type:  xdr.LedgerEntryChangeTypeLedgerEntryState,
-					State: &liveEntry,
+				if r.filter.Matches(Change{Type: liveEntry.Data.Type, Post: &liveEntry}) {
+					entryChange := xdr.LedgerEntryChange{
+						Type:  xdr.LedgerEntryChangeTypeLedgerEntryState,
+						State: &liveEntry,
+					}
+					r.readChan <- readResult{entryChange, nil}
 				}
-				r.readChan <- readResult{entryChange, nil}
 
 				// We don't update `visitedLedgerKeys` for INITENTRY because CAP-20 says:
 				// > a bucket entry marked INITENTRY implies that either no entry
@@ -0,0 +1,174 @@
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	stderrors "errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/stellar/go/support/datastore"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// Cursor identifies the last ledger processed by an ingestion pipeline.
+type Cursor struct {
+	// Ledger is the sequence of the ledger.
+	Ledger uint32
+	// LedgerHash is the hash of the ledger, which lets the pipeline check that
+	// the ledgers it resumes with are built on the ledger it stopped at.
+	LedgerHash xdr.Hash
+}
+
+type cursorJSON struct {
+	Ledger     uint32 `json:"ledger"`
+	LedgerHash string `json:"ledger_hash"`
+}
+
+func (c Cursor) MarshalJSON() ([]byte, error) {
+	return json.Marshal(cursorJSON{Ledger: c.Ledger, LedgerHash: c.LedgerHash.HexString()})
+}
+
+func (c *Cursor) UnmarshalJSON(data []byte) error {
+	var decoded cursorJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	hash, err := hex.DecodeString(decoded.LedgerHash)
+	if err != nil || len(hash) != len(c.LedgerHash) {
+		return errors.Errorf("invalid ledger hash %q", decoded.LedgerHash)
+	}
+	c.Ledger = decoded.Ledger
+	copy(c.LedgerHash[:], hash)
+	return nil
+}
+
+// CursorOf returns the Cursor of a processed ledger.
+func CursorOf(ledger xdr.LedgerCloseMeta) Cursor {
+	return Cursor{Ledger: ledger.LedgerSequence(), LedgerHash: ledger.LedgerHash()}
+}
+
+// Verify returns an error if ledger doesn't follow the ledger of the cursor.
+func (c Cursor) Verify(ledger xdr.LedgerCloseMeta) error {
+	if ledger.LedgerSequence() != c.Ledger+1 {
+		return errors.Errorf("ledger %d doesn't follow the cursor ledger %d", ledger.LedgerSequence(), c.Ledger)
+	}
+	if previousHash := ledger.PreviousLedgerHash(); previousHash != c.LedgerHash {
+		return errors.Errorf(
+			"previous ledger hash %s of ledger %d doesn't match the hash %s of the cursor ledger",
+			previousHash.HexString(), ledger.LedgerSequence(), c.LedgerHash.HexString(),
+		)
+	}
+	return nil
+}
+
+// CursorStore persists the Cursor of an ingestion pipeline, so that it resumes
+// after a restart with the ledger following the last one it processed.
+// Pipelines commit the cursor of each ledger once they are done processing it.
+type CursorStore interface {
+	// Load returns the last committed cursor, and false if none was committed.
+	Load(ctx context.Context) (Cursor, bool, error)
+	// Commit atomically replaces the committed cursor.
+	Commit(ctx context.Context, cursor Cursor) error
+}
+
+// FileCursorStore is a CursorStore which keeps the cursor in a local file.
+type FileCursorStore struct {
+	path string
+}
+
+// NewFileCursorStore returns a FileCursorStore keeping the cursor in the file
+// at path. The file is created on the first commit.
+func NewFileCursorStore(path string) *FileCursorStore {
+	return &FileCursorStore{path: path}
+}
+
+func (s *FileCursorStore) Load(ctx context.Context) (Cursor, bool, error) {
+	contents, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return Cursor{}, false, nil
+	} else if err != nil {
+		return Cursor{}, false, errors.Wrapf(err, "could not read cursor file %s", s.path)
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(contents, &cursor); err != nil {
+		return Cursor{}, false, errors.Wrapf(err, "could not decode cursor file %s", s.path)
+	}
+	return cursor, true, nil
+}
+
+// Commit writes the cursor to a temporary file which is then renamed over the
+// cursor file, so the cursor file always holds a complete cursor.
+func (s *FileCursorStore) Commit(ctx context.Context, cursor Cursor) error {
+	contents, err := json.Marshal(cursor)
+	if err != nil {
+		return errors.Wrap(err, "could not encode cursor")
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return errors.Wrap(err, "could not create temporary cursor file")
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(contents); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "could not write temporary cursor file")
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "could not sync temporary cursor file")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "could not close temporary cursor file")
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return errors.Wrapf(err, "could not replace cursor file %s", s.path)
+	}
+	return nil
+}
+
+// DataStoreCursorStore is a CursorStore which keeps the cursor in an object of
+// a DataStore. Objects are replaced atomically by the storage services.
+type DataStoreCursorStore struct {
+	store datastore.DataStore
+	path  string
+}
+
+// NewDataStoreCursorStore returns a DataStoreCursorStore keeping the cursor in
+// the object at path.
+func NewDataStoreCursorStore(store datastore.DataStore, path string) *DataStoreCursorStore {
+	return &DataStoreCursorStore{store: store, path: path}
+}
+
+func (s *DataStoreCursorStore) Load(ctx context.Context) (Cursor, bool, error) {
+	reader, err := s.store.GetFile(ctx, s.path)
+	if stderrors.Is(err, os.ErrNotExist) {
+		return Cursor{}, false, nil
+	} else if err != nil {
+		return Cursor{}, false, errors.Wrapf(err, "could not get cursor object %s", s.path)
+	}
+	defer reader.Close()
+	contents, err := io.ReadAll(reader)
+	if err != nil {
+		return Cursor{}, false, errors.Wrapf(err, "could not read cursor object %s", s.path)
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(contents, &cursor); err != nil {
+		return Cursor{}, false, errors.Wrapf(err, "could not decode cursor object %s", s.path)
+	}
+	return cursor, true, nil
+}
+
+func (s *DataStoreCursorStore) Commit(ctx context.Context, cursor Cursor) error {
+	contents, err := json.Marshal(cursor)
+	if err != nil {
+		return errors.Wrap(err, "could not encode cursor")
+	}
+	if err := s.store.PutFile(ctx, s.path, bytes.NewBuffer(contents), nil); err != nil {
+		return errors.Wrapf(err, "could not put cursor object %s", s.path)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package ingest
+
+import (
+	"context"
+	"encoding/hex"
+
+	"github.com/stellar/go/support/db"
+	"github.com/stellar/go/support/errors"
+)
+
+const createCursorTable = `CREATE TABLE IF NOT EXISTS ingest_cursors (
+	name text PRIMARY KEY,
+	ledger bigint NOT NULL,
+	ledger_hash text NOT NULL
+)`
+
+// DBCursorStore is a CursorStore which keeps the cursors of pipelines in the
+// ingest_cursors table of a Postgres database, one row per pipeline name.
+//
+// A pipeline writing its results to the same database commits its cursor
+// atomically with the results of a ledger by committing the cursor within the
+// session's transaction, before committing the transaction.
+type DBCursorStore struct {
+	session db.SessionInterface
+	name    string
+}
+
+// NewDBCursorStore returns a DBCursorStore keeping the cursor of the pipeline
+// called name. It creates the ingest_cursors table if it doesn't exist.
+func NewDBCursorStore(ctx context.Context, session db.SessionInterface, name string) (*DBCursorStore, error) {
+	if _, err := session.ExecRaw(ctx, createCursorTable); err != nil {
+		return nil, errors.Wrap(err, "could not create ingest_cursors table")
+	}
+	return &DBCursorStore{session: session, name: name}, nil
+}
+
+func (s *DBCursorStore) Load(ctx context.Context) (Cursor, bool, error) {
+	var row struct {
+		Ledger     uint32 `db:"ledger"`
+		LedgerHash string `db:"ledger_hash"`
+	}
+	err := s.session.GetRaw(ctx, &row, "SELECT ledger, ledger_hash FROM ingest_cursors WHERE name = ?", s.name)
+	if s.session.NoRows(err) {
+		return Cursor{}, false, nil
+	} else if err != nil {
+		return Cursor{}, false, errors.Wrapf(err, "could not load cursor %s", s.name)
+	}
+
+	cursor := Cursor{Ledger: row.Ledger}
+	hash, err := hex.DecodeString(row.LedgerHash)
+	if err != nil || len(hash) != len(cursor.LedgerHash) {
+		return Cursor{}, false, errors.Errorf("invalid ledger hash %q of cursor %s", row.LedgerHash, s.name)
+	}
+	copy(cursor.LedgerHash[:], hash)
+	return cursor, true, nil
+}
+
+func (s *DBCursorStore) Commit(ctx context.Context, cursor Cursor) error {
+	_, err := s.session.ExecRaw(ctx,
+		`INSERT INTO ingest_cursors (name, ledger, ledger_hash) VALUES (?, ?, ?)
+		ON CONFLICT (name) DO UPDATE SET ledger = EXCLUDED.ledger, ledger_hash = EXCLUDED.ledger_hash`,
+		s.name, cursor.Ledger, cursor.LedgerHash.HexString(),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "could not commit cursor %s", s.name)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/support/datastore"
+	"github.com/stellar/go/xdr"
+)
+
+func cursorTestLedger(seq uint32, hash, previousHash xdr.Hash) xdr.LedgerCloseMeta {
+	return xdr.LedgerCloseMeta{
+		V: 0,
+		V0: &xdr.LedgerCloseMetaV0{
+			LedgerHeader: xdr.LedgerHeaderHistoryEntry{
+				Hash: hash,
+				Header: xdr.LedgerHeader{
+					LedgerSeq:          xdr.Uint32(seq),
+					PreviousLedgerHash: previousHash,
+				},
+			},
+		},
+	}
+}
+
+func TestCursorVerify(t *testing.T) {
+	cursor := CursorOf(cursorTestLedger(10, xdr.Hash{1}, xdr.Hash{}))
+	assert.Equal(t, Cursor{Ledger: 10, LedgerHash: xdr.Hash{1}}, cursor)
+
+	assert.NoError(t, cursor.Verify(cursorTestLedger(11, xdr.Hash{2}, xdr.Hash{1})))
+	assert.EqualError(t,
+		cursor.Verify(cursorTestLedger(12, xdr.Hash{2}, xdr.Hash{1})),
+		"ledger 12 doesn't follow the cursor ledger 10",
+	)
+	assert.ErrorContains(t,
+		cursor.Verify(cursorTestLedger(11, xdr.Hash{2}, xdr.Hash{3})),
+		"previous ledger hash 0300000000000000000000000000000000000000000000000000000000000000 of ledger 11 doesn't match",
+	)
+}
+
+func TestCursorJSON(t *testing.T) {
+	cursor := Cursor{Ledger: 10, LedgerHash: xdr.Hash{0xab}}
+	encoded, err := cursor.MarshalJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t,
+		`{"ledger":10,"ledger_hash":"ab00000000000000000000000000000000000000000000000000000000000000"}`,
+		string(encoded),
+	)
+
+	var decoded Cursor
+	require.NoError(t, decoded.UnmarshalJSON(encoded))
+	assert.Equal(t, cursor, decoded)
+
+	assert.EqualError(t, decoded.UnmarshalJSON([]byte(`{"ledger":10,"ledger_hash":"ab"}`)), `invalid ledger hash "ab"`)
+}
+
+func TestFileCursorStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cursor.json")
+	store := NewFileCursorStore(path)
+
+	_, found, err := store.Load(ctx)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	for _, cursor := range []Cursor{{Ledger: 10, LedgerHash: xdr.Hash{1}}, {Ledger: 11, LedgerHash: xdr.Hash{2}}} {
+		require.NoError(t, store.Commit(ctx, cursor))
+		loaded, found, err := store.Load(ctx)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, cursor, loaded)
+	}
+
+	// no temporary files are left behind
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o644))
+	_, _, err = store.Load(ctx)
+	assert.ErrorContains(t, err, "could not decode cursor file")
+}
+
+func TestDataStoreCursorStore(t *testing.T) {
+	ctx := context.Background()
+	cursor := Cursor{Ledger: 10, LedgerHash: xdr.Hash{1}}
+	encoded, err := cursor.MarshalJSON()
+	require.NoError(t, err)
+
+	mockDataStore := new(datastore.MockDataStore)
+	mockDataStore.On("GetFile", mock.Anything, "cursors/pipeline.json").
+		Return(nil, os.ErrNotExist).Once()
+	mockDataStore.On("PutFile", mock.Anything, "cursors/pipeline.json", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			contents, err := io.ReadAll(args.Get(2).(io.WriterTo).(io.Reader))
+			require.NoError(t, err)
+			assert.JSONEq(t, string(encoded), string(contents))
+		}).Return(nil).Once()
+	mockDataStore.On("GetFile", mock.Anything, "cursors/pipeline.json").
+		Return(io.NopCloser(bytes.NewReader(encoded)), nil).Once()
+	defer mockDataStore.AssertExpectations(t)
+
+	store := NewDataStoreCursorStore(mockDataStore, "cursors/pipeline.json")
+	_, found, err := store.Load(ctx)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	require.NoError(t, store.Commit(ctx, cursor))
+
+	loaded, found, err := store.Load(ctx)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, cursor, loaded)
+}
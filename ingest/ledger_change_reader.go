@@ -41,6 +41,7 @@ type LedgerChangeReader struct {
 	pending      []Change
 	pendingIndex int
 	upgradeIndex int
+	filter       *ChangeFilter
 }
 
 // Ensure LedgerChangeReader implements ChangeReader
@@ -48,8 +49,8 @@ var _ ChangeReader = (*LedgerChangeReader)(nil)
 
 // NewLedgerChangeReader constructs a new LedgerChangeReader instance bound to the given ledger.
 // Note that the returned LedgerChangeReader is not thread safe and should not be shared
-// by multiple goroutines.
-func NewLedgerChangeReader(ctx context.Context, backend ledgerbackend.LedgerBackend, networkPassphrase string, sequence uint32) (*LedgerChangeReader, error) {
+// by multiple goroutines. The options select the changes returned by the reader.
+func NewLedgerChangeReader(ctx context.Context, backend ledgerbackend.LedgerBackend, networkPassphrase string, sequence uint32, options ...ChangeFilterOption) (*LedgerChangeReader, error) {
 	transactionReader, err := NewLedgerTransactionReader(ctx, backend, networkPassphrase, sequence)
 	if err != nil {
 		return nil, err
@@ -58,13 +59,14 @@ func NewLedgerChangeReader(ctx context.Context, backend ledgerbackend.LedgerBack
 	return &LedgerChangeReader{
 		LedgerTransactionReader: transactionReader,
 		state:                   feeChangesState,
+		filter:                  NewChangeFilter(options...),
 	}, nil
 }
 
 // NewLedgerChangeReaderFromLedgerCloseMeta constructs a new LedgerChangeReader instance bound to the given ledger.
 // Note that the returned LedgerChangeReader is not thread safe and should not be shared
-// by multiple goroutines.
-func NewLedgerChangeReaderFromLedgerCloseMeta(networkPassphrase string, ledger xdr.LedgerCloseMeta) (*LedgerChangeReader, error) {
+// by multiple goroutines. The options select the changes returned by the reader.
+func NewLedgerChangeReaderFromLedgerCloseMeta(networkPassphrase string, ledger xdr.LedgerCloseMeta, options ...ChangeFilterOption) (*LedgerChangeReader, error) {
 	transactionReader, err := NewLedgerTransactionReaderFromLedgerCloseMeta(networkPassphrase, ledger)
 	if err != nil {
 		return nil, err
@@ -73,6 +75,7 @@ func NewLedgerChangeReaderFromLedgerCloseMeta(networkPassphrase string, ledger x
 	return &LedgerChangeReader{
 		LedgerTransactionReader: transactionReader,
 		state:                   feeChangesState,
+		filter:                  NewChangeFilter(options...),
 	}, nil
 }
 
@@ -142,14 +145,16 @@ func (r *LedgerChangeReader) Read() (Change, error) {
 	// When Read() is called we stream pending changes first. We also call Read()
 	// recursively after adding some changes (what will return them from r.pending)
 	// to not duplicate the code.
-	if r.pendingIndex < len(r.pending) {
+	for r.pendingIndex < len(r.pending) {
 		next := r.pending[r.pendingIndex]
 		r.pendingIndex++
 		if r.pendingIndex == len(r.pending) {
 			r.pendingIndex = 0
 			r.pending = r.pending[:0]
 		}
-		return next, nil
+		if r.filter.Matches(next) {
+			return next, nil
+		}
 	}
 
 	switch r.state {
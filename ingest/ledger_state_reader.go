@@ -0,0 +1,401 @@
+package ingest
+
+import (
+	"context"
+	"crypto/sha256"
+	"io"
+
+	"github.com/stellar/go/historyarchive"
+	"github.com/stellar/go/ingest/ledgerbackend"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// LedgerEntryState is the state of a ledger entry at a given ledger.
+type LedgerEntryState struct {
+	// Key is the key of the ledger entry.
+	Key xdr.LedgerKey
+	// Entry is the ledger entry, nil if it doesn't exist at the ledger.
+	// For persistent Soroban entries evicted to the hot archive it is the
+	// archived entry.
+	Entry *xdr.LedgerEntry
+	// TTL is the TTL entry of Soroban entries, nil for other entries and for
+	// evicted entries.
+	TTL *xdr.TtlEntry
+	// Expired is set for Soroban entries whose TTL ended before the ledger.
+	// Expired persistent entries must be restored before being used again,
+	// expired temporary entries are deleted.
+	Expired bool
+	// Evicted is set for persistent Soroban entries which were evicted from
+	// the live state to the hot archive.
+	Evicted bool
+}
+
+// LedgerStateReader looks up the state of ledger entries at a given ledger
+// by reading the state of the nearest preceding checkpoint from a history
+// archive and replaying the changes of the following ledgers obtained from a
+// LedgerBackend.
+//
+// Every lookup streams all the buckets of the checkpoint, so it should be
+// used for occasional lookups. The whole state at a ledger is better kept in
+// a snapshot (see the ingest/snapshot package).
+type LedgerStateReader struct {
+	archive           historyarchive.ArchiveInterface
+	backend           ledgerbackend.LedgerBackend
+	networkPassphrase string
+}
+
+// NewLedgerStateReader constructs a LedgerStateReader reading checkpoints from
+// archive and ledgers from backend. The ranges of ledgers replayed are prepared
+// on backend unless they already are.
+func NewLedgerStateReader(
+	archive historyarchive.ArchiveInterface,
+	backend ledgerbackend.LedgerBackend,
+	networkPassphrase string,
+) *LedgerStateReader {
+	return &LedgerStateReader{
+		archive:           archive,
+		backend:           backend,
+		networkPassphrase: networkPassphrase,
+	}
+}
+
+// GetEntry returns the state of the ledger entry with the given key at ledger.
+func (r *LedgerStateReader) GetEntry(ctx context.Context, key xdr.LedgerKey, ledger uint32) (LedgerEntryState, error) {
+	states, err := r.GetEntries(ctx, []xdr.LedgerKey{key}, ledger)
+	if err != nil {
+		return LedgerEntryState{}, err
+	}
+	return states[0], nil
+}
+
+// GetEntries returns the states of the ledger entries with the given keys at
+// ledger, in the same order as keys.
+func (r *LedgerStateReader) GetEntries(ctx context.Context, keys []xdr.LedgerKey, ledger uint32) ([]LedgerEntryState, error) {
+	manager := r.archive.GetCheckpointManager()
+	checkpoint := manager.PrevCheckpoint(ledger)
+	if checkpoint > ledger {
+		return nil, errors.Errorf("ledger %d precedes the first checkpoint ledger %d", ledger, checkpoint)
+	}
+
+	lookup, err := newStateLookup(keys)
+	if err != nil {
+		return nil, err
+	}
+	if err = lookup.readCheckpoint(ctx, r.archive, checkpoint); err != nil {
+		return nil, err
+	}
+	if ledger > checkpoint {
+		if err = r.replay(ctx, lookup, ledgerbackend.BoundedRange(checkpoint+1, ledger)); err != nil {
+			return nil, err
+		}
+	}
+	return lookup.states(ledger), nil
+}
+
+func (r *LedgerStateReader) replay(ctx context.Context, lookup *stateLookup, ledgerRange ledgerbackend.Range) error {
+	prepared, err := r.backend.IsPrepared(ctx, ledgerRange)
+	if err != nil {
+		return errors.Wrap(err, "error checking if range is prepared")
+	}
+	if !prepared {
+		if err = r.backend.PrepareRange(ctx, ledgerRange); err != nil {
+			return errors.Wrapf(err, "error preparing range %s", ledgerRange)
+		}
+	}
+
+	for sequence := ledgerRange.From(); sequence <= ledgerRange.To(); sequence++ {
+		ledgerCloseMeta, err := r.backend.GetLedger(ctx, sequence)
+		if err != nil {
+			return errors.Wrapf(err, "error getting ledger %d", sequence)
+		}
+		if err = lookup.applyLedger(r.networkPassphrase, ledgerCloseMeta); err != nil {
+			return errors.Wrapf(err, "error applying changes of ledger %d", sequence)
+		}
+	}
+	return nil
+}
+
+// stateLookup tracks the state of the requested ledger entries, and of the
+// TTL entries of the Soroban ones, keyed by their XDR encoding.
+type stateLookup struct {
+	keys     []xdr.LedgerKey
+	encoded  []string
+	ttlKeys  map[string]string // Soroban entry key => TTL entry key
+	tracked  map[string]bool
+	live     map[string]*xdr.LedgerEntry
+	archived map[string]*xdr.LedgerEntry
+
+	encodingBuffer *xdr.EncodingBuffer
+}
+
+func newStateLookup(keys []xdr.LedgerKey) (*stateLookup, error) {
+	lookup := &stateLookup{
+		keys:           keys,
+		encoded:        make([]string, len(keys)),
+		ttlKeys:        map[string]string{},
+		tracked:        map[string]bool{},
+		live:           map[string]*xdr.LedgerEntry{},
+		archived:       map[string]*xdr.LedgerEntry{},
+		encodingBuffer: xdr.NewEncodingBuffer(),
+	}
+	for i, key := range keys {
+		encoded, err := key.MarshalBinary()
+		if err != nil {
+			return nil, errors.Wrap(err, "error marshaling ledger key")
+		}
+		lookup.encoded[i] = string(encoded)
+		lookup.tracked[string(encoded)] = true
+
+		if !isSorobanKey(key) {
+			continue
+		}
+		var ttlKey xdr.LedgerKey
+		if err = ttlKey.SetTtl(sha256.Sum256(encoded)); err != nil {
+			return nil, errors.Wrap(err, "error creating TTL key")
+		}
+		encodedTTLKey, err := ttlKey.MarshalBinary()
+		if err != nil {
+			return nil, errors.Wrap(err, "error marshaling TTL key")
+		}
+		lookup.ttlKeys[string(encoded)] = string(encodedTTLKey)
+		lookup.tracked[string(encodedTTLKey)] = true
+	}
+	return lookup, nil
+}
+
+func isSorobanKey(key xdr.LedgerKey) bool {
+	return key.Type == xdr.LedgerEntryTypeContractData || key.Type == xdr.LedgerEntryTypeContractCode
+}
+
+func isPersistentKey(key xdr.LedgerKey) bool {
+	switch key.Type {
+	case xdr.LedgerEntryTypeContractCode:
+		return true
+	case xdr.LedgerEntryTypeContractData:
+		return key.ContractData.Durability == xdr.ContractDataDurabilityPersistent
+	default:
+		return false
+	}
+}
+
+// trackedKey returns the encoded key if it is tracked.
+func (l *stateLookup) trackedKey(key xdr.LedgerKey) (string, bool, error) {
+	encoded, err := l.encodingBuffer.UnsafeMarshalBinary(key)
+	if err != nil {
+		return "", false, errors.Wrap(err, "error marshaling ledger key")
+	}
+	if !l.tracked[string(encoded)] {
+		return "", false, nil
+	}
+	return string(encoded), true, nil
+}
+
+// readCheckpoint reads the tracked entries from the live state and the hot
+// archive of the checkpoint.
+func (l *stateLookup) readCheckpoint(ctx context.Context, archive historyarchive.ArchiveInterface, checkpoint uint32) error {
+	var entryTypes []xdr.LedgerEntryType
+	for _, key := range l.keys {
+		entryTypes = append(entryTypes, key.Type)
+		if isSorobanKey(key) {
+			entryTypes = append(entryTypes, xdr.LedgerEntryTypeTtl)
+		}
+	}
+	reader, err := NewCheckpointChangeReader(ctx, archive, checkpoint, WithEntryTypes(entryTypes...))
+	if err != nil {
+		return errors.Wrapf(err, "error creating checkpoint change reader for ledger %d", checkpoint)
+	}
+	defer reader.Close()
+
+	for {
+		change, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "error reading checkpoint")
+		}
+		key, err := change.LedgerKey()
+		if err != nil {
+			return errors.Wrap(err, "error getting ledger key")
+		}
+		encoded, ok, err := l.trackedKey(key)
+		if err != nil {
+			return err
+		}
+		if ok {
+			l.live[encoded] = change.Post
+		}
+	}
+
+	return l.readHotArchive(ctx, archive, reader.has)
+}
+
+// readHotArchive reads the persistent Soroban entries which are not in the
+// live state from the hot archive buckets, from the newest to the oldest.
+func (l *stateLookup) readHotArchive(ctx context.Context, archive historyarchive.ArchiveInterface, has *historyarchive.HistoryArchiveState) error {
+	pending := map[string]bool{}
+	for i, key := range l.keys {
+		if isPersistentKey(key) && l.live[l.encoded[i]] == nil {
+			pending[l.encoded[i]] = true
+		}
+	}
+
+	for _, level := range has.HotArchiveBuckets {
+		for _, hashString := range []string{level.Curr, level.Snap} {
+			if len(pending) == 0 {
+				return nil
+			}
+			// history archive states created before protocol 23 have no hot archive
+			if hashString == "" {
+				continue
+			}
+			hash, err := historyarchive.DecodeHash(hashString)
+			if err != nil {
+				return errors.Wrap(err, "error decoding hot archive bucket hash")
+			}
+			if hash.IsZero() {
+				continue
+			}
+			if err = l.readHotArchiveBucket(ctx, archive, hash, pending); err != nil {
+				return errors.Wrapf(err, "error reading hot archive bucket %s", hash)
+			}
+		}
+	}
+	return nil
+}
+
+func (l *stateLookup) readHotArchiveBucket(ctx context.Context, archive historyarchive.ArchiveInterface, hash historyarchive.Hash, pending map[string]bool) error {
+	stream, err := archive.GetXdrStreamForHash(hash)
+	if err != nil {
+		return err
+	}
+	stream.SetExpectedHash(hash)
+
+	// the keys found in a newer bucket are only removed from pending once
+	// the bucket is read, since a bucket contains a single entry per key
+	var found []string
+	for {
+		if err = ctx.Err(); err != nil {
+			stream.Close()
+			return err
+		}
+		var entry xdr.HotArchiveBucketEntry
+		if err = stream.ReadOne(&entry); err == io.EOF {
+			break
+		} else if err != nil {
+			stream.Close()
+			return err
+		}
+
+		var key xdr.LedgerKey
+		switch entry.Type {
+		case xdr.HotArchiveBucketEntryTypeHotArchiveArchived:
+			archivedEntry := entry.MustArchivedEntry()
+			if key, err = archivedEntry.LedgerKey(); err != nil {
+				stream.Close()
+				return err
+			}
+		case xdr.HotArchiveBucketEntryTypeHotArchiveLive:
+			key = entry.MustKey()
+		default:
+			continue
+		}
+		encoded, err := l.encodingBuffer.UnsafeMarshalBinary(key)
+		if err != nil {
+			stream.Close()
+			return err
+		}
+		if !pending[string(encoded)] {
+			continue
+		}
+		found = append(found, string(encoded))
+		if entry.Type == xdr.HotArchiveBucketEntryTypeHotArchiveArchived {
+			l.archived[string(encoded)] = entry.ArchivedEntry
+		}
+	}
+
+	for _, key := range found {
+		delete(pending, key)
+	}
+	return stream.Close()
+}
+
+// applyLedger applies the changes of the tracked entries in the ledger.
+func (l *stateLookup) applyLedger(networkPassphrase string, ledgerCloseMeta xdr.LedgerCloseMeta) error {
+	reader, err := NewLedgerChangeReaderFromLedgerCloseMeta(networkPassphrase, ledgerCloseMeta)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	for {
+		change, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		key, err := change.LedgerKey()
+		if err != nil {
+			return errors.Wrap(err, "error getting ledger key")
+		}
+		encoded, ok, err := l.trackedKey(key)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		l.live[encoded] = change.Post
+		if change.Post != nil {
+			// restored or recreated entries are no longer archived
+			delete(l.archived, encoded)
+		}
+	}
+
+	evictedKeys, err := ledgerCloseMeta.EvictedLedgerKeys()
+	if err != nil {
+		return errors.Wrap(err, "error getting evicted ledger keys")
+	}
+	for _, key := range evictedKeys {
+		encoded, ok, err := l.trackedKey(key)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if entry := l.live[encoded]; entry != nil && isPersistentKey(key) {
+			l.archived[encoded] = entry
+		}
+		delete(l.live, encoded)
+	}
+	return nil
+}
+
+// states returns the states of the requested entries at ledger.
+func (l *stateLookup) states(ledger uint32) []LedgerEntryState {
+	states := make([]LedgerEntryState, len(l.keys))
+	for i, key := range l.keys {
+		encoded := l.encoded[i]
+		state := LedgerEntryState{Key: key, Entry: l.live[encoded]}
+
+		if state.Entry == nil {
+			if archived := l.archived[encoded]; archived != nil {
+				state.Entry = archived
+				state.Expired = true
+				state.Evicted = true
+			}
+		} else if ttlKey, ok := l.ttlKeys[encoded]; ok {
+			if ttl := l.live[ttlKey]; ttl != nil {
+				ttlEntry := ttl.Data.MustTtl()
+				state.TTL = &ttlEntry
+				state.Expired = uint32(ttlEntry.LiveUntilLedgerSeq) < ledger
+			}
+		}
+		states[i] = state
+	}
+	return states
+}
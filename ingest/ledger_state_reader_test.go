@@ -0,0 +1,232 @@
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/historyarchive"
+	"github.com/stellar/go/ingest/ledgerbackend"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/xdr"
+)
+
+const zeroBucketHash = "0000000000000000000000000000000000000000000000000000000000000000"
+
+func contractDataEntry(contract xdr.ContractId, key string, durability xdr.ContractDataDurability) xdr.LedgerEntry {
+	sym := xdr.ScSymbol(key)
+	return xdr.LedgerEntry{
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeContractData,
+			ContractData: &xdr.ContractDataEntry{
+				Contract: xdr.ScAddress{
+					Type:       xdr.ScAddressTypeScAddressTypeContract,
+					ContractId: &contract,
+				},
+				Key:        xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym},
+				Durability: durability,
+				Val:        xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym},
+			},
+		},
+	}
+}
+
+func accountLedgerEntry(address string, balance xdr.Int64) xdr.LedgerEntry {
+	return xdr.LedgerEntry{
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeAccount,
+			Account: &xdr.AccountEntry{
+				AccountId: xdr.MustAddress(address),
+				Balance:   balance,
+			},
+		},
+	}
+}
+
+func ttlLedgerEntry(t *testing.T, entry xdr.LedgerEntry, liveUntil uint32) xdr.LedgerEntry {
+	key, err := entry.LedgerKey()
+	require.NoError(t, err)
+	encoded, err := key.MarshalBinary()
+	require.NoError(t, err)
+	return xdr.LedgerEntry{
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeTtl,
+			Ttl: &xdr.TtlEntry{
+				KeyHash:            sha256.Sum256(encoded),
+				LiveUntilLedgerSeq: xdr.Uint32(liveUntil),
+			},
+		},
+	}
+}
+
+func mustLedgerKey(t *testing.T, entry xdr.LedgerEntry) xdr.LedgerKey {
+	key, err := entry.LedgerKey()
+	require.NoError(t, err)
+	return key
+}
+
+// bucket returns the framed XDR of the entries and its hash.
+func bucket[T any](t *testing.T, entries ...T) ([]byte, historyarchive.Hash) {
+	b := &bytes.Buffer{}
+	for _, entry := range entries {
+		require.NoError(t, xdr.MarshalFramed(b, entry))
+	}
+	return b.Bytes(), sha256.Sum256(b.Bytes())
+}
+
+func liveBucketEntry(entry xdr.LedgerEntry) xdr.BucketEntry {
+	return xdr.BucketEntry{Type: xdr.BucketEntryTypeLiveentry, LiveEntry: &entry}
+}
+
+func emptyLedger(sequence uint32) xdr.LedgerCloseMeta {
+	return xdr.LedgerCloseMeta{
+		V: 0,
+		V0: &xdr.LedgerCloseMetaV0{
+			LedgerHeader: xdr.LedgerHeaderHistoryEntry{
+				Header: xdr.LedgerHeader{LedgerSeq: xdr.Uint32(sequence), LedgerVersion: 10},
+			},
+		},
+	}
+}
+
+func TestLedgerStateReader(t *testing.T) {
+	ctx := context.Background()
+	address := "GBXGQJWVLWOYHFLVTKWV5FGHA3LNYY2JQKM7OAJAUEQFU6LPCSEFVXON"
+	contract := xdr.ContractId{1, 2, 3}
+
+	account := accountLedgerEntry(address, 100)
+	persistent := contractDataEntry(contract, "persistent", xdr.ContractDataDurabilityPersistent)
+	temporary := contractDataEntry(contract, "temporary", xdr.ContractDataDurabilityTemporary)
+	archived := contractDataEntry(contract, "archived", xdr.ContractDataDurabilityPersistent)
+	restored := contractDataEntry(contract, "restored", xdr.ContractDataDurabilityPersistent)
+	missing := accountLedgerEntry("GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU", 0)
+
+	liveBucket, liveHash := bucket(t,
+		liveBucketEntry(account),
+		liveBucketEntry(persistent),
+		liveBucketEntry(ttlLedgerEntry(t, persistent, 70)),
+		liveBucketEntry(temporary),
+		liveBucketEntry(ttlLedgerEntry(t, temporary, 65)),
+	)
+	// the newest hot archive bucket records the restoration of an entry archived in the oldest one
+	restoredKey := mustLedgerKey(t, restored)
+	newHotBucket, newHotHash := bucket(t,
+		xdr.HotArchiveBucketEntry{Type: xdr.HotArchiveBucketEntryTypeHotArchiveLive, Key: &restoredKey},
+	)
+	oldHotBucket, oldHotHash := bucket(t,
+		xdr.HotArchiveBucketEntry{Type: xdr.HotArchiveBucketEntryTypeHotArchiveArchived, ArchivedEntry: &archived},
+		xdr.HotArchiveBucketEntry{Type: xdr.HotArchiveBucketEntryTypeHotArchiveArchived, ArchivedEntry: &restored},
+	)
+	has := historyarchive.HistoryArchiveState{}
+	for i := range has.CurrentBuckets {
+		has.CurrentBuckets[i].Curr, has.CurrentBuckets[i].Snap = zeroBucketHash, zeroBucketHash
+		has.HotArchiveBuckets[i].Curr, has.HotArchiveBuckets[i].Snap = zeroBucketHash, zeroBucketHash
+	}
+	has.CurrentBuckets[0].Curr = liveHash.String()
+	has.HotArchiveBuckets[0].Curr, has.HotArchiveBuckets[0].Snap = newHotHash.String(), oldHotHash.String()
+
+	archive := &historyarchive.MockArchive{}
+	defer archive.AssertExpectations(t)
+	archive.On("GetCheckpointManager").
+		Return(historyarchive.NewCheckpointManager(historyarchive.DefaultCheckpointFrequency))
+	expectCheckpoint := func() {
+		archive.On("GetCheckpointHAS", uint32(63)).Return(has, nil).Once()
+		archive.On("BucketExists", liveHash).Return(true, nil).Once()
+		archive.On("BucketSize", liveHash).Return(int64(len(liveBucket)), nil).Once()
+		for _, b := range []struct {
+			hash     historyarchive.Hash
+			contents []byte
+		}{{liveHash, liveBucket}, {newHotHash, newHotBucket}, {oldHotHash, oldHotBucket}} {
+			archive.On("GetXdrStreamForHash", b.hash).
+				Return(xdr.NewStream(io.NopCloser(bytes.NewReader(b.contents))), nil).Once()
+		}
+	}
+
+	backend := &ledgerbackend.MockDatabaseBackend{}
+	defer backend.AssertExpectations(t)
+	reader := NewLedgerStateReader(archive, backend, network.TestNetworkPassphrase)
+
+	keys := []xdr.LedgerKey{
+		mustLedgerKey(t, account),
+		mustLedgerKey(t, persistent),
+		mustLedgerKey(t, temporary),
+		mustLedgerKey(t, archived),
+		restoredKey,
+		mustLedgerKey(t, missing),
+	}
+
+	// the state of a checkpoint ledger is read from the history archive only
+	expectCheckpoint()
+	states, err := reader.GetEntries(ctx, keys, 63)
+	require.NoError(t, err)
+	require.Len(t, states, len(keys))
+	for i, state := range states {
+		assert.Equal(t, keys[i], state.Key)
+	}
+	assert.Equal(t, LedgerEntryState{Key: keys[0], Entry: &account}, states[0])
+	assert.Equal(t, &persistent, states[1].Entry)
+	assert.Equal(t, xdr.Uint32(70), states[1].TTL.LiveUntilLedgerSeq)
+	assert.False(t, states[1].Expired)
+	assert.Equal(t, &temporary, states[2].Entry)
+	assert.Equal(t, xdr.Uint32(65), states[2].TTL.LiveUntilLedgerSeq)
+	assert.Equal(t, LedgerEntryState{Key: keys[3], Entry: &archived, Expired: true, Evicted: true}, states[3])
+	assert.Equal(t, LedgerEntryState{Key: keys[4]}, states[4])
+	assert.Equal(t, LedgerEntryState{Key: keys[5]}, states[5])
+
+	// later ledgers are replayed from the backend
+	updatedAccount := accountLedgerEntry(address, 200)
+	updateLedger := emptyLedger(64)
+	updateLedger.V0.UpgradesProcessing = []xdr.UpgradeEntryMeta{{Changes: xdr.LedgerEntryChanges{
+		{Type: xdr.LedgerEntryChangeTypeLedgerEntryState, State: &account},
+		{Type: xdr.LedgerEntryChangeTypeLedgerEntryUpdated, Updated: &updatedAccount},
+	}}}
+	evictionLedger := xdr.LedgerCloseMeta{
+		V: 1,
+		V1: &xdr.LedgerCloseMetaV1{
+			LedgerHeader: xdr.LedgerHeaderHistoryEntry{
+				Header: xdr.LedgerHeader{LedgerSeq: 66, LedgerVersion: 23},
+			},
+			TxSet: xdr.GeneralizedTransactionSet{V: 1, V1TxSet: &xdr.TransactionSetV1{}},
+			EvictedKeys: []xdr.LedgerKey{
+				mustLedgerKey(t, temporary),
+				mustLedgerKey(t, ttlLedgerEntry(t, temporary, 65)),
+			},
+		},
+	}
+
+	expectCheckpoint()
+	backend.On("IsPrepared", ctx, ledgerbackend.BoundedRange(64, 71)).Return(false, nil).Once()
+	backend.On("PrepareRange", ctx, ledgerbackend.BoundedRange(64, 71)).Return(nil).Once()
+	backend.On("GetLedger", ctx, uint32(64)).Return(updateLedger, nil).Once()
+	backend.On("GetLedger", ctx, uint32(65)).Return(emptyLedger(65), nil).Once()
+	backend.On("GetLedger", ctx, uint32(66)).Return(evictionLedger, nil).Once()
+	for sequence := uint32(67); sequence <= 71; sequence++ {
+		backend.On("GetLedger", ctx, sequence).Return(emptyLedger(sequence), nil).Once()
+	}
+
+	states, err = reader.GetEntries(ctx, keys, 71)
+	require.NoError(t, err)
+	assert.Equal(t, LedgerEntryState{Key: keys[0], Entry: &updatedAccount}, states[0])
+	// the TTL of the persistent entry ended but it wasn't evicted yet
+	assert.Equal(t, &persistent, states[1].Entry)
+	assert.True(t, states[1].Expired)
+	assert.False(t, states[1].Evicted)
+	// evicted temporary entries are deleted
+	assert.Equal(t, LedgerEntryState{Key: keys[2]}, states[2])
+	assert.Equal(t, LedgerEntryState{Key: keys[3], Entry: &archived, Expired: true, Evicted: true}, states[3])
+}
+
+func TestLedgerStateReaderBeforeFirstCheckpoint(t *testing.T) {
+	archive := &historyarchive.MockArchive{}
+	archive.On("GetCheckpointManager").
+		Return(historyarchive.NewCheckpointManager(historyarchive.DefaultCheckpointFrequency))
+
+	reader := NewLedgerStateReader(archive, &ledgerbackend.MockDatabaseBackend{}, network.TestNetworkPassphrase)
+	_, err := reader.GetEntry(context.Background(), xdr.LedgerKey{}, 10)
+	assert.EqualError(t, err, "ledger 10 precedes the first checkpoint ledger 63")
+}
@@ -0,0 +1,310 @@
+package ledgerbackend
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pkg/errors"
+
+	"github.com/stellar/go/support/compressxdr"
+	"github.com/stellar/go/support/datastore"
+	"github.com/stellar/go/support/log"
+	"github.com/stellar/go/xdr"
+)
+
+// defaultDiskCacheSize is the default number of ledger files kept in the disk cache.
+const defaultDiskCacheSize = 64000
+
+// batchCache provides random access to the ledger objects of a DataStore for the RandomAccess
+// mode of BufferedStorageBackend. The decompressed batches are kept in an LRU, optionally backed
+// by an LRU of the compressed objects on local disk, and concurrent requests for the same object
+// share a single download.
+type batchCache struct {
+	dataStore  datastore.DataStore
+	schema     datastore.DataStoreSchema
+	dictionary []byte
+	readAhead  uint32
+
+	fileExtension *ledgerFileExtension
+
+	batches *lru.Cache // file start sequence -> xdr.LedgerCloseMetaBatch
+	files   *lru.Cache // file start sequence -> diskCacheEntry, nil without a disk cache
+	diskDir string
+
+	// context used to cancel the downloads when the cache is closed
+	context context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+
+	prefetchQueue chan uint32
+
+	lock         sync.Mutex
+	inFlight     map[uint32]*batchFetch
+	latestLedger uint32
+	closed       bool
+}
+
+// batchFetch is the download of a ledger object, shared by all the requests for it.
+type batchFetch struct {
+	done  chan struct{}
+	batch xdr.LedgerCloseMetaBatch
+	err   error
+}
+
+type diskCacheEntry struct {
+	path       string
+	compressor compressxdr.Compressor
+}
+
+func (bsb *BufferedStorageBackend) newBatchCache() (*batchCache, error) {
+	cacheSize := bsb.config.CacheSize
+	if cacheSize == 0 {
+		cacheSize = bsb.config.BufferSize
+	}
+	batches, err := lru.New(int(cacheSize))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create ledger batch cache")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	bc := &batchCache{
+		dataStore:     bsb.dataStore,
+		schema:        bsb.schema,
+		dictionary:    bsb.dictionary,
+		fileExtension: &bsb.fileExtension,
+		readAhead:     bsb.config.NumWorkers,
+		batches:       batches,
+		context:       ctx,
+		cancel:        cancel,
+		prefetchQueue: make(chan uint32, bsb.config.BufferSize),
+		inFlight:      map[uint32]*batchFetch{},
+	}
+
+	if bsb.config.DiskCachePath != "" {
+		diskCacheSize := bsb.config.DiskCacheSize
+		if diskCacheSize == 0 {
+			diskCacheSize = defaultDiskCacheSize
+		}
+		if err = os.MkdirAll(bsb.config.DiskCachePath, 0755); err != nil {
+			cancel()
+			return nil, errors.Wrap(err, "unable to create disk cache directory")
+		}
+		if bc.diskDir, err = os.MkdirTemp(bsb.config.DiskCachePath, "ledgers-*"); err != nil {
+			cancel()
+			return nil, errors.Wrap(err, "unable to create disk cache directory")
+		}
+		if bc.files, err = lru.NewWithEvict(int(diskCacheSize), bc.onFileEviction); err != nil {
+			cancel()
+			return nil, errors.Wrap(err, "unable to create disk cache")
+		}
+	}
+
+	bc.wg.Add(int(bsb.config.NumWorkers))
+	for i := uint32(0); i < bsb.config.NumWorkers; i++ {
+		go bc.prefetchWorker()
+	}
+
+	return bc, nil
+}
+
+// get returns the batch containing sequence, downloading it if it isn't cached.
+func (bc *batchCache) get(ctx context.Context, sequence uint32) (xdr.LedgerCloseMetaBatch, error) {
+	start := bc.schema.GetSequenceNumberStartBoundary(sequence)
+	if batch, ok := bc.batches.Get(start); ok {
+		return batch.(xdr.LedgerCloseMetaBatch), nil
+	}
+
+	fetch := bc.fetch(start)
+	select {
+	case <-ctx.Done():
+		return xdr.LedgerCloseMetaBatch{}, ctx.Err()
+	case <-fetch.done:
+		return fetch.batch, fetch.err
+	}
+}
+
+// fetch starts downloading the object starting at start, unless it is already being downloaded.
+func (bc *batchCache) fetch(start uint32) *batchFetch {
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+
+	if fetch, ok := bc.inFlight[start]; ok {
+		return fetch
+	}
+	fetch := &batchFetch{done: make(chan struct{})}
+	if bc.closed {
+		fetch.err = errors.New("ledger batch cache is closed")
+		close(fetch.done)
+		return fetch
+	}
+	bc.inFlight[start] = fetch
+
+	bc.wg.Add(1)
+	go func() {
+		defer bc.wg.Done()
+		fetch.batch, fetch.err = bc.load(start)
+
+		bc.lock.Lock()
+		if fetch.err == nil {
+			bc.batches.Add(start, fetch.batch)
+			bc.latestLedger = max(bc.latestLedger, uint32(fetch.batch.EndSequence))
+		}
+		delete(bc.inFlight, start)
+		bc.lock.Unlock()
+		close(fetch.done)
+	}()
+	return fetch
+}
+
+func (bc *batchCache) load(start uint32) (xdr.LedgerCloseMetaBatch, error) {
+	if bc.files != nil {
+		if entry, ok := bc.files.Get(start); ok {
+			cached := entry.(diskCacheEntry)
+			payload, err := os.ReadFile(cached.path)
+			if err == nil {
+				return ledgerBatchObject{payload: payload, compressor: cached.compressor, startLedger: int(start)}.decode()
+			}
+			log.Warnf("unable to read cached ledger file %s: %v", cached.path, err)
+			bc.files.Remove(start)
+		}
+	}
+
+	object, err := downloadLedgerObject(bc.context, bc.dataStore, bc.schema, bc.fileExtension, bc.dictionary, start)
+	if err != nil {
+		return xdr.LedgerCloseMetaBatch{}, err
+	}
+	batch, err := object.decode()
+	if err != nil {
+		return xdr.LedgerCloseMetaBatch{}, errors.Wrapf(err, "unable to decode ledger object containing sequence %d", start)
+	}
+
+	if bc.files != nil {
+		cachedPath := filepath.Join(bc.diskDir, fmt.Sprintf("%d.xdr.%s", start, object.compressor.Name()))
+		if err = os.WriteFile(cachedPath, object.payload, 0644); err != nil {
+			// the disk cache is best effort
+			log.Warnf("unable to cache ledger file %s: %v", cachedPath, err)
+		} else {
+			bc.files.Add(start, diskCacheEntry{path: cachedPath, compressor: object.compressor})
+		}
+	}
+	return batch, nil
+}
+
+func (bc *batchCache) onFileEviction(key, value interface{}) {
+	cached := value.(diskCacheEntry)
+	if err := os.Remove(cached.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Warnf("unable to remove cached ledger file %s: %v", cached.path, err)
+	}
+}
+
+// scheduleReadAhead queues the download of the objects following the one containing sequence,
+// up to end if it is not 0. Objects are skipped when the prefetch queue is full.
+func (bc *batchCache) scheduleReadAhead(sequence, end uint32) {
+	next := bc.schema.GetSequenceNumberStartBoundary(sequence)
+	for i := uint32(0); i < bc.readAhead; i++ {
+		next += bc.schema.LedgersPerFile
+		if end != 0 && next > end {
+			return
+		}
+		if bc.batches.Contains(next) {
+			continue
+		}
+		select {
+		case bc.prefetchQueue <- next:
+		default:
+			return
+		}
+	}
+}
+
+func (bc *batchCache) prefetchWorker() {
+	defer bc.wg.Done()
+
+	for {
+		select {
+		case <-bc.context.Done():
+			return
+		case start := <-bc.prefetchQueue:
+			if bc.batches.Contains(start) {
+				continue
+			}
+			fetch := bc.fetch(start)
+			select {
+			case <-bc.context.Done():
+				return
+			case <-fetch.done:
+			}
+		}
+	}
+}
+
+// prefetch downloads the objects of the bounded ranges concurrently, returning the first error.
+func (bc *batchCache) prefetch(ctx context.Context, ranges []Range, workers uint32) error {
+	sem := make(chan struct{}, max(1, workers))
+	var wg sync.WaitGroup
+	var errLock sync.Mutex
+	var firstErr error
+	failed := func() error {
+		errLock.Lock()
+		defer errLock.Unlock()
+		return firstErr
+	}
+
+	for _, ledgerRange := range ranges {
+		start := bc.schema.GetSequenceNumberStartBoundary(ledgerRange.from)
+		for sequence := start; sequence <= ledgerRange.to; sequence += bc.schema.LedgersPerFile {
+			select {
+			case <-ctx.Done():
+				wg.Wait()
+				return ctx.Err()
+			case sem <- struct{}{}:
+			}
+			if err := failed(); err != nil {
+				<-sem
+				wg.Wait()
+				return err
+			}
+
+			wg.Add(1)
+			go func(sequence uint32) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				if _, err := bc.get(ctx, sequence); err != nil {
+					errLock.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					errLock.Unlock()
+				}
+			}(sequence)
+		}
+	}
+	wg.Wait()
+	return failed()
+}
+
+// getLatestLedgerSequence returns the most recent ledger downloaded.
+func (bc *batchCache) getLatestLedgerSequence() uint32 {
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+	return bc.latestLedger
+}
+
+func (bc *batchCache) close() {
+	bc.lock.Lock()
+	bc.closed = true
+	bc.lock.Unlock()
+
+	bc.cancel()
+	bc.wg.Wait()
+	if bc.files != nil {
+		bc.files.Purge()
+		if err := os.RemoveAll(bc.diskDir); err != nil {
+			log.Warnf("unable to remove disk cache directory %s: %v", bc.diskDir, err)
+		}
+	}
+}
@@ -5,6 +5,7 @@ package ledgerbackend
 
 import (
 	"context"
+	"os"
 	"sync"
 	"time"
 
@@ -22,6 +23,28 @@ type BufferedStorageBackendConfig struct {
 	NumWorkers uint32        `toml:"num_workers"`
 	RetryLimit uint32        `toml:"retry_limit"`
 	RetryWait  time.Duration `toml:"retry_wait"`
+	// CompressionDictionaryPath is the zstd dictionary the ledger files were written with, if any.
+	// The codec of each file is detected from its extension.
+	CompressionDictionaryPath string `toml:"compression_dictionary_path"`
+	// Notifier, optional, wakes up the workers waiting for the next ledger files of an unbounded
+	// range as soon as they are written. The workers still poll the DataStore every RetryWait
+	// in case a notification is missed.
+	Notifier datastore.LedgerNotifier `toml:"-"`
+
+	// RandomAccess allows GetLedger to be called with any sequence of the prepared range, in any
+	// order and concurrently, instead of streaming the range sequentially. The decompressed batches
+	// are kept in an LRU of CacheSize batches and the NumWorkers files following the requested ones
+	// are read ahead. Only bounded ranges whose first and last ledgers are in the DataStore can
+	// be prepared.
+	RandomAccess bool `toml:"random_access"`
+	// CacheSize is the number of decompressed batches kept in memory in RandomAccess mode,
+	// defaults to BufferSize.
+	CacheSize uint32 `toml:"cache_size"`
+	// DiskCachePath, optional, spills the compressed files downloaded in RandomAccess mode
+	// to a temporary directory created under it, which is removed on Close.
+	DiskCachePath string `toml:"disk_cache_path"`
+	// DiskCacheSize is the number of files kept in the disk cache, defaults to 64000.
+	DiskCacheSize uint32 `toml:"disk_cache_size"`
 }
 
 // BufferedStorageBackend is a ledger backend that reads from a storage service.
@@ -33,14 +56,18 @@ type BufferedStorageBackend struct {
 
 	// ledgerBuffer is the buffer for LedgerCloseMeta data read in parallel.
 	ledgerBuffer *ledgerBuffer
-
-	dataStore  datastore.DataStore
-	schema     datastore.DataStoreSchema
-	prepared   *Range // Non-nil if any range is prepared
-	closed     bool   // False until the core is closed
-	lcmBatch   xdr.LedgerCloseMetaBatch
-	nextLedger uint32
-	lastLedger uint32
+	// batchCache replaces ledgerBuffer in RandomAccess mode.
+	batchCache *batchCache
+
+	dataStore     datastore.DataStore
+	schema        datastore.DataStoreSchema
+	dictionary    []byte
+	fileExtension ledgerFileExtension // Resolved from the first ledger object if the schema has none
+	prepared      *Range              // Non-nil if any range is prepared
+	closed        bool                // False until the core is closed
+	lcmBatch      xdr.LedgerCloseMetaBatch
+	nextLedger    uint32
+	lastLedger    uint32
 }
 
 // NewBufferedStorageBackend returns a new BufferedStorageBackend instance.
@@ -57,10 +84,26 @@ func NewBufferedStorageBackend(config BufferedStorageBackendConfig, dataStore da
 		return nil, errors.New("ledgersPerFile must be > 0")
 	}
 
+	var dictionary []byte
+	if config.CompressionDictionaryPath != "" {
+		var err error
+		if dictionary, err = os.ReadFile(config.CompressionDictionaryPath); err != nil {
+			return nil, errors.Wrap(err, "unable to read compression dictionary")
+		}
+	}
+
 	bsBackend := &BufferedStorageBackend{
-		config:    config,
-		dataStore: dataStore,
-		schema:    schema,
+		config:     config,
+		dataStore:  dataStore,
+		schema:     schema,
+		dictionary: dictionary,
+	}
+
+	if config.RandomAccess {
+		var err error
+		if bsBackend.batchCache, err = bsBackend.newBatchCache(); err != nil {
+			return nil, err
+		}
 	}
 
 	return bsBackend, nil
@@ -79,6 +122,10 @@ func (bsb *BufferedStorageBackend) GetLatestLedgerSequence(ctx context.Context)
 		return 0, errors.New("BufferedStorageBackend must be prepared, call PrepareRange first")
 	}
 
+	if bsb.batchCache != nil {
+		return bsb.batchCache.getLatestLedgerSequence(), nil
+	}
+
 	latestSeq, err := bsb.ledgerBuffer.getLatestLedgerSequence()
 	if err != nil {
 		return 0, err
@@ -135,16 +182,20 @@ func (bsb *BufferedStorageBackend) GetLedger(ctx context.Context, sequence uint3
 		return xdr.LedgerCloseMeta{}, errors.New("session is not prepared, call PrepareRange first")
 	}
 
-	if sequence < bsb.ledgerBuffer.ledgerRange.from {
+	if sequence < bsb.prepared.from {
 		return xdr.LedgerCloseMeta{}, errors.New("requested sequence preceeds current LedgerRange")
 	}
 
-	if bsb.ledgerBuffer.ledgerRange.bounded {
-		if sequence > bsb.ledgerBuffer.ledgerRange.to {
+	if bsb.prepared.bounded {
+		if sequence > bsb.prepared.to {
 			return xdr.LedgerCloseMeta{}, errors.New("requested sequence beyond current LedgerRange")
 		}
 	}
 
+	if bsb.batchCache != nil {
+		return bsb.getLedgerRandomAccess(ctx, sequence)
+	}
+
 	if sequence < bsb.lastLedger {
 		return xdr.LedgerCloseMeta{}, errors.New("requested sequence preceeds the lastLedger")
 	}
@@ -168,6 +219,47 @@ func (bsb *BufferedStorageBackend) GetLedger(ctx context.Context, sequence uint3
 	return ledgerCloseMeta, nil
 }
 
+// getLedgerRandomAccess returns the ledger from the cached batch containing it, downloading
+// the batch if needed, and reads ahead the following batches.
+func (bsb *BufferedStorageBackend) getLedgerRandomAccess(ctx context.Context, sequence uint32) (xdr.LedgerCloseMeta, error) {
+	var end uint32
+	if bsb.prepared.bounded {
+		end = bsb.prepared.to
+	}
+	bsb.batchCache.scheduleReadAhead(sequence, end)
+
+	lcmBatch, err := bsb.batchCache.get(ctx, sequence)
+	if err != nil {
+		return xdr.LedgerCloseMeta{}, errors.Wrapf(err, "failed getting ledger batch containing sequence %d", sequence)
+	}
+	return lcmBatch.GetLedger(sequence)
+}
+
+// PrefetchRanges downloads the ledger files of the given bounded ranges concurrently, in any order,
+// into the cache of a RandomAccess BufferedStorageBackend so that the following GetLedger calls
+// don't wait for them. Only the last CacheSize batches (or DiskCacheSize files with a disk cache)
+// are retained, ranges larger than that are not fully cached.
+func (bsb *BufferedStorageBackend) PrefetchRanges(ctx context.Context, ranges ...Range) error {
+	bsb.bsBackendLock.RLock()
+	defer bsb.bsBackendLock.RUnlock()
+
+	if bsb.closed {
+		return errors.New("BufferedStorageBackend is closed; cannot PrefetchRanges")
+	}
+
+	if bsb.batchCache == nil {
+		return errors.New("PrefetchRanges is only supported in RandomAccess mode")
+	}
+
+	for _, ledgerRange := range ranges {
+		if !ledgerRange.bounded {
+			return errors.Errorf("cannot prefetch unbounded range %s", ledgerRange)
+		}
+	}
+
+	return bsb.batchCache.prefetch(ctx, ranges, bsb.config.NumWorkers)
+}
+
 // PrepareRange checks if the starting and ending (if bounded) ledgers exist.
 func (bsb *BufferedStorageBackend) PrepareRange(ctx context.Context, ledgerRange Range) error {
 	bsb.bsBackendLock.Lock()
@@ -177,7 +269,7 @@ func (bsb *BufferedStorageBackend) PrepareRange(ctx context.Context, ledgerRange
 		return errors.New("BufferedStorageBackend is closed; cannot PrepareRange")
 	}
 
-	if alreadyPrepared, err := bsb.startPreparingRange(ledgerRange); err != nil {
+	if alreadyPrepared, err := bsb.startPreparingRange(ctx, ledgerRange); err != nil {
 		return errors.Wrap(err, "error starting prepare range")
 	} else if alreadyPrepared {
 		return nil
@@ -209,23 +301,23 @@ func (bsb *BufferedStorageBackend) isPrepared(ledgerRange Range) bool {
 		return false
 	}
 
-	if bsb.ledgerBuffer.ledgerRange.from > ledgerRange.from {
+	if bsb.prepared.from > ledgerRange.from {
 		return false
 	}
 
-	if bsb.ledgerBuffer.ledgerRange.bounded && !ledgerRange.bounded {
+	if bsb.prepared.bounded && !ledgerRange.bounded {
 		return false
 	}
 
-	if !bsb.ledgerBuffer.ledgerRange.bounded && !ledgerRange.bounded {
+	if !bsb.prepared.bounded && !ledgerRange.bounded {
 		return true
 	}
 
-	if !bsb.ledgerBuffer.ledgerRange.bounded && ledgerRange.bounded {
+	if !bsb.prepared.bounded && ledgerRange.bounded {
 		return true
 	}
 
-	if bsb.ledgerBuffer.ledgerRange.to >= ledgerRange.to {
+	if bsb.prepared.to >= ledgerRange.to {
 		return true
 	}
 
@@ -243,6 +335,9 @@ func (bsb *BufferedStorageBackend) Close() error {
 	if bsb.ledgerBuffer != nil {
 		bsb.ledgerBuffer.close()
 	}
+	if bsb.batchCache != nil {
+		bsb.batchCache.close()
+	}
 
 	bsb.closed = true
 
@@ -250,11 +345,30 @@ func (bsb *BufferedStorageBackend) Close() error {
 }
 
 // startPreparingRange prepares the ledger range by setting the range in the ledgerBuffer
-func (bsb *BufferedStorageBackend) startPreparingRange(ledgerRange Range) (bool, error) {
+func (bsb *BufferedStorageBackend) startPreparingRange(ctx context.Context, ledgerRange Range) (bool, error) {
 	if bsb.isPrepared(ledgerRange) {
 		return true, nil
 	}
 
+	// in RandomAccess mode the batches are downloaded when requested, the batches containing
+	// the boundaries of the range are downloaded upfront to check they exist
+	if bsb.batchCache != nil {
+		if !ledgerRange.bounded {
+			return false, errors.Errorf("cannot prepare unbounded range %s in RandomAccess mode", ledgerRange)
+		}
+		for _, sequence := range []uint32{ledgerRange.from, ledgerRange.to} {
+			if _, err := bsb.batchCache.get(ctx, sequence); err != nil {
+				return false, errors.Wrapf(err, "failed getting ledger batch containing sequence %d", sequence)
+			}
+		}
+		return false, nil
+	}
+
+	// stop the workers of the previously prepared range
+	if bsb.ledgerBuffer != nil {
+		bsb.ledgerBuffer.close()
+	}
+
 	var err error
 	bsb.ledgerBuffer, err = bsb.newLedgerBuffer(ledgerRange)
 	if err != nil {
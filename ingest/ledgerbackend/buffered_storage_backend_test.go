@@ -7,6 +7,8 @@ import (
 	"io"
 	"math"
 	"os"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -534,6 +536,7 @@ func TestLedgerBufferBoundedObjectNotFound(t *testing.T) {
 
 	objectName := fmt.Sprintf("FFFFFFFF--0-%d/%08X--%d.xdr.zstd", partition, math.MaxUint32-3, 3)
 	mockDataStore.On("GetFile", mock.Anything, objectName).Return(io.NopCloser(&bytes.Buffer{}), os.ErrNotExist).Once()
+	mockDataStore.On("ListFilePaths", mock.Anything, strings.TrimSuffix(objectName, "zstd"), 1).Return([]string{}, nil).Once()
 	t.Cleanup(func() {
 		mockDataStore.AssertExpectations(t)
 	})
@@ -569,6 +572,7 @@ func TestLedgerBufferUnboundedObjectNotFound(t *testing.T) {
 		}
 		iteration.Add(1)
 	})
+	mockDataStore.On("ListFilePaths", mock.Anything, strings.TrimSuffix(objectName, "zstd"), 1).Return([]string{}, nil)
 	t.Cleanup(func() {
 		mockDataStore.AssertExpectations(t)
 	})
@@ -612,3 +616,279 @@ func TestLedgerBufferRetryLimit(t *testing.T) {
 	assert.ErrorContains(t, err, objectName)
 	assert.ErrorContains(t, err, "transient error")
 }
+
+func TestLedgerBufferMixedCodecs(t *testing.T) {
+	ctx := context.Background()
+	bsb := createBufferedStorageBackendForTesting()
+	bsb.config.NumWorkers = 1
+	bsb.config.BufferSize = 2
+	ledgerRange := BoundedRange(3, 4)
+
+	mockDataStore := new(datastore.MockDataStore)
+	partition := ledgerPerFileCount*partitionSize - 1
+
+	// ledger 3 uses the schema's extension, ledger 4 was written with gzip
+	objectName := fmt.Sprintf("FFFFFFFF--0-%d/%08X--%d.xdr.zstd", partition, math.MaxUint32-3, 3)
+	mockDataStore.On("GetFile", mock.Anything, objectName).Return(createLCMBatchReader(3, 3, 1), nil).Once()
+
+	gzipCompressor, err := compressxdr.NewCompressor(compressxdr.Gzip, compressxdr.CompressorOptions{})
+	assert.NoError(t, err)
+	var buf bytes.Buffer
+	_, err = compressxdr.NewXDREncoder(gzipCompressor, createTestLedgerCloseMetaBatch(4, 4, 1)).WriteTo(&buf)
+	assert.NoError(t, err)
+
+	objectPrefix := fmt.Sprintf("FFFFFFFF--0-%d/%08X--%d.xdr.", partition, math.MaxUint32-4, 4)
+	mockDataStore.On("GetFile", mock.Anything, objectPrefix+"zstd").Return(nil, os.ErrNotExist).Once()
+	mockDataStore.On("ListFilePaths", mock.Anything, objectPrefix, 1).Return([]string{"bucket/" + objectPrefix + "gz"}, nil).Once()
+	mockDataStore.On("GetFile", mock.Anything, objectPrefix+"gz").Return(io.NopCloser(&buf), nil).Once()
+	t.Cleanup(func() {
+		mockDataStore.AssertExpectations(t)
+	})
+
+	bsb.dataStore = mockDataStore
+	assert.NoError(t, bsb.PrepareRange(ctx, ledgerRange))
+
+	for sequence := uint32(3); sequence <= 4; sequence++ {
+		lcm, err := bsb.GetLedger(ctx, sequence)
+		assert.NoError(t, err)
+		assert.Equal(t, xdr.Uint32(sequence), lcm.V0.LedgerHeader.Header.LedgerSeq)
+	}
+	assert.NoError(t, bsb.Close())
+}
+
+func TestLedgerBufferResolvesFileExtension(t *testing.T) {
+	ctx := context.Background()
+	bsb := createBufferedStorageBackendForTesting()
+	bsb.config.NumWorkers = 1
+	bsb.config.BufferSize = 2
+	bsb.schema.FileExtension = ""
+	ledgerRange := BoundedRange(3, 4)
+
+	mockDataStore := new(datastore.MockDataStore)
+	partition := ledgerPerFileCount*partitionSize - 1
+
+	gzipCompressor, err := compressxdr.NewCompressor(compressxdr.Gzip, compressxdr.CompressorOptions{})
+	assert.NoError(t, err)
+	gzipReader := func(sequence uint32) io.ReadCloser {
+		var buf bytes.Buffer
+		_, err := compressxdr.NewXDREncoder(gzipCompressor, createTestLedgerCloseMetaBatch(sequence, sequence, 1)).WriteTo(&buf)
+		assert.NoError(t, err)
+		return io.NopCloser(&buf)
+	}
+
+	// the objects were written with gzip rather than the default codec, which is
+	// only looked up for the first object
+	objectPrefix := fmt.Sprintf("FFFFFFFF--0-%d/%08X--%d.xdr.", partition, math.MaxUint32-3, 3)
+	mockDataStore.On("GetFile", mock.Anything, objectPrefix+"zst").Return(nil, os.ErrNotExist).Once()
+	mockDataStore.On("ListFilePaths", mock.Anything, objectPrefix, 1).Return([]string{"bucket/" + objectPrefix + "gz"}, nil).Once()
+	mockDataStore.On("GetFile", mock.Anything, objectPrefix+"gz").Return(gzipReader(3), nil).Once()
+	objectName := fmt.Sprintf("FFFFFFFF--0-%d/%08X--%d.xdr.gz", partition, math.MaxUint32-4, 4)
+	mockDataStore.On("GetFile", mock.Anything, objectName).Return(gzipReader(4), nil).Once()
+	t.Cleanup(func() {
+		mockDataStore.AssertExpectations(t)
+	})
+
+	bsb.dataStore = mockDataStore
+	assert.NoError(t, bsb.PrepareRange(ctx, ledgerRange))
+
+	for sequence := uint32(3); sequence <= 4; sequence++ {
+		lcm, err := bsb.GetLedger(ctx, sequence)
+		assert.NoError(t, err)
+		assert.Equal(t, xdr.Uint32(sequence), lcm.V0.LedgerHeader.Header.LedgerSeq)
+	}
+	assert.NoError(t, bsb.Close())
+	assert.Equal(t, "gz", bsb.fileExtension.get())
+}
+
+func TestLedgerBufferUnboundedNotified(t *testing.T) {
+	ctx := context.Background()
+	bsb := createBufferedStorageBackendForTesting()
+	bsb.config.NumWorkers = 1
+	bsb.config.BufferSize = 5
+	// the worker would not poll the data store again within the test without a notification
+	bsb.config.RetryWait = time.Hour
+	notifier := datastore.NewObjectCreatedNotifier()
+	bsb.config.Notifier = notifier
+	ledgerRange := UnboundedRange(3)
+
+	mockDataStore := new(datastore.MockDataStore)
+	partition := ledgerPerFileCount*partitionSize - 1
+
+	objectName := fmt.Sprintf("FFFFFFFF--0-%d/%08X--%d.xdr.zstd", partition, math.MaxUint32-3, 3)
+	polled := make(chan struct{})
+	mockDataStore.On("GetFile", mock.Anything, objectName).Return(nil, os.ErrNotExist).Once().Run(func(args mock.Arguments) {
+		close(polled)
+	})
+	mockDataStore.On("GetFile", mock.Anything, objectName).Return(createLCMBatchReader(3, 3, 1), nil).Once()
+	mockDataStore.On("GetFile", mock.Anything, mock.Anything).Return(nil, os.ErrNotExist).Maybe()
+	mockDataStore.On("ListFilePaths", mock.Anything, mock.Anything, 1).Return([]string{}, nil)
+	t.Cleanup(func() {
+		mockDataStore.AssertExpectations(t)
+	})
+
+	bsb.dataStore = mockDataStore
+	assert.NoError(t, bsb.PrepareRange(ctx, ledgerRange))
+
+	<-polled
+	notifier.ObjectCreated(objectName)
+
+	getCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	lcm, err := bsb.GetLedger(getCtx, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, xdr.Uint32(3), lcm.V0.LedgerHeader.Header.LedgerSeq)
+	assert.NoError(t, bsb.Close())
+}
+
+func createRandomAccessBufferedStorageBackendForTesting(t *testing.T, config BufferedStorageBackendConfig, dataStore datastore.DataStore) *BufferedStorageBackend {
+	config.RandomAccess = true
+	bsb, err := NewBufferedStorageBackend(config, dataStore, datastore.DataStoreSchema{
+		LedgersPerFile:    ledgerPerFileCount,
+		FilesPerPartition: partitionSize,
+		FileExtension:     "zstd",
+	})
+	assert.NoError(t, err)
+	return bsb
+}
+
+func TestBSBRandomAccess(t *testing.T) {
+	ctx := context.Background()
+	config := createBufferedStorageBackendConfigForTesting()
+	bsb := createRandomAccessBufferedStorageBackendForTesting(t, config, createMockdataStore(t, 3, 10, partitionSize, ledgerPerFileCount))
+	assert.NoError(t, bsb.PrepareRange(ctx, BoundedRange(3, 10)))
+
+	// every file is downloaded once, regardless of the order and concurrency of the reads
+	var wg sync.WaitGroup
+	for _, sequence := range []uint32{7, 3, 10, 5, 4, 9, 6, 8, 3, 7} {
+		wg.Add(1)
+		go func(sequence uint32) {
+			defer wg.Done()
+			lcm, err := bsb.GetLedger(ctx, sequence)
+			assert.NoError(t, err)
+			assert.Equal(t, xdr.Uint32(sequence), lcm.V0.LedgerHeader.Header.LedgerSeq)
+		}(sequence)
+	}
+	wg.Wait()
+
+	lcm, err := bsb.GetLedger(ctx, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, xdr.Uint32(4), lcm.V0.LedgerHeader.Header.LedgerSeq)
+
+	latest, err := bsb.GetLatestLedgerSequence(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(10), latest)
+
+	_, err = bsb.GetLedger(ctx, 11)
+	assert.EqualError(t, err, "requested sequence beyond current LedgerRange")
+	assert.NoError(t, bsb.Close())
+}
+
+func TestBSBRandomAccessDiskCache(t *testing.T) {
+	ctx := context.Background()
+	config := createBufferedStorageBackendConfigForTesting()
+	config.NumWorkers = 1
+	config.CacheSize = 1
+	config.DiskCachePath = t.TempDir()
+	bsb := createRandomAccessBufferedStorageBackendForTesting(t, config, createMockdataStore(t, 3, 5, partitionSize, ledgerPerFileCount))
+	assert.NoError(t, bsb.PrepareRange(ctx, BoundedRange(3, 5)))
+
+	// the batches evicted from memory are read back from the disk cache
+	for _, sequence := range []uint32{3, 4, 5, 3, 4, 5} {
+		lcm, err := bsb.GetLedger(ctx, sequence)
+		assert.NoError(t, err)
+		assert.Equal(t, xdr.Uint32(sequence), lcm.V0.LedgerHeader.Header.LedgerSeq)
+	}
+
+	assert.NoError(t, bsb.Close())
+	entries, err := os.ReadDir(config.DiskCachePath)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestBSBRandomAccessObjectNotFound(t *testing.T) {
+	ctx := context.Background()
+	config := createBufferedStorageBackendConfigForTesting()
+	config.NumWorkers = 0
+
+	mockDataStore := new(datastore.MockDataStore)
+	partition := ledgerPerFileCount*partitionSize - 1
+	objectName := fmt.Sprintf("FFFFFFFF--0-%d/%08X--%d.xdr.zstd", partition, math.MaxUint32-3, 3)
+	mockDataStore.On("GetFile", mock.Anything, objectName).Return(nil, os.ErrNotExist).Once()
+	mockDataStore.On("ListFilePaths", mock.Anything, strings.TrimSuffix(objectName, "zstd"), 1).Return([]string{}, nil).Once()
+	t.Cleanup(func() {
+		mockDataStore.AssertExpectations(t)
+	})
+
+	bsb := createRandomAccessBufferedStorageBackendForTesting(t, config, mockDataStore)
+	assert.EqualError(t, bsb.PrepareRange(ctx, UnboundedRange(3)),
+		"error starting prepare range: cannot prepare unbounded range [3,latest) in RandomAccess mode")
+
+	err := bsb.PrepareRange(ctx, BoundedRange(3, 5))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	assert.ErrorContains(t, err, "failed getting ledger batch containing sequence 3")
+
+	prepared, err := bsb.IsPrepared(ctx, BoundedRange(3, 5))
+	assert.NoError(t, err)
+	assert.False(t, prepared)
+	assert.NoError(t, bsb.Close())
+}
+
+func TestBSBRandomAccessPrepareRangeEndNotFound(t *testing.T) {
+	ctx := context.Background()
+	config := createBufferedStorageBackendConfigForTesting()
+	config.NumWorkers = 0
+
+	mockDataStore := new(datastore.MockDataStore)
+	partition := ledgerPerFileCount*partitionSize - 1
+	objectName := fmt.Sprintf("FFFFFFFF--0-%d/%08X--%d.xdr.zstd", partition, math.MaxUint32-3, 3)
+	mockDataStore.On("GetFile", mock.Anything, objectName).Return(createLCMBatchReader(3, 3, 1), nil).Once()
+	objectName = fmt.Sprintf("FFFFFFFF--0-%d/%08X--%d.xdr.zstd", partition, math.MaxUint32-9, 9)
+	mockDataStore.On("GetFile", mock.Anything, objectName).Return(nil, os.ErrNotExist).Once()
+	mockDataStore.On("ListFilePaths", mock.Anything, strings.TrimSuffix(objectName, "zstd"), 1).Return([]string{}, nil).Once()
+	t.Cleanup(func() {
+		mockDataStore.AssertExpectations(t)
+	})
+
+	bsb := createRandomAccessBufferedStorageBackendForTesting(t, config, mockDataStore)
+	err := bsb.PrepareRange(ctx, BoundedRange(3, 9))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	assert.ErrorContains(t, err, "failed getting ledger batch containing sequence 9")
+
+	// the batch downloaded while preparing the range is cached
+	assert.NoError(t, bsb.PrepareRange(ctx, BoundedRange(3, 3)))
+	lcm, err := bsb.GetLedger(ctx, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, xdr.Uint32(3), lcm.V0.LedgerHeader.Header.LedgerSeq)
+	assert.NoError(t, bsb.Close())
+}
+
+func TestBSBPrefetchRanges(t *testing.T) {
+	ctx := context.Background()
+	config := createBufferedStorageBackendConfigForTesting()
+	config.NumWorkers = 2
+	mockDataStore := new(datastore.MockDataStore)
+	partition := ledgerPerFileCount*partitionSize - 1
+	for _, sequence := range []uint32{3, 4, 5, 20, 21} {
+		objectName := fmt.Sprintf("FFFFFFFF--0-%d/%08X--%d.xdr.zstd", partition, math.MaxUint32-sequence, sequence)
+		mockDataStore.On("GetFile", mock.Anything, objectName).Return(createLCMBatchReader(sequence, sequence, 1), nil).Once()
+	}
+	t.Cleanup(func() {
+		mockDataStore.AssertExpectations(t)
+	})
+
+	bsb := createRandomAccessBufferedStorageBackendForTesting(t, config, mockDataStore)
+	assert.NoError(t, bsb.PrefetchRanges(ctx, BoundedRange(3, 5), BoundedRange(20, 21)))
+	assert.EqualError(t, bsb.PrefetchRanges(ctx, UnboundedRange(3)), "cannot prefetch unbounded range [3,latest)")
+
+	// the prefetched ledgers are served from the cache
+	assert.NoError(t, bsb.PrepareRange(ctx, BoundedRange(20, 21)))
+	for _, sequence := range []uint32{21, 20} {
+		lcm, err := bsb.GetLedger(ctx, sequence)
+		assert.NoError(t, err)
+		assert.Equal(t, xdr.Uint32(sequence), lcm.V0.LedgerHeader.Header.LedgerSeq)
+	}
+	assert.NoError(t, bsb.Close())
+
+	sequential := createBufferedStorageBackendForTesting()
+	assert.EqualError(t, sequential.PrefetchRanges(ctx, BoundedRange(3, 5)), "PrefetchRanges is only supported in RandomAccess mode")
+}
@@ -277,7 +277,7 @@ func (c *CaptiveStellarCore) GetCoreVersion() string {
 	return c.captiveCoreVersion
 }
 
-func (c *CaptiveStellarCore) registerMetrics(registry *prometheus.Registry, namespace string) {
+func (c *CaptiveStellarCore) registerMetrics(registry prometheus.Registerer, namespace string) {
 	coreSynced := prometheus.NewGaugeFunc(
 		prometheus.GaugeOpts{
 			Namespace: namespace, Subsystem: "ingest", Name: "captive_stellar_core_synced",
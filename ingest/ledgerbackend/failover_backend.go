@@ -0,0 +1,392 @@
+package ledgerbackend
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stellar/go/support/log"
+	"github.com/stellar/go/xdr"
+)
+
+// Ensure FailoverLedgerBackend implements LedgerBackend
+var _ LedgerBackend = (*FailoverLedgerBackend)(nil)
+
+// FailoverSource is one of the backends of a FailoverLedgerBackend.
+type FailoverSource struct {
+	// Name identifies the backend in the logs and in the "backend" label of the metrics.
+	Name    string
+	Backend LedgerBackend
+}
+
+type FailoverLedgerBackendConfig struct {
+	// StallTimeout, optional, is how long GetLedger waits for a ledger from the active
+	// backend before failing over to the next one. It must be well above the ledger close
+	// time of the network when streaming an unbounded range. Zero disables stall detection.
+	StallTimeout time.Duration
+	// FailureCooldown is how long a backend which failed is only used as a last resort,
+	// defaults to 5 minutes.
+	FailureCooldown time.Duration
+	// Failback makes GetLedger switch back to a higher priority backend once its FailureCooldown
+	// has passed, preparing the rest of the range on it. Otherwise the backend failed over to
+	// stays active until it fails in turn.
+	Failback bool
+	// Registry, optional, registers the failover metrics under Namespace.
+	Registry  prometheus.Registerer
+	Namespace string
+	// Log, optional, defaults to the default logger.
+	Log *log.Entry
+}
+
+// FailoverLedgerBackend delegates to the first healthy backend of an ordered list of backends,
+// e.g. captive core, then an RPC server, then a data lake read with BufferedStorageBackend.
+// When the active backend fails or stalls, the range is prepared on the next healthy backend,
+// starting from the ledger which was requested, and the hash chain of the ledgers returned by
+// the new backend is checked against the ledgers returned so far.
+type FailoverLedgerBackend struct {
+	config  FailoverLedgerBackendConfig
+	sources []FailoverSource
+	log     *log.Entry
+
+	// callLock serializes PrepareRange and GetLedger, which call the backends while holding it.
+	// lock only guards active, so that IsPrepared and GetLatestLedgerSequence don't wait for a
+	// blocked backend call.
+	callLock sync.Mutex
+	lock     sync.Mutex
+	active   int // index of the active source, -1 until a range is prepared
+	failedAt []time.Time
+	prepared *Range
+	closed   atomic.Bool // set without the locks so that Close can interrupt a blocked call
+
+	// the last ledger returned, to cross-check the ledgers of the backend failed over to
+	lastLedger uint32
+	lastHash   xdr.Hash
+	verifyNext bool
+
+	activeBackendGauge     *prometheus.GaugeVec
+	switchCounter          *prometheus.CounterVec
+	errorCounter           *prometheus.CounterVec
+	ledgerFetchDurationVec *prometheus.SummaryVec
+}
+
+// NewFailoverLedgerBackend returns a FailoverLedgerBackend over sources, ordered by priority.
+func NewFailoverLedgerBackend(config FailoverLedgerBackendConfig, sources ...FailoverSource) (*FailoverLedgerBackend, error) {
+	if len(sources) == 0 {
+		return nil, errors.New("at least one backend is required")
+	}
+	names := map[string]bool{}
+	for _, source := range sources {
+		if source.Name == "" || source.Backend == nil {
+			return nil, errors.New("backends must have a name and a LedgerBackend")
+		}
+		if names[source.Name] {
+			return nil, errors.Errorf("duplicate backend name %s", source.Name)
+		}
+		names[source.Name] = true
+	}
+	if config.FailureCooldown == 0 {
+		config.FailureCooldown = 5 * time.Minute
+	}
+
+	backend := &FailoverLedgerBackend{
+		config:   config,
+		sources:  sources,
+		log:      config.Log,
+		active:   -1,
+		failedAt: make([]time.Time, len(sources)),
+	}
+	if backend.log == nil {
+		backend.log = log.DefaultLogger
+	}
+	backend.log = backend.log.WithField("subservice", "failover-backend")
+
+	backend.activeBackendGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: config.Namespace, Subsystem: "ingest", Name: "failover_active_backend",
+		Help: "1 for the backend ledgers are currently read from, 0 for the others",
+	}, []string{"backend"})
+	backend.switchCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: config.Namespace, Subsystem: "ingest", Name: "failover_switches_total",
+		Help: "number of times ledgers started being read from another backend",
+	}, []string{"from", "to"})
+	backend.errorCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: config.Namespace, Subsystem: "ingest", Name: "failover_backend_errors_total",
+		Help: "number of errors and stalls of the backends, by reason",
+	}, []string{"backend", "reason"})
+	backend.ledgerFetchDurationVec = prometheus.NewSummaryVec(prometheus.SummaryOpts{
+		Namespace: config.Namespace, Subsystem: "ingest", Name: "failover_ledger_fetch_duration_seconds",
+		Help:       "duration of fetching ledgers from each backend, sliding window = 10m",
+		Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+	}, []string{"backend"})
+	for _, source := range sources {
+		backend.activeBackendGauge.WithLabelValues(source.Name).Set(0)
+	}
+	if config.Registry != nil {
+		config.Registry.MustRegister(
+			backend.activeBackendGauge,
+			backend.switchCounter,
+			backend.errorCounter,
+			backend.ledgerFetchDurationVec,
+		)
+	}
+
+	return backend, nil
+}
+
+// candidates returns the indexes of the sources to try in order, the healthy ones by priority
+// followed by the ones which failed recently, excluding exclude.
+func (b *FailoverLedgerBackend) candidates(exclude int) []int {
+	var healthy, cooling []int
+	for i := range b.sources {
+		if i == exclude {
+			continue
+		}
+		if b.coolingDown(i) {
+			cooling = append(cooling, i)
+		} else {
+			healthy = append(healthy, i)
+		}
+	}
+	return append(healthy, cooling...)
+}
+
+// coolingDown returns true if the i-th backend failed less than FailureCooldown ago.
+func (b *FailoverLedgerBackend) coolingDown(i int) bool {
+	return !b.failedAt[i].IsZero() && time.Since(b.failedAt[i]) < b.config.FailureCooldown
+}
+
+func (b *FailoverLedgerBackend) markFailed(i int, reason string, err error) {
+	b.failedAt[i] = time.Now()
+	b.errorCounter.WithLabelValues(b.sources[i].Name, reason).Inc()
+	b.log.WithError(err).WithField("backend", b.sources[i].Name).Warnf("Backend failed (%s)", reason)
+}
+
+func (b *FailoverLedgerBackend) activate(i int) {
+	if b.active == i {
+		return
+	}
+	if b.active >= 0 {
+		b.switchCounter.WithLabelValues(b.sources[b.active].Name, b.sources[i].Name).Inc()
+		b.activeBackendGauge.WithLabelValues(b.sources[b.active].Name).Set(0)
+		b.log.Infof("Switching from backend %s to %s", b.sources[b.active].Name, b.sources[i].Name)
+		// cross-check the first ledger of the new backend with the previous one
+		b.verifyNext = b.lastLedger != 0
+	}
+	b.lock.Lock()
+	b.active = i
+	b.lock.Unlock()
+	b.activeBackendGauge.WithLabelValues(b.sources[i].Name).Set(1)
+}
+
+// prepareFrom prepares ledgerRange on the first candidate which succeeds.
+func (b *FailoverLedgerBackend) prepareFrom(ctx context.Context, ledgerRange Range, candidates []int) error {
+	var lastErr error
+	for _, i := range candidates {
+		if err := b.sources[i].Backend.PrepareRange(ctx, ledgerRange); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			b.markFailed(i, "prepare", err)
+			lastErr = err
+			continue
+		}
+		b.activate(i)
+		return nil
+	}
+	return errors.Wrapf(lastErr, "no backend could prepare range %s", ledgerRange)
+}
+
+// activeBackend returns the active backend, or nil until a range is prepared.
+func (b *FailoverLedgerBackend) activeBackend() LedgerBackend {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.active < 0 {
+		return nil
+	}
+	return b.sources[b.active].Backend
+}
+
+// GetLatestLedgerSequence returns the latest ledger sequence of the active backend.
+func (b *FailoverLedgerBackend) GetLatestLedgerSequence(ctx context.Context) (uint32, error) {
+	if b.closed.Load() {
+		return 0, errors.New("FailoverLedgerBackend is closed; cannot GetLatestLedgerSequence")
+	}
+	backend := b.activeBackend()
+	if backend == nil {
+		return 0, errors.New("FailoverLedgerBackend must be prepared, call PrepareRange first")
+	}
+	return backend.GetLatestLedgerSequence(ctx)
+}
+
+// PrepareRange prepares the range on the first healthy backend which succeeds.
+func (b *FailoverLedgerBackend) PrepareRange(ctx context.Context, ledgerRange Range) error {
+	b.callLock.Lock()
+	defer b.callLock.Unlock()
+
+	if b.closed.Load() {
+		return errors.New("FailoverLedgerBackend is closed; cannot PrepareRange")
+	}
+
+	candidates := b.candidates(-1)
+	if err := b.prepareFrom(ctx, ledgerRange, candidates); err != nil {
+		return err
+	}
+	b.prepared = &ledgerRange
+	return nil
+}
+
+// IsPrepared returns true if the given range is prepared on the active backend.
+func (b *FailoverLedgerBackend) IsPrepared(ctx context.Context, ledgerRange Range) (bool, error) {
+	if b.closed.Load() {
+		return false, errors.New("FailoverLedgerBackend is closed; cannot IsPrepared")
+	}
+	backend := b.activeBackend()
+	if backend == nil {
+		return false, nil
+	}
+	return backend.IsPrepared(ctx, ledgerRange)
+}
+
+// GetLedger returns the ledger from the active backend, failing over to the other
+// backends if it fails or stalls for longer than StallTimeout. With Failback, it first
+// switches back to a higher priority backend whose FailureCooldown has passed.
+func (b *FailoverLedgerBackend) GetLedger(ctx context.Context, sequence uint32) (xdr.LedgerCloseMeta, error) {
+	b.callLock.Lock()
+	defer b.callLock.Unlock()
+
+	if b.closed.Load() {
+		return xdr.LedgerCloseMeta{}, errors.New("FailoverLedgerBackend is closed; cannot GetLedger")
+	}
+	if b.active < 0 {
+		return xdr.LedgerCloseMeta{}, errors.New("session is not prepared, call PrepareRange first")
+	}
+
+	if b.config.Failback {
+		b.failback(ctx, sequence)
+		if ctx.Err() != nil {
+			return xdr.LedgerCloseMeta{}, ctx.Err()
+		}
+	}
+
+	lcm, err := b.getLedger(ctx, b.active, sequence)
+	if err == nil {
+		return lcm, nil
+	}
+	if ctx.Err() != nil {
+		return xdr.LedgerCloseMeta{}, ctx.Err()
+	}
+	if b.closed.Load() {
+		return xdr.LedgerCloseMeta{}, errors.New("FailoverLedgerBackend is closed; cannot GetLedger")
+	}
+
+	// fail over, preparing the rest of the range on the other backends
+	failed := b.active
+	for _, i := range b.candidates(failed) {
+		if err = b.prepareFrom(ctx, b.remainingRange(sequence), []int{i}); err != nil {
+			if ctx.Err() != nil {
+				return xdr.LedgerCloseMeta{}, ctx.Err()
+			}
+			continue
+		}
+		if lcm, err = b.getLedger(ctx, i, sequence); err == nil {
+			return lcm, nil
+		}
+		if ctx.Err() != nil {
+			return xdr.LedgerCloseMeta{}, ctx.Err()
+		}
+	}
+	return xdr.LedgerCloseMeta{}, errors.Wrapf(err, "no backend could get ledger %d", sequence)
+}
+
+// remainingRange returns the part of the prepared range starting at sequence.
+func (b *FailoverLedgerBackend) remainingRange(sequence uint32) Range {
+	if b.prepared.bounded {
+		return BoundedRange(sequence, b.prepared.to)
+	}
+	return UnboundedRange(sequence)
+}
+
+// failback prepares the rest of the range on the highest priority backend above the active
+// one whose FailureCooldown has passed, switching to it if it succeeds. A backend which fails
+// to prepare starts a new cooldown, so that it is retried at most once per FailureCooldown.
+func (b *FailoverLedgerBackend) failback(ctx context.Context, sequence uint32) {
+	for i := 0; i < b.active; i++ {
+		if b.coolingDown(i) {
+			continue
+		}
+		// failures are logged and counted by prepareFrom, the active backend is then kept
+		_ = b.prepareFrom(ctx, b.remainingRange(sequence), []int{i})
+		return
+	}
+}
+
+// getLedger gets the ledger from the i-th backend, marking it as failed if it fails, stalls
+// or returns a ledger which is not consistent with the ledgers returned previously.
+func (b *FailoverLedgerBackend) getLedger(ctx context.Context, i int, sequence uint32) (xdr.LedgerCloseMeta, error) {
+	source := b.sources[i]
+	getCtx := ctx
+	if b.config.StallTimeout > 0 {
+		var cancel context.CancelFunc
+		getCtx, cancel = context.WithTimeout(ctx, b.config.StallTimeout)
+		defer cancel()
+	}
+
+	startTime := time.Now()
+	lcm, err := source.Backend.GetLedger(getCtx, sequence)
+	if err != nil {
+		if ctx.Err() == nil {
+			reason := "get_ledger"
+			if errors.Is(getCtx.Err(), context.DeadlineExceeded) {
+				reason = "stall"
+			}
+			b.markFailed(i, reason, err)
+		}
+		return xdr.LedgerCloseMeta{}, err
+	}
+	b.ledgerFetchDurationVec.WithLabelValues(source.Name).Observe(time.Since(startTime).Seconds())
+
+	if b.verifyNext {
+		if err = b.checkContinuity(lcm); err != nil {
+			b.markFailed(i, "hash_mismatch", err)
+			return xdr.LedgerCloseMeta{}, err
+		}
+		b.verifyNext = false
+	}
+	b.lastLedger = lcm.LedgerSequence()
+	b.lastHash = lcm.LedgerHash()
+	return lcm, nil
+}
+
+// checkContinuity checks that lcm is on the same hash chain as the last ledger returned.
+func (b *FailoverLedgerBackend) checkContinuity(lcm xdr.LedgerCloseMeta) error {
+	switch lcm.LedgerSequence() {
+	case b.lastLedger:
+		if lcm.LedgerHash() != b.lastHash {
+			return errors.Errorf("ledger %d hash %s does not match hash %s returned previously",
+				b.lastLedger, lcm.LedgerHash().HexString(), b.lastHash.HexString())
+		}
+	case b.lastLedger + 1:
+		if lcm.PreviousLedgerHash() != b.lastHash {
+			return errors.Errorf("ledger %d previous ledger hash %s does not match hash %s of ledger %d",
+				lcm.LedgerSequence(), lcm.PreviousLedgerHash().HexString(), b.lastHash.HexString(), b.lastLedger)
+		}
+	}
+	return nil
+}
+
+// Close closes all the backends. It can be called from another goroutine to
+// interrupt a blocked GetLedger or PrepareRange.
+func (b *FailoverLedgerBackend) Close() error {
+	b.closed.Store(true)
+
+	var firstErr error
+	for _, source := range b.sources {
+		if err := source.Backend.Close(); err != nil && firstErr == nil {
+			firstErr = errors.Wrapf(err, "error closing backend %s", source.Name)
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,296 @@
+package ledgerbackend
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/xdr"
+)
+
+func counterValue(t *testing.T, counter *prometheus.CounterVec, labels ...string) float64 {
+	value := &dto.Metric{}
+	require.NoError(t, counter.WithLabelValues(labels...).Write(value))
+	return value.GetCounter().GetValue()
+}
+
+func gaugeValue(t *testing.T, gauge *prometheus.GaugeVec, labels ...string) float64 {
+	value := &dto.Metric{}
+	require.NoError(t, gauge.WithLabelValues(labels...).Write(value))
+	return value.GetGauge().GetValue()
+}
+
+// chainedLedgerCloseMeta returns a ledger whose hash is derived from its sequence and
+// whose previous ledger hash is the hash of the previous sequence.
+func chainedLedgerCloseMeta(sequence uint32) xdr.LedgerCloseMeta {
+	lcm := createLedgerCloseMeta(sequence)
+	binary.BigEndian.PutUint32(lcm.V0.LedgerHeader.Hash[:], sequence)
+	binary.BigEndian.PutUint32(lcm.V0.LedgerHeader.Header.PreviousLedgerHash[:], sequence-1)
+	return lcm
+}
+
+func newTestFailoverBackend(t *testing.T, config FailoverLedgerBackendConfig, backends ...*MockDatabaseBackend) *FailoverLedgerBackend {
+	var sources []FailoverSource
+	for i, backend := range backends {
+		sources = append(sources, FailoverSource{Name: fmt.Sprintf("backend-%d", i), Backend: backend})
+		t.Cleanup(func() {
+			backend.AssertExpectations(t)
+		})
+	}
+	config.Registry = prometheus.NewRegistry()
+	failover, err := NewFailoverLedgerBackend(config, sources...)
+	require.NoError(t, err)
+	return failover
+}
+
+func TestNewFailoverLedgerBackendInvalidConfig(t *testing.T) {
+	_, err := NewFailoverLedgerBackend(FailoverLedgerBackendConfig{})
+	assert.EqualError(t, err, "at least one backend is required")
+
+	backend := &MockDatabaseBackend{}
+	_, err = NewFailoverLedgerBackend(FailoverLedgerBackendConfig{},
+		FailoverSource{Name: "core", Backend: backend},
+		FailoverSource{Name: "core", Backend: backend},
+	)
+	assert.EqualError(t, err, "duplicate backend name core")
+}
+
+func TestFailoverPrepareRange(t *testing.T) {
+	ctx := context.Background()
+	primary, secondary := &MockDatabaseBackend{}, &MockDatabaseBackend{}
+	primary.On("PrepareRange", ctx, UnboundedRange(2)).Return(fmt.Errorf("core is not available")).Once()
+	secondary.On("PrepareRange", ctx, UnboundedRange(2)).Return(nil).Once()
+	secondary.On("GetLedger", mock.Anything, uint32(2)).Return(chainedLedgerCloseMeta(2), nil).Once()
+	secondary.On("IsPrepared", ctx, UnboundedRange(2)).Return(true, nil).Once()
+
+	failover := newTestFailoverBackend(t, FailoverLedgerBackendConfig{}, primary, secondary)
+	_, err := failover.GetLedger(ctx, 2)
+	assert.EqualError(t, err, "session is not prepared, call PrepareRange first")
+
+	require.NoError(t, failover.PrepareRange(ctx, UnboundedRange(2)))
+	prepared, err := failover.IsPrepared(ctx, UnboundedRange(2))
+	require.NoError(t, err)
+	assert.True(t, prepared)
+
+	lcm, err := failover.GetLedger(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), lcm.LedgerSequence())
+
+	assert.Equal(t, float64(0), gaugeValue(t, failover.activeBackendGauge, "backend-0"))
+	assert.Equal(t, float64(1), gaugeValue(t, failover.activeBackendGauge, "backend-1"))
+	assert.Equal(t, float64(1), counterValue(t, failover.errorCounter, "backend-0", "prepare"))
+}
+
+func TestFailoverPrepareRangeAllFail(t *testing.T) {
+	ctx := context.Background()
+	primary, secondary := &MockDatabaseBackend{}, &MockDatabaseBackend{}
+	primary.On("PrepareRange", ctx, BoundedRange(2, 10)).Return(fmt.Errorf("core is not available")).Once()
+	secondary.On("PrepareRange", ctx, BoundedRange(2, 10)).Return(fmt.Errorf("rpc is not available")).Once()
+
+	failover := newTestFailoverBackend(t, FailoverLedgerBackendConfig{}, primary, secondary)
+	assert.EqualError(t, failover.PrepareRange(ctx, BoundedRange(2, 10)),
+		"no backend could prepare range [2,10]: rpc is not available")
+}
+
+func TestFailoverGetLedgerError(t *testing.T) {
+	ctx := context.Background()
+	primary, secondary := &MockDatabaseBackend{}, &MockDatabaseBackend{}
+	primary.On("PrepareRange", ctx, BoundedRange(2, 10)).Return(nil).Once()
+	primary.On("GetLedger", mock.Anything, uint32(2)).Return(chainedLedgerCloseMeta(2), nil).Once()
+	primary.On("GetLedger", mock.Anything, uint32(3)).Return(xdr.LedgerCloseMeta{}, fmt.Errorf("core crashed")).Once()
+	// the rest of the range is prepared on the secondary backend
+	secondary.On("PrepareRange", ctx, BoundedRange(3, 10)).Return(nil).Once()
+	secondary.On("GetLedger", mock.Anything, uint32(3)).Return(chainedLedgerCloseMeta(3), nil).Once()
+	secondary.On("GetLedger", mock.Anything, uint32(4)).Return(chainedLedgerCloseMeta(4), nil).Once()
+
+	failover := newTestFailoverBackend(t, FailoverLedgerBackendConfig{}, primary, secondary)
+	require.NoError(t, failover.PrepareRange(ctx, BoundedRange(2, 10)))
+	for sequence := uint32(2); sequence <= 4; sequence++ {
+		lcm, err := failover.GetLedger(ctx, sequence)
+		require.NoError(t, err)
+		assert.Equal(t, sequence, lcm.LedgerSequence())
+	}
+
+	assert.Equal(t, float64(1), counterValue(t, failover.switchCounter, "backend-0", "backend-1"))
+	assert.Equal(t, float64(1), counterValue(t, failover.errorCounter, "backend-0", "get_ledger"))
+	assert.Equal(t, float64(1), gaugeValue(t, failover.activeBackendGauge, "backend-1"))
+}
+
+func TestFailoverGetLedgerStall(t *testing.T) {
+	ctx := context.Background()
+	primary, secondary := &MockDatabaseBackend{}, &MockDatabaseBackend{}
+	primary.On("PrepareRange", ctx, UnboundedRange(2)).Return(nil).Once()
+	primary.On("GetLedger", mock.Anything, uint32(2)).Return(chainedLedgerCloseMeta(2), nil).Once()
+	primary.On("GetLedger", mock.Anything, uint32(3)).
+		Run(func(args mock.Arguments) {
+			<-args.Get(0).(context.Context).Done()
+		}).
+		Return(xdr.LedgerCloseMeta{}, context.DeadlineExceeded).Once()
+	secondary.On("PrepareRange", ctx, UnboundedRange(3)).Return(nil).Once()
+	secondary.On("GetLedger", mock.Anything, uint32(3)).Return(chainedLedgerCloseMeta(3), nil).Once()
+
+	failover := newTestFailoverBackend(t, FailoverLedgerBackendConfig{StallTimeout: 10 * time.Millisecond}, primary, secondary)
+	require.NoError(t, failover.PrepareRange(ctx, UnboundedRange(2)))
+	for sequence := uint32(2); sequence <= 3; sequence++ {
+		lcm, err := failover.GetLedger(ctx, sequence)
+		require.NoError(t, err)
+		assert.Equal(t, sequence, lcm.LedgerSequence())
+	}
+	assert.Equal(t, float64(1), counterValue(t, failover.errorCounter, "backend-0", "stall"))
+}
+
+func TestFailoverGetLedgerHashMismatch(t *testing.T) {
+	ctx := context.Background()
+	primary, secondary, tertiary := &MockDatabaseBackend{}, &MockDatabaseBackend{}, &MockDatabaseBackend{}
+	primary.On("PrepareRange", ctx, UnboundedRange(2)).Return(nil).Once()
+	primary.On("GetLedger", mock.Anything, uint32(2)).Return(chainedLedgerCloseMeta(2), nil).Once()
+	primary.On("GetLedger", mock.Anything, uint32(3)).Return(xdr.LedgerCloseMeta{}, fmt.Errorf("core crashed")).Once()
+
+	// the secondary backend is on another chain
+	forked := chainedLedgerCloseMeta(3)
+	forked.V0.LedgerHeader.Header.PreviousLedgerHash = xdr.Hash{0xff}
+	secondary.On("PrepareRange", ctx, UnboundedRange(3)).Return(nil).Once()
+	secondary.On("GetLedger", mock.Anything, uint32(3)).Return(forked, nil).Once()
+
+	tertiary.On("PrepareRange", ctx, UnboundedRange(3)).Return(nil).Once()
+	tertiary.On("GetLedger", mock.Anything, uint32(3)).Return(chainedLedgerCloseMeta(3), nil).Once()
+
+	failover := newTestFailoverBackend(t, FailoverLedgerBackendConfig{}, primary, secondary, tertiary)
+	require.NoError(t, failover.PrepareRange(ctx, UnboundedRange(2)))
+	for sequence := uint32(2); sequence <= 3; sequence++ {
+		lcm, err := failover.GetLedger(ctx, sequence)
+		require.NoError(t, err)
+		assert.Equal(t, sequence, lcm.LedgerSequence())
+		assert.Equal(t, chainedLedgerCloseMeta(sequence), lcm)
+	}
+	assert.Equal(t, float64(1), counterValue(t, failover.errorCounter, "backend-1", "hash_mismatch"))
+	assert.Equal(t, float64(1), gaugeValue(t, failover.activeBackendGauge, "backend-2"))
+}
+
+func TestFailoverGetLedgerAllFail(t *testing.T) {
+	ctx := context.Background()
+	primary, secondary := &MockDatabaseBackend{}, &MockDatabaseBackend{}
+	primary.On("PrepareRange", ctx, UnboundedRange(2)).Return(nil).Once()
+	primary.On("GetLedger", mock.Anything, uint32(2)).Return(xdr.LedgerCloseMeta{}, fmt.Errorf("core crashed")).Once()
+	secondary.On("PrepareRange", ctx, UnboundedRange(2)).Return(nil).Once()
+	secondary.On("GetLedger", mock.Anything, uint32(2)).Return(xdr.LedgerCloseMeta{}, fmt.Errorf("ledger 2 is missing")).Once()
+
+	failover := newTestFailoverBackend(t, FailoverLedgerBackendConfig{}, primary, secondary)
+	require.NoError(t, failover.PrepareRange(ctx, UnboundedRange(2)))
+	_, err := failover.GetLedger(ctx, 2)
+	assert.EqualError(t, err, "no backend could get ledger 2: ledger 2 is missing")
+
+	// the backends which failed recently are tried last
+	assert.Equal(t, []int{0}, failover.candidates(1))
+	failover.failedAt[0] = time.Now().Add(-time.Hour)
+	assert.Equal(t, []int{0, 1}, failover.candidates(-1))
+}
+
+func TestFailoverClose(t *testing.T) {
+	primary, secondary := &MockDatabaseBackend{}, &MockDatabaseBackend{}
+	primary.On("Close").Return(nil).Once()
+	secondary.On("Close").Return(fmt.Errorf("already closed")).Once()
+
+	failover := newTestFailoverBackend(t, FailoverLedgerBackendConfig{}, primary, secondary)
+	assert.EqualError(t, failover.Close(), "error closing backend backend-1: already closed")
+	assert.EqualError(t, failover.PrepareRange(context.Background(), UnboundedRange(2)),
+		"FailoverLedgerBackend is closed; cannot PrepareRange")
+}
+
+func TestFailoverStaysOnFailedOverBackend(t *testing.T) {
+	ctx := context.Background()
+	primary, secondary := &MockDatabaseBackend{}, &MockDatabaseBackend{}
+	primary.On("PrepareRange", ctx, BoundedRange(2, 10)).Return(nil).Once()
+	primary.On("GetLedger", mock.Anything, uint32(2)).Return(xdr.LedgerCloseMeta{}, fmt.Errorf("core crashed")).Once()
+	secondary.On("PrepareRange", ctx, BoundedRange(2, 10)).Return(nil).Once()
+	secondary.On("GetLedger", mock.Anything, uint32(2)).Return(chainedLedgerCloseMeta(2), nil).Once()
+	secondary.On("GetLedger", mock.Anything, uint32(3)).Return(chainedLedgerCloseMeta(3), nil).Once()
+
+	// without Failback the primary backend is not used again once its cooldown has passed
+	failover := newTestFailoverBackend(t, FailoverLedgerBackendConfig{FailureCooldown: time.Millisecond}, primary, secondary)
+	require.NoError(t, failover.PrepareRange(ctx, BoundedRange(2, 10)))
+	for sequence := uint32(2); sequence <= 3; sequence++ {
+		time.Sleep(5 * time.Millisecond)
+		lcm, err := failover.GetLedger(ctx, sequence)
+		require.NoError(t, err)
+		assert.Equal(t, sequence, lcm.LedgerSequence())
+	}
+	assert.Equal(t, float64(1), gaugeValue(t, failover.activeBackendGauge, "backend-1"))
+}
+
+func TestFailoverFailback(t *testing.T) {
+	ctx := context.Background()
+	primary, secondary := &MockDatabaseBackend{}, &MockDatabaseBackend{}
+	primary.On("PrepareRange", ctx, BoundedRange(2, 10)).Return(nil).Once()
+	primary.On("GetLedger", mock.Anything, uint32(2)).Return(chainedLedgerCloseMeta(2), nil).Once()
+	primary.On("GetLedger", mock.Anything, uint32(3)).Return(xdr.LedgerCloseMeta{}, fmt.Errorf("core crashed")).Once()
+	secondary.On("PrepareRange", ctx, BoundedRange(3, 10)).Return(nil).Once()
+	secondary.On("GetLedger", mock.Anything, uint32(3)).Return(chainedLedgerCloseMeta(3), nil).Once()
+
+	// the primary backend is retried once its cooldown has passed, until it recovers
+	primary.On("PrepareRange", ctx, BoundedRange(4, 10)).Return(fmt.Errorf("core is not available")).Once()
+	secondary.On("GetLedger", mock.Anything, uint32(4)).Return(chainedLedgerCloseMeta(4), nil).Once()
+	primary.On("PrepareRange", ctx, BoundedRange(5, 10)).Return(nil).Once()
+	primary.On("GetLedger", mock.Anything, uint32(5)).Return(chainedLedgerCloseMeta(5), nil).Once()
+	primary.On("GetLedger", mock.Anything, uint32(6)).Return(chainedLedgerCloseMeta(6), nil).Once()
+
+	failover := newTestFailoverBackend(t, FailoverLedgerBackendConfig{FailureCooldown: time.Millisecond, Failback: true}, primary, secondary)
+	require.NoError(t, failover.PrepareRange(ctx, BoundedRange(2, 10)))
+	for sequence := uint32(2); sequence <= 6; sequence++ {
+		time.Sleep(5 * time.Millisecond)
+		lcm, err := failover.GetLedger(ctx, sequence)
+		require.NoError(t, err)
+		assert.Equal(t, chainedLedgerCloseMeta(sequence), lcm)
+	}
+
+	assert.Equal(t, float64(1), counterValue(t, failover.switchCounter, "backend-0", "backend-1"))
+	assert.Equal(t, float64(1), counterValue(t, failover.switchCounter, "backend-1", "backend-0"))
+	assert.Equal(t, float64(1), counterValue(t, failover.errorCounter, "backend-0", "prepare"))
+	assert.Equal(t, float64(1), gaugeValue(t, failover.activeBackendGauge, "backend-0"))
+}
+
+func TestFailoverBlockedGetLedger(t *testing.T) {
+	ctx := context.Background()
+	primary := &MockDatabaseBackend{}
+	started, release := make(chan struct{}), make(chan struct{})
+	primary.On("PrepareRange", ctx, UnboundedRange(2)).Return(nil).Once()
+	primary.On("GetLedger", mock.Anything, uint32(2)).
+		Run(func(args mock.Arguments) {
+			close(started)
+			<-release
+		}).
+		Return(xdr.LedgerCloseMeta{}, fmt.Errorf("core was closed")).Once()
+	primary.On("IsPrepared", ctx, UnboundedRange(2)).Return(true, nil).Once()
+	primary.On("GetLatestLedgerSequence", ctx).Return(uint32(1), nil).Once()
+	primary.On("Close").Run(func(args mock.Arguments) {
+		close(release)
+	}).Return(nil).Once()
+
+	failover := newTestFailoverBackend(t, FailoverLedgerBackendConfig{}, primary)
+	require.NoError(t, failover.PrepareRange(ctx, UnboundedRange(2)))
+	errCh := make(chan error)
+	go func() {
+		_, err := failover.GetLedger(ctx, 2)
+		errCh <- err
+	}()
+	<-started
+
+	// the other methods don't wait for the blocked GetLedger call
+	prepared, err := failover.IsPrepared(ctx, UnboundedRange(2))
+	require.NoError(t, err)
+	assert.True(t, prepared)
+	latest, err := failover.GetLatestLedgerSequence(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), latest)
+
+	require.NoError(t, failover.Close())
+	assert.EqualError(t, <-errCh, "FailoverLedgerBackend is closed; cannot GetLedger")
+}
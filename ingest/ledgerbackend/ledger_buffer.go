@@ -5,6 +5,8 @@ import (
 	"context"
 	"io"
 	"os"
+	"path"
+	"strings"
 	"sync"
 	"time"
 
@@ -18,14 +20,18 @@ import (
 
 type ledgerBatchObject struct {
 	payload     []byte
-	startLedger int // Ledger sequence used as the priority for the priorityqueue.
+	compressor  compressxdr.Compressor // Codec the payload was written with.
+	startLedger int                    // Ledger sequence used as the priority for the priorityqueue.
 }
 
 type ledgerBuffer struct {
 	// Passed through from BufferedStorageBackend to control lifetime of ledgerBuffer instance
-	config    BufferedStorageBackendConfig
-	dataStore datastore.DataStore
-	schema    datastore.DataStoreSchema
+	config     BufferedStorageBackendConfig
+	dataStore  datastore.DataStore
+	schema     datastore.DataStoreSchema
+	dictionary []byte // Optional zstd dictionary passed to the codec of each object
+
+	fileExtension *ledgerFileExtension // Shared by the buffers of the backend
 
 	// context used to cancel workers within the ledgerBuffer
 	context context.Context
@@ -37,7 +43,7 @@ type ledgerBuffer struct {
 	// the number of tasks (both pending and in-flight) + len(ledgerQueue) + ledgerPriorityQueue.Len()
 	// is always less than or equal to the config.BufferSize
 	taskQueue           chan uint32                   // Buffer next object read
-	ledgerQueue         chan []byte                   // Order corrected lcm batches
+	ledgerQueue         chan ledgerBatchObject        // Order corrected lcm batches
 	ledgerPriorityQueue *heap.Heap[ledgerBatchObject] // Priority is set to the sequence number
 	priorityQueueLock   sync.Mutex
 
@@ -47,6 +53,12 @@ type ledgerBuffer struct {
 	nextTaskLedger    uint32 // The next task ledger that should be added to taskQueue
 	ledgerRange       Range
 	currentLedgerLock sync.RWMutex
+
+	// notifyCh is closed and replaced whenever the notifier reports a new latest ledger,
+	// to wake up the workers waiting for the next ledger objects of an unbounded range.
+	notifyCh       chan struct{}
+	latestNotified uint32
+	notifyLock     sync.Mutex
 }
 
 func (bsb *BufferedStorageBackend) newLedgerBuffer(ledgerRange Range) (*ledgerBuffer, error) {
@@ -55,40 +67,52 @@ func (bsb *BufferedStorageBackend) newLedgerBuffer(ledgerRange Range) (*ledgerBu
 	less := func(a, b ledgerBatchObject) bool {
 		return a.startLedger < b.startLedger
 	}
-	// ensure BufferSize does not exceed the total range
+	// ensure BufferSize does not exceed the total range, without affecting the ranges prepared later
+	config := bsb.config
 	if ledgerRange.bounded {
-		bsb.config.BufferSize = uint32(min(int(bsb.config.BufferSize), int(ledgerRange.to-ledgerRange.from)+1))
+		config.BufferSize = uint32(min(int(config.BufferSize), int(ledgerRange.to-ledgerRange.from)+1))
 	}
-	pq := heap.New(less, int(bsb.config.BufferSize))
+	pq := heap.New(less, int(config.BufferSize))
 
 	ledgerBuffer := &ledgerBuffer{
-		config:              bsb.config,
+		config:              config,
 		dataStore:           bsb.dataStore,
 		schema:              bsb.schema,
-		taskQueue:           make(chan uint32, bsb.config.BufferSize),
-		ledgerQueue:         make(chan []byte, bsb.config.BufferSize),
+		dictionary:          bsb.dictionary,
+		fileExtension:       &bsb.fileExtension,
+		taskQueue:           make(chan uint32, config.BufferSize),
+		ledgerQueue:         make(chan ledgerBatchObject, config.BufferSize),
 		ledgerPriorityQueue: pq,
 		currentLedger:       ledgerRange.from,
 		nextTaskLedger:      ledgerRange.from,
 		ledgerRange:         ledgerRange,
 		context:             ctx,
 		cancel:              cancel,
+		notifyCh:            make(chan struct{}),
+	}
+
+	if config.Notifier != nil && !ledgerRange.bounded {
+		ledgerBuffer.wg.Add(1)
+		go func() {
+			defer ledgerBuffer.wg.Done()
+			config.Notifier.Subscribe(ctx, ledgerBuffer.notify)
+		}()
 	}
 
 	// Start workers to read LCM files
-	ledgerBuffer.wg.Add(int(bsb.config.NumWorkers))
-	for i := uint32(0); i < bsb.config.NumWorkers; i++ {
+	ledgerBuffer.wg.Add(int(config.NumWorkers))
+	for i := uint32(0); i < config.NumWorkers; i++ {
 		go ledgerBuffer.worker(ctx)
 	}
 
 	// Upon initialization, the ledgerBuffer invariant is maintained because
-	// we create bsb.config.BufferSize tasks while the len(ledgerQueue) and ledgerPriorityQueue.Len() are 0.
-	// Effectively, this is len(taskQueue) + len(ledgerQueue) + ledgerPriorityQueue.Len() <= bsb.config.BufferSize
-	// which enforces a limit of max tasks (both pending and in-flight) to be less than or equal to bsb.config.BufferSize.
+	// we create config.BufferSize tasks while the len(ledgerQueue) and ledgerPriorityQueue.Len() are 0.
+	// Effectively, this is len(taskQueue) + len(ledgerQueue) + ledgerPriorityQueue.Len() <= config.BufferSize
+	// which enforces a limit of max tasks (both pending and in-flight) to be less than or equal to config.BufferSize.
 	// Note: when a task is in-flight it is no longer in the taskQueue
 	// but for easier conceptualization, len(taskQueue) can be interpreted as both pending and in-flight tasks
 	// where we assume the workers are empty and not processing any tasks.
-	for i := 0; i <= int(bsb.config.BufferSize); i++ {
+	for i := 0; i <= int(config.BufferSize); i++ {
 		ledgerBuffer.pushTaskQueue()
 	}
 
@@ -118,6 +142,52 @@ func (lb *ledgerBuffer) sleepWithContext(ctx context.Context, d time.Duration) b
 	return true
 }
 
+// notify wakes up the workers waiting for ledger objects up to latestLedger.
+func (lb *ledgerBuffer) notify(latestLedger uint32) {
+	lb.notifyLock.Lock()
+	defer lb.notifyLock.Unlock()
+
+	if latestLedger <= lb.latestNotified {
+		return
+	}
+	lb.latestNotified = latestLedger
+	close(lb.notifyCh)
+	lb.notifyCh = make(chan struct{})
+}
+
+// notified returns the channel closed upon the next notification.
+func (lb *ledgerBuffer) notified() <-chan struct{} {
+	lb.notifyLock.Lock()
+	defer lb.notifyLock.Unlock()
+	return lb.notifyCh
+}
+
+// waitForLedger waits for the ledger object starting at sequence to be written, returning true
+// after RetryWait or once notified of it, whichever comes first, and false if ctx is done.
+// notifyCh must be obtained before looking for the object, so that a notification arriving
+// in the meantime is not missed.
+func (lb *ledgerBuffer) waitForLedger(ctx context.Context, sequence uint32, notifyCh <-chan struct{}) bool {
+	timer := time.NewTimer(lb.config.RetryWait)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-timer.C:
+			return true
+		case <-notifyCh:
+			lb.notifyLock.Lock()
+			latestNotified := lb.latestNotified
+			lb.notifyLock.Unlock()
+			if latestNotified >= sequence {
+				return true
+			}
+			notifyCh = lb.notified()
+		}
+	}
+}
+
 func (lb *ledgerBuffer) worker(ctx context.Context) {
 	defer lb.wg.Done()
 
@@ -127,12 +197,13 @@ func (lb *ledgerBuffer) worker(ctx context.Context) {
 			return
 		case sequence := <-lb.taskQueue:
 			for attempt := uint32(0); attempt <= lb.config.RetryLimit; {
+				notifyCh := lb.notified()
 				ledgerObject, err := lb.downloadLedgerObject(ctx, sequence)
 				if err != nil {
 					if errors.Is(err, os.ErrNotExist) {
 						// ledgerObject not found and unbounded
 						if !lb.ledgerRange.bounded {
-							if !lb.sleepWithContext(ctx, lb.config.RetryWait) {
+							if !lb.waitForLedger(ctx, sequence, notifyCh) {
 								return
 							}
 							continue
@@ -161,49 +232,113 @@ func (lb *ledgerBuffer) worker(ctx context.Context) {
 				// Thus, the number of tasks decreases by 1 and the priority queue length increases by 1.
 				// This keeps the overall total the same (<= BufferSize). As long as the the ledger buffer invariant
 				// was maintained in the previous state, it is still maintained during this state transition.
-				lb.storeObject(ledgerObject, sequence)
+				lb.storeObject(ledgerObject)
 				break
 			}
 		}
 	}
 }
 
-func (lb *ledgerBuffer) downloadLedgerObject(ctx context.Context, sequence uint32) ([]byte, error) {
-	objectKey := lb.schema.GetObjectKeyFromSequenceNumber(sequence)
+func (lb *ledgerBuffer) downloadLedgerObject(ctx context.Context, sequence uint32) (ledgerBatchObject, error) {
+	return downloadLedgerObject(ctx, lb.dataStore, lb.schema, lb.fileExtension, lb.dictionary, sequence)
+}
+
+// ledgerFileExtension caches the file extension, i.e. the codec, of the last ledger
+// object found by a backend. Adjacent objects usually share their codec, so it is tried
+// before looking up the objects written with another codec.
+type ledgerFileExtension struct {
+	lock      sync.RWMutex
+	extension string
+}
+
+func (e *ledgerFileExtension) get() string {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	return e.extension
+}
 
-	reader, err := lb.dataStore.GetFile(ctx, objectKey)
+func (e *ledgerFileExtension) set(extension string) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.extension = extension
+}
+
+// downloadLedgerObject downloads the compressed ledger object containing sequence.
+func downloadLedgerObject(
+	ctx context.Context,
+	dataStore datastore.DataStore,
+	schema datastore.DataStoreSchema,
+	fileExtension *ledgerFileExtension,
+	dictionary []byte,
+	sequence uint32,
+) (ledgerBatchObject, error) {
+	if extension := fileExtension.get(); extension != "" {
+		schema.FileExtension = extension
+	}
+	objectKey := schema.GetObjectKeyFromSequenceNumber(sequence)
+
+	reader, err := dataStore.GetFile(ctx, objectKey)
+	if errors.Is(err, os.ErrNotExist) {
+		// The object may have been written with another codec, look it up by
+		// its extension-less prefix.
+		if foundKey, findErr := datastore.FindLedgerObjectKey(ctx, dataStore, schema, sequence); findErr == nil {
+			objectKey = foundKey
+			reader, err = dataStore.GetFile(ctx, objectKey)
+		} else if !errors.Is(findErr, os.ErrNotExist) {
+			err = findErr
+		}
+	}
 	if err != nil {
-		return nil, errors.Wrapf(err, "unable to retrieve file: %s", objectKey)
+		return ledgerBatchObject{}, errors.Wrapf(err, "unable to retrieve file: %s", objectKey)
 	}
 
 	defer reader.Close()
 
+	extension := strings.TrimPrefix(path.Ext(objectKey), ".")
+	compressor, err := compressxdr.NewCompressorFromExtension(
+		extension,
+		compressxdr.CompressorOptions{Dictionary: dictionary},
+	)
+	if err != nil {
+		return ledgerBatchObject{}, errors.Wrapf(err, "unable to decompress file: %s", objectKey)
+	}
+	if extension != schema.FileExtension {
+		fileExtension.set(extension)
+	}
+
 	objectBytes, err := io.ReadAll(reader)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed reading file: %s", objectKey)
+		return ledgerBatchObject{}, errors.Wrapf(err, "failed reading file: %s", objectKey)
 	}
 
-	return objectBytes, nil
+	return ledgerBatchObject{payload: objectBytes, compressor: compressor, startLedger: int(sequence)}, nil
 }
 
-func (lb *ledgerBuffer) storeObject(ledgerObject []byte, sequence uint32) {
+// decode decompresses and unmarshals the LedgerCloseMetaBatch of the object.
+func (o ledgerBatchObject) decode() (xdr.LedgerCloseMetaBatch, error) {
+	lcmBatch := xdr.LedgerCloseMetaBatch{}
+	decoder := compressxdr.NewXDRDecoder(o.compressor, &lcmBatch)
+	if _, err := decoder.ReadFrom(bytes.NewReader(o.payload)); err != nil {
+		return xdr.LedgerCloseMetaBatch{}, err
+	}
+	return lcmBatch, nil
+}
+
+func (lb *ledgerBuffer) storeObject(ledgerObject ledgerBatchObject) {
 	lb.priorityQueueLock.Lock()
 	defer lb.priorityQueueLock.Unlock()
 
 	lb.currentLedgerLock.Lock()
 	defer lb.currentLedgerLock.Unlock()
 
-	lb.ledgerPriorityQueue.Push(ledgerBatchObject{
-		payload:     ledgerObject,
-		startLedger: int(sequence),
-	})
+	lb.ledgerPriorityQueue.Push(ledgerObject)
 
 	// Check if the nextLedger is the next item in the ledgerPriorityQueue
 	// The ledgerBuffer invariant is maintained here because items are transferred from the ledgerPriorityQueue to the ledgerQueue.
 	// Thus the overall sum of ledgerPriorityQueue.Len() + len(lb.ledgerQueue) remains the same.
 	for lb.ledgerPriorityQueue.Len() > 0 && lb.currentLedger == uint32(lb.ledgerPriorityQueue.Peek().startLedger) {
 		item := lb.ledgerPriorityQueue.Pop()
-		lb.ledgerQueue <- item.payload
+		lb.ledgerQueue <- item
 		lb.currentLedger += lb.schema.LedgersPerFile
 	}
 }
@@ -215,7 +350,7 @@ func (lb *ledgerBuffer) getFromLedgerQueue(ctx context.Context) (xdr.LedgerClose
 			return xdr.LedgerCloseMetaBatch{}, context.Cause(lb.context)
 		case <-ctx.Done():
 			return xdr.LedgerCloseMetaBatch{}, ctx.Err()
-		case compressedBinary := <-lb.ledgerQueue:
+		case ledgerObject := <-lb.ledgerQueue:
 			// The ledger buffer invariant is maintained here because
 			// we create an extra task when consuming one item from the ledger queue.
 			// Thus len(ledgerQueue) decreases by 1 and the number of tasks increases by 1.
@@ -223,14 +358,7 @@ func (lb *ledgerBuffer) getFromLedgerQueue(ctx context.Context) (xdr.LedgerClose
 			// len(taskQueue) + len(ledgerQueue) + ledgerPriorityQueue.Len() <= bsb.config.BufferSize
 			lb.pushTaskQueue()
 
-			lcmBatch := xdr.LedgerCloseMetaBatch{}
-			decoder := compressxdr.NewXDRDecoder(compressxdr.DefaultCompressor, &lcmBatch)
-			_, err := decoder.ReadFrom(bytes.NewReader(compressedBinary))
-			if err != nil {
-				return xdr.LedgerCloseMetaBatch{}, err
-			}
-
-			return lcmBatch, nil
+			return ledgerObject.decode()
 		}
 	}
 }
@@ -10,7 +10,7 @@ import (
 )
 
 // WithMetrics decorates the given LedgerBackend with metrics
-func WithMetrics(base LedgerBackend, registry *prometheus.Registry, namespace string) LedgerBackend {
+func WithMetrics(base LedgerBackend, registry prometheus.Registerer, namespace string) LedgerBackend {
 	if captiveCoreBackend, ok := base.(*CaptiveStellarCore); ok {
 		captiveCoreBackend.registerMetrics(registry, namespace)
 	}
@@ -191,6 +191,10 @@ func (b *RPCLedgerBackend) PrepareRange(ctx context.Context, ledgerRange Range)
 	return nil
 }
 
+// IsPrepared returns true if the given range matches the prepared range. An
+// unbounded prepared range also covers the unbounded range starting at the
+// next ledger to be returned by GetLedger, so callers streaming ledgers can
+// check it before requesting each ledger.
 func (b *RPCLedgerBackend) IsPrepared(ctx context.Context, ledgerRange Range) (bool, error) {
 	b.bufferLock.RLock()
 	defer b.bufferLock.RUnlock()
@@ -207,7 +211,10 @@ func (b *RPCLedgerBackend) IsPrepared(ctx context.Context, ledgerRange Range) (b
 		b.preparedRange.bounded == ledgerRange.bounded &&
 		(!b.preparedRange.bounded || b.preparedRange.to == ledgerRange.to)
 
-	return rangesMatch, nil
+	continuesUnbounded := !b.preparedRange.bounded && !ledgerRange.bounded &&
+		ledgerRange.from == b.nextLedger
+
+	return rangesMatch || continuesUnbounded, nil
 }
 
 // Close cleans up the RPCLedgerBackend resources and closes the backend.
@@ -415,6 +415,29 @@ func TestIsPrepared(t *testing.T) {
 		}
 	})
 
+	t.Run("unbounded range continues from the next ledger", func(t *testing.T) {
+		backend, _ := setupRPCTest(t)
+		ctx := context.Background()
+
+		start := uint32(150)
+		backend.preparedRange = &Range{from: start}
+		backend.nextLedger = start + 5
+
+		prepared, err := backend.IsPrepared(ctx, Range{from: start + 5})
+		assert.NoError(t, err)
+		assert.True(t, prepared)
+
+		for _, ledgerRange := range []Range{
+			{from: start + 4},
+			{from: start + 6},
+			{from: start + 5, to: start + 10, bounded: true},
+		} {
+			prepared, err = backend.IsPrepared(ctx, ledgerRange)
+			assert.NoError(t, err)
+			assert.False(t, prepared, "range %v", ledgerRange)
+		}
+	})
+
 	t.Run("returns error when backend is closed", func(t *testing.T) {
 		backend, _ := setupRPCTest(t)
 		assert.NoError(t, backend.Close())
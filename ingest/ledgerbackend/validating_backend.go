@@ -0,0 +1,232 @@
+package ledgerbackend
+
+import (
+	"context"
+	"crypto/sha256"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/stellar/go/historyarchive"
+	"github.com/stellar/go/support/log"
+	"github.com/stellar/go/xdr"
+)
+
+// Ensure ValidatingLedgerBackend implements LedgerBackend
+var _ LedgerBackend = (*ValidatingLedgerBackend)(nil)
+
+// ErrInvalidLedgerChain is returned, wrapped, by ValidatingLedgerBackend when a ledger
+// does not hash to its header hash, does not follow the hash chain of the ledgers
+// returned before it, or does not match the ledger header of the history archives.
+var ErrInvalidLedgerChain = errors.New("invalid ledger chain")
+
+type ValidatingLedgerBackendConfig struct {
+	// Archive, optional, is used to verify the hashes of checkpoint ledgers against the
+	// ledger headers published in the history archives. Nil disables the verification.
+	Archive historyarchive.ArchiveInterface
+	// CheckpointFrequency is the checkpoint frequency of the history archives, defaults
+	// to historyarchive.DefaultCheckpointFrequency.
+	CheckpointFrequency uint32
+	// CheckpointInterval is the number of checkpoints between two verified checkpoints,
+	// defaults to 1 which verifies every checkpoint ledger.
+	CheckpointInterval uint32
+	// ArchiveRetryInterval is how long to wait before retrying the verification of a
+	// checkpoint ledger which could not be fetched from the history archives, e.g. because
+	// it is not published yet. Defaults to 1 minute.
+	ArchiveRetryInterval time.Duration
+	// Log, optional, defaults to the default logger.
+	Log *log.Entry
+}
+
+// ValidatingLedgerBackend wraps a LedgerBackend and validates every ledger returned by
+// GetLedger before returning it:
+//
+//   - the hash of the ledger header must be the ledger hash,
+//   - the previous ledger hash must be the hash of the ledger returned before it, when
+//     ledgers are requested in sequence, and a ledger requested again must not change,
+//   - the hash of checkpoint ledgers must match the ledger header of the history
+//     archives. Checkpoints are only published a few minutes after they close, so the
+//     checkpoints which can't be fetched yet are verified by the following calls.
+//
+// GetLedger returns an error wrapping ErrInvalidLedgerChain when a ledger is invalid, which
+// protects consumers from corrupted or tampered ledgers read from a datastore or an RPC
+// server. Once a checkpoint ledger does not match the history archives, the ledgers returned
+// since can't be trusted either and all the following GetLedger calls fail.
+type ValidatingLedgerBackend struct {
+	LedgerBackend
+	config            ValidatingLedgerBackendConfig
+	checkpointManager historyarchive.CheckpointManager
+	log               *log.Entry
+
+	lock       sync.Mutex
+	lastLedger uint32
+	lastHash   xdr.Hash
+	// the checkpoint ledgers waiting to be verified against the history archives
+	pending   map[uint32]xdr.Hash
+	nextRetry time.Time
+	verifying bool  // true while a GetLedger call fetches checkpoint ledger headers
+	failed    error // the checkpoint mismatch which failed the backend
+}
+
+// NewValidatingLedgerBackend returns a ValidatingLedgerBackend validating the ledgers of base.
+func NewValidatingLedgerBackend(base LedgerBackend, config ValidatingLedgerBackendConfig) *ValidatingLedgerBackend {
+	if config.CheckpointInterval == 0 {
+		config.CheckpointInterval = 1
+	}
+	if config.ArchiveRetryInterval == 0 {
+		config.ArchiveRetryInterval = time.Minute
+	}
+	backend := &ValidatingLedgerBackend{
+		LedgerBackend:     base,
+		config:            config,
+		checkpointManager: historyarchive.NewCheckpointManager(config.CheckpointFrequency),
+		log:               config.Log,
+		pending:           map[uint32]xdr.Hash{},
+	}
+	if backend.log == nil {
+		backend.log = log.DefaultLogger
+	}
+	backend.log = backend.log.WithField("subservice", "validating-backend")
+	return backend
+}
+
+// GetLedger returns the ledger from the wrapped backend once it is validated.
+func (b *ValidatingLedgerBackend) GetLedger(ctx context.Context, sequence uint32) (xdr.LedgerCloseMeta, error) {
+	lcm, err := b.LedgerBackend.GetLedger(ctx, sequence)
+	if err != nil {
+		return xdr.LedgerCloseMeta{}, err
+	}
+
+	b.lock.Lock()
+	if b.failed != nil {
+		b.lock.Unlock()
+		return xdr.LedgerCloseMeta{}, b.failed
+	}
+	if err = b.validate(sequence, lcm); err != nil {
+		b.lock.Unlock()
+		b.log.WithField("sequence", sequence).Errorf("Ledger failed validation: %v", err)
+		return xdr.LedgerCloseMeta{}, err
+	}
+	var checkpoints []uint32
+	if b.config.Archive != nil {
+		if b.isVerifiedCheckpoint(sequence) {
+			b.pending[sequence] = lcm.LedgerHash()
+		}
+		checkpoints = b.checkpointsToVerify()
+	}
+	b.lock.Unlock()
+
+	// the history archives are queried without holding the lock
+	headers, fetchErr := b.fetchCheckpointHeaders(checkpoints)
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if len(checkpoints) > 0 {
+		b.verifying = false
+		if err = b.verifyCheckpoints(checkpoints, headers, fetchErr); err != nil {
+			b.failed = err
+		}
+	}
+	if b.failed != nil {
+		b.log.Errorf("Checkpoint ledger failed validation: %v", b.failed)
+		return xdr.LedgerCloseMeta{}, b.failed
+	}
+	b.lastLedger = sequence
+	b.lastHash = lcm.LedgerHash()
+	return lcm, nil
+}
+
+func (b *ValidatingLedgerBackend) validate(sequence uint32, lcm xdr.LedgerCloseMeta) error {
+	if lcm.LedgerSequence() != sequence {
+		return errors.Wrapf(ErrInvalidLedgerChain, "requested ledger %d but got ledger %d",
+			sequence, lcm.LedgerSequence())
+	}
+
+	header, err := lcm.LedgerHeaderHistoryEntry().Header.MarshalBinary()
+	if err != nil {
+		return errors.Wrapf(err, "could not marshal header of ledger %d", sequence)
+	}
+	if hash := xdr.Hash(sha256.Sum256(header)); hash != lcm.LedgerHash() {
+		return errors.Wrapf(ErrInvalidLedgerChain, "ledger %d hash %s does not match the hash %s of its header",
+			sequence, lcm.LedgerHash().HexString(), hash.HexString())
+	}
+
+	switch {
+	case b.lastLedger == 0:
+	case sequence == b.lastLedger:
+		if lcm.LedgerHash() != b.lastHash {
+			return errors.Wrapf(ErrInvalidLedgerChain, "ledger %d hash %s does not match hash %s returned previously",
+				sequence, lcm.LedgerHash().HexString(), b.lastHash.HexString())
+		}
+	case sequence == b.lastLedger+1:
+		if lcm.PreviousLedgerHash() != b.lastHash {
+			return errors.Wrapf(ErrInvalidLedgerChain, "ledger %d previous ledger hash %s does not match hash %s of ledger %d",
+				sequence, lcm.PreviousLedgerHash().HexString(), b.lastHash.HexString(), b.lastLedger)
+		}
+	}
+	return nil
+}
+
+func (b *ValidatingLedgerBackend) isVerifiedCheckpoint(sequence uint32) bool {
+	if !b.checkpointManager.IsCheckpoint(sequence) {
+		return false
+	}
+	checkpoint := (sequence + 1) / b.checkpointManager.GetCheckpointFrequency()
+	return checkpoint%b.config.CheckpointInterval == 0
+}
+
+// checkpointsToVerify returns the pending checkpoint ledgers in order, unless another call
+// is verifying them or the last attempt failed less than ArchiveRetryInterval ago.
+func (b *ValidatingLedgerBackend) checkpointsToVerify() []uint32 {
+	if len(b.pending) == 0 || b.verifying || time.Now().Before(b.nextRetry) {
+		return nil
+	}
+	b.verifying = true
+
+	checkpoints := make([]uint32, 0, len(b.pending))
+	for checkpoint := range b.pending {
+		checkpoints = append(checkpoints, checkpoint)
+	}
+	sort.Slice(checkpoints, func(i, j int) bool { return checkpoints[i] < checkpoints[j] })
+	return checkpoints
+}
+
+// fetchCheckpointHeaders gets the ledger headers of checkpoints from the history archives,
+// stopping at the first one which can't be fetched.
+func (b *ValidatingLedgerBackend) fetchCheckpointHeaders(checkpoints []uint32) ([]xdr.LedgerHeaderHistoryEntry, error) {
+	headers := make([]xdr.LedgerHeaderHistoryEntry, 0, len(checkpoints))
+	for _, checkpoint := range checkpoints {
+		header, err := b.config.Archive.GetLedgerHeader(checkpoint)
+		if err != nil {
+			return headers, errors.Wrapf(err, "could not get header of checkpoint ledger %d", checkpoint)
+		}
+		headers = append(headers, header)
+	}
+	return headers, nil
+}
+
+// verifyCheckpoints verifies the checkpoint ledgers against the headers fetched from the
+// history archives, which are the headers of the first len(headers) checkpoints.
+func (b *ValidatingLedgerBackend) verifyCheckpoints(checkpoints []uint32, headers []xdr.LedgerHeaderHistoryEntry, fetchErr error) error {
+	for i, header := range headers {
+		checkpoint := checkpoints[i]
+		hash := b.pending[checkpoint]
+		delete(b.pending, checkpoint)
+		if header.Hash != hash {
+			return errors.Wrapf(ErrInvalidLedgerChain,
+				"ledger %d hash %s does not match hash %s of the history archives",
+				checkpoint, hash.HexString(), header.Hash.HexString())
+		}
+		b.log.WithField("checkpoint", checkpoint).Debug("Checkpoint ledger matches the history archives")
+	}
+	if fetchErr != nil {
+		// most likely the checkpoint is not published yet, the following ones aren't either
+		b.log.Warnf("Could not get checkpoint ledger header from history archives, retrying in %v: %v",
+			b.config.ArchiveRetryInterval, fetchErr)
+		b.nextRetry = time.Now().Add(b.config.ArchiveRetryInterval)
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+package ledgerbackend
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/historyarchive"
+	"github.com/stellar/go/xdr"
+)
+
+// hashChain returns the ledgers from..to, each with the hash of its header and
+// the hash of the ledger before it.
+func hashChain(t *testing.T, from, to uint32) map[uint32]xdr.LedgerCloseMeta {
+	ledgers := map[uint32]xdr.LedgerCloseMeta{}
+	previousHash := xdr.Hash{0xff}
+	for sequence := from; sequence <= to; sequence++ {
+		lcm := createLedgerCloseMeta(sequence)
+		lcm.V0.LedgerHeader.Header.PreviousLedgerHash = previousHash
+		rehash(t, &lcm)
+		ledgers[sequence] = lcm
+		previousHash = lcm.LedgerHash()
+	}
+	return ledgers
+}
+
+func rehash(t *testing.T, lcm *xdr.LedgerCloseMeta) {
+	header, err := lcm.V0.LedgerHeader.Header.MarshalBinary()
+	require.NoError(t, err)
+	lcm.V0.LedgerHeader.Hash = sha256.Sum256(header)
+}
+
+func TestValidatingBackendHashChain(t *testing.T) {
+	ctx := context.Background()
+	ledgers := hashChain(t, 2, 4)
+	base := &MockDatabaseBackend{}
+	defer base.AssertExpectations(t)
+	base.On("PrepareRange", ctx, BoundedRange(2, 4)).Return(nil).Once()
+	base.On("GetLedger", ctx, uint32(2)).Return(ledgers[2], nil).Once()
+	base.On("GetLedger", ctx, uint32(3)).Return(ledgers[3], nil).Twice()
+	base.On("GetLedger", ctx, uint32(4)).Return(ledgers[4], nil).Once()
+
+	backend := NewValidatingLedgerBackend(base, ValidatingLedgerBackendConfig{})
+	require.NoError(t, backend.PrepareRange(ctx, BoundedRange(2, 4)))
+	for _, sequence := range []uint32{2, 3, 3, 4} {
+		lcm, err := backend.GetLedger(ctx, sequence)
+		require.NoError(t, err)
+		assert.Equal(t, ledgers[sequence], lcm)
+	}
+}
+
+func TestValidatingBackendInvalidLedgers(t *testing.T) {
+	ctx := context.Background()
+	ledgers := hashChain(t, 2, 3)
+
+	forked := createLedgerCloseMeta(3)
+	forked.V0.LedgerHeader.Header.PreviousLedgerHash = xdr.Hash{1}
+	rehash(t, &forked)
+
+	tampered := createLedgerCloseMeta(3)
+	tampered.V0.LedgerHeader.Header.PreviousLedgerHash = ledgers[2].LedgerHash()
+	tampered.V0.LedgerHeader.Hash = ledgers[3].LedgerHash()
+	tampered.V0.LedgerHeader.Header.TotalCoins = 1
+
+	changed := createLedgerCloseMeta(2)
+	changed.V0.LedgerHeader.Header.BaseFee = 100
+	rehash(t, &changed)
+
+	for _, testCase := range []struct {
+		name     string
+		sequence uint32
+		lcm      xdr.LedgerCloseMeta
+		err      string
+	}{
+		{"wrong sequence", 3, ledgers[2], "requested ledger 3 but got ledger 2"},
+		{"broken chain", 3, forked, "ledger 3 previous ledger hash 0100000000000000000000000000000000000000000000000000000000000000 does not match hash " +
+			ledgers[2].LedgerHash().HexString() + " of ledger 2"},
+		{"tampered header", 3, tampered, "ledger 3 hash " + ledgers[3].LedgerHash().HexString() + " does not match the hash"},
+		{"changed ledger", 2, changed, "does not match hash " + ledgers[2].LedgerHash().HexString() + " returned previously"},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			base := &MockDatabaseBackend{}
+			defer base.AssertExpectations(t)
+			base.On("GetLedger", ctx, uint32(2)).Return(ledgers[2], nil).Once()
+			base.On("GetLedger", ctx, testCase.sequence).Return(testCase.lcm, nil).Once()
+
+			backend := NewValidatingLedgerBackend(base, ValidatingLedgerBackendConfig{})
+			_, err := backend.GetLedger(ctx, 2)
+			require.NoError(t, err)
+			_, err = backend.GetLedger(ctx, testCase.sequence)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, ErrInvalidLedgerChain))
+			assert.ErrorContains(t, err, testCase.err)
+		})
+	}
+}
+
+func TestValidatingBackendCheckpoints(t *testing.T) {
+	ctx := context.Background()
+	ledgers := hashChain(t, 62, 66)
+	base := &MockDatabaseBackend{}
+	defer base.AssertExpectations(t)
+	for sequence := uint32(62); sequence <= 66; sequence++ {
+		base.On("GetLedger", ctx, sequence).Return(ledgers[sequence], nil).Once()
+	}
+
+	archive := &historyarchive.MockArchive{}
+	defer archive.AssertExpectations(t)
+	// the checkpoint is not published when ledger 63 is returned
+	archive.On("GetLedgerHeader", uint32(63)).
+		Return(xdr.LedgerHeaderHistoryEntry{}, errors.New("checkpoint not found")).Once()
+	archive.On("GetLedgerHeader", uint32(63)).Return(ledgers[63].LedgerHeaderHistoryEntry(), nil).Once()
+
+	backend := NewValidatingLedgerBackend(base, ValidatingLedgerBackendConfig{
+		Archive:              archive,
+		ArchiveRetryInterval: time.Millisecond,
+	})
+	for sequence := uint32(62); sequence <= 64; sequence++ {
+		_, err := backend.GetLedger(ctx, sequence)
+		require.NoError(t, err)
+	}
+	time.Sleep(2 * time.Millisecond)
+	_, err := backend.GetLedger(ctx, 65)
+	require.NoError(t, err)
+	assert.Empty(t, backend.pending)
+	_, err = backend.GetLedger(ctx, 66)
+	require.NoError(t, err)
+}
+
+func TestValidatingBackendCheckpointMismatch(t *testing.T) {
+	ctx := context.Background()
+	ledgers := hashChain(t, 63, 63)
+	base := &MockDatabaseBackend{}
+	defer base.AssertExpectations(t)
+	base.On("GetLedger", ctx, uint32(63)).Return(ledgers[63], nil).Once()
+
+	archive := &historyarchive.MockArchive{}
+	defer archive.AssertExpectations(t)
+	archive.On("GetLedgerHeader", uint32(63)).
+		Return(xdr.LedgerHeaderHistoryEntry{Hash: xdr.Hash{1}}, nil).Once()
+
+	backend := NewValidatingLedgerBackend(base, ValidatingLedgerBackendConfig{Archive: archive})
+	_, err := backend.GetLedger(ctx, 63)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrInvalidLedgerChain))
+	assert.ErrorContains(t, err, "does not match hash 0100000000000000000000000000000000000000000000000000000000000000 of the history archives")
+}
+
+func TestValidatingBackendFailsAfterCheckpointMismatch(t *testing.T) {
+	ctx := context.Background()
+	ledgers := hashChain(t, 63, 65)
+	base := &MockDatabaseBackend{}
+	defer base.AssertExpectations(t)
+	for sequence := uint32(63); sequence <= 65; sequence++ {
+		base.On("GetLedger", ctx, sequence).Return(ledgers[sequence], nil).Once()
+	}
+
+	archive := &historyarchive.MockArchive{}
+	defer archive.AssertExpectations(t)
+	archive.On("GetLedgerHeader", uint32(63)).
+		Return(xdr.LedgerHeaderHistoryEntry{}, errors.New("checkpoint not found")).Once()
+	archive.On("GetLedgerHeader", uint32(63)).
+		Return(xdr.LedgerHeaderHistoryEntry{Hash: xdr.Hash{1}}, nil).Once()
+
+	backend := NewValidatingLedgerBackend(base, ValidatingLedgerBackendConfig{
+		Archive:              archive,
+		ArchiveRetryInterval: time.Millisecond,
+	})
+	_, err := backend.GetLedger(ctx, 63)
+	require.NoError(t, err)
+	time.Sleep(2 * time.Millisecond)
+
+	// the mismatch of checkpoint 63 is detected when ledger 64 is requested, which is
+	// not returned, and the following ledgers are not returned either
+	for sequence := uint32(64); sequence <= 65; sequence++ {
+		_, err = backend.GetLedger(ctx, sequence)
+		assert.True(t, errors.Is(err, ErrInvalidLedgerChain))
+		assert.ErrorContains(t, err, "ledger 63 hash")
+	}
+	assert.Equal(t, uint32(63), backend.lastLedger)
+}
+
+func TestValidatingBackendFetchesCheckpointsWithoutLock(t *testing.T) {
+	ctx := context.Background()
+	ledgers := hashChain(t, 63, 64)
+	base := &MockDatabaseBackend{}
+	defer base.AssertExpectations(t)
+	for sequence := uint32(63); sequence <= 64; sequence++ {
+		base.On("GetLedger", ctx, sequence).Return(ledgers[sequence], nil).Once()
+	}
+
+	archive := &historyarchive.MockArchive{}
+	defer archive.AssertExpectations(t)
+	started, release := make(chan struct{}), make(chan struct{})
+	archive.On("GetLedgerHeader", uint32(63)).
+		Run(func(args mock.Arguments) {
+			close(started)
+			<-release
+		}).
+		Return(ledgers[63].LedgerHeaderHistoryEntry(), nil).Once()
+
+	backend := NewValidatingLedgerBackend(base, ValidatingLedgerBackendConfig{Archive: archive})
+	errCh := make(chan error)
+	go func() {
+		_, err := backend.GetLedger(ctx, 63)
+		errCh <- err
+	}()
+	<-started
+
+	// the checkpoint being fetched by the first call is not fetched again
+	_, err := backend.GetLedger(ctx, 64)
+	require.NoError(t, err)
+	close(release)
+	require.NoError(t, <-errCh)
+	assert.Empty(t, backend.pending)
+}
+
+func TestValidatingBackendCheckpointInterval(t *testing.T) {
+	backend := NewValidatingLedgerBackend(&MockDatabaseBackend{}, ValidatingLedgerBackendConfig{
+		CheckpointFrequency: 8,
+		CheckpointInterval:  2,
+	})
+	assert.False(t, backend.isVerifiedCheckpoint(6))
+	assert.False(t, backend.isVerifiedCheckpoint(7))
+	assert.True(t, backend.isVerifiedCheckpoint(15))
+	assert.False(t, backend.isVerifiedCheckpoint(23))
+	assert.True(t, backend.isVerifiedCheckpoint(31))
+}
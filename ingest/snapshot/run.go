@@ -0,0 +1,333 @@
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/stellar/go/support/collections/heap"
+	"github.com/stellar/go/support/errors"
+)
+
+// indexInterval is the number of records between two entries of the sparse
+// index kept at the end of every run file.
+const indexInterval = 64
+
+// trailerSize is the size of the trailer holding the offset of the index.
+const trailerSize = 8
+
+// A run file contains records sorted by key, each record being
+//
+//	[uvarint key length][key][uvarint value length][value]
+//
+// where the key is the XDR encoding of a LedgerKey and the value is the XDR
+// encoding of the LedgerEntry. An empty value is a tombstone, marking an
+// entry removed since an older run. The records are followed by a sparse
+// index of every indexInterval-th key, each index entry being
+//
+//	[uvarint key length][key][uvarint record offset]
+//
+// and by the offset of the index as an 8 byte big endian integer.
+
+type indexEntry struct {
+	key    []byte
+	offset int64
+}
+
+// runWriter writes the records of a run file, which must be added in strictly
+// increasing key order.
+type runWriter struct {
+	file    *os.File
+	path    string
+	writer  *bufio.Writer
+	offset  int64
+	count   int
+	lastKey []byte
+	index   []indexEntry
+	scratch [binary.MaxVarintLen64]byte
+}
+
+// newRunWriter creates a run file at path. The file is written to a temporary
+// location and only moved to path by finish.
+func newRunWriter(path string) (*runWriter, error) {
+	file, err := os.Create(path + ".tmp")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create run file")
+	}
+	return &runWriter{
+		file:   file,
+		path:   path,
+		writer: bufio.NewWriterSize(file, 1<<20),
+	}, nil
+}
+
+func (w *runWriter) writeBytes(b []byte) error {
+	n := binary.PutUvarint(w.scratch[:], uint64(len(b)))
+	if _, err := w.writer.Write(w.scratch[:n]); err != nil {
+		return err
+	}
+	if _, err := w.writer.Write(b); err != nil {
+		return err
+	}
+	w.offset += int64(n + len(b))
+	return nil
+}
+
+func (w *runWriter) add(key, value []byte) error {
+	if w.count > 0 && bytes.Compare(key, w.lastKey) <= 0 {
+		return errors.New("run keys are not in increasing order")
+	}
+	if w.count%indexInterval == 0 {
+		w.index = append(w.index, indexEntry{key: append([]byte{}, key...), offset: w.offset})
+	}
+	w.lastKey = append(w.lastKey[:0], key...)
+	w.count++
+
+	if err := w.writeBytes(key); err != nil {
+		return errors.Wrap(err, "could not write run record")
+	}
+	if err := w.writeBytes(value); err != nil {
+		return errors.Wrap(err, "could not write run record")
+	}
+	return nil
+}
+
+// finish writes the index, syncs the file and moves it to its final path.
+func (w *runWriter) finish() error {
+	indexOffset := w.offset
+	for _, entry := range w.index {
+		if err := w.writeBytes(entry.key); err != nil {
+			return w.fail(errors.Wrap(err, "could not write run index"))
+		}
+		n := binary.PutUvarint(w.scratch[:], uint64(entry.offset))
+		if _, err := w.writer.Write(w.scratch[:n]); err != nil {
+			return w.fail(errors.Wrap(err, "could not write run index"))
+		}
+	}
+	var trailer [trailerSize]byte
+	binary.BigEndian.PutUint64(trailer[:], uint64(indexOffset))
+	if _, err := w.writer.Write(trailer[:]); err != nil {
+		return w.fail(errors.Wrap(err, "could not write run trailer"))
+	}
+
+	if err := w.writer.Flush(); err != nil {
+		return w.fail(errors.Wrap(err, "could not flush run file"))
+	}
+	if err := w.file.Sync(); err != nil {
+		return w.fail(errors.Wrap(err, "could not sync run file"))
+	}
+	if err := w.file.Close(); err != nil {
+		return w.fail(errors.Wrap(err, "could not close run file"))
+	}
+	if err := os.Rename(w.file.Name(), w.path); err != nil {
+		return w.fail(errors.Wrap(err, "could not rename run file"))
+	}
+	return nil
+}
+
+// abort discards the run file.
+func (w *runWriter) abort() {
+	w.file.Close()
+	os.Remove(w.file.Name())
+}
+
+func (w *runWriter) fail(err error) error {
+	w.abort()
+	return err
+}
+
+// run is an open run file.
+type run struct {
+	file *os.File
+	// dataSize is the size of the records, which are followed by the index
+	dataSize int64
+	index    []indexEntry
+}
+
+func openRun(path string) (*run, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open run file")
+	}
+	r, err := loadRun(file)
+	if err != nil {
+		file.Close()
+		return nil, errors.Wrapf(err, "could not load run file %s", path)
+	}
+	return r, nil
+}
+
+func loadRun(file *os.File) (*run, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() < trailerSize {
+		return nil, errors.New("run file is truncated")
+	}
+	var trailer [trailerSize]byte
+	if _, err = file.ReadAt(trailer[:], info.Size()-trailerSize); err != nil {
+		return nil, err
+	}
+	dataSize := int64(binary.BigEndian.Uint64(trailer[:]))
+	if dataSize > info.Size()-trailerSize {
+		return nil, errors.New("run file index offset is invalid")
+	}
+
+	r := &run{file: file, dataSize: dataSize}
+	reader := bufio.NewReader(io.NewSectionReader(file, dataSize, info.Size()-trailerSize-dataSize))
+	for {
+		key, err := readBytes(reader)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read run index")
+		}
+		offset, err := binary.ReadUvarint(reader)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read run index")
+		}
+		r.index = append(r.index, indexEntry{key: key, offset: int64(offset)})
+	}
+	return r, nil
+}
+
+func readBytes(reader *bufio.Reader) ([]byte, error) {
+	length, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, length)
+	if _, err = io.ReadFull(reader, b); err != nil {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return b, nil
+}
+
+// get returns the value of key in the run and whether the run contains key.
+func (r *run) get(key []byte) ([]byte, bool, error) {
+	// find the last index entry not greater than key
+	i := sort.Search(len(r.index), func(i int) bool {
+		return bytes.Compare(r.index[i].key, key) > 0
+	}) - 1
+	if i < 0 {
+		return nil, false, nil
+	}
+
+	it := r.iteratorFrom(r.index[i].offset)
+	for it.next() {
+		switch cmp := bytes.Compare(it.key, key); {
+		case cmp == 0:
+			return it.value, true, nil
+		case cmp > 0:
+			return nil, false, nil
+		}
+	}
+	return nil, false, it.err
+}
+
+func (r *run) iterator() *runIterator {
+	return r.iteratorFrom(0)
+}
+
+func (r *run) iteratorFrom(offset int64) *runIterator {
+	return &runIterator{
+		reader: bufio.NewReader(io.NewSectionReader(r.file, offset, r.dataSize-offset)),
+	}
+}
+
+func (r *run) close() error {
+	return r.file.Close()
+}
+
+// runIterator iterates over the records of a run in key order.
+type runIterator struct {
+	reader *bufio.Reader
+	key    []byte
+	value  []byte
+	err    error
+}
+
+// next advances to the next record, returning false once the records are
+// exhausted or an error occurred.
+func (it *runIterator) next() bool {
+	if it.err != nil {
+		return false
+	}
+	key, err := readBytes(it.reader)
+	if err == io.EOF {
+		return false
+	}
+	if err != nil {
+		it.err = errors.Wrap(err, "could not read run record")
+		return false
+	}
+	value, err := readBytes(it.reader)
+	if err != nil {
+		it.err = errors.Wrap(err, "could not read run record")
+		return false
+	}
+	it.key, it.value = key, value
+	return true
+}
+
+// mergeIterator merges the records of runs ordered from oldest to newest. When
+// several runs contain the same key, only the record of the newest one is returned.
+type mergeIterator struct {
+	iterators []*runIterator
+	heap      *heap.Heap[int]
+	started   bool
+	key       []byte
+	value     []byte
+	err       error
+}
+
+func newMergeIterator(iterators []*runIterator) *mergeIterator {
+	m := &mergeIterator{iterators: iterators}
+	m.heap = heap.New(func(a, b int) bool {
+		if cmp := bytes.Compare(m.iterators[a].key, m.iterators[b].key); cmp != 0 {
+			return cmp < 0
+		}
+		// newer runs come first
+		return a > b
+	}, len(iterators))
+	return m
+}
+
+func (m *mergeIterator) advance(i int) {
+	if m.iterators[i].next() {
+		m.heap.Push(i)
+	} else if err := m.iterators[i].err; err != nil && m.err == nil {
+		m.err = err
+	}
+}
+
+func (m *mergeIterator) next() bool {
+	if !m.started {
+		m.started = true
+		for i := range m.iterators {
+			m.advance(i)
+		}
+	}
+	if m.err != nil || m.heap.Len() == 0 {
+		return false
+	}
+
+	i := m.heap.Pop()
+	m.key, m.value = m.iterators[i].key, m.iterators[i].value
+	m.advance(i)
+	// skip the older records of the same key
+	for m.heap.Len() > 0 && bytes.Equal(m.iterators[m.heap.Peek()].key, m.key) {
+		m.advance(m.heap.Pop())
+	}
+	return m.err == nil
+}
+
+func runFileName(id uint64) string {
+	return fmt.Sprintf("run-%010d.dat", id)
+}
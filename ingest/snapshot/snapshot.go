@@ -0,0 +1,654 @@
+// Package snapshot materializes the ledger state of a checkpoint into a local
+// on-disk key-value store, keyed by LedgerKey, which can be rolled forward
+// with the changes of the following ledgers. This allows obtaining the full
+// ledger state at any later ledger without streaming the history archive
+// buckets again.
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/stellar/go/ingest"
+	"github.com/stellar/go/ingest/ledgerbackend"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+const manifestFileName = "manifest.json"
+
+var (
+	// maxChunkSize is the amount of entries (in bytes) sorted in memory by
+	// Create before being written to disk.
+	maxChunkSize = 256 * 1024 * 1024
+	// maxRuns is the number of run files above which the snapshot is compacted.
+	maxRuns = 32
+	// rollForwardBatchSize is the number of ledgers whose changes are
+	// accumulated in memory by RollForward before being written to disk.
+	rollForwardBatchSize = uint32(64)
+)
+
+// ErrNotFound is returned by Open when the directory doesn't contain a snapshot.
+var ErrNotFound = errors.New("snapshot not found")
+
+// CompactionError is returned by the updates of a snapshot when the changes
+// were applied, i.e. the snapshot is at Ledger, but the compaction following
+// them failed. The snapshot is consistent and the compaction is attempted
+// again by the next update, or by Compact.
+type CompactionError struct {
+	Ledger uint32
+	Err    error
+}
+
+func (e *CompactionError) Error() string {
+	return fmt.Sprintf("ledger %d was applied but the snapshot could not be compacted: %v", e.Ledger, e.Err)
+}
+
+func (e *CompactionError) Unwrap() error {
+	return e.Err
+}
+
+// manifest describes the files of a snapshot. It is replaced atomically
+// every time the snapshot is updated.
+type manifest struct {
+	// Ledger is the sequence of the ledger whose state is held by the snapshot.
+	Ledger uint32 `json:"ledger"`
+	// Runs are the run files, from oldest to newest. The first one is the
+	// base containing the state of the last compaction, the following ones
+	// contain the entries changed since then.
+	Runs []string `json:"runs"`
+	// NextRun is the identifier of the next run file.
+	NextRun uint64 `json:"next_run"`
+}
+
+// Snapshot is the ledger state at a given ledger, stored on disk in a set of
+// immutable files of entries sorted by ledger key: a base file and the deltas
+// applied since it was written. Reads consult the newest files first and the
+// deltas are merged into a new base by Compact.
+//
+// Snapshot is safe for concurrent use, although a single goroutine should
+// update it.
+type Snapshot struct {
+	dir string
+
+	lock     sync.RWMutex
+	manifest manifest
+	runs     []*run
+	closed   bool
+}
+
+// Create materializes the ledger entries read from reader, usually a
+// CheckpointChangeReader, into a new snapshot of the state at ledger in dir.
+// reader must not return more than one change per ledger entry. The snapshot
+// is only visible to Open once it is completely written.
+func Create(ctx context.Context, dir string, reader ingest.ChangeReader, ledger uint32) (*Snapshot, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrap(err, "could not create snapshot directory")
+	}
+	if _, err := os.Stat(filepath.Join(dir, manifestFileName)); err == nil {
+		return nil, errors.Errorf("a snapshot already exists in %s", dir)
+	}
+
+	s := &Snapshot{dir: dir}
+	var chunks []string
+	removeChunks := func() {
+		for _, chunk := range chunks {
+			os.Remove(filepath.Join(dir, chunk))
+		}
+	}
+
+	var chunk []record
+	chunkSize := 0
+	flush := func() error {
+		if len(chunk) == 0 {
+			return nil
+		}
+		sort.Slice(chunk, func(i, j int) bool {
+			return bytes.Compare(chunk[i].key, chunk[j].key) < 0
+		})
+		name := s.nextRunFileName()
+		if err := s.writeRun(name, chunk); err != nil {
+			return err
+		}
+		chunks = append(chunks, name)
+		chunk, chunkSize = chunk[:0], 0
+		return nil
+	}
+
+	for count := 0; ; count++ {
+		if count%10000 == 0 && ctx.Err() != nil {
+			removeChunks()
+			return nil, ctx.Err()
+		}
+		change, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			removeChunks()
+			return nil, errors.Wrap(err, "could not read ledger entry")
+		}
+		if change.Post == nil {
+			removeChunks()
+			return nil, errors.New("snapshots can only be created from CREATED changes")
+		}
+		r, err := newRecord(*change.Post)
+		if err != nil {
+			removeChunks()
+			return nil, err
+		}
+		chunk = append(chunk, r)
+		chunkSize += len(r.key) + len(r.value)
+		if chunkSize >= maxChunkSize {
+			if err = flush(); err != nil {
+				removeChunks()
+				return nil, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		removeChunks()
+		return nil, err
+	}
+
+	// merge the sorted chunks into the base
+	base := chunks
+	if len(chunks) != 1 {
+		name := s.nextRunFileName()
+		if err := s.mergeRuns(name, chunks, true); err != nil {
+			removeChunks()
+			return nil, err
+		}
+		base = []string{name}
+	}
+
+	s.manifest.Ledger = ledger
+	s.manifest.Runs = base
+	if err := s.writeManifest(s.manifest); err != nil {
+		removeChunks()
+		return nil, err
+	}
+	if len(chunks) != 1 {
+		removeChunks()
+	}
+	if err := s.openRuns(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Open opens the snapshot stored in dir. ErrNotFound is returned if there is none.
+func Open(dir string) (*Snapshot, error) {
+	contents, err := os.ReadFile(filepath.Join(dir, manifestFileName))
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read snapshot manifest")
+	}
+
+	s := &Snapshot{dir: dir}
+	if err = json.Unmarshal(contents, &s.manifest); err != nil {
+		return nil, errors.Wrap(err, "could not parse snapshot manifest")
+	}
+	if err = s.openRuns(); err != nil {
+		return nil, err
+	}
+	s.removeUnusedFiles()
+	return s, nil
+}
+
+// removeUnusedFiles removes the files left behind by an update which was
+// interrupted.
+func (s *Snapshot) removeUnusedFiles() {
+	used := map[string]bool{}
+	for _, name := range s.manifest.Runs {
+		used[name] = true
+	}
+	files, _ := filepath.Glob(filepath.Join(s.dir, "run-*"))
+	for _, path := range files {
+		if !used[filepath.Base(path)] {
+			os.Remove(path)
+		}
+	}
+}
+
+// Ledger returns the sequence of the ledger whose state is held by the snapshot.
+func (s *Snapshot) Ledger() uint32 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.manifest.Ledger
+}
+
+// Get returns the ledger entry with the given key and whether it exists.
+func (s *Snapshot) Get(key xdr.LedgerKey) (xdr.LedgerEntry, bool, error) {
+	var entry xdr.LedgerEntry
+	encodedKey, err := key.MarshalBinary()
+	if err != nil {
+		return entry, false, errors.Wrap(err, "could not marshal ledger key")
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.closed {
+		return entry, false, errors.New("snapshot is closed")
+	}
+
+	for i := len(s.runs) - 1; i >= 0; i-- {
+		value, ok, err := s.runs[i].get(encodedKey)
+		if err != nil {
+			return entry, false, err
+		}
+		if !ok {
+			continue
+		}
+		if len(value) == 0 {
+			// the entry was removed
+			return entry, false, nil
+		}
+		if err = entry.UnmarshalBinary(value); err != nil {
+			return entry, false, errors.Wrap(err, "could not unmarshal ledger entry")
+		}
+		return entry, true, nil
+	}
+	return entry, false, nil
+}
+
+// ApplyChanges updates the snapshot to the state at ledger, which must be
+// greater than the current one, by applying changes in order. The changes
+// of each ledger between the current one and ledger must be included, in the
+// order they were applied, or compacted by a ChangeCompactor. A
+// *CompactionError is returned if the changes were applied but the snapshot
+// could not be compacted.
+func (s *Snapshot) ApplyChanges(ledger uint32, changes []ingest.Change) error {
+	delta := map[string][]byte{}
+	for _, change := range changes {
+		if err := addChange(delta, change); err != nil {
+			return err
+		}
+	}
+	return s.applyDelta(ledger, delta)
+}
+
+// RollForward updates the snapshot to the state at toLedger by applying the
+// changes of every ledger following the current one, read from backend.
+// The range of ledgers must be prepared on backend beforehand. A
+// *CompactionError is returned if the changes up to its ledger were applied
+// but the snapshot could not be compacted.
+func (s *Snapshot) RollForward(ctx context.Context, backend ledgerbackend.LedgerBackend, networkPassphrase string, toLedger uint32) error {
+	from := s.Ledger() + 1
+	if toLedger < from {
+		return errors.Errorf("snapshot is already at ledger %d", from-1)
+	}
+
+	delta := map[string][]byte{}
+	for sequence := from; sequence <= toLedger; sequence++ {
+		ledgerCloseMeta, err := backend.GetLedger(ctx, sequence)
+		if err != nil {
+			return errors.Wrapf(err, "could not get ledger %d", sequence)
+		}
+		if err = addLedgerChanges(delta, networkPassphrase, ledgerCloseMeta); err != nil {
+			return errors.Wrapf(err, "could not apply ledger %d", sequence)
+		}
+
+		if sequence == toLedger || (sequence-from+1)%rollForwardBatchSize == 0 {
+			if err = s.applyDelta(sequence, delta); err != nil {
+				return err
+			}
+			delta = map[string][]byte{}
+		}
+	}
+	return nil
+}
+
+// addLedgerChanges adds the compacted changes of a ledger, and the removal of
+// the entries evicted in it, to delta.
+func addLedgerChanges(delta map[string][]byte, networkPassphrase string, ledgerCloseMeta xdr.LedgerCloseMeta) error {
+	changeReader, err := ingest.NewLedgerChangeReaderFromLedgerCloseMeta(networkPassphrase, ledgerCloseMeta)
+	if err != nil {
+		return err
+	}
+	reader := ingest.NewCompactingChangeReader(changeReader, ingest.ChangeCompactorConfig{})
+	defer reader.Close()
+
+	for {
+		change, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err = addChange(delta, change); err != nil {
+			return err
+		}
+	}
+
+	evictedKeys, err := ledgerCloseMeta.EvictedLedgerKeys()
+	if err != nil {
+		return err
+	}
+	for _, key := range evictedKeys {
+		encodedKey, err := key.MarshalBinary()
+		if err != nil {
+			return errors.Wrap(err, "could not marshal ledger key")
+		}
+		delta[string(encodedKey)] = nil
+	}
+	return nil
+}
+
+func addChange(delta map[string][]byte, change ingest.Change) error {
+	key, err := change.LedgerKey()
+	if err != nil {
+		return errors.Wrap(err, "could not get ledger key")
+	}
+	encodedKey, err := key.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "could not marshal ledger key")
+	}
+	if change.Post == nil {
+		delta[string(encodedKey)] = nil
+		return nil
+	}
+	value, err := change.Post.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "could not marshal ledger entry")
+	}
+	delta[string(encodedKey)] = value
+	return nil
+}
+
+// applyDelta writes the entries changed up to ledger in a new run file.
+func (s *Snapshot) applyDelta(ledger uint32, delta map[string][]byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.closed {
+		return errors.New("snapshot is closed")
+	}
+	if ledger <= s.manifest.Ledger {
+		return errors.Errorf("ledger %d is not after the snapshot ledger %d", ledger, s.manifest.Ledger)
+	}
+
+	updated := s.manifest
+	updated.Runs = append([]string{}, s.manifest.Runs...)
+	updated.Ledger = ledger
+	if len(delta) == 0 {
+		return s.replaceManifest(updated)
+	}
+
+	records := make([]record, 0, len(delta))
+	for key, value := range delta {
+		records = append(records, record{key: []byte(key), value: value})
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return bytes.Compare(records[i].key, records[j].key) < 0
+	})
+
+	name := s.nextRunFileName()
+	if err := s.writeRun(name, records); err != nil {
+		return err
+	}
+	updated.Runs = append(updated.Runs, name)
+	if err := s.replaceManifest(updated); err != nil {
+		os.Remove(filepath.Join(s.dir, name))
+		return err
+	}
+
+	if len(s.runs) > maxRuns {
+		if err := s.compact(); err != nil {
+			return &CompactionError{Ledger: ledger, Err: err}
+		}
+	}
+	return nil
+}
+
+// Compact merges the files of the snapshot into a single one, dropping the
+// removed entries. It speeds up reads after many updates.
+func (s *Snapshot) Compact() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.closed {
+		return errors.New("snapshot is closed")
+	}
+	if len(s.runs) <= 1 {
+		return nil
+	}
+	return s.compact()
+}
+
+func (s *Snapshot) compact() error {
+	name := s.nextRunFileName()
+	if err := s.mergeRuns(name, s.manifest.Runs, true); err != nil {
+		return err
+	}
+	updated := s.manifest
+	updated.Runs = []string{name}
+	if err := s.replaceManifest(updated); err != nil {
+		os.Remove(filepath.Join(s.dir, name))
+		return err
+	}
+	return nil
+}
+
+// NewChangeReader returns a ChangeReader streaming every ledger entry of the
+// snapshot as a CREATED change, like a CheckpointChangeReader, in ledger key
+// order. The reader is not affected by later updates of the snapshot.
+func (s *Snapshot) NewChangeReader() (ingest.ChangeReader, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.closed {
+		return nil, errors.New("snapshot is closed")
+	}
+
+	reader := &changeReader{}
+	for _, name := range s.manifest.Runs {
+		r, err := openRun(filepath.Join(s.dir, name))
+		if err != nil {
+			reader.Close()
+			return nil, err
+		}
+		reader.runs = append(reader.runs, r)
+	}
+	iterators := make([]*runIterator, len(reader.runs))
+	for i, r := range reader.runs {
+		iterators[i] = r.iterator()
+	}
+	reader.merge = newMergeIterator(iterators)
+	return reader, nil
+}
+
+// Close closes the files of the snapshot.
+func (s *Snapshot) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	return closeRuns(s.runs)
+}
+
+type changeReader struct {
+	runs  []*run
+	merge *mergeIterator
+}
+
+var _ ingest.ChangeReader = (*changeReader)(nil)
+
+func (r *changeReader) Read() (ingest.Change, error) {
+	for r.merge.next() {
+		if len(r.merge.value) == 0 {
+			continue
+		}
+		var entry xdr.LedgerEntry
+		if err := entry.UnmarshalBinary(r.merge.value); err != nil {
+			return ingest.Change{}, errors.Wrap(err, "could not unmarshal ledger entry")
+		}
+		return ingest.Change{
+			Type:       entry.Data.Type,
+			ChangeType: xdr.LedgerEntryChangeTypeLedgerEntryCreated,
+			Post:       &entry,
+		}, nil
+	}
+	if r.merge.err != nil {
+		return ingest.Change{}, r.merge.err
+	}
+	return ingest.Change{}, io.EOF
+}
+
+func (r *changeReader) Close() error {
+	return closeRuns(r.runs)
+}
+
+type record struct {
+	key   []byte
+	value []byte
+}
+
+func newRecord(entry xdr.LedgerEntry) (record, error) {
+	key, err := entry.LedgerKey()
+	if err != nil {
+		return record{}, errors.Wrap(err, "could not get ledger key")
+	}
+	encodedKey, err := key.MarshalBinary()
+	if err != nil {
+		return record{}, errors.Wrap(err, "could not marshal ledger key")
+	}
+	value, err := entry.MarshalBinary()
+	if err != nil {
+		return record{}, errors.Wrap(err, "could not marshal ledger entry")
+	}
+	return record{key: encodedKey, value: value}, nil
+}
+
+func (s *Snapshot) nextRunFileName() string {
+	name := runFileName(s.manifest.NextRun)
+	s.manifest.NextRun++
+	return name
+}
+
+func (s *Snapshot) writeRun(name string, records []record) error {
+	writer, err := newRunWriter(filepath.Join(s.dir, name))
+	if err != nil {
+		return err
+	}
+	for _, r := range records {
+		if err = writer.add(r.key, r.value); err != nil {
+			writer.abort()
+			return err
+		}
+	}
+	return writer.finish()
+}
+
+// mergeRuns writes the merge of the given run files into a new run file.
+// Removed entries are dropped if dropRemoved is set.
+func (s *Snapshot) mergeRuns(name string, names []string, dropRemoved bool) error {
+	var runs []*run
+	iterators := make([]*runIterator, len(names))
+	for i, runName := range names {
+		r, err := openRun(filepath.Join(s.dir, runName))
+		if err != nil {
+			closeRuns(runs)
+			return err
+		}
+		runs = append(runs, r)
+		iterators[i] = r.iterator()
+	}
+	defer closeRuns(runs)
+
+	writer, err := newRunWriter(filepath.Join(s.dir, name))
+	if err != nil {
+		return err
+	}
+	merge := newMergeIterator(iterators)
+	for merge.next() {
+		if dropRemoved && len(merge.value) == 0 {
+			continue
+		}
+		if err = writer.add(merge.key, merge.value); err != nil {
+			writer.abort()
+			return err
+		}
+	}
+	if merge.err != nil {
+		writer.abort()
+		return merge.err
+	}
+	return writer.finish()
+}
+
+func (s *Snapshot) writeManifest(m manifest) error {
+	contents, err := json.Marshal(m)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal snapshot manifest")
+	}
+	path := filepath.Join(s.dir, manifestFileName)
+	if err = os.WriteFile(path+".tmp", contents, 0644); err != nil {
+		return errors.Wrap(err, "could not write snapshot manifest")
+	}
+	if err = os.Rename(path+".tmp", path); err != nil {
+		return errors.Wrap(err, "could not write snapshot manifest")
+	}
+	return nil
+}
+
+// replaceManifest writes the updated manifest, then opens the new run files
+// and removes the ones which are no longer used.
+func (s *Snapshot) replaceManifest(updated manifest) error {
+	updated.NextRun = s.manifest.NextRun
+	if err := s.writeManifest(updated); err != nil {
+		return err
+	}
+
+	previous := s.runs
+	previousNames := s.manifest.Runs
+	s.manifest = updated
+	if err := s.openRuns(); err != nil {
+		return err
+	}
+	closeRuns(previous)
+
+	used := map[string]bool{}
+	for _, name := range updated.Runs {
+		used[name] = true
+	}
+	for _, name := range previousNames {
+		if !used[name] {
+			os.Remove(filepath.Join(s.dir, name))
+		}
+	}
+	return nil
+}
+
+func (s *Snapshot) openRuns() error {
+	runs := make([]*run, 0, len(s.manifest.Runs))
+	for _, name := range s.manifest.Runs {
+		r, err := openRun(filepath.Join(s.dir, name))
+		if err != nil {
+			closeRuns(runs)
+			return err
+		}
+		runs = append(runs, r)
+	}
+	s.runs = runs
+	return nil
+}
+
+func closeRuns(runs []*run) error {
+	var firstErr error
+	for _, r := range runs {
+		if err := r.close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,412 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/ingest"
+	"github.com/stellar/go/ingest/ledgerbackend"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/xdr"
+)
+
+func accountEntry(address string, balance xdr.Int64) xdr.LedgerEntry {
+	return xdr.LedgerEntry{
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeAccount,
+			Account: &xdr.AccountEntry{
+				AccountId: xdr.MustAddress(address),
+				Balance:   balance,
+			},
+		},
+	}
+}
+
+func accountKey(address string) xdr.LedgerKey {
+	var key xdr.LedgerKey
+	if err := key.SetAccount(xdr.MustAddress(address)); err != nil {
+		panic(err)
+	}
+	return key
+}
+
+func randomAddresses(count int) []string {
+	addresses := make([]string, count)
+	for i := range addresses {
+		addresses[i] = keypair.MustRandom().Address()
+	}
+	return addresses
+}
+
+// checkpointReader mocks a CheckpointChangeReader returning an account per address.
+func checkpointReader(addresses []string, balance xdr.Int64) *ingest.MockChangeReader {
+	reader := &ingest.MockChangeReader{}
+	for _, address := range addresses {
+		entry := accountEntry(address, balance)
+		reader.On("Read").Return(ingest.Change{Type: xdr.LedgerEntryTypeAccount, Post: &entry}, nil).Once()
+	}
+	reader.On("Read").Return(ingest.Change{}, io.EOF).Once()
+	return reader
+}
+
+func assertBalance(t *testing.T, s *Snapshot, address string, balance xdr.Int64) {
+	entry, ok, err := s.Get(accountKey(address))
+	require.NoError(t, err)
+	require.True(t, ok, "account %s not found", address)
+	assert.Equal(t, balance, entry.Data.MustAccount().Balance)
+}
+
+func assertMissing(t *testing.T, s *Snapshot, address string) {
+	_, ok, err := s.Get(accountKey(address))
+	require.NoError(t, err)
+	assert.False(t, ok, "account %s found", address)
+}
+
+func readAll(t *testing.T, s *Snapshot) map[string]xdr.Int64 {
+	reader, err := s.NewChangeReader()
+	require.NoError(t, err)
+	defer reader.Close()
+
+	balances := map[string]xdr.Int64{}
+	for {
+		change, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		assert.Nil(t, change.Pre)
+		assert.Equal(t, xdr.LedgerEntryChangeTypeLedgerEntryCreated, change.ChangeType)
+		account := change.Post.Data.MustAccount()
+		balances[account.AccountId.Address()] = account.Balance
+	}
+	return balances
+}
+
+func TestCreateAndOpen(t *testing.T) {
+	// force the entries to be sorted in several chunks
+	defer func(size int) { maxChunkSize = size }(maxChunkSize)
+	maxChunkSize = 1000
+
+	dir := t.TempDir()
+	_, err := Open(dir)
+	assert.Equal(t, ErrNotFound, err)
+
+	addresses := randomAddresses(500)
+	reader := checkpointReader(addresses, 100)
+	s, err := Create(context.Background(), dir, reader, 63)
+	require.NoError(t, err)
+	reader.AssertExpectations(t)
+	assert.Equal(t, uint32(63), s.Ledger())
+	for _, address := range addresses {
+		assertBalance(t, s, address, 100)
+	}
+	assertMissing(t, s, keypair.MustRandom().Address())
+	require.NoError(t, s.Close())
+
+	// the sorted chunks were merged into a single file
+	files, err := filepath.Glob(filepath.Join(dir, "run-*"))
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	_, err = Create(context.Background(), dir, checkpointReader(nil, 0), 63)
+	assert.EqualError(t, err, fmt.Sprintf("a snapshot already exists in %s", dir))
+
+	s, err = Open(dir)
+	require.NoError(t, err)
+	defer s.Close()
+	assert.Equal(t, uint32(63), s.Ledger())
+	balances := readAll(t, s)
+	assert.Len(t, balances, len(addresses))
+	for _, address := range addresses {
+		assert.Equal(t, xdr.Int64(100), balances[address])
+	}
+}
+
+func TestCreateInvalidChange(t *testing.T) {
+	entry := accountEntry(keypair.MustRandom().Address(), 100)
+	reader := &ingest.MockChangeReader{}
+	reader.On("Read").Return(ingest.Change{Type: xdr.LedgerEntryTypeAccount, Pre: &entry}, nil).Once()
+
+	dir := t.TempDir()
+	_, err := Create(context.Background(), dir, reader, 63)
+	assert.EqualError(t, err, "snapshots can only be created from CREATED changes")
+	_, err = Open(dir)
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestApplyChanges(t *testing.T) {
+	addresses := randomAddresses(3)
+	s, err := Create(context.Background(), t.TempDir(), checkpointReader(addresses[:2], 100), 63)
+	require.NoError(t, err)
+	defer s.Close()
+
+	updated, removed, created := accountEntry(addresses[0], 200), accountEntry(addresses[1], 100), accountEntry(addresses[2], 300)
+	require.NoError(t, s.ApplyChanges(64, []ingest.Change{
+		{Type: xdr.LedgerEntryTypeAccount, Pre: &updated, Post: &updated},
+		{Type: xdr.LedgerEntryTypeAccount, Pre: &removed},
+		{Type: xdr.LedgerEntryTypeAccount, Post: &created},
+	}))
+	assert.Equal(t, uint32(64), s.Ledger())
+	assertBalance(t, s, addresses[0], 200)
+	assertMissing(t, s, addresses[1])
+	assertBalance(t, s, addresses[2], 300)
+	assert.Equal(t, map[string]xdr.Int64{addresses[0]: 200, addresses[2]: 300}, readAll(t, s))
+
+	assert.EqualError(t, s.ApplyChanges(64, nil), "ledger 64 is not after the snapshot ledger 64")
+
+	// a reader isn't affected by compaction
+	reader, err := s.NewChangeReader()
+	require.NoError(t, err)
+	require.NoError(t, s.Compact())
+	assert.Equal(t, uint32(64), s.Ledger())
+	change, err := reader.Read()
+	require.NoError(t, err)
+	assert.NotNil(t, change.Post)
+	require.NoError(t, reader.Close())
+
+	assert.Equal(t, map[string]xdr.Int64{addresses[0]: 200, addresses[2]: 300}, readAll(t, s))
+	assertMissing(t, s, addresses[1])
+}
+
+func TestAutomaticCompaction(t *testing.T) {
+	defer func(runs int) { maxRuns = runs }(maxRuns)
+	maxRuns = 3
+
+	dir := t.TempDir()
+	address := keypair.MustRandom().Address()
+	s, err := Create(context.Background(), dir, checkpointReader([]string{address}, 0), 63)
+	require.NoError(t, err)
+	defer s.Close()
+
+	for ledger := uint32(64); ledger < 74; ledger++ {
+		entry := accountEntry(address, xdr.Int64(ledger))
+		require.NoError(t, s.ApplyChanges(ledger, []ingest.Change{
+			{Type: xdr.LedgerEntryTypeAccount, Pre: &entry, Post: &entry},
+		}))
+		assertBalance(t, s, address, xdr.Int64(ledger))
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "run-*"))
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(files), 4)
+	assert.Len(t, files, len(s.manifest.Runs))
+}
+
+func TestCompactionFailure(t *testing.T) {
+	defer func(runs int) { maxRuns = runs }(maxRuns)
+	maxRuns = 1
+
+	dir := t.TempDir()
+	address := keypair.MustRandom().Address()
+	s, err := Create(context.Background(), dir, checkpointReader([]string{address}, 0), 63)
+	require.NoError(t, err)
+	defer s.Close()
+
+	// the run file of the compaction, following the one of the delta, can't be created
+	blocked := filepath.Join(dir, runFileName(s.manifest.NextRun+1)+".tmp")
+	require.NoError(t, os.Mkdir(blocked, 0755))
+
+	entry := accountEntry(address, 64)
+	err = s.ApplyChanges(64, []ingest.Change{{Type: xdr.LedgerEntryTypeAccount, Pre: &entry, Post: &entry}})
+	var compactionErr *CompactionError
+	require.ErrorAs(t, err, &compactionErr)
+	assert.Equal(t, uint32(64), compactionErr.Ledger)
+
+	// the delta was applied
+	assert.Equal(t, uint32(64), s.Ledger())
+	assertBalance(t, s, address, 64)
+
+	require.NoError(t, os.Remove(blocked))
+	require.NoError(t, s.Compact())
+	assert.Len(t, s.manifest.Runs, 1)
+	assertBalance(t, s, address, 64)
+}
+
+func upgradeLedger(sequence uint32, changes ...xdr.LedgerEntryChange) xdr.LedgerCloseMeta {
+	return xdr.LedgerCloseMeta{
+		V: 0,
+		V0: &xdr.LedgerCloseMetaV0{
+			LedgerHeader: xdr.LedgerHeaderHistoryEntry{
+				Header: xdr.LedgerHeader{
+					LedgerSeq:     xdr.Uint32(sequence),
+					LedgerVersion: 10,
+				},
+			},
+			UpgradesProcessing: []xdr.UpgradeEntryMeta{{Changes: changes}},
+		},
+	}
+}
+
+func stateChange(entry xdr.LedgerEntry) xdr.LedgerEntryChange {
+	return xdr.LedgerEntryChange{Type: xdr.LedgerEntryChangeTypeLedgerEntryState, State: &entry}
+}
+
+func updatedChange(entry xdr.LedgerEntry) xdr.LedgerEntryChange {
+	return xdr.LedgerEntryChange{Type: xdr.LedgerEntryChangeTypeLedgerEntryUpdated, Updated: &entry}
+}
+
+func createdChange(entry xdr.LedgerEntry) xdr.LedgerEntryChange {
+	return xdr.LedgerEntryChange{Type: xdr.LedgerEntryChangeTypeLedgerEntryCreated, Created: &entry}
+}
+
+func removedChange(entry xdr.LedgerEntry) xdr.LedgerEntryChange {
+	key, err := entry.LedgerKey()
+	if err != nil {
+		panic(err)
+	}
+	return xdr.LedgerEntryChange{Type: xdr.LedgerEntryChangeTypeLedgerEntryRemoved, Removed: &key}
+}
+
+func TestRollForward(t *testing.T) {
+	defer func(size uint32) { rollForwardBatchSize = size }(rollForwardBatchSize)
+	rollForwardBatchSize = 2
+
+	ctx := context.Background()
+	addresses := randomAddresses(3)
+	dir := t.TempDir()
+	s, err := Create(ctx, dir, checkpointReader(addresses[:2], 100), 63)
+	require.NoError(t, err)
+
+	backend := &ledgerbackend.MockDatabaseBackend{}
+	backend.On("GetLedger", ctx, uint32(64)).Return(upgradeLedger(64,
+		stateChange(accountEntry(addresses[0], 100)),
+		updatedChange(accountEntry(addresses[0], 150)),
+		stateChange(accountEntry(addresses[0], 150)),
+		updatedChange(accountEntry(addresses[0], 200)),
+	), nil).Once()
+	backend.On("GetLedger", ctx, uint32(65)).Return(upgradeLedger(65,
+		createdChange(accountEntry(addresses[2], 300)),
+	), nil).Once()
+	backend.On("GetLedger", ctx, uint32(66)).Return(upgradeLedger(66,
+		stateChange(accountEntry(addresses[1], 100)),
+		removedChange(accountEntry(addresses[1], 100)),
+	), nil).Once()
+
+	require.NoError(t, s.RollForward(ctx, backend, network.TestNetworkPassphrase, 66))
+	backend.AssertExpectations(t)
+	assert.Equal(t, uint32(66), s.Ledger())
+	assertBalance(t, s, addresses[0], 200)
+	assertMissing(t, s, addresses[1])
+	assertBalance(t, s, addresses[2], 300)
+	require.NoError(t, s.Close())
+
+	s, err = Open(dir)
+	require.NoError(t, err)
+	defer s.Close()
+	assert.Equal(t, uint32(66), s.Ledger())
+	assert.Equal(t, map[string]xdr.Int64{addresses[0]: 200, addresses[2]: 300}, readAll(t, s))
+
+	assert.EqualError(t, s.RollForward(ctx, backend, network.TestNetworkPassphrase, 66),
+		"snapshot is already at ledger 66")
+
+	backend.On("GetLedger", ctx, uint32(67)).Return(upgradeLedger(67,
+		stateChange(accountEntry(addresses[1], 100)),
+		removedChange(accountEntry(addresses[1], 100)),
+		stateChange(accountEntry(addresses[1], 100)),
+		removedChange(accountEntry(addresses[1], 100)),
+	), nil).Once()
+	err = s.RollForward(ctx, backend, network.TestNetworkPassphrase, 67)
+	assert.ErrorContains(t, err, "could not apply ledger 67")
+	assert.Equal(t, uint32(66), s.Ledger())
+}
+
+func TestRemoveUnusedFiles(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Create(context.Background(), dir, checkpointReader(randomAddresses(2), 100), 63)
+	require.NoError(t, err)
+	require.NoError(t, s.Close())
+
+	// files left behind by an interrupted update
+	require.NoError(t, os.WriteFile(filepath.Join(dir, runFileName(10)), nil, 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, runFileName(11)+".tmp"), nil, 0644))
+
+	s, err = Open(dir)
+	require.NoError(t, err)
+	defer s.Close()
+	files, err := filepath.Glob(filepath.Join(dir, "run-*"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, s.manifest.Runs[0])}, files)
+}
+
+func TestMergeIterator(t *testing.T) {
+	dir := t.TempDir()
+	s := &Snapshot{dir: dir}
+	require.NoError(t, s.writeRun("a", []record{
+		{key: []byte("a"), value: []byte("1")},
+		{key: []byte("b"), value: []byte("1")},
+		{key: []byte("d"), value: []byte("1")},
+	}))
+	require.NoError(t, s.writeRun("b", []record{
+		{key: []byte("b"), value: nil},
+		{key: []byte("c"), value: []byte("2")},
+		{key: []byte("d"), value: []byte("2")},
+	}))
+	require.NoError(t, s.mergeRuns("c", []string{"a", "b"}, false))
+	require.NoError(t, s.mergeRuns("d", []string{"a", "b"}, true))
+
+	read := func(name string) []record {
+		r, err := openRun(filepath.Join(dir, name))
+		require.NoError(t, err)
+		defer r.close()
+		var records []record
+		it := r.iterator()
+		for it.next() {
+			records = append(records, record{key: it.key, value: it.value})
+		}
+		require.NoError(t, it.err)
+		return records
+	}
+	assert.Equal(t, []record{
+		{key: []byte("a"), value: []byte("1")},
+		{key: []byte("b"), value: []byte{}},
+		{key: []byte("c"), value: []byte("2")},
+		{key: []byte("d"), value: []byte("2")},
+	}, read("c"))
+	assert.Equal(t, []record{
+		{key: []byte("a"), value: []byte("1")},
+		{key: []byte("c"), value: []byte("2")},
+		{key: []byte("d"), value: []byte("2")},
+	}, read("d"))
+}
+
+func TestRunGet(t *testing.T) {
+	dir := t.TempDir()
+	s := &Snapshot{dir: dir}
+	var records []record
+	for i := 0; i < 3*indexInterval+5; i++ {
+		records = append(records, record{key: []byte(fmt.Sprintf("key-%04d", 2*i)), value: []byte(fmt.Sprint(i))})
+	}
+	require.NoError(t, s.writeRun("run", records))
+	r, err := openRun(filepath.Join(dir, "run"))
+	require.NoError(t, err)
+	defer r.close()
+	assert.Len(t, r.index, 4)
+
+	for i, record := range records {
+		value, ok, err := r.get(record.key)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, record.value, value)
+
+		_, ok, err = r.get([]byte(fmt.Sprintf("key-%04d", 2*i+1)))
+		require.NoError(t, err)
+		assert.False(t, ok)
+	}
+	_, ok, err := r.get([]byte("a"))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	writer, err := newRunWriter(filepath.Join(dir, "unsorted"))
+	require.NoError(t, err)
+	require.NoError(t, writer.add([]byte("b"), nil))
+	assert.EqualError(t, writer.add([]byte("a"), nil), "run keys are not in increasing order")
+	writer.abort()
+}
@@ -0,0 +1,389 @@
+package contract
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/stellar/go/ingest"
+	"github.com/stellar/go/processors/utils"
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/xdr"
+)
+
+// The events of the lifecycle of contract data and contract code entries
+// emitted by StateArchivalProcessor.
+const (
+	// StateArchivalEventCreated is emitted when an entry is created.
+	StateArchivalEventCreated = "created"
+	// StateArchivalEventTtlExtended is emitted when the TTL of an entry is extended.
+	StateArchivalEventTtlExtended = "ttl_extended"
+	// StateArchivalEventExpiring is emitted once when an entry is going to expire
+	// within the configured number of ledgers.
+	StateArchivalEventExpiring = "expiring"
+	// StateArchivalEventArchived is emitted when the TTL of a persistent entry,
+	// or of contract code, is reached. The entry can be restored until evicted.
+	StateArchivalEventArchived = "archived"
+	// StateArchivalEventExpired is emitted when the TTL of a temporary entry is
+	// reached. The entry can't be restored.
+	StateArchivalEventExpired = "expired"
+	// StateArchivalEventRestored is emitted when an archived entry is restored.
+	StateArchivalEventRestored = "restored"
+	// StateArchivalEventEvicted is emitted when an entry is evicted from the
+	// live state.
+	StateArchivalEventEvicted = "evicted"
+	// StateArchivalEventDeleted is emitted when an entry is deleted by a contract.
+	StateArchivalEventDeleted = "deleted"
+)
+
+// StateArchivalOutput is a lifecycle event of a contract data or contract code entry
+type StateArchivalOutput struct {
+	Event                      string    `json:"event"`
+	LedgerKeyHash              string    `json:"ledger_key_hash"`
+	EntryType                  string    `json:"entry_type"`
+	ContractId                 string    `json:"contract_id"`
+	ContractKeyType            string    `json:"contract_key_type"`
+	ContractDurability         string    `json:"contract_durability"`
+	ContractCodeHash           string    `json:"contract_code_hash"`
+	LiveUntilLedgerSeq         uint32    `json:"live_until_ledger_seq"`
+	PreviousLiveUntilLedgerSeq uint32    `json:"previous_live_until_ledger_seq"`
+	ClosedAt                   time.Time `json:"closed_at"`
+	LedgerSequence             uint32    `json:"ledger_sequence"`
+}
+
+// archivalEntry is the state tracked for a contract data or contract code entry.
+type archivalEntry struct {
+	// description of the entry, unknown until its data or code entry is seen
+	known           bool
+	entryType       string
+	contractId      string
+	contractKeyType string
+	durability      string
+	codeHash        string
+
+	liveUntil uint32
+	expired   bool
+	warned    bool
+	// the ledger the expiring event of the entry is scheduled in
+	warnAt uint32
+}
+
+// StateArchivalProcessor derives the lifecycle events of contract data and
+// contract code entries from the changes of their entries and of their TTL
+// entries, and from the keys evicted in every ledger.
+//
+// Expiration isn't caused by a change, so the processor tracks the TTL of the
+// entries and must be given every ledger, in order. It only knows the entries
+// changed since it started, unless it is seeded with the entries of a
+// checkpoint with Seed.
+type StateArchivalProcessor struct {
+	networkPassphrase string
+	warningLedgers    uint32
+
+	entries map[string]*archivalEntry
+	// the ledger key hashes of the entries by the ledger they expire or are
+	// warned about
+	expirations map[uint32]map[string]bool
+	warnings    map[uint32]map[string]bool
+	lastLedger  uint32
+}
+
+// NewStateArchivalProcessor returns a StateArchivalProcessor for the ledgers
+// of the network of networkPassphrase. When warningLedgers is not zero, an
+// expiring event is emitted once the TTL of an entry is reached within
+// warningLedgers ledgers.
+func NewStateArchivalProcessor(networkPassphrase string, warningLedgers uint32) *StateArchivalProcessor {
+	return &StateArchivalProcessor{
+		networkPassphrase: networkPassphrase,
+		warningLedgers:    warningLedgers,
+		entries:           map[string]*archivalEntry{},
+		expirations:       map[uint32]map[string]bool{},
+		warnings:          map[uint32]map[string]bool{},
+	}
+}
+
+// Seed adds an entry of the live state, e.g. read from a checkpoint with
+// ingest.CheckpointChangeReader, without emitting events.
+func (p *StateArchivalProcessor) Seed(change ingest.Change) error {
+	if change.Post == nil {
+		return fmt.Errorf("seeded change of %s entry has no post state", change.Type)
+	}
+	switch change.Type {
+	case xdr.LedgerEntryTypeContractData, xdr.LedgerEntryTypeContractCode:
+		_, err := p.describe(*change.Post)
+		return err
+	case xdr.LedgerEntryTypeTtl:
+		ttl := change.Post.Data.MustTtl()
+		p.setLiveUntil(ttl.KeyHash.HexString(), uint32(ttl.LiveUntilLedgerSeq))
+	}
+	return nil
+}
+
+// ProcessLedger returns the lifecycle events of the entries in a ledger.
+func (p *StateArchivalProcessor) ProcessLedger(ledger xdr.LedgerCloseMeta) ([]StateArchivalOutput, error) {
+	header := ledger.LedgerHeaderHistoryEntry()
+	sequence := uint32(header.Header.LedgerSeq)
+	if p.lastLedger != 0 && sequence != p.lastLedger+1 {
+		return nil, fmt.Errorf("ledger %d does not follow the last processed ledger %d", sequence, p.lastLedger)
+	}
+	closedAt, err := utils.TimePointToUTCTimeStamp(header.Header.ScpValue.CloseTime)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := ingest.NewLedgerChangeReaderFromLedgerCloseMeta(p.networkPassphrase, ledger,
+		ingest.WithEntryTypes(xdr.LedgerEntryTypeContractData, xdr.LedgerEntryTypeContractCode, xdr.LedgerEntryTypeTtl))
+	if err != nil {
+		return nil, fmt.Errorf("could not create change reader for ledger %d: %w", sequence, err)
+	}
+	defer reader.Close()
+
+	var events []*StateArchivalOutput
+	emit := func(event string, keyHash string, entry *archivalEntry) *StateArchivalOutput {
+		output := &StateArchivalOutput{
+			Event:              event,
+			LedgerKeyHash:      keyHash,
+			EntryType:          entry.entryType,
+			ContractId:         entry.contractId,
+			ContractKeyType:    entry.contractKeyType,
+			ContractDurability: entry.durability,
+			ContractCodeHash:   entry.codeHash,
+			LiveUntilLedgerSeq: entry.liveUntil,
+			ClosedAt:           closedAt,
+			LedgerSequence:     sequence,
+		}
+		events = append(events, output)
+		return output
+	}
+
+	// created and restored events are completed with the TTL of their entries,
+	// which may change after them
+	pending := map[*StateArchivalOutput]string{}
+	for {
+		change, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not read change from ledger %d: %w", sequence, err)
+		}
+
+		switch change.Type {
+		case xdr.LedgerEntryTypeContractData, xdr.LedgerEntryTypeContractCode:
+			if change.Post == nil {
+				keyHash := utils.LedgerEntryToLedgerKeyHash(*change.Pre)
+				entry, err := p.describe(*change.Pre)
+				if err != nil {
+					return nil, err
+				}
+				emit(StateArchivalEventDeleted, keyHash, entry)
+				p.remove(keyHash)
+				continue
+			}
+			keyHash := utils.LedgerEntryToLedgerKeyHash(*change.Post)
+			entry, err := p.describe(*change.Post)
+			if err != nil {
+				return nil, err
+			}
+			switch change.ChangeType {
+			case xdr.LedgerEntryChangeTypeLedgerEntryCreated:
+				pending[emit(StateArchivalEventCreated, keyHash, entry)] = keyHash
+			case xdr.LedgerEntryChangeTypeLedgerEntryRestored:
+				entry.expired = false
+				pending[emit(StateArchivalEventRestored, keyHash, entry)] = keyHash
+			}
+
+		case xdr.LedgerEntryTypeTtl:
+			if change.Post == nil {
+				continue
+			}
+			ttl := change.Post.Data.MustTtl()
+			keyHash := ttl.KeyHash.HexString()
+			liveUntil := uint32(ttl.LiveUntilLedgerSeq)
+			var previous uint32
+			if change.Pre != nil {
+				previous = uint32(change.Pre.Data.MustTtl().LiveUntilLedgerSeq)
+			}
+			entry := p.setLiveUntil(keyHash, liveUntil)
+
+			if change.ChangeType != xdr.LedgerEntryChangeTypeLedgerEntryUpdated {
+				continue
+			}
+			if previous < sequence {
+				// before protocol 23 archived entries are restored by extending
+				// their TTL with RestoreFootprint
+				entry.expired = false
+				emit(StateArchivalEventRestored, keyHash, entry).PreviousLiveUntilLedgerSeq = previous
+			} else if liveUntil > previous {
+				emit(StateArchivalEventTtlExtended, keyHash, entry).PreviousLiveUntilLedgerSeq = previous
+			}
+		}
+	}
+	for output, keyHash := range pending {
+		if entry, ok := p.entries[keyHash]; ok {
+			output.LiveUntilLedgerSeq = entry.liveUntil
+		}
+	}
+
+	evictedKeys, err := ledger.EvictedLedgerKeys()
+	if err != nil {
+		return nil, fmt.Errorf("could not read evicted keys of ledger %d: %w", sequence, err)
+	}
+	for _, key := range evictedKeys {
+		if key.Type != xdr.LedgerEntryTypeContractData && key.Type != xdr.LedgerEntryTypeContractCode {
+			continue
+		}
+		keyHash := utils.LedgerKeyToLedgerKeyHash(key)
+		entry, ok := p.entries[keyHash]
+		if !ok {
+			entry = &archivalEntry{}
+			describeKey(key, entry)
+		}
+		emit(StateArchivalEventEvicted, keyHash, entry)
+		p.remove(keyHash)
+	}
+
+	// on the first ledger, the seeded entries whose TTL was reached before it
+	// are archived without emitting events
+	from := sequence
+	if p.lastLedger == 0 {
+		from = 0
+		for _, keyHash := range p.due(p.expirations, 0, sequence-1) {
+			p.entries[keyHash].expired = true
+		}
+	}
+	for _, keyHash := range p.due(p.warnings, from, sequence) {
+		entry := p.entries[keyHash]
+		if !entry.warned && !entry.expired && entry.liveUntil >= sequence {
+			entry.warned = true
+			emit(StateArchivalEventExpiring, keyHash, entry)
+		}
+	}
+	for _, keyHash := range p.due(p.expirations, sequence, sequence) {
+		entry := p.entries[keyHash]
+		if entry.expired || entry.liveUntil >= sequence {
+			continue
+		}
+		entry.expired = true
+		if entry.durability == xdr.ContractDataDurabilityTemporary.String() {
+			emit(StateArchivalEventExpired, keyHash, entry)
+		} else {
+			emit(StateArchivalEventArchived, keyHash, entry)
+		}
+	}
+	p.lastLedger = sequence
+
+	outputs := make([]StateArchivalOutput, len(events))
+	for i, event := range events {
+		outputs[i] = *event
+	}
+	return outputs, nil
+}
+
+// describe returns the state of the entry of a contract data or contract code
+// ledger entry, describing it.
+func (p *StateArchivalProcessor) describe(ledgerEntry xdr.LedgerEntry) (*archivalEntry, error) {
+	key, err := ledgerEntry.LedgerKey()
+	if err != nil {
+		return nil, fmt.Errorf("could not get ledger key of %s entry: %w", ledgerEntry.Data.Type, err)
+	}
+	entry := p.entry(utils.LedgerKeyToLedgerKeyHash(key))
+	describeKey(key, entry)
+	if contractData, ok := ledgerEntry.Data.GetContractData(); ok {
+		entry.contractKeyType = contractData.Key.Type.String()
+	}
+	return entry, nil
+}
+
+func describeKey(key xdr.LedgerKey, entry *archivalEntry) {
+	entry.known = true
+	entry.entryType = key.Type.String()
+	switch key.Type {
+	case xdr.LedgerEntryTypeContractData:
+		contractData := key.MustContractData()
+		if contractId, ok := contractData.Contract.GetContractId(); ok {
+			entry.contractId, _ = strkey.Encode(strkey.VersionByteContract, contractId[:])
+		}
+		entry.contractKeyType = contractData.Key.Type.String()
+		entry.durability = contractData.Durability.String()
+	case xdr.LedgerEntryTypeContractCode:
+		entry.codeHash = key.MustContractCode().Hash.HexString()
+		// contract code is always persistent
+		entry.durability = xdr.ContractDataDurabilityPersistent.String()
+	}
+}
+
+func (p *StateArchivalProcessor) entry(keyHash string) *archivalEntry {
+	entry, ok := p.entries[keyHash]
+	if !ok {
+		entry = &archivalEntry{}
+		p.entries[keyHash] = entry
+	}
+	return entry
+}
+
+// setLiveUntil sets the TTL of an entry and schedules its expiration.
+func (p *StateArchivalProcessor) setLiveUntil(keyHash string, liveUntil uint32) *archivalEntry {
+	entry := p.entry(keyHash)
+	p.unschedule(keyHash, entry)
+	entry.liveUntil = liveUntil
+	entry.warned = false
+	schedule(p.expirations, liveUntil+1, keyHash)
+	if p.warningLedgers > 0 {
+		// a TTL set within the warning window is warned about at the end of
+		// the current ledger
+		entry.warnAt = max(liveUntil-min(liveUntil, p.warningLedgers), p.lastLedger+1)
+		schedule(p.warnings, entry.warnAt, keyHash)
+	}
+	return entry
+}
+
+func (p *StateArchivalProcessor) remove(keyHash string) {
+	if entry, ok := p.entries[keyHash]; ok {
+		p.unschedule(keyHash, entry)
+		delete(p.entries, keyHash)
+	}
+}
+
+func (p *StateArchivalProcessor) unschedule(keyHash string, entry *archivalEntry) {
+	if entry.liveUntil == 0 {
+		return
+	}
+	delete(p.expirations[entry.liveUntil+1], keyHash)
+	if p.warningLedgers > 0 {
+		delete(p.warnings[entry.warnAt], keyHash)
+	}
+}
+
+func schedule(schedules map[uint32]map[string]bool, ledger uint32, keyHash string) {
+	if schedules[ledger] == nil {
+		schedules[ledger] = map[string]bool{}
+	}
+	schedules[ledger][keyHash] = true
+}
+
+// due removes the entries scheduled in the ledgers from..to from schedules,
+// and returns them sorted.
+func (p *StateArchivalProcessor) due(schedules map[uint32]map[string]bool, from, to uint32) []string {
+	var keyHashes []string
+	collect := func(ledger uint32) {
+		for keyHash := range schedules[ledger] {
+			if _, ok := p.entries[keyHash]; ok {
+				keyHashes = append(keyHashes, keyHash)
+			}
+		}
+		delete(schedules, ledger)
+	}
+	if from == to {
+		collect(to)
+	} else {
+		for ledger := range schedules {
+			if ledger >= from && ledger <= to {
+				collect(ledger)
+			}
+		}
+	}
+	sort.Strings(keyHashes)
+	return keyHashes
+}
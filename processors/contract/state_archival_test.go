@@ -0,0 +1,226 @@
+package contract
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/hash"
+	"github.com/stellar/go/ingest"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/processors/utils"
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/xdr"
+)
+
+var archivalContractId = xdr.ContractId{1, 2, 3}
+
+func archivalDataEntry(key string, durability xdr.ContractDataDurability) xdr.LedgerEntry {
+	sym := xdr.ScSymbol(key)
+	return xdr.LedgerEntry{
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeContractData,
+			ContractData: &xdr.ContractDataEntry{
+				Contract: xdr.ScAddress{
+					Type:       xdr.ScAddressTypeScAddressTypeContract,
+					ContractId: &archivalContractId,
+				},
+				Key:        xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym},
+				Durability: durability,
+				Val:        xdr.ScVal{Type: xdr.ScValTypeScvVoid},
+			},
+		},
+	}
+}
+
+func archivalTtlEntry(t *testing.T, entry xdr.LedgerEntry, liveUntil uint32) xdr.LedgerEntry {
+	key, err := entry.LedgerKey()
+	require.NoError(t, err)
+	keyBytes, err := key.MarshalBinary()
+	require.NoError(t, err)
+	return xdr.LedgerEntry{
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeTtl,
+			Ttl:  &xdr.TtlEntry{KeyHash: xdr.Hash(hash.Hash(keyBytes)), LiveUntilLedgerSeq: xdr.Uint32(liveUntil)},
+		},
+	}
+}
+
+func archivalLedger(sequence uint32, changes xdr.LedgerEntryChanges, evicted ...xdr.LedgerKey) xdr.LedgerCloseMeta {
+	var upgrades []xdr.UpgradeEntryMeta
+	if len(changes) > 0 {
+		upgrades = []xdr.UpgradeEntryMeta{{
+			Upgrade: xdr.LedgerUpgrade{Type: xdr.LedgerUpgradeTypeLedgerUpgradeVersion, NewLedgerVersion: &[]xdr.Uint32{22}[0]},
+			Changes: changes,
+		}}
+	}
+	return xdr.LedgerCloseMeta{
+		V: 1,
+		V1: &xdr.LedgerCloseMetaV1{
+			LedgerHeader: xdr.LedgerHeaderHistoryEntry{
+				Header: xdr.LedgerHeader{
+					LedgerSeq: xdr.Uint32(sequence),
+					ScpValue:  xdr.StellarValue{CloseTime: xdr.TimePoint(1000 + sequence)},
+				},
+			},
+			TxSet:              xdr.GeneralizedTransactionSet{V: 1, V1TxSet: &xdr.TransactionSetV1{}},
+			UpgradesProcessing: upgrades,
+			EvictedKeys:        evicted,
+		},
+	}
+}
+
+func created(entry xdr.LedgerEntry) xdr.LedgerEntryChange {
+	return xdr.LedgerEntryChange{Type: xdr.LedgerEntryChangeTypeLedgerEntryCreated, Created: &entry}
+}
+
+func updated(pre, post xdr.LedgerEntry) []xdr.LedgerEntryChange {
+	return []xdr.LedgerEntryChange{
+		{Type: xdr.LedgerEntryChangeTypeLedgerEntryState, State: &pre},
+		{Type: xdr.LedgerEntryChangeTypeLedgerEntryUpdated, Updated: &post},
+	}
+}
+
+func TestStateArchivalProcessor(t *testing.T) {
+	persistent := archivalDataEntry("persistent", xdr.ContractDataDurabilityPersistent)
+	temporary := archivalDataEntry("temporary", xdr.ContractDataDurabilityTemporary)
+	persistentHash := utils.LedgerEntryToLedgerKeyHash(persistent)
+	temporaryHash := utils.LedgerEntryToLedgerKeyHash(temporary)
+	temporaryKey, err := temporary.LedgerKey()
+	require.NoError(t, err)
+	contractId, err := strkey.Encode(strkey.VersionByteContract, archivalContractId[:])
+	require.NoError(t, err)
+
+	ledgers := map[uint32]xdr.LedgerCloseMeta{
+		10: archivalLedger(10, xdr.LedgerEntryChanges{
+			created(persistent), created(archivalTtlEntry(t, persistent, 20)),
+			created(temporary), created(archivalTtlEntry(t, temporary, 12)),
+		}),
+		11: archivalLedger(11, updated(archivalTtlEntry(t, persistent, 20), archivalTtlEntry(t, persistent, 30))),
+		14: archivalLedger(14, nil, temporaryKey),
+		32: archivalLedger(32, updated(archivalTtlEntry(t, persistent, 30), archivalTtlEntry(t, persistent, 40))),
+	}
+
+	type event struct {
+		sequence          uint32
+		event             string
+		keyHash           string
+		liveUntil         uint32
+		previousLiveUntil uint32
+	}
+	var events []event
+	processor := NewStateArchivalProcessor(network.TestNetworkPassphrase, 5)
+	for sequence := uint32(10); sequence <= 32; sequence++ {
+		ledger, ok := ledgers[sequence]
+		if !ok {
+			ledger = archivalLedger(sequence, nil)
+		}
+		outputs, err := processor.ProcessLedger(ledger)
+		require.NoError(t, err)
+		for _, output := range outputs {
+			assert.Equal(t, sequence, output.LedgerSequence)
+			assert.Equal(t, time.Unix(int64(1000+sequence), 0).UTC(), output.ClosedAt)
+			assert.Equal(t, contractId, output.ContractId)
+			assert.Equal(t, "LedgerEntryTypeContractData", output.EntryType)
+			assert.Equal(t, "ScValTypeScvSymbol", output.ContractKeyType)
+			if output.LedgerKeyHash == temporaryHash {
+				assert.Equal(t, "ContractDataDurabilityTemporary", output.ContractDurability)
+			} else {
+				assert.Equal(t, "ContractDataDurabilityPersistent", output.ContractDurability)
+			}
+			events = append(events, event{
+				output.LedgerSequence, output.Event, output.LedgerKeyHash,
+				output.LiveUntilLedgerSeq, output.PreviousLiveUntilLedgerSeq,
+			})
+		}
+	}
+
+	assert.Equal(t, []event{
+		{10, StateArchivalEventCreated, temporaryHash, 12, 0},
+		{10, StateArchivalEventCreated, persistentHash, 20, 0},
+		{10, StateArchivalEventExpiring, temporaryHash, 12, 0},
+		{11, StateArchivalEventTtlExtended, persistentHash, 30, 20},
+		{13, StateArchivalEventExpired, temporaryHash, 12, 0},
+		{14, StateArchivalEventEvicted, temporaryHash, 12, 0},
+		{25, StateArchivalEventExpiring, persistentHash, 30, 0},
+		{31, StateArchivalEventArchived, persistentHash, 30, 0},
+		{32, StateArchivalEventRestored, persistentHash, 40, 30},
+	}, events)
+
+	_, err = processor.ProcessLedger(archivalLedger(34, nil))
+	assert.EqualError(t, err, "ledger 34 does not follow the last processed ledger 32")
+}
+
+func TestStateArchivalProcessorWarningWindow(t *testing.T) {
+	entry := archivalDataEntry("entry", xdr.ContractDataDurabilityPersistent)
+	keyHash := utils.LedgerEntryToLedgerKeyHash(entry)
+	ledgers := map[uint32]xdr.LedgerCloseMeta{
+		11: archivalLedger(11, xdr.LedgerEntryChanges{created(entry), created(archivalTtlEntry(t, entry, 13))}),
+		12: archivalLedger(12, updated(archivalTtlEntry(t, entry, 13), archivalTtlEntry(t, entry, 14))),
+	}
+
+	type event struct {
+		sequence  uint32
+		event     string
+		liveUntil uint32
+	}
+	var events []event
+	processor := NewStateArchivalProcessor(network.TestNetworkPassphrase, 5)
+	for sequence := uint32(10); sequence <= 16; sequence++ {
+		ledger, ok := ledgers[sequence]
+		if !ok {
+			ledger = archivalLedger(sequence, nil)
+		}
+		outputs, err := processor.ProcessLedger(ledger)
+		require.NoError(t, err)
+		for _, output := range outputs {
+			assert.Equal(t, keyHash, output.LedgerKeyHash)
+			events = append(events, event{output.LedgerSequence, output.Event, output.LiveUntilLedgerSeq})
+		}
+	}
+
+	// the TTLs set within the warning window are warned about immediately
+	assert.Equal(t, []event{
+		{11, StateArchivalEventCreated, 13},
+		{11, StateArchivalEventExpiring, 13},
+		{12, StateArchivalEventTtlExtended, 14},
+		{12, StateArchivalEventExpiring, 14},
+		{15, StateArchivalEventArchived, 14},
+	}, events)
+	assert.Empty(t, processor.warnings)
+	assert.Empty(t, processor.expirations)
+}
+
+func TestStateArchivalProcessorSeed(t *testing.T) {
+	archived := archivalDataEntry("archived", xdr.ContractDataDurabilityPersistent)
+	live := archivalDataEntry("live", xdr.ContractDataDurabilityPersistent)
+
+	processor := NewStateArchivalProcessor(network.TestNetworkPassphrase, 0)
+	for _, entry := range []xdr.LedgerEntry{
+		archived, archivalTtlEntry(t, archived, 50), live, archivalTtlEntry(t, live, 100),
+	} {
+		require.NoError(t, processor.Seed(ingest.Change{
+			ChangeType: xdr.LedgerEntryChangeTypeLedgerEntryCreated,
+			Type:       entry.Data.Type,
+			Post:       &entry,
+		}))
+	}
+
+	// the entry archived before the first ledger doesn't emit events
+	outputs, err := processor.ProcessLedger(archivalLedger(100, nil))
+	require.NoError(t, err)
+	assert.Empty(t, outputs)
+
+	outputs, err = processor.ProcessLedger(archivalLedger(101, xdr.LedgerEntryChanges(
+		updated(archivalTtlEntry(t, archived, 50), archivalTtlEntry(t, archived, 200)),
+	)))
+	require.NoError(t, err)
+	require.Len(t, outputs, 2)
+	assert.Equal(t, StateArchivalEventRestored, outputs[0].Event)
+	assert.Equal(t, utils.LedgerEntryToLedgerKeyHash(archived), outputs[0].LedgerKeyHash)
+	assert.Equal(t, uint32(50), outputs[0].PreviousLiveUntilLedgerSeq)
+	assert.Equal(t, StateArchivalEventArchived, outputs[1].Event)
+	assert.Equal(t, utils.LedgerEntryToLedgerKeyHash(live), outputs[1].LedgerKeyHash)
+}
@@ -0,0 +1,264 @@
+package ledger_export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/stellar/go/ingest"
+	"github.com/stellar/go/xdr"
+)
+
+// SchemaVersion is the version of the ledger_export schema produced by the
+// encoders of this package. It is incremented whenever fields are added to the
+// schema, so consumers can tell which fields a message may contain.
+const SchemaVersion uint32 = 1
+
+// EncodeLedgerCloseMeta converts a LedgerCloseMeta, the transactions applied
+// in it and the changes caused by its upgrades into their exported form.
+func EncodeLedgerCloseMeta(networkPassphrase string, ledger xdr.LedgerCloseMeta) (*LedgerCloseMeta, error) {
+	header := ledger.LedgerHeaderHistoryEntry()
+	encoded := &LedgerCloseMeta{
+		SchemaVersion:   SchemaVersion,
+		MetaVersion:     uint32(ledger.V),
+		Sequence:        ledger.LedgerSequence(),
+		Hash:            ledger.LedgerHash().HexString(),
+		PreviousHash:    ledger.PreviousLedgerHash().HexString(),
+		ProtocolVersion: ledger.ProtocolVersion(),
+		ClosedAt:        timestamppb.New(ledger.ClosedAt()),
+		BaseFee:         uint32(header.Header.BaseFee),
+		BaseReserve:     uint32(header.Header.BaseReserve),
+		MaxTxSetSize:    uint32(header.Header.MaxTxSetSize),
+		TotalCoins:      int64(header.Header.TotalCoins),
+		FeePool:         int64(header.Header.FeePool),
+		BucketListHash:  ledger.BucketListHash().HexString(),
+	}
+
+	reader, err := ingest.NewLedgerTransactionReaderFromLedgerCloseMeta(networkPassphrase, ledger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction reader for ledger %d: %w", encoded.Sequence, err)
+	}
+	defer reader.Close()
+	for {
+		tx, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read transaction from ledger %d: %w", encoded.Sequence, err)
+		}
+		encodedTx, err := encodeLedgerTransaction(tx)
+		if err != nil {
+			return nil, err
+		}
+		encoded.Transactions = append(encoded.Transactions, encodedTx)
+	}
+
+	for _, upgrade := range ledger.UpgradesProcessing() {
+		for _, change := range ingest.GetChangesFromLedgerEntryChanges(upgrade.Changes) {
+			change.Reason = ingest.LedgerEntryChangeReasonUpgrade
+			change.Ledger = &ledger
+			encodedChange, err := encodeChange(change)
+			if err != nil {
+				return nil, err
+			}
+			encoded.UpgradeChanges = append(encoded.UpgradeChanges, encodedChange)
+		}
+	}
+
+	evictedKeys, err := ledger.EvictedLedgerKeys()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read evicted keys of ledger %d: %w", encoded.Sequence, err)
+	}
+	for _, key := range evictedKeys {
+		encodedKey, err := key.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal evicted key: %w", err)
+		}
+		encoded.EvictedKeys = append(encoded.EvictedKeys, encodedKey)
+	}
+	return encoded, nil
+}
+
+// EncodeLedgerTransaction converts a LedgerTransaction, its operations and the
+// changes it caused into their exported form.
+func EncodeLedgerTransaction(tx ingest.LedgerTransaction) (*LedgerTransaction, error) {
+	encoded, err := encodeLedgerTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+	encoded.SchemaVersion = SchemaVersion
+	return encoded, nil
+}
+
+// EncodeChange converts a Change into its exported form.
+func EncodeChange(change ingest.Change) (*Change, error) {
+	encoded, err := encodeChange(change)
+	if err != nil {
+		return nil, err
+	}
+	encoded.SchemaVersion = SchemaVersion
+	return encoded, nil
+}
+
+// Marshal returns the Protobuf encoding of an exported message. Map entries
+// are encoded in a deterministic order, so equal messages have equal encodings.
+func Marshal(message proto.Message) ([]byte, error) {
+	return proto.MarshalOptions{Deterministic: true}.Marshal(message)
+}
+
+// MarshalJSON returns the canonical JSON form of an exported message: the
+// proto3 JSON mapping using the field names of the schema, without
+// insignificant whitespace. Fields set to their default value are omitted and
+// bytes fields, including the opaque XDR fields, are base64 encoded.
+func MarshalJSON(message proto.Message) ([]byte, error) {
+	encoded, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+	// protojson doesn't guarantee a stable output, compacting it removes the
+	// whitespace it varies
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, encoded); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func encodeLedgerTransaction(tx ingest.LedgerTransaction) (*LedgerTransaction, error) {
+	sourceAccount := tx.Envelope.SourceAccount()
+	feeAccount := tx.FeeAccount()
+	feeCharged, _ := tx.FeeCharged()
+	encoded := &LedgerTransaction{
+		LedgerSequence:  tx.Ledger.LedgerSequence(),
+		Index:           tx.Index,
+		Hash:            tx.Hash.HexString(),
+		Successful:      tx.Successful(),
+		ResultCode:      tx.ResultCode(),
+		SourceAccount:   sourceAccount.Address(),
+		FeeAccount:      feeAccount.Address(),
+		AccountSequence: tx.AccountSequence(),
+		MaxFee:          int64(tx.MaxFee()),
+		FeeCharged:      feeCharged,
+		Memo:            encodeMemo(tx.Envelope.Memo()),
+	}
+	if tx.Envelope.IsFeeBump() {
+		encoded.MaxFee = tx.Envelope.FeeBumpFee()
+	}
+
+	var err error
+	if encoded.Envelope, err = tx.Envelope.MarshalBinary(); err != nil {
+		return nil, fmt.Errorf("failed to marshal envelope of transaction %s: %w", encoded.Hash, err)
+	}
+	if encoded.Result, err = tx.Result.Result.MarshalBinary(); err != nil {
+		return nil, fmt.Errorf("failed to marshal result of transaction %s: %w", encoded.Hash, err)
+	}
+	if encoded.Meta, err = tx.UnsafeMeta.MarshalBinary(); err != nil {
+		return nil, fmt.Errorf("failed to marshal meta of transaction %s: %w", encoded.Hash, err)
+	}
+
+	results, _ := tx.Result.OperationResults()
+	for i, op := range tx.Envelope.Operations() {
+		var result *xdr.OperationResult
+		if i < len(results) {
+			result = &results[i]
+		}
+		encodedOp, err := encodeOperation(uint32(i), op, sourceAccount, result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode operation %d of transaction %s: %w", i, encoded.Hash, err)
+		}
+		encoded.Operations = append(encoded.Operations, encodedOp)
+	}
+
+	feeChanges := append(tx.GetFeeChanges(), tx.GetPostApplyFeeChanges()...)
+	if encoded.FeeChanges, err = encodeChanges(feeChanges); err != nil {
+		return nil, err
+	}
+	changes, err := tx.GetChanges()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read changes of transaction %s: %w", encoded.Hash, err)
+	}
+	if encoded.Changes, err = encodeChanges(changes); err != nil {
+		return nil, err
+	}
+	return encoded, nil
+}
+
+func encodeMemo(memo xdr.Memo) *Memo {
+	switch memo.Type {
+	case xdr.MemoTypeMemoText:
+		return &Memo{Value: &Memo_Text{Text: memo.MustText()}}
+	case xdr.MemoTypeMemoId:
+		return &Memo{Value: &Memo_Id{Id: uint64(memo.MustId())}}
+	case xdr.MemoTypeMemoHash:
+		hash := memo.MustHash()
+		return &Memo{Value: &Memo_Hash{Hash: hash[:]}}
+	case xdr.MemoTypeMemoReturn:
+		hash := memo.MustRetHash()
+		return &Memo{Value: &Memo_ReturnHash{ReturnHash: hash[:]}}
+	default:
+		return nil
+	}
+}
+
+func encodeChanges(changes []ingest.Change) ([]*Change, error) {
+	var encoded []*Change
+	for _, change := range changes {
+		encodedChange, err := encodeChange(change)
+		if err != nil {
+			return nil, err
+		}
+		encoded = append(encoded, encodedChange)
+	}
+	return encoded, nil
+}
+
+var changeTypes = map[xdr.LedgerEntryChangeType]ChangeType{
+	xdr.LedgerEntryChangeTypeLedgerEntryCreated:  ChangeType_CHANGE_TYPE_CREATED,
+	xdr.LedgerEntryChangeTypeLedgerEntryUpdated:  ChangeType_CHANGE_TYPE_UPDATED,
+	xdr.LedgerEntryChangeTypeLedgerEntryRemoved:  ChangeType_CHANGE_TYPE_REMOVED,
+	xdr.LedgerEntryChangeTypeLedgerEntryRestored: ChangeType_CHANGE_TYPE_RESTORED,
+}
+
+func encodeChange(change ingest.Change) (*Change, error) {
+	encoded := &Change{
+		ChangeType: changeTypes[change.ChangeType],
+		Reason:     ChangeReason(change.Reason),
+		// the values of LedgerEntryType are those of xdr.LedgerEntryType shifted
+		// by one, to leave zero for the unspecified type
+		EntryType: LedgerEntryType(change.Type + 1),
+	}
+	if change.Ledger != nil {
+		encoded.LedgerSequence = change.Ledger.LedgerSequence()
+	}
+	if change.Transaction != nil {
+		encoded.TransactionHash = change.Transaction.Hash.HexString()
+	}
+	if change.Reason == ingest.LedgerEntryChangeReasonOperation {
+		encoded.OperationIndex = proto.Uint32(change.OperationIndex)
+	}
+
+	key, err := change.LedgerKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ledger key of change: %w", err)
+	}
+	if encoded.Key, err = key.MarshalBinary(); err != nil {
+		return nil, fmt.Errorf("failed to marshal ledger key of change: %w", err)
+	}
+	if change.Pre != nil {
+		if encoded.Pre, err = change.Pre.MarshalBinary(); err != nil {
+			return nil, fmt.Errorf("failed to marshal ledger entry of change: %w", err)
+		}
+	}
+	if change.Post != nil {
+		if encoded.Post, err = change.Post.MarshalBinary(); err != nil {
+			return nil, fmt.Errorf("failed to marshal ledger entry of change: %w", err)
+		}
+	}
+	return encoded, nil
+}
@@ -0,0 +1,4017 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.3
+// 	protoc        v5.29.3
+// source: processors/ledger_export/ledger_export.proto
+
+package ledger_export
+
+import (
+	asset "github.com/stellar/go/asset"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ChangeType int32
+
+const (
+	ChangeType_CHANGE_TYPE_UNSPECIFIED ChangeType = 0
+	ChangeType_CHANGE_TYPE_CREATED     ChangeType = 1
+	ChangeType_CHANGE_TYPE_UPDATED     ChangeType = 2
+	ChangeType_CHANGE_TYPE_REMOVED     ChangeType = 3
+	ChangeType_CHANGE_TYPE_RESTORED    ChangeType = 4
+)
+
+// Enum value maps for ChangeType.
+var (
+	ChangeType_name = map[int32]string{
+		0: "CHANGE_TYPE_UNSPECIFIED",
+		1: "CHANGE_TYPE_CREATED",
+		2: "CHANGE_TYPE_UPDATED",
+		3: "CHANGE_TYPE_REMOVED",
+		4: "CHANGE_TYPE_RESTORED",
+	}
+	ChangeType_value = map[string]int32{
+		"CHANGE_TYPE_UNSPECIFIED": 0,
+		"CHANGE_TYPE_CREATED":     1,
+		"CHANGE_TYPE_UPDATED":     2,
+		"CHANGE_TYPE_REMOVED":     3,
+		"CHANGE_TYPE_RESTORED":    4,
+	}
+)
+
+func (x ChangeType) Enum() *ChangeType {
+	p := new(ChangeType)
+	*p = x
+	return p
+}
+
+func (x ChangeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChangeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_processors_ledger_export_ledger_export_proto_enumTypes[0].Descriptor()
+}
+
+func (ChangeType) Type() protoreflect.EnumType {
+	return &file_processors_ledger_export_ledger_export_proto_enumTypes[0]
+}
+
+func (x ChangeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChangeType.Descriptor instead.
+func (ChangeType) EnumDescriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{0}
+}
+
+// Mirrors ingest.LedgerEntryChangeReason
+type ChangeReason int32
+
+const (
+	ChangeReason_CHANGE_REASON_UNKNOWN     ChangeReason = 0
+	ChangeReason_CHANGE_REASON_OPERATION   ChangeReason = 1
+	ChangeReason_CHANGE_REASON_TRANSACTION ChangeReason = 2
+	ChangeReason_CHANGE_REASON_FEE         ChangeReason = 3
+	ChangeReason_CHANGE_REASON_FEE_REFUND  ChangeReason = 4
+	ChangeReason_CHANGE_REASON_UPGRADE     ChangeReason = 5
+)
+
+// Enum value maps for ChangeReason.
+var (
+	ChangeReason_name = map[int32]string{
+		0: "CHANGE_REASON_UNKNOWN",
+		1: "CHANGE_REASON_OPERATION",
+		2: "CHANGE_REASON_TRANSACTION",
+		3: "CHANGE_REASON_FEE",
+		4: "CHANGE_REASON_FEE_REFUND",
+		5: "CHANGE_REASON_UPGRADE",
+	}
+	ChangeReason_value = map[string]int32{
+		"CHANGE_REASON_UNKNOWN":     0,
+		"CHANGE_REASON_OPERATION":   1,
+		"CHANGE_REASON_TRANSACTION": 2,
+		"CHANGE_REASON_FEE":         3,
+		"CHANGE_REASON_FEE_REFUND":  4,
+		"CHANGE_REASON_UPGRADE":     5,
+	}
+)
+
+func (x ChangeReason) Enum() *ChangeReason {
+	p := new(ChangeReason)
+	*p = x
+	return p
+}
+
+func (x ChangeReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChangeReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_processors_ledger_export_ledger_export_proto_enumTypes[1].Descriptor()
+}
+
+func (ChangeReason) Type() protoreflect.EnumType {
+	return &file_processors_ledger_export_ledger_export_proto_enumTypes[1]
+}
+
+func (x ChangeReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChangeReason.Descriptor instead.
+func (ChangeReason) EnumDescriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{1}
+}
+
+type LedgerEntryType int32
+
+const (
+	LedgerEntryType_LEDGER_ENTRY_TYPE_UNSPECIFIED       LedgerEntryType = 0
+	LedgerEntryType_LEDGER_ENTRY_TYPE_ACCOUNT           LedgerEntryType = 1
+	LedgerEntryType_LEDGER_ENTRY_TYPE_TRUSTLINE         LedgerEntryType = 2
+	LedgerEntryType_LEDGER_ENTRY_TYPE_OFFER             LedgerEntryType = 3
+	LedgerEntryType_LEDGER_ENTRY_TYPE_DATA              LedgerEntryType = 4
+	LedgerEntryType_LEDGER_ENTRY_TYPE_CLAIMABLE_BALANCE LedgerEntryType = 5
+	LedgerEntryType_LEDGER_ENTRY_TYPE_LIQUIDITY_POOL    LedgerEntryType = 6
+	LedgerEntryType_LEDGER_ENTRY_TYPE_CONTRACT_DATA     LedgerEntryType = 7
+	LedgerEntryType_LEDGER_ENTRY_TYPE_CONTRACT_CODE     LedgerEntryType = 8
+	LedgerEntryType_LEDGER_ENTRY_TYPE_CONFIG_SETTING    LedgerEntryType = 9
+	LedgerEntryType_LEDGER_ENTRY_TYPE_TTL               LedgerEntryType = 10
+)
+
+// Enum value maps for LedgerEntryType.
+var (
+	LedgerEntryType_name = map[int32]string{
+		0:  "LEDGER_ENTRY_TYPE_UNSPECIFIED",
+		1:  "LEDGER_ENTRY_TYPE_ACCOUNT",
+		2:  "LEDGER_ENTRY_TYPE_TRUSTLINE",
+		3:  "LEDGER_ENTRY_TYPE_OFFER",
+		4:  "LEDGER_ENTRY_TYPE_DATA",
+		5:  "LEDGER_ENTRY_TYPE_CLAIMABLE_BALANCE",
+		6:  "LEDGER_ENTRY_TYPE_LIQUIDITY_POOL",
+		7:  "LEDGER_ENTRY_TYPE_CONTRACT_DATA",
+		8:  "LEDGER_ENTRY_TYPE_CONTRACT_CODE",
+		9:  "LEDGER_ENTRY_TYPE_CONFIG_SETTING",
+		10: "LEDGER_ENTRY_TYPE_TTL",
+	}
+	LedgerEntryType_value = map[string]int32{
+		"LEDGER_ENTRY_TYPE_UNSPECIFIED":       0,
+		"LEDGER_ENTRY_TYPE_ACCOUNT":           1,
+		"LEDGER_ENTRY_TYPE_TRUSTLINE":         2,
+		"LEDGER_ENTRY_TYPE_OFFER":             3,
+		"LEDGER_ENTRY_TYPE_DATA":              4,
+		"LEDGER_ENTRY_TYPE_CLAIMABLE_BALANCE": 5,
+		"LEDGER_ENTRY_TYPE_LIQUIDITY_POOL":    6,
+		"LEDGER_ENTRY_TYPE_CONTRACT_DATA":     7,
+		"LEDGER_ENTRY_TYPE_CONTRACT_CODE":     8,
+		"LEDGER_ENTRY_TYPE_CONFIG_SETTING":    9,
+		"LEDGER_ENTRY_TYPE_TTL":               10,
+	}
+)
+
+func (x LedgerEntryType) Enum() *LedgerEntryType {
+	p := new(LedgerEntryType)
+	*p = x
+	return p
+}
+
+func (x LedgerEntryType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LedgerEntryType) Descriptor() protoreflect.EnumDescriptor {
+	return file_processors_ledger_export_ledger_export_proto_enumTypes[2].Descriptor()
+}
+
+func (LedgerEntryType) Type() protoreflect.EnumType {
+	return &file_processors_ledger_export_ledger_export_proto_enumTypes[2]
+}
+
+func (x LedgerEntryType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LedgerEntryType.Descriptor instead.
+func (LedgerEntryType) EnumDescriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{2}
+}
+
+// LedgerCloseMeta is the exported form of a closed ledger and of the transactions applied in it.
+type LedgerCloseMeta struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Version of the ledger_export schema the message was encoded with.
+	SchemaVersion uint32 `protobuf:"varint,1,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	// Version of the XDR LedgerCloseMeta the message was encoded from.
+	MetaVersion uint32 `protobuf:"varint,2,opt,name=meta_version,json=metaVersion,proto3" json:"meta_version,omitempty"`
+	Sequence    uint32 `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// Hex encoded hash of the ledger header
+	Hash string `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash,omitempty"`
+	// Hex encoded hash of the previous ledger header
+	PreviousHash    string                 `protobuf:"bytes,5,opt,name=previous_hash,json=previousHash,proto3" json:"previous_hash,omitempty"`
+	ProtocolVersion uint32                 `protobuf:"varint,6,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	ClosedAt        *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=closed_at,json=closedAt,proto3" json:"closed_at,omitempty"`
+	BaseFee         uint32                 `protobuf:"varint,8,opt,name=base_fee,json=baseFee,proto3" json:"base_fee,omitempty"`
+	BaseReserve     uint32                 `protobuf:"varint,9,opt,name=base_reserve,json=baseReserve,proto3" json:"base_reserve,omitempty"`
+	MaxTxSetSize    uint32                 `protobuf:"varint,10,opt,name=max_tx_set_size,json=maxTxSetSize,proto3" json:"max_tx_set_size,omitempty"`
+	TotalCoins      int64                  `protobuf:"varint,11,opt,name=total_coins,json=totalCoins,proto3" json:"total_coins,omitempty"`
+	FeePool         int64                  `protobuf:"varint,12,opt,name=fee_pool,json=feePool,proto3" json:"fee_pool,omitempty"`
+	// Hex encoded hash of the bucket list
+	BucketListHash string               `protobuf:"bytes,13,opt,name=bucket_list_hash,json=bucketListHash,proto3" json:"bucket_list_hash,omitempty"`
+	Transactions   []*LedgerTransaction `protobuf:"bytes,14,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	// Changes caused by the protocol upgrades applied in the ledger
+	UpgradeChanges []*Change `protobuf:"bytes,15,rep,name=upgrade_changes,json=upgradeChanges,proto3" json:"upgrade_changes,omitempty"`
+	// Opaque XDR encoded keys of the ledger entries evicted in the ledger
+	EvictedKeys   [][]byte `protobuf:"bytes,16,rep,name=evicted_keys,json=evictedKeys,proto3" json:"evicted_keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LedgerCloseMeta) Reset() {
+	*x = LedgerCloseMeta{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LedgerCloseMeta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LedgerCloseMeta) ProtoMessage() {}
+
+func (x *LedgerCloseMeta) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LedgerCloseMeta.ProtoReflect.Descriptor instead.
+func (*LedgerCloseMeta) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LedgerCloseMeta) GetSchemaVersion() uint32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *LedgerCloseMeta) GetMetaVersion() uint32 {
+	if x != nil {
+		return x.MetaVersion
+	}
+	return 0
+}
+
+func (x *LedgerCloseMeta) GetSequence() uint32 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *LedgerCloseMeta) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *LedgerCloseMeta) GetPreviousHash() string {
+	if x != nil {
+		return x.PreviousHash
+	}
+	return ""
+}
+
+func (x *LedgerCloseMeta) GetProtocolVersion() uint32 {
+	if x != nil {
+		return x.ProtocolVersion
+	}
+	return 0
+}
+
+func (x *LedgerCloseMeta) GetClosedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClosedAt
+	}
+	return nil
+}
+
+func (x *LedgerCloseMeta) GetBaseFee() uint32 {
+	if x != nil {
+		return x.BaseFee
+	}
+	return 0
+}
+
+func (x *LedgerCloseMeta) GetBaseReserve() uint32 {
+	if x != nil {
+		return x.BaseReserve
+	}
+	return 0
+}
+
+func (x *LedgerCloseMeta) GetMaxTxSetSize() uint32 {
+	if x != nil {
+		return x.MaxTxSetSize
+	}
+	return 0
+}
+
+func (x *LedgerCloseMeta) GetTotalCoins() int64 {
+	if x != nil {
+		return x.TotalCoins
+	}
+	return 0
+}
+
+func (x *LedgerCloseMeta) GetFeePool() int64 {
+	if x != nil {
+		return x.FeePool
+	}
+	return 0
+}
+
+func (x *LedgerCloseMeta) GetBucketListHash() string {
+	if x != nil {
+		return x.BucketListHash
+	}
+	return ""
+}
+
+func (x *LedgerCloseMeta) GetTransactions() []*LedgerTransaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *LedgerCloseMeta) GetUpgradeChanges() []*Change {
+	if x != nil {
+		return x.UpgradeChanges
+	}
+	return nil
+}
+
+func (x *LedgerCloseMeta) GetEvictedKeys() [][]byte {
+	if x != nil {
+		return x.EvictedKeys
+	}
+	return nil
+}
+
+// LedgerTransaction is the exported form of a transaction applied in a ledger.
+type LedgerTransaction struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Version of the ledger_export schema the message was encoded with. Only set when
+	// the transaction is encoded on its own.
+	SchemaVersion  uint32 `protobuf:"varint,1,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	LedgerSequence uint32 `protobuf:"varint,2,opt,name=ledger_sequence,json=ledgerSequence,proto3" json:"ledger_sequence,omitempty"`
+	// Index of transaction within its ledger. This is 1-indexed as per SEP-35
+	Index uint32 `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	// Hex encoded hash of the transaction
+	Hash       string `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash,omitempty"`
+	Successful bool   `protobuf:"varint,5,opt,name=successful,proto3" json:"successful,omitempty"`
+	// Name of the XDR TransactionResultCode
+	ResultCode    string `protobuf:"bytes,6,opt,name=result_code,json=resultCode,proto3" json:"result_code,omitempty"`
+	SourceAccount string `protobuf:"bytes,7,opt,name=source_account,json=sourceAccount,proto3" json:"source_account,omitempty"`
+	// Account paying the fee, which differs from the source account for fee bump transactions
+	FeeAccount      string       `protobuf:"bytes,8,opt,name=fee_account,json=feeAccount,proto3" json:"fee_account,omitempty"`
+	AccountSequence int64        `protobuf:"varint,9,opt,name=account_sequence,json=accountSequence,proto3" json:"account_sequence,omitempty"`
+	MaxFee          int64        `protobuf:"varint,10,opt,name=max_fee,json=maxFee,proto3" json:"max_fee,omitempty"`
+	FeeCharged      int64        `protobuf:"varint,11,opt,name=fee_charged,json=feeCharged,proto3" json:"fee_charged,omitempty"`
+	Memo            *Memo        `protobuf:"bytes,12,opt,name=memo,proto3" json:"memo,omitempty"`
+	Operations      []*Operation `protobuf:"bytes,13,rep,name=operations,proto3" json:"operations,omitempty"`
+	// Fee and fee refund changes
+	FeeChanges []*Change `protobuf:"bytes,14,rep,name=fee_changes,json=feeChanges,proto3" json:"fee_changes,omitempty"`
+	// Changes caused by the transaction and its operations
+	Changes []*Change `protobuf:"bytes,15,rep,name=changes,proto3" json:"changes,omitempty"`
+	// Opaque XDR encoded TransactionEnvelope
+	Envelope []byte `protobuf:"bytes,16,opt,name=envelope,proto3" json:"envelope,omitempty"`
+	// Opaque XDR encoded TransactionResult
+	Result []byte `protobuf:"bytes,17,opt,name=result,proto3" json:"result,omitempty"`
+	// Opaque XDR encoded TransactionMeta
+	Meta          []byte `protobuf:"bytes,18,opt,name=meta,proto3" json:"meta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LedgerTransaction) Reset() {
+	*x = LedgerTransaction{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LedgerTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LedgerTransaction) ProtoMessage() {}
+
+func (x *LedgerTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LedgerTransaction.ProtoReflect.Descriptor instead.
+func (*LedgerTransaction) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LedgerTransaction) GetSchemaVersion() uint32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *LedgerTransaction) GetLedgerSequence() uint32 {
+	if x != nil {
+		return x.LedgerSequence
+	}
+	return 0
+}
+
+func (x *LedgerTransaction) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *LedgerTransaction) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *LedgerTransaction) GetSuccessful() bool {
+	if x != nil {
+		return x.Successful
+	}
+	return false
+}
+
+func (x *LedgerTransaction) GetResultCode() string {
+	if x != nil {
+		return x.ResultCode
+	}
+	return ""
+}
+
+func (x *LedgerTransaction) GetSourceAccount() string {
+	if x != nil {
+		return x.SourceAccount
+	}
+	return ""
+}
+
+func (x *LedgerTransaction) GetFeeAccount() string {
+	if x != nil {
+		return x.FeeAccount
+	}
+	return ""
+}
+
+func (x *LedgerTransaction) GetAccountSequence() int64 {
+	if x != nil {
+		return x.AccountSequence
+	}
+	return 0
+}
+
+func (x *LedgerTransaction) GetMaxFee() int64 {
+	if x != nil {
+		return x.MaxFee
+	}
+	return 0
+}
+
+func (x *LedgerTransaction) GetFeeCharged() int64 {
+	if x != nil {
+		return x.FeeCharged
+	}
+	return 0
+}
+
+func (x *LedgerTransaction) GetMemo() *Memo {
+	if x != nil {
+		return x.Memo
+	}
+	return nil
+}
+
+func (x *LedgerTransaction) GetOperations() []*Operation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+func (x *LedgerTransaction) GetFeeChanges() []*Change {
+	if x != nil {
+		return x.FeeChanges
+	}
+	return nil
+}
+
+func (x *LedgerTransaction) GetChanges() []*Change {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *LedgerTransaction) GetEnvelope() []byte {
+	if x != nil {
+		return x.Envelope
+	}
+	return nil
+}
+
+func (x *LedgerTransaction) GetResult() []byte {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *LedgerTransaction) GetMeta() []byte {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+type Memo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Value:
+	//
+	//	*Memo_Text
+	//	*Memo_Id
+	//	*Memo_Hash
+	//	*Memo_ReturnHash
+	Value         isMemo_Value `protobuf_oneof:"value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Memo) Reset() {
+	*x = Memo{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Memo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Memo) ProtoMessage() {}
+
+func (x *Memo) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Memo.ProtoReflect.Descriptor instead.
+func (*Memo) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Memo) GetValue() isMemo_Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *Memo) GetText() string {
+	if x != nil {
+		if x, ok := x.Value.(*Memo_Text); ok {
+			return x.Text
+		}
+	}
+	return ""
+}
+
+func (x *Memo) GetId() uint64 {
+	if x != nil {
+		if x, ok := x.Value.(*Memo_Id); ok {
+			return x.Id
+		}
+	}
+	return 0
+}
+
+func (x *Memo) GetHash() []byte {
+	if x != nil {
+		if x, ok := x.Value.(*Memo_Hash); ok {
+			return x.Hash
+		}
+	}
+	return nil
+}
+
+func (x *Memo) GetReturnHash() []byte {
+	if x != nil {
+		if x, ok := x.Value.(*Memo_ReturnHash); ok {
+			return x.ReturnHash
+		}
+	}
+	return nil
+}
+
+type isMemo_Value interface {
+	isMemo_Value()
+}
+
+type Memo_Text struct {
+	Text string `protobuf:"bytes,1,opt,name=text,proto3,oneof"`
+}
+
+type Memo_Id struct {
+	Id uint64 `protobuf:"varint,2,opt,name=id,proto3,oneof"`
+}
+
+type Memo_Hash struct {
+	Hash []byte `protobuf:"bytes,3,opt,name=hash,proto3,oneof"`
+}
+
+type Memo_ReturnHash struct {
+	ReturnHash []byte `protobuf:"bytes,4,opt,name=return_hash,json=returnHash,proto3,oneof"`
+}
+
+func (*Memo_Text) isMemo_Value() {}
+
+func (*Memo_Id) isMemo_Value() {}
+
+func (*Memo_Hash) isMemo_Value() {}
+
+func (*Memo_ReturnHash) isMemo_Value() {}
+
+// Change is the exported form of a change to a ledger entry.
+type Change struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Version of the ledger_export schema the message was encoded with. Only set when
+	// the change is encoded on its own.
+	SchemaVersion uint32          `protobuf:"varint,1,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	ChangeType    ChangeType      `protobuf:"varint,2,opt,name=change_type,json=changeType,proto3,enum=ledger_export.ChangeType" json:"change_type,omitempty"`
+	Reason        ChangeReason    `protobuf:"varint,3,opt,name=reason,proto3,enum=ledger_export.ChangeReason" json:"reason,omitempty"`
+	EntryType     LedgerEntryType `protobuf:"varint,4,opt,name=entry_type,json=entryType,proto3,enum=ledger_export.LedgerEntryType" json:"entry_type,omitempty"`
+	// Sequence of the ledger the change was applied in, if known
+	LedgerSequence uint32 `protobuf:"varint,5,opt,name=ledger_sequence,json=ledgerSequence,proto3" json:"ledger_sequence,omitempty"`
+	// Hex encoded hash of the transaction which caused the change, if any
+	TransactionHash string `protobuf:"bytes,6,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	// Index of the operation which caused the change, within its transaction. This is 0-indexed
+	OperationIndex *uint32 `protobuf:"varint,7,opt,name=operation_index,json=operationIndex,proto3,oneof" json:"operation_index,omitempty"`
+	// Opaque XDR encoded LedgerKey of the entry
+	Key []byte `protobuf:"bytes,8,opt,name=key,proto3" json:"key,omitempty"`
+	// Opaque XDR encoded LedgerEntry before the change, unset for created entries
+	Pre []byte `protobuf:"bytes,9,opt,name=pre,proto3" json:"pre,omitempty"`
+	// Opaque XDR encoded LedgerEntry after the change, unset for removed entries
+	Post          []byte `protobuf:"bytes,10,opt,name=post,proto3" json:"post,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Change) Reset() {
+	*x = Change{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Change) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Change) ProtoMessage() {}
+
+func (x *Change) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Change.ProtoReflect.Descriptor instead.
+func (*Change) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Change) GetSchemaVersion() uint32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *Change) GetChangeType() ChangeType {
+	if x != nil {
+		return x.ChangeType
+	}
+	return ChangeType_CHANGE_TYPE_UNSPECIFIED
+}
+
+func (x *Change) GetReason() ChangeReason {
+	if x != nil {
+		return x.Reason
+	}
+	return ChangeReason_CHANGE_REASON_UNKNOWN
+}
+
+func (x *Change) GetEntryType() LedgerEntryType {
+	if x != nil {
+		return x.EntryType
+	}
+	return LedgerEntryType_LEDGER_ENTRY_TYPE_UNSPECIFIED
+}
+
+func (x *Change) GetLedgerSequence() uint32 {
+	if x != nil {
+		return x.LedgerSequence
+	}
+	return 0
+}
+
+func (x *Change) GetTransactionHash() string {
+	if x != nil {
+		return x.TransactionHash
+	}
+	return ""
+}
+
+func (x *Change) GetOperationIndex() uint32 {
+	if x != nil && x.OperationIndex != nil {
+		return *x.OperationIndex
+	}
+	return 0
+}
+
+func (x *Change) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *Change) GetPre() []byte {
+	if x != nil {
+		return x.Pre
+	}
+	return nil
+}
+
+func (x *Change) GetPost() []byte {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+type Operation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Index of the operation within its transaction. This is 0-indexed
+	Index uint32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// Source account of the operation, which defaults to the source account of its transaction
+	SourceAccount string `protobuf:"bytes,2,opt,name=source_account,json=sourceAccount,proto3" json:"source_account,omitempty"`
+	// Opaque XDR encoded OperationResult, unset if the transaction failed before applying its operations
+	Result []byte `protobuf:"bytes,3,opt,name=result,proto3" json:"result,omitempty"`
+	// The field number of each body is 10 more than the XDR OperationType
+	//
+	// Types that are valid to be assigned to Body:
+	//
+	//	*Operation_CreateAccount
+	//	*Operation_Payment
+	//	*Operation_PathPaymentStrictReceive
+	//	*Operation_ManageSellOffer
+	//	*Operation_CreatePassiveSellOffer
+	//	*Operation_SetOptions
+	//	*Operation_ChangeTrust
+	//	*Operation_AllowTrust
+	//	*Operation_AccountMerge
+	//	*Operation_Inflation
+	//	*Operation_ManageData
+	//	*Operation_BumpSequence
+	//	*Operation_ManageBuyOffer
+	//	*Operation_PathPaymentStrictSend
+	//	*Operation_CreateClaimableBalance
+	//	*Operation_ClaimClaimableBalance
+	//	*Operation_BeginSponsoringFutureReserves
+	//	*Operation_EndSponsoringFutureReserves
+	//	*Operation_RevokeSponsorship
+	//	*Operation_Clawback
+	//	*Operation_ClawbackClaimableBalance
+	//	*Operation_SetTrustLineFlags
+	//	*Operation_LiquidityPoolDeposit
+	//	*Operation_LiquidityPoolWithdraw
+	//	*Operation_InvokeHostFunction
+	//	*Operation_ExtendFootprintTtl
+	//	*Operation_RestoreFootprint
+	Body          isOperation_Body `protobuf_oneof:"body"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Operation) Reset() {
+	*x = Operation{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Operation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Operation) ProtoMessage() {}
+
+func (x *Operation) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Operation.ProtoReflect.Descriptor instead.
+func (*Operation) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Operation) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *Operation) GetSourceAccount() string {
+	if x != nil {
+		return x.SourceAccount
+	}
+	return ""
+}
+
+func (x *Operation) GetResult() []byte {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *Operation) GetBody() isOperation_Body {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+func (x *Operation) GetCreateAccount() *CreateAccount {
+	if x != nil {
+		if x, ok := x.Body.(*Operation_CreateAccount); ok {
+			return x.CreateAccount
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetPayment() *Payment {
+	if x != nil {
+		if x, ok := x.Body.(*Operation_Payment); ok {
+			return x.Payment
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetPathPaymentStrictReceive() *PathPaymentStrictReceive {
+	if x != nil {
+		if x, ok := x.Body.(*Operation_PathPaymentStrictReceive); ok {
+			return x.PathPaymentStrictReceive
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetManageSellOffer() *ManageSellOffer {
+	if x != nil {
+		if x, ok := x.Body.(*Operation_ManageSellOffer); ok {
+			return x.ManageSellOffer
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetCreatePassiveSellOffer() *CreatePassiveSellOffer {
+	if x != nil {
+		if x, ok := x.Body.(*Operation_CreatePassiveSellOffer); ok {
+			return x.CreatePassiveSellOffer
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetSetOptions() *SetOptions {
+	if x != nil {
+		if x, ok := x.Body.(*Operation_SetOptions); ok {
+			return x.SetOptions
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetChangeTrust() *ChangeTrust {
+	if x != nil {
+		if x, ok := x.Body.(*Operation_ChangeTrust); ok {
+			return x.ChangeTrust
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetAllowTrust() *AllowTrust {
+	if x != nil {
+		if x, ok := x.Body.(*Operation_AllowTrust); ok {
+			return x.AllowTrust
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetAccountMerge() *AccountMerge {
+	if x != nil {
+		if x, ok := x.Body.(*Operation_AccountMerge); ok {
+			return x.AccountMerge
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetInflation() *Inflation {
+	if x != nil {
+		if x, ok := x.Body.(*Operation_Inflation); ok {
+			return x.Inflation
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetManageData() *ManageData {
+	if x != nil {
+		if x, ok := x.Body.(*Operation_ManageData); ok {
+			return x.ManageData
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetBumpSequence() *BumpSequence {
+	if x != nil {
+		if x, ok := x.Body.(*Operation_BumpSequence); ok {
+			return x.BumpSequence
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetManageBuyOffer() *ManageBuyOffer {
+	if x != nil {
+		if x, ok := x.Body.(*Operation_ManageBuyOffer); ok {
+			return x.ManageBuyOffer
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetPathPaymentStrictSend() *PathPaymentStrictSend {
+	if x != nil {
+		if x, ok := x.Body.(*Operation_PathPaymentStrictSend); ok {
+			return x.PathPaymentStrictSend
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetCreateClaimableBalance() *CreateClaimableBalance {
+	if x != nil {
+		if x, ok := x.Body.(*Operation_CreateClaimableBalance); ok {
+			return x.CreateClaimableBalance
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetClaimClaimableBalance() *ClaimClaimableBalance {
+	if x != nil {
+		if x, ok := x.Body.(*Operation_ClaimClaimableBalance); ok {
+			return x.ClaimClaimableBalance
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetBeginSponsoringFutureReserves() *BeginSponsoringFutureReserves {
+	if x != nil {
+		if x, ok := x.Body.(*Operation_BeginSponsoringFutureReserves); ok {
+			return x.BeginSponsoringFutureReserves
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetEndSponsoringFutureReserves() *EndSponsoringFutureReserves {
+	if x != nil {
+		if x, ok := x.Body.(*Operation_EndSponsoringFutureReserves); ok {
+			return x.EndSponsoringFutureReserves
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetRevokeSponsorship() *RevokeSponsorship {
+	if x != nil {
+		if x, ok := x.Body.(*Operation_RevokeSponsorship); ok {
+			return x.RevokeSponsorship
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetClawback() *Clawback {
+	if x != nil {
+		if x, ok := x.Body.(*Operation_Clawback); ok {
+			return x.Clawback
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetClawbackClaimableBalance() *ClawbackClaimableBalance {
+	if x != nil {
+		if x, ok := x.Body.(*Operation_ClawbackClaimableBalance); ok {
+			return x.ClawbackClaimableBalance
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetSetTrustLineFlags() *SetTrustLineFlags {
+	if x != nil {
+		if x, ok := x.Body.(*Operation_SetTrustLineFlags); ok {
+			return x.SetTrustLineFlags
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetLiquidityPoolDeposit() *LiquidityPoolDeposit {
+	if x != nil {
+		if x, ok := x.Body.(*Operation_LiquidityPoolDeposit); ok {
+			return x.LiquidityPoolDeposit
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetLiquidityPoolWithdraw() *LiquidityPoolWithdraw {
+	if x != nil {
+		if x, ok := x.Body.(*Operation_LiquidityPoolWithdraw); ok {
+			return x.LiquidityPoolWithdraw
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetInvokeHostFunction() *InvokeHostFunction {
+	if x != nil {
+		if x, ok := x.Body.(*Operation_InvokeHostFunction); ok {
+			return x.InvokeHostFunction
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetExtendFootprintTtl() *ExtendFootprintTtl {
+	if x != nil {
+		if x, ok := x.Body.(*Operation_ExtendFootprintTtl); ok {
+			return x.ExtendFootprintTtl
+		}
+	}
+	return nil
+}
+
+func (x *Operation) GetRestoreFootprint() *RestoreFootprint {
+	if x != nil {
+		if x, ok := x.Body.(*Operation_RestoreFootprint); ok {
+			return x.RestoreFootprint
+		}
+	}
+	return nil
+}
+
+type isOperation_Body interface {
+	isOperation_Body()
+}
+
+type Operation_CreateAccount struct {
+	CreateAccount *CreateAccount `protobuf:"bytes,10,opt,name=create_account,json=createAccount,proto3,oneof"`
+}
+
+type Operation_Payment struct {
+	Payment *Payment `protobuf:"bytes,11,opt,name=payment,proto3,oneof"`
+}
+
+type Operation_PathPaymentStrictReceive struct {
+	PathPaymentStrictReceive *PathPaymentStrictReceive `protobuf:"bytes,12,opt,name=path_payment_strict_receive,json=pathPaymentStrictReceive,proto3,oneof"`
+}
+
+type Operation_ManageSellOffer struct {
+	ManageSellOffer *ManageSellOffer `protobuf:"bytes,13,opt,name=manage_sell_offer,json=manageSellOffer,proto3,oneof"`
+}
+
+type Operation_CreatePassiveSellOffer struct {
+	CreatePassiveSellOffer *CreatePassiveSellOffer `protobuf:"bytes,14,opt,name=create_passive_sell_offer,json=createPassiveSellOffer,proto3,oneof"`
+}
+
+type Operation_SetOptions struct {
+	SetOptions *SetOptions `protobuf:"bytes,15,opt,name=set_options,json=setOptions,proto3,oneof"`
+}
+
+type Operation_ChangeTrust struct {
+	ChangeTrust *ChangeTrust `protobuf:"bytes,16,opt,name=change_trust,json=changeTrust,proto3,oneof"`
+}
+
+type Operation_AllowTrust struct {
+	AllowTrust *AllowTrust `protobuf:"bytes,17,opt,name=allow_trust,json=allowTrust,proto3,oneof"`
+}
+
+type Operation_AccountMerge struct {
+	AccountMerge *AccountMerge `protobuf:"bytes,18,opt,name=account_merge,json=accountMerge,proto3,oneof"`
+}
+
+type Operation_Inflation struct {
+	Inflation *Inflation `protobuf:"bytes,19,opt,name=inflation,proto3,oneof"`
+}
+
+type Operation_ManageData struct {
+	ManageData *ManageData `protobuf:"bytes,20,opt,name=manage_data,json=manageData,proto3,oneof"`
+}
+
+type Operation_BumpSequence struct {
+	BumpSequence *BumpSequence `protobuf:"bytes,21,opt,name=bump_sequence,json=bumpSequence,proto3,oneof"`
+}
+
+type Operation_ManageBuyOffer struct {
+	ManageBuyOffer *ManageBuyOffer `protobuf:"bytes,22,opt,name=manage_buy_offer,json=manageBuyOffer,proto3,oneof"`
+}
+
+type Operation_PathPaymentStrictSend struct {
+	PathPaymentStrictSend *PathPaymentStrictSend `protobuf:"bytes,23,opt,name=path_payment_strict_send,json=pathPaymentStrictSend,proto3,oneof"`
+}
+
+type Operation_CreateClaimableBalance struct {
+	CreateClaimableBalance *CreateClaimableBalance `protobuf:"bytes,24,opt,name=create_claimable_balance,json=createClaimableBalance,proto3,oneof"`
+}
+
+type Operation_ClaimClaimableBalance struct {
+	ClaimClaimableBalance *ClaimClaimableBalance `protobuf:"bytes,25,opt,name=claim_claimable_balance,json=claimClaimableBalance,proto3,oneof"`
+}
+
+type Operation_BeginSponsoringFutureReserves struct {
+	BeginSponsoringFutureReserves *BeginSponsoringFutureReserves `protobuf:"bytes,26,opt,name=begin_sponsoring_future_reserves,json=beginSponsoringFutureReserves,proto3,oneof"`
+}
+
+type Operation_EndSponsoringFutureReserves struct {
+	EndSponsoringFutureReserves *EndSponsoringFutureReserves `protobuf:"bytes,27,opt,name=end_sponsoring_future_reserves,json=endSponsoringFutureReserves,proto3,oneof"`
+}
+
+type Operation_RevokeSponsorship struct {
+	RevokeSponsorship *RevokeSponsorship `protobuf:"bytes,28,opt,name=revoke_sponsorship,json=revokeSponsorship,proto3,oneof"`
+}
+
+type Operation_Clawback struct {
+	Clawback *Clawback `protobuf:"bytes,29,opt,name=clawback,proto3,oneof"`
+}
+
+type Operation_ClawbackClaimableBalance struct {
+	ClawbackClaimableBalance *ClawbackClaimableBalance `protobuf:"bytes,30,opt,name=clawback_claimable_balance,json=clawbackClaimableBalance,proto3,oneof"`
+}
+
+type Operation_SetTrustLineFlags struct {
+	SetTrustLineFlags *SetTrustLineFlags `protobuf:"bytes,31,opt,name=set_trust_line_flags,json=setTrustLineFlags,proto3,oneof"`
+}
+
+type Operation_LiquidityPoolDeposit struct {
+	LiquidityPoolDeposit *LiquidityPoolDeposit `protobuf:"bytes,32,opt,name=liquidity_pool_deposit,json=liquidityPoolDeposit,proto3,oneof"`
+}
+
+type Operation_LiquidityPoolWithdraw struct {
+	LiquidityPoolWithdraw *LiquidityPoolWithdraw `protobuf:"bytes,33,opt,name=liquidity_pool_withdraw,json=liquidityPoolWithdraw,proto3,oneof"`
+}
+
+type Operation_InvokeHostFunction struct {
+	InvokeHostFunction *InvokeHostFunction `protobuf:"bytes,34,opt,name=invoke_host_function,json=invokeHostFunction,proto3,oneof"`
+}
+
+type Operation_ExtendFootprintTtl struct {
+	ExtendFootprintTtl *ExtendFootprintTtl `protobuf:"bytes,35,opt,name=extend_footprint_ttl,json=extendFootprintTtl,proto3,oneof"`
+}
+
+type Operation_RestoreFootprint struct {
+	RestoreFootprint *RestoreFootprint `protobuf:"bytes,36,opt,name=restore_footprint,json=restoreFootprint,proto3,oneof"`
+}
+
+func (*Operation_CreateAccount) isOperation_Body() {}
+
+func (*Operation_Payment) isOperation_Body() {}
+
+func (*Operation_PathPaymentStrictReceive) isOperation_Body() {}
+
+func (*Operation_ManageSellOffer) isOperation_Body() {}
+
+func (*Operation_CreatePassiveSellOffer) isOperation_Body() {}
+
+func (*Operation_SetOptions) isOperation_Body() {}
+
+func (*Operation_ChangeTrust) isOperation_Body() {}
+
+func (*Operation_AllowTrust) isOperation_Body() {}
+
+func (*Operation_AccountMerge) isOperation_Body() {}
+
+func (*Operation_Inflation) isOperation_Body() {}
+
+func (*Operation_ManageData) isOperation_Body() {}
+
+func (*Operation_BumpSequence) isOperation_Body() {}
+
+func (*Operation_ManageBuyOffer) isOperation_Body() {}
+
+func (*Operation_PathPaymentStrictSend) isOperation_Body() {}
+
+func (*Operation_CreateClaimableBalance) isOperation_Body() {}
+
+func (*Operation_ClaimClaimableBalance) isOperation_Body() {}
+
+func (*Operation_BeginSponsoringFutureReserves) isOperation_Body() {}
+
+func (*Operation_EndSponsoringFutureReserves) isOperation_Body() {}
+
+func (*Operation_RevokeSponsorship) isOperation_Body() {}
+
+func (*Operation_Clawback) isOperation_Body() {}
+
+func (*Operation_ClawbackClaimableBalance) isOperation_Body() {}
+
+func (*Operation_SetTrustLineFlags) isOperation_Body() {}
+
+func (*Operation_LiquidityPoolDeposit) isOperation_Body() {}
+
+func (*Operation_LiquidityPoolWithdraw) isOperation_Body() {}
+
+func (*Operation_InvokeHostFunction) isOperation_Body() {}
+
+func (*Operation_ExtendFootprintTtl) isOperation_Body() {}
+
+func (*Operation_RestoreFootprint) isOperation_Body() {}
+
+type Price struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	N             int32                  `protobuf:"varint,1,opt,name=n,proto3" json:"n,omitempty"`
+	D             int32                  `protobuf:"varint,2,opt,name=d,proto3" json:"d,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Price) Reset() {
+	*x = Price{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Price) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Price) ProtoMessage() {}
+
+func (x *Price) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Price.ProtoReflect.Descriptor instead.
+func (*Price) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Price) GetN() int32 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+func (x *Price) GetD() int32 {
+	if x != nil {
+		return x.D
+	}
+	return 0
+}
+
+type CreateAccount struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Destination     string                 `protobuf:"bytes,1,opt,name=destination,proto3" json:"destination,omitempty"`
+	StartingBalance int64                  `protobuf:"varint,2,opt,name=starting_balance,json=startingBalance,proto3" json:"starting_balance,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateAccount) Reset() {
+	*x = CreateAccount{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAccount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAccount) ProtoMessage() {}
+
+func (x *CreateAccount) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAccount.ProtoReflect.Descriptor instead.
+func (*CreateAccount) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateAccount) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *CreateAccount) GetStartingBalance() int64 {
+	if x != nil {
+		return x.StartingBalance
+	}
+	return 0
+}
+
+type Payment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Destination   string                 `protobuf:"bytes,1,opt,name=destination,proto3" json:"destination,omitempty"`
+	Asset         *asset.Asset           `protobuf:"bytes,2,opt,name=asset,proto3" json:"asset,omitempty"`
+	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Payment) Reset() {
+	*x = Payment{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Payment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Payment) ProtoMessage() {}
+
+func (x *Payment) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Payment.ProtoReflect.Descriptor instead.
+func (*Payment) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Payment) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *Payment) GetAsset() *asset.Asset {
+	if x != nil {
+		return x.Asset
+	}
+	return nil
+}
+
+func (x *Payment) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type PathPaymentStrictReceive struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SendAsset     *asset.Asset           `protobuf:"bytes,1,opt,name=send_asset,json=sendAsset,proto3" json:"send_asset,omitempty"`
+	SendMax       int64                  `protobuf:"varint,2,opt,name=send_max,json=sendMax,proto3" json:"send_max,omitempty"`
+	Destination   string                 `protobuf:"bytes,3,opt,name=destination,proto3" json:"destination,omitempty"`
+	DestAsset     *asset.Asset           `protobuf:"bytes,4,opt,name=dest_asset,json=destAsset,proto3" json:"dest_asset,omitempty"`
+	DestAmount    int64                  `protobuf:"varint,5,opt,name=dest_amount,json=destAmount,proto3" json:"dest_amount,omitempty"`
+	Path          []*asset.Asset         `protobuf:"bytes,6,rep,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PathPaymentStrictReceive) Reset() {
+	*x = PathPaymentStrictReceive{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PathPaymentStrictReceive) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathPaymentStrictReceive) ProtoMessage() {}
+
+func (x *PathPaymentStrictReceive) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathPaymentStrictReceive.ProtoReflect.Descriptor instead.
+func (*PathPaymentStrictReceive) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PathPaymentStrictReceive) GetSendAsset() *asset.Asset {
+	if x != nil {
+		return x.SendAsset
+	}
+	return nil
+}
+
+func (x *PathPaymentStrictReceive) GetSendMax() int64 {
+	if x != nil {
+		return x.SendMax
+	}
+	return 0
+}
+
+func (x *PathPaymentStrictReceive) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *PathPaymentStrictReceive) GetDestAsset() *asset.Asset {
+	if x != nil {
+		return x.DestAsset
+	}
+	return nil
+}
+
+func (x *PathPaymentStrictReceive) GetDestAmount() int64 {
+	if x != nil {
+		return x.DestAmount
+	}
+	return 0
+}
+
+func (x *PathPaymentStrictReceive) GetPath() []*asset.Asset {
+	if x != nil {
+		return x.Path
+	}
+	return nil
+}
+
+type ManageSellOffer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Selling       *asset.Asset           `protobuf:"bytes,1,opt,name=selling,proto3" json:"selling,omitempty"`
+	Buying        *asset.Asset           `protobuf:"bytes,2,opt,name=buying,proto3" json:"buying,omitempty"`
+	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Price         *Price                 `protobuf:"bytes,4,opt,name=price,proto3" json:"price,omitempty"`
+	OfferId       int64                  `protobuf:"varint,5,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ManageSellOffer) Reset() {
+	*x = ManageSellOffer{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ManageSellOffer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ManageSellOffer) ProtoMessage() {}
+
+func (x *ManageSellOffer) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ManageSellOffer.ProtoReflect.Descriptor instead.
+func (*ManageSellOffer) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ManageSellOffer) GetSelling() *asset.Asset {
+	if x != nil {
+		return x.Selling
+	}
+	return nil
+}
+
+func (x *ManageSellOffer) GetBuying() *asset.Asset {
+	if x != nil {
+		return x.Buying
+	}
+	return nil
+}
+
+func (x *ManageSellOffer) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ManageSellOffer) GetPrice() *Price {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *ManageSellOffer) GetOfferId() int64 {
+	if x != nil {
+		return x.OfferId
+	}
+	return 0
+}
+
+type CreatePassiveSellOffer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Selling       *asset.Asset           `protobuf:"bytes,1,opt,name=selling,proto3" json:"selling,omitempty"`
+	Buying        *asset.Asset           `protobuf:"bytes,2,opt,name=buying,proto3" json:"buying,omitempty"`
+	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Price         *Price                 `protobuf:"bytes,4,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePassiveSellOffer) Reset() {
+	*x = CreatePassiveSellOffer{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePassiveSellOffer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePassiveSellOffer) ProtoMessage() {}
+
+func (x *CreatePassiveSellOffer) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePassiveSellOffer.ProtoReflect.Descriptor instead.
+func (*CreatePassiveSellOffer) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CreatePassiveSellOffer) GetSelling() *asset.Asset {
+	if x != nil {
+		return x.Selling
+	}
+	return nil
+}
+
+func (x *CreatePassiveSellOffer) GetBuying() *asset.Asset {
+	if x != nil {
+		return x.Buying
+	}
+	return nil
+}
+
+func (x *CreatePassiveSellOffer) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CreatePassiveSellOffer) GetPrice() *Price {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+type Signer struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Strkey encoded signer key
+	Key           string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Weight        uint32 `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Signer) Reset() {
+	*x = Signer{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Signer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Signer) ProtoMessage() {}
+
+func (x *Signer) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Signer.ProtoReflect.Descriptor instead.
+func (*Signer) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Signer) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Signer) GetWeight() uint32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+type SetOptions struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	InflationDestination *string                `protobuf:"bytes,1,opt,name=inflation_destination,json=inflationDestination,proto3,oneof" json:"inflation_destination,omitempty"`
+	ClearFlags           *uint32                `protobuf:"varint,2,opt,name=clear_flags,json=clearFlags,proto3,oneof" json:"clear_flags,omitempty"`
+	SetFlags             *uint32                `protobuf:"varint,3,opt,name=set_flags,json=setFlags,proto3,oneof" json:"set_flags,omitempty"`
+	MasterWeight         *uint32                `protobuf:"varint,4,opt,name=master_weight,json=masterWeight,proto3,oneof" json:"master_weight,omitempty"`
+	LowThreshold         *uint32                `protobuf:"varint,5,opt,name=low_threshold,json=lowThreshold,proto3,oneof" json:"low_threshold,omitempty"`
+	MedThreshold         *uint32                `protobuf:"varint,6,opt,name=med_threshold,json=medThreshold,proto3,oneof" json:"med_threshold,omitempty"`
+	HighThreshold        *uint32                `protobuf:"varint,7,opt,name=high_threshold,json=highThreshold,proto3,oneof" json:"high_threshold,omitempty"`
+	HomeDomain           *string                `protobuf:"bytes,8,opt,name=home_domain,json=homeDomain,proto3,oneof" json:"home_domain,omitempty"`
+	Signer               *Signer                `protobuf:"bytes,9,opt,name=signer,proto3" json:"signer,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *SetOptions) Reset() {
+	*x = SetOptions{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOptions) ProtoMessage() {}
+
+func (x *SetOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOptions.ProtoReflect.Descriptor instead.
+func (*SetOptions) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SetOptions) GetInflationDestination() string {
+	if x != nil && x.InflationDestination != nil {
+		return *x.InflationDestination
+	}
+	return ""
+}
+
+func (x *SetOptions) GetClearFlags() uint32 {
+	if x != nil && x.ClearFlags != nil {
+		return *x.ClearFlags
+	}
+	return 0
+}
+
+func (x *SetOptions) GetSetFlags() uint32 {
+	if x != nil && x.SetFlags != nil {
+		return *x.SetFlags
+	}
+	return 0
+}
+
+func (x *SetOptions) GetMasterWeight() uint32 {
+	if x != nil && x.MasterWeight != nil {
+		return *x.MasterWeight
+	}
+	return 0
+}
+
+func (x *SetOptions) GetLowThreshold() uint32 {
+	if x != nil && x.LowThreshold != nil {
+		return *x.LowThreshold
+	}
+	return 0
+}
+
+func (x *SetOptions) GetMedThreshold() uint32 {
+	if x != nil && x.MedThreshold != nil {
+		return *x.MedThreshold
+	}
+	return 0
+}
+
+func (x *SetOptions) GetHighThreshold() uint32 {
+	if x != nil && x.HighThreshold != nil {
+		return *x.HighThreshold
+	}
+	return 0
+}
+
+func (x *SetOptions) GetHomeDomain() string {
+	if x != nil && x.HomeDomain != nil {
+		return *x.HomeDomain
+	}
+	return ""
+}
+
+func (x *SetOptions) GetSigner() *Signer {
+	if x != nil {
+		return x.Signer
+	}
+	return nil
+}
+
+type LiquidityPoolParameters struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AssetA        *asset.Asset           `protobuf:"bytes,1,opt,name=asset_a,json=assetA,proto3" json:"asset_a,omitempty"`
+	AssetB        *asset.Asset           `protobuf:"bytes,2,opt,name=asset_b,json=assetB,proto3" json:"asset_b,omitempty"`
+	Fee           int32                  `protobuf:"varint,3,opt,name=fee,proto3" json:"fee,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LiquidityPoolParameters) Reset() {
+	*x = LiquidityPoolParameters{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LiquidityPoolParameters) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LiquidityPoolParameters) ProtoMessage() {}
+
+func (x *LiquidityPoolParameters) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LiquidityPoolParameters.ProtoReflect.Descriptor instead.
+func (*LiquidityPoolParameters) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *LiquidityPoolParameters) GetAssetA() *asset.Asset {
+	if x != nil {
+		return x.AssetA
+	}
+	return nil
+}
+
+func (x *LiquidityPoolParameters) GetAssetB() *asset.Asset {
+	if x != nil {
+		return x.AssetB
+	}
+	return nil
+}
+
+func (x *LiquidityPoolParameters) GetFee() int32 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+type ChangeTrust struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Line:
+	//
+	//	*ChangeTrust_Asset
+	//	*ChangeTrust_LiquidityPool
+	Line          isChangeTrust_Line `protobuf_oneof:"line"`
+	Limit         int64              `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeTrust) Reset() {
+	*x = ChangeTrust{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeTrust) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeTrust) ProtoMessage() {}
+
+func (x *ChangeTrust) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeTrust.ProtoReflect.Descriptor instead.
+func (*ChangeTrust) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ChangeTrust) GetLine() isChangeTrust_Line {
+	if x != nil {
+		return x.Line
+	}
+	return nil
+}
+
+func (x *ChangeTrust) GetAsset() *asset.Asset {
+	if x != nil {
+		if x, ok := x.Line.(*ChangeTrust_Asset); ok {
+			return x.Asset
+		}
+	}
+	return nil
+}
+
+func (x *ChangeTrust) GetLiquidityPool() *LiquidityPoolParameters {
+	if x != nil {
+		if x, ok := x.Line.(*ChangeTrust_LiquidityPool); ok {
+			return x.LiquidityPool
+		}
+	}
+	return nil
+}
+
+func (x *ChangeTrust) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type isChangeTrust_Line interface {
+	isChangeTrust_Line()
+}
+
+type ChangeTrust_Asset struct {
+	Asset *asset.Asset `protobuf:"bytes,1,opt,name=asset,proto3,oneof"`
+}
+
+type ChangeTrust_LiquidityPool struct {
+	LiquidityPool *LiquidityPoolParameters `protobuf:"bytes,2,opt,name=liquidity_pool,json=liquidityPool,proto3,oneof"`
+}
+
+func (*ChangeTrust_Asset) isChangeTrust_Line() {}
+
+func (*ChangeTrust_LiquidityPool) isChangeTrust_Line() {}
+
+type AllowTrust struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Trustor       string                 `protobuf:"bytes,1,opt,name=trustor,proto3" json:"trustor,omitempty"`
+	AssetCode     string                 `protobuf:"bytes,2,opt,name=asset_code,json=assetCode,proto3" json:"asset_code,omitempty"`
+	Authorize     uint32                 `protobuf:"varint,3,opt,name=authorize,proto3" json:"authorize,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AllowTrust) Reset() {
+	*x = AllowTrust{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AllowTrust) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllowTrust) ProtoMessage() {}
+
+func (x *AllowTrust) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllowTrust.ProtoReflect.Descriptor instead.
+func (*AllowTrust) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AllowTrust) GetTrustor() string {
+	if x != nil {
+		return x.Trustor
+	}
+	return ""
+}
+
+func (x *AllowTrust) GetAssetCode() string {
+	if x != nil {
+		return x.AssetCode
+	}
+	return ""
+}
+
+func (x *AllowTrust) GetAuthorize() uint32 {
+	if x != nil {
+		return x.Authorize
+	}
+	return 0
+}
+
+type AccountMerge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Destination   string                 `protobuf:"bytes,1,opt,name=destination,proto3" json:"destination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountMerge) Reset() {
+	*x = AccountMerge{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountMerge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountMerge) ProtoMessage() {}
+
+func (x *AccountMerge) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountMerge.ProtoReflect.Descriptor instead.
+func (*AccountMerge) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AccountMerge) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+type Inflation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Inflation) Reset() {
+	*x = Inflation{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Inflation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Inflation) ProtoMessage() {}
+
+func (x *Inflation) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Inflation.ProtoReflect.Descriptor instead.
+func (*Inflation) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{17}
+}
+
+type ManageData struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Unset when the data entry is removed
+	Value         []byte `protobuf:"bytes,2,opt,name=value,proto3,oneof" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ManageData) Reset() {
+	*x = ManageData{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ManageData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ManageData) ProtoMessage() {}
+
+func (x *ManageData) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ManageData.ProtoReflect.Descriptor instead.
+func (*ManageData) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ManageData) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ManageData) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type BumpSequence struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BumpTo        int64                  `protobuf:"varint,1,opt,name=bump_to,json=bumpTo,proto3" json:"bump_to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BumpSequence) Reset() {
+	*x = BumpSequence{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BumpSequence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BumpSequence) ProtoMessage() {}
+
+func (x *BumpSequence) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BumpSequence.ProtoReflect.Descriptor instead.
+func (*BumpSequence) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *BumpSequence) GetBumpTo() int64 {
+	if x != nil {
+		return x.BumpTo
+	}
+	return 0
+}
+
+type ManageBuyOffer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Selling       *asset.Asset           `protobuf:"bytes,1,opt,name=selling,proto3" json:"selling,omitempty"`
+	Buying        *asset.Asset           `protobuf:"bytes,2,opt,name=buying,proto3" json:"buying,omitempty"`
+	BuyAmount     int64                  `protobuf:"varint,3,opt,name=buy_amount,json=buyAmount,proto3" json:"buy_amount,omitempty"`
+	Price         *Price                 `protobuf:"bytes,4,opt,name=price,proto3" json:"price,omitempty"`
+	OfferId       int64                  `protobuf:"varint,5,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ManageBuyOffer) Reset() {
+	*x = ManageBuyOffer{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ManageBuyOffer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ManageBuyOffer) ProtoMessage() {}
+
+func (x *ManageBuyOffer) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ManageBuyOffer.ProtoReflect.Descriptor instead.
+func (*ManageBuyOffer) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ManageBuyOffer) GetSelling() *asset.Asset {
+	if x != nil {
+		return x.Selling
+	}
+	return nil
+}
+
+func (x *ManageBuyOffer) GetBuying() *asset.Asset {
+	if x != nil {
+		return x.Buying
+	}
+	return nil
+}
+
+func (x *ManageBuyOffer) GetBuyAmount() int64 {
+	if x != nil {
+		return x.BuyAmount
+	}
+	return 0
+}
+
+func (x *ManageBuyOffer) GetPrice() *Price {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *ManageBuyOffer) GetOfferId() int64 {
+	if x != nil {
+		return x.OfferId
+	}
+	return 0
+}
+
+type PathPaymentStrictSend struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SendAsset     *asset.Asset           `protobuf:"bytes,1,opt,name=send_asset,json=sendAsset,proto3" json:"send_asset,omitempty"`
+	SendAmount    int64                  `protobuf:"varint,2,opt,name=send_amount,json=sendAmount,proto3" json:"send_amount,omitempty"`
+	Destination   string                 `protobuf:"bytes,3,opt,name=destination,proto3" json:"destination,omitempty"`
+	DestAsset     *asset.Asset           `protobuf:"bytes,4,opt,name=dest_asset,json=destAsset,proto3" json:"dest_asset,omitempty"`
+	DestMin       int64                  `protobuf:"varint,5,opt,name=dest_min,json=destMin,proto3" json:"dest_min,omitempty"`
+	Path          []*asset.Asset         `protobuf:"bytes,6,rep,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PathPaymentStrictSend) Reset() {
+	*x = PathPaymentStrictSend{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PathPaymentStrictSend) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathPaymentStrictSend) ProtoMessage() {}
+
+func (x *PathPaymentStrictSend) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathPaymentStrictSend.ProtoReflect.Descriptor instead.
+func (*PathPaymentStrictSend) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *PathPaymentStrictSend) GetSendAsset() *asset.Asset {
+	if x != nil {
+		return x.SendAsset
+	}
+	return nil
+}
+
+func (x *PathPaymentStrictSend) GetSendAmount() int64 {
+	if x != nil {
+		return x.SendAmount
+	}
+	return 0
+}
+
+func (x *PathPaymentStrictSend) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *PathPaymentStrictSend) GetDestAsset() *asset.Asset {
+	if x != nil {
+		return x.DestAsset
+	}
+	return nil
+}
+
+func (x *PathPaymentStrictSend) GetDestMin() int64 {
+	if x != nil {
+		return x.DestMin
+	}
+	return 0
+}
+
+func (x *PathPaymentStrictSend) GetPath() []*asset.Asset {
+	if x != nil {
+		return x.Path
+	}
+	return nil
+}
+
+type Claimant struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Destination string                 `protobuf:"bytes,1,opt,name=destination,proto3" json:"destination,omitempty"`
+	// Opaque XDR encoded ClaimPredicate
+	Predicate     []byte `protobuf:"bytes,2,opt,name=predicate,proto3" json:"predicate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Claimant) Reset() {
+	*x = Claimant{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Claimant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Claimant) ProtoMessage() {}
+
+func (x *Claimant) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Claimant.ProtoReflect.Descriptor instead.
+func (*Claimant) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Claimant) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *Claimant) GetPredicate() []byte {
+	if x != nil {
+		return x.Predicate
+	}
+	return nil
+}
+
+type CreateClaimableBalance struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Asset         *asset.Asset           `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	Amount        int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Claimants     []*Claimant            `protobuf:"bytes,3,rep,name=claimants,proto3" json:"claimants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateClaimableBalance) Reset() {
+	*x = CreateClaimableBalance{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateClaimableBalance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateClaimableBalance) ProtoMessage() {}
+
+func (x *CreateClaimableBalance) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateClaimableBalance.ProtoReflect.Descriptor instead.
+func (*CreateClaimableBalance) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CreateClaimableBalance) GetAsset() *asset.Asset {
+	if x != nil {
+		return x.Asset
+	}
+	return nil
+}
+
+func (x *CreateClaimableBalance) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CreateClaimableBalance) GetClaimants() []*Claimant {
+	if x != nil {
+		return x.Claimants
+	}
+	return nil
+}
+
+type ClaimClaimableBalance struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Hex encoded XDR ClaimableBalanceId
+	BalanceId     string `protobuf:"bytes,1,opt,name=balance_id,json=balanceId,proto3" json:"balance_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClaimClaimableBalance) Reset() {
+	*x = ClaimClaimableBalance{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimClaimableBalance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimClaimableBalance) ProtoMessage() {}
+
+func (x *ClaimClaimableBalance) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimClaimableBalance.ProtoReflect.Descriptor instead.
+func (*ClaimClaimableBalance) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ClaimClaimableBalance) GetBalanceId() string {
+	if x != nil {
+		return x.BalanceId
+	}
+	return ""
+}
+
+type BeginSponsoringFutureReserves struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SponsoredId   string                 `protobuf:"bytes,1,opt,name=sponsored_id,json=sponsoredId,proto3" json:"sponsored_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BeginSponsoringFutureReserves) Reset() {
+	*x = BeginSponsoringFutureReserves{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BeginSponsoringFutureReserves) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeginSponsoringFutureReserves) ProtoMessage() {}
+
+func (x *BeginSponsoringFutureReserves) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeginSponsoringFutureReserves.ProtoReflect.Descriptor instead.
+func (*BeginSponsoringFutureReserves) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *BeginSponsoringFutureReserves) GetSponsoredId() string {
+	if x != nil {
+		return x.SponsoredId
+	}
+	return ""
+}
+
+type EndSponsoringFutureReserves struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EndSponsoringFutureReserves) Reset() {
+	*x = EndSponsoringFutureReserves{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EndSponsoringFutureReserves) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EndSponsoringFutureReserves) ProtoMessage() {}
+
+func (x *EndSponsoringFutureReserves) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EndSponsoringFutureReserves.ProtoReflect.Descriptor instead.
+func (*EndSponsoringFutureReserves) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{26}
+}
+
+type RevokeSponsorshipSigner struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AccountId string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// Strkey encoded signer key
+	SignerKey     string `protobuf:"bytes,2,opt,name=signer_key,json=signerKey,proto3" json:"signer_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSponsorshipSigner) Reset() {
+	*x = RevokeSponsorshipSigner{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSponsorshipSigner) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSponsorshipSigner) ProtoMessage() {}
+
+func (x *RevokeSponsorshipSigner) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSponsorshipSigner.ProtoReflect.Descriptor instead.
+func (*RevokeSponsorshipSigner) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RevokeSponsorshipSigner) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *RevokeSponsorshipSigner) GetSignerKey() string {
+	if x != nil {
+		return x.SignerKey
+	}
+	return ""
+}
+
+type RevokeSponsorship struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Target:
+	//
+	//	*RevokeSponsorship_LedgerKey
+	//	*RevokeSponsorship_Signer
+	Target        isRevokeSponsorship_Target `protobuf_oneof:"target"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSponsorship) Reset() {
+	*x = RevokeSponsorship{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSponsorship) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSponsorship) ProtoMessage() {}
+
+func (x *RevokeSponsorship) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSponsorship.ProtoReflect.Descriptor instead.
+func (*RevokeSponsorship) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RevokeSponsorship) GetTarget() isRevokeSponsorship_Target {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *RevokeSponsorship) GetLedgerKey() []byte {
+	if x != nil {
+		if x, ok := x.Target.(*RevokeSponsorship_LedgerKey); ok {
+			return x.LedgerKey
+		}
+	}
+	return nil
+}
+
+func (x *RevokeSponsorship) GetSigner() *RevokeSponsorshipSigner {
+	if x != nil {
+		if x, ok := x.Target.(*RevokeSponsorship_Signer); ok {
+			return x.Signer
+		}
+	}
+	return nil
+}
+
+type isRevokeSponsorship_Target interface {
+	isRevokeSponsorship_Target()
+}
+
+type RevokeSponsorship_LedgerKey struct {
+	// Opaque XDR encoded LedgerKey
+	LedgerKey []byte `protobuf:"bytes,1,opt,name=ledger_key,json=ledgerKey,proto3,oneof"`
+}
+
+type RevokeSponsorship_Signer struct {
+	Signer *RevokeSponsorshipSigner `protobuf:"bytes,2,opt,name=signer,proto3,oneof"`
+}
+
+func (*RevokeSponsorship_LedgerKey) isRevokeSponsorship_Target() {}
+
+func (*RevokeSponsorship_Signer) isRevokeSponsorship_Target() {}
+
+type Clawback struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Asset         *asset.Asset           `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	From          string                 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Clawback) Reset() {
+	*x = Clawback{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Clawback) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Clawback) ProtoMessage() {}
+
+func (x *Clawback) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Clawback.ProtoReflect.Descriptor instead.
+func (*Clawback) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *Clawback) GetAsset() *asset.Asset {
+	if x != nil {
+		return x.Asset
+	}
+	return nil
+}
+
+func (x *Clawback) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *Clawback) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type ClawbackClaimableBalance struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Hex encoded XDR ClaimableBalanceId
+	BalanceId     string `protobuf:"bytes,1,opt,name=balance_id,json=balanceId,proto3" json:"balance_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClawbackClaimableBalance) Reset() {
+	*x = ClawbackClaimableBalance{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClawbackClaimableBalance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClawbackClaimableBalance) ProtoMessage() {}
+
+func (x *ClawbackClaimableBalance) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClawbackClaimableBalance.ProtoReflect.Descriptor instead.
+func (*ClawbackClaimableBalance) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ClawbackClaimableBalance) GetBalanceId() string {
+	if x != nil {
+		return x.BalanceId
+	}
+	return ""
+}
+
+type SetTrustLineFlags struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Trustor       string                 `protobuf:"bytes,1,opt,name=trustor,proto3" json:"trustor,omitempty"`
+	Asset         *asset.Asset           `protobuf:"bytes,2,opt,name=asset,proto3" json:"asset,omitempty"`
+	ClearFlags    uint32                 `protobuf:"varint,3,opt,name=clear_flags,json=clearFlags,proto3" json:"clear_flags,omitempty"`
+	SetFlags      uint32                 `protobuf:"varint,4,opt,name=set_flags,json=setFlags,proto3" json:"set_flags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTrustLineFlags) Reset() {
+	*x = SetTrustLineFlags{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTrustLineFlags) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTrustLineFlags) ProtoMessage() {}
+
+func (x *SetTrustLineFlags) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTrustLineFlags.ProtoReflect.Descriptor instead.
+func (*SetTrustLineFlags) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SetTrustLineFlags) GetTrustor() string {
+	if x != nil {
+		return x.Trustor
+	}
+	return ""
+}
+
+func (x *SetTrustLineFlags) GetAsset() *asset.Asset {
+	if x != nil {
+		return x.Asset
+	}
+	return nil
+}
+
+func (x *SetTrustLineFlags) GetClearFlags() uint32 {
+	if x != nil {
+		return x.ClearFlags
+	}
+	return 0
+}
+
+func (x *SetTrustLineFlags) GetSetFlags() uint32 {
+	if x != nil {
+		return x.SetFlags
+	}
+	return 0
+}
+
+type LiquidityPoolDeposit struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Hex encoded liquidity pool ID
+	LiquidityPoolId string `protobuf:"bytes,1,opt,name=liquidity_pool_id,json=liquidityPoolId,proto3" json:"liquidity_pool_id,omitempty"`
+	MaxAmountA      int64  `protobuf:"varint,2,opt,name=max_amount_a,json=maxAmountA,proto3" json:"max_amount_a,omitempty"`
+	MaxAmountB      int64  `protobuf:"varint,3,opt,name=max_amount_b,json=maxAmountB,proto3" json:"max_amount_b,omitempty"`
+	MinPrice        *Price `protobuf:"bytes,4,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	MaxPrice        *Price `protobuf:"bytes,5,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *LiquidityPoolDeposit) Reset() {
+	*x = LiquidityPoolDeposit{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LiquidityPoolDeposit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LiquidityPoolDeposit) ProtoMessage() {}
+
+func (x *LiquidityPoolDeposit) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LiquidityPoolDeposit.ProtoReflect.Descriptor instead.
+func (*LiquidityPoolDeposit) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *LiquidityPoolDeposit) GetLiquidityPoolId() string {
+	if x != nil {
+		return x.LiquidityPoolId
+	}
+	return ""
+}
+
+func (x *LiquidityPoolDeposit) GetMaxAmountA() int64 {
+	if x != nil {
+		return x.MaxAmountA
+	}
+	return 0
+}
+
+func (x *LiquidityPoolDeposit) GetMaxAmountB() int64 {
+	if x != nil {
+		return x.MaxAmountB
+	}
+	return 0
+}
+
+func (x *LiquidityPoolDeposit) GetMinPrice() *Price {
+	if x != nil {
+		return x.MinPrice
+	}
+	return nil
+}
+
+func (x *LiquidityPoolDeposit) GetMaxPrice() *Price {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return nil
+}
+
+type LiquidityPoolWithdraw struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Hex encoded liquidity pool ID
+	LiquidityPoolId string `protobuf:"bytes,1,opt,name=liquidity_pool_id,json=liquidityPoolId,proto3" json:"liquidity_pool_id,omitempty"`
+	Amount          int64  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	MinAmountA      int64  `protobuf:"varint,3,opt,name=min_amount_a,json=minAmountA,proto3" json:"min_amount_a,omitempty"`
+	MinAmountB      int64  `protobuf:"varint,4,opt,name=min_amount_b,json=minAmountB,proto3" json:"min_amount_b,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *LiquidityPoolWithdraw) Reset() {
+	*x = LiquidityPoolWithdraw{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LiquidityPoolWithdraw) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LiquidityPoolWithdraw) ProtoMessage() {}
+
+func (x *LiquidityPoolWithdraw) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LiquidityPoolWithdraw.ProtoReflect.Descriptor instead.
+func (*LiquidityPoolWithdraw) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *LiquidityPoolWithdraw) GetLiquidityPoolId() string {
+	if x != nil {
+		return x.LiquidityPoolId
+	}
+	return ""
+}
+
+func (x *LiquidityPoolWithdraw) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *LiquidityPoolWithdraw) GetMinAmountA() int64 {
+	if x != nil {
+		return x.MinAmountA
+	}
+	return 0
+}
+
+func (x *LiquidityPoolWithdraw) GetMinAmountB() int64 {
+	if x != nil {
+		return x.MinAmountB
+	}
+	return 0
+}
+
+type InvokeHostFunction struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Opaque XDR encoded HostFunction
+	HostFunction []byte `protobuf:"bytes,1,opt,name=host_function,json=hostFunction,proto3" json:"host_function,omitempty"`
+	// Opaque XDR encoded SorobanAuthorizationEntry of each authorization
+	Auth          [][]byte `protobuf:"bytes,2,rep,name=auth,proto3" json:"auth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvokeHostFunction) Reset() {
+	*x = InvokeHostFunction{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvokeHostFunction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvokeHostFunction) ProtoMessage() {}
+
+func (x *InvokeHostFunction) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvokeHostFunction.ProtoReflect.Descriptor instead.
+func (*InvokeHostFunction) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *InvokeHostFunction) GetHostFunction() []byte {
+	if x != nil {
+		return x.HostFunction
+	}
+	return nil
+}
+
+func (x *InvokeHostFunction) GetAuth() [][]byte {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+type ExtendFootprintTtl struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExtendTo      uint32                 `protobuf:"varint,1,opt,name=extend_to,json=extendTo,proto3" json:"extend_to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExtendFootprintTtl) Reset() {
+	*x = ExtendFootprintTtl{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExtendFootprintTtl) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtendFootprintTtl) ProtoMessage() {}
+
+func (x *ExtendFootprintTtl) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtendFootprintTtl.ProtoReflect.Descriptor instead.
+func (*ExtendFootprintTtl) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ExtendFootprintTtl) GetExtendTo() uint32 {
+	if x != nil {
+		return x.ExtendTo
+	}
+	return 0
+}
+
+type RestoreFootprint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreFootprint) Reset() {
+	*x = RestoreFootprint{}
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreFootprint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreFootprint) ProtoMessage() {}
+
+func (x *RestoreFootprint) ProtoReflect() protoreflect.Message {
+	mi := &file_processors_ledger_export_ledger_export_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreFootprint.ProtoReflect.Descriptor instead.
+func (*RestoreFootprint) Descriptor() ([]byte, []int) {
+	return file_processors_ledger_export_ledger_export_proto_rawDescGZIP(), []int{36}
+}
+
+var File_processors_ledger_export_ledger_export_proto protoreflect.FileDescriptor
+
+var file_processors_ledger_export_ledger_export_proto_rawDesc = []byte{
+	0x0a, 0x2c, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x73, 0x2f, 0x6c, 0x65, 0x64,
+	0x67, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2f, 0x6c, 0x65, 0x64, 0x67, 0x65,
+	0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d,
+	0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x1a, 0x1f, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x11,
+	0x61, 0x73, 0x73, 0x65, 0x74, 0x2f, 0x61, 0x73, 0x73, 0x65, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0x88, 0x05, 0x0a, 0x0f, 0x4c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x43, 0x6c, 0x6f, 0x73,
+	0x65, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x73,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c,
+	0x6d, 0x65, 0x74, 0x61, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0b, 0x6d, 0x65, 0x74, 0x61, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x68,
+	0x61, 0x73, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12,
+	0x23, 0x0a, 0x0d, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x68, 0x61, 0x73, 0x68,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73,
+	0x48, 0x61, 0x73, 0x68, 0x12, 0x29, 0x0a, 0x10, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c,
+	0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x37, 0x0a, 0x09, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08,
+	0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x41, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x61, 0x73, 0x65,
+	0x5f, 0x66, 0x65, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x62, 0x61, 0x73, 0x65,
+	0x46, 0x65, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x62, 0x61, 0x73, 0x65, 0x52,
+	0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x12, 0x25, 0x0a, 0x0f, 0x6d, 0x61, 0x78, 0x5f, 0x74, 0x78,
+	0x5f, 0x73, 0x65, 0x74, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0c, 0x6d, 0x61, 0x78, 0x54, 0x78, 0x53, 0x65, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x69, 0x6e, 0x73, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x69, 0x6e, 0x73, 0x12, 0x19,
+	0x0a, 0x08, 0x66, 0x65, 0x65, 0x5f, 0x70, 0x6f, 0x6f, 0x6c, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x66, 0x65, 0x65, 0x50, 0x6f, 0x6f, 0x6c, 0x12, 0x28, 0x0a, 0x10, 0x62, 0x75, 0x63,
+	0x6b, 0x65, 0x74, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x48,
+	0x61, 0x73, 0x68, 0x12, 0x44, 0x0a, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6c, 0x65, 0x64, 0x67,
+	0x65, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x4c, 0x65, 0x64, 0x67, 0x65, 0x72,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x3e, 0x0a, 0x0f, 0x75, 0x70, 0x67,
+	0x72, 0x61, 0x64, 0x65, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x0f, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x0e, 0x75, 0x70, 0x67, 0x72, 0x61,
+	0x64, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x65, 0x76, 0x69,
+	0x63, 0x74, 0x65, 0x64, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0c, 0x52,
+	0x0b, 0x65, 0x76, 0x69, 0x63, 0x74, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x73, 0x22, 0x8f, 0x05, 0x0a,
+	0x11, 0x4c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x6c, 0x65, 0x64,
+	0x67, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0e, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e,
+	0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x1e, 0x0a, 0x0a,
+	0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x66, 0x75, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0a, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x66, 0x75, 0x6c, 0x12, 0x1f, 0x0a, 0x0b,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x25, 0x0a,
+	0x0e, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x41, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x65, 0x65, 0x5f, 0x61, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x65, 0x65, 0x41, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x5f, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
+	0x12, 0x17, 0x0a, 0x07, 0x6d, 0x61, 0x78, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x6d, 0x61, 0x78, 0x46, 0x65, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x65, 0x65,
+	0x5f, 0x63, 0x68, 0x61, 0x72, 0x67, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a,
+	0x66, 0x65, 0x65, 0x43, 0x68, 0x61, 0x72, 0x67, 0x65, 0x64, 0x12, 0x27, 0x0a, 0x04, 0x6d, 0x65,
+	0x6d, 0x6f, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65,
+	0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x4d, 0x65, 0x6d, 0x6f, 0x52, 0x04, 0x6d,
+	0x65, 0x6d, 0x6f, 0x12, 0x38, 0x0a, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72,
+	0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x36, 0x0a,
+	0x0b, 0x66, 0x65, 0x65, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x0e, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x0a, 0x66, 0x65, 0x65, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x2f, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73,
+	0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x5f,
+	0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x07, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6e, 0x76, 0x65, 0x6c, 0x6f,
+	0x70, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x65, 0x6e, 0x76, 0x65, 0x6c, 0x6f,
+	0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x11, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x65,
+	0x74, 0x61, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x22, 0x70,
+	0x0a, 0x04, 0x4d, 0x65, 0x6d, 0x6f, 0x12, 0x14, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x10, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14,
+	0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x04,
+	0x68, 0x61, 0x73, 0x68, 0x12, 0x21, 0x0a, 0x0b, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x5f, 0x68,
+	0x61, 0x73, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x0a, 0x72, 0x65, 0x74,
+	0x75, 0x72, 0x6e, 0x48, 0x61, 0x73, 0x68, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x22, 0xad, 0x03, 0x0a, 0x06, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x73,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0d, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x3a, 0x0a, 0x0b, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72,
+	0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x33,
+	0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b,
+	0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x12, 0x3d, 0x0a, 0x0a, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72,
+	0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x4c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x71,
+	0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x6c, 0x65, 0x64,
+	0x67, 0x65, 0x72, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x48, 0x61, 0x73, 0x68, 0x12, 0x2c, 0x0a, 0x0f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x48,
+	0x00, 0x52, 0x0e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x88, 0x01, 0x01, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x72, 0x65, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x03, 0x70, 0x72, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x74,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x70, 0x6f, 0x73, 0x74, 0x42, 0x12, 0x0a, 0x10,
+	0x5f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x22, 0x9a, 0x12, 0x0a, 0x09, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14,
+	0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x12, 0x45, 0x0a, 0x0e, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6c, 0x65,
+	0x64, 0x67, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x32, 0x0a, 0x07, 0x70, 0x61,
+	0x79, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x65,
+	0x64, 0x67, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x50, 0x61, 0x79, 0x6d,
+	0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x68,
+	0x0a, 0x1b, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x73,
+	0x74, 0x72, 0x69, 0x63, 0x74, 0x5f, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x53,
+	0x74, 0x72, 0x69, 0x63, 0x74, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x48, 0x00, 0x52, 0x18,
+	0x70, 0x61, 0x74, 0x68, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x69, 0x63,
+	0x74, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x12, 0x4c, 0x0a, 0x11, 0x6d, 0x61, 0x6e, 0x61,
+	0x67, 0x65, 0x5f, 0x73, 0x65, 0x6c, 0x6c, 0x5f, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x2e, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x53, 0x65, 0x6c, 0x6c, 0x4f, 0x66,
+	0x66, 0x65, 0x72, 0x48, 0x00, 0x52, 0x0f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x53, 0x65, 0x6c,
+	0x6c, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x12, 0x62, 0x0a, 0x19, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x5f, 0x70, 0x61, 0x73, 0x73, 0x69, 0x76, 0x65, 0x5f, 0x73, 0x65, 0x6c, 0x6c, 0x5f, 0x6f, 0x66,
+	0x66, 0x65, 0x72, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6c, 0x65, 0x64, 0x67,
+	0x65, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x50, 0x61, 0x73, 0x73, 0x69, 0x76, 0x65, 0x53, 0x65, 0x6c, 0x6c, 0x4f, 0x66, 0x66, 0x65, 0x72,
+	0x48, 0x00, 0x52, 0x16, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x61, 0x73, 0x73, 0x69, 0x76,
+	0x65, 0x53, 0x65, 0x6c, 0x6c, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x12, 0x3c, 0x0a, 0x0b, 0x73, 0x65,
+	0x74, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e,
+	0x53, 0x65, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x48, 0x00, 0x52, 0x0a, 0x73, 0x65,
+	0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x3f, 0x0a, 0x0c, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x5f, 0x74, 0x72, 0x75, 0x73, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x54, 0x72, 0x75, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x54, 0x72, 0x75, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x0b, 0x61, 0x6c, 0x6c,
+	0x6f, 0x77, 0x5f, 0x74, 0x72, 0x75, 0x73, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x41,
+	0x6c, 0x6c, 0x6f, 0x77, 0x54, 0x72, 0x75, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x61, 0x6c, 0x6c,
+	0x6f, 0x77, 0x54, 0x72, 0x75, 0x73, 0x74, 0x12, 0x42, 0x0a, 0x0d, 0x61, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x5f, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x41,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x69,
+	0x6e, 0x66, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x49,
+	0x6e, 0x66, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x09, 0x69, 0x6e, 0x66, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3c, 0x0a, 0x0b, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x5f,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6c, 0x65, 0x64,
+	0x67, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x4d, 0x61, 0x6e, 0x61, 0x67,
+	0x65, 0x44, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x0a, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x44,
+	0x61, 0x74, 0x61, 0x12, 0x42, 0x0a, 0x0d, 0x62, 0x75, 0x6d, 0x70, 0x5f, 0x73, 0x65, 0x71, 0x75,
+	0x65, 0x6e, 0x63, 0x65, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6c, 0x65, 0x64,
+	0x67, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x42, 0x75, 0x6d, 0x70, 0x53,
+	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x62, 0x75, 0x6d, 0x70, 0x53,
+	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x49, 0x0a, 0x10, 0x6d, 0x61, 0x6e, 0x61, 0x67,
+	0x65, 0x5f, 0x62, 0x75, 0x79, 0x5f, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x18, 0x16, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1d, 0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72,
+	0x74, 0x2e, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x42, 0x75, 0x79, 0x4f, 0x66, 0x66, 0x65, 0x72,
+	0x48, 0x00, 0x52, 0x0e, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x42, 0x75, 0x79, 0x4f, 0x66, 0x66,
+	0x65, 0x72, 0x12, 0x5f, 0x0a, 0x18, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x70, 0x61, 0x79, 0x6d, 0x65,
+	0x6e, 0x74, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x5f, 0x73, 0x65, 0x6e, 0x64, 0x18, 0x17,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x5f, 0x65, 0x78,
+	0x70, 0x6f, 0x72, 0x74, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74,
+	0x53, 0x74, 0x72, 0x69, 0x63, 0x74, 0x53, 0x65, 0x6e, 0x64, 0x48, 0x00, 0x52, 0x15, 0x70, 0x61,
+	0x74, 0x68, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x69, 0x63, 0x74, 0x53,
+	0x65, 0x6e, 0x64, 0x12, 0x61, 0x0a, 0x18, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x63, 0x6c,
+	0x61, 0x69, 0x6d, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18,
+	0x18, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x5f, 0x65,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6c, 0x61, 0x69,
+	0x6d, 0x61, 0x62, 0x6c, 0x65, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x48, 0x00, 0x52, 0x16,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x61, 0x62, 0x6c, 0x65, 0x42,
+	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x5e, 0x0a, 0x17, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x5f,
+	0x63, 0x6c, 0x61, 0x69, 0x6d, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63,
+	0x65, 0x18, 0x19, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72,
+	0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x43, 0x6c, 0x61,
+	0x69, 0x6d, 0x61, 0x62, 0x6c, 0x65, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x48, 0x00, 0x52,
+	0x15, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x61, 0x62, 0x6c, 0x65, 0x42,
+	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x77, 0x0a, 0x20, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x5f,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x66, 0x75, 0x74, 0x75, 0x72,
+	0x65, 0x5f, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x73, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x2c, 0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x2e, 0x42, 0x65, 0x67, 0x69, 0x6e, 0x53, 0x70, 0x6f, 0x6e, 0x73, 0x6f, 0x72, 0x69, 0x6e, 0x67,
+	0x46, 0x75, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x73, 0x48, 0x00,
+	0x52, 0x1d, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x53, 0x70, 0x6f, 0x6e, 0x73, 0x6f, 0x72, 0x69, 0x6e,
+	0x67, 0x46, 0x75, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x73, 0x12,
+	0x71, 0x0a, 0x1e, 0x65, 0x6e, 0x64, 0x5f, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x6f, 0x72, 0x69, 0x6e,
+	0x67, 0x5f, 0x66, 0x75, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x73, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72,
+	0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x45, 0x6e, 0x64, 0x53, 0x70, 0x6f, 0x6e, 0x73,
+	0x6f, 0x72, 0x69, 0x6e, 0x67, 0x46, 0x75, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x73, 0x48, 0x00, 0x52, 0x1b, 0x65, 0x6e, 0x64, 0x53, 0x70, 0x6f, 0x6e, 0x73, 0x6f,
+	0x72, 0x69, 0x6e, 0x67, 0x46, 0x75, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x73, 0x12, 0x51, 0x0a, 0x12, 0x72, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x5f, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x6f, 0x72, 0x73, 0x68, 0x69, 0x70, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20,
+	0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x52,
+	0x65, 0x76, 0x6f, 0x6b, 0x65, 0x53, 0x70, 0x6f, 0x6e, 0x73, 0x6f, 0x72, 0x73, 0x68, 0x69, 0x70,
+	0x48, 0x00, 0x52, 0x11, 0x72, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x53, 0x70, 0x6f, 0x6e, 0x73, 0x6f,
+	0x72, 0x73, 0x68, 0x69, 0x70, 0x12, 0x35, 0x0a, 0x08, 0x63, 0x6c, 0x61, 0x77, 0x62, 0x61, 0x63,
+	0x6b, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72,
+	0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x43, 0x6c, 0x61, 0x77, 0x62, 0x61, 0x63, 0x6b,
+	0x48, 0x00, 0x52, 0x08, 0x63, 0x6c, 0x61, 0x77, 0x62, 0x61, 0x63, 0x6b, 0x12, 0x67, 0x0a, 0x1a,
+	0x63, 0x6c, 0x61, 0x77, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x61, 0x62,
+	0x6c, 0x65, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x27, 0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x2e, 0x43, 0x6c, 0x61, 0x77, 0x62, 0x61, 0x63, 0x6b, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x61, 0x62,
+	0x6c, 0x65, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x48, 0x00, 0x52, 0x18, 0x63, 0x6c, 0x61,
+	0x77, 0x62, 0x61, 0x63, 0x6b, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x61, 0x62, 0x6c, 0x65, 0x42, 0x61,
+	0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x53, 0x0a, 0x14, 0x73, 0x65, 0x74, 0x5f, 0x74, 0x72, 0x75,
+	0x73, 0x74, 0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x1f, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x2e, 0x53, 0x65, 0x74, 0x54, 0x72, 0x75, 0x73, 0x74, 0x4c, 0x69, 0x6e, 0x65,
+	0x46, 0x6c, 0x61, 0x67, 0x73, 0x48, 0x00, 0x52, 0x11, 0x73, 0x65, 0x74, 0x54, 0x72, 0x75, 0x73,
+	0x74, 0x4c, 0x69, 0x6e, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x5b, 0x0a, 0x16, 0x6c, 0x69,
+	0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x5f, 0x70, 0x6f, 0x6f, 0x6c, 0x5f, 0x64, 0x65, 0x70,
+	0x6f, 0x73, 0x69, 0x74, 0x18, 0x20, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6c, 0x65, 0x64,
+	0x67, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x4c, 0x69, 0x71, 0x75, 0x69,
+	0x64, 0x69, 0x74, 0x79, 0x50, 0x6f, 0x6f, 0x6c, 0x44, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x48,
+	0x00, 0x52, 0x14, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x6f, 0x6f, 0x6c,
+	0x44, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x12, 0x5e, 0x0a, 0x17, 0x6c, 0x69, 0x71, 0x75, 0x69,
+	0x64, 0x69, 0x74, 0x79, 0x5f, 0x70, 0x6f, 0x6f, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x64, 0x72,
+	0x61, 0x77, 0x18, 0x21, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65,
+	0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69,
+	0x74, 0x79, 0x50, 0x6f, 0x6f, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61, 0x77, 0x48, 0x00,
+	0x52, 0x15, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x6f, 0x6f, 0x6c, 0x57,
+	0x69, 0x74, 0x68, 0x64, 0x72, 0x61, 0x77, 0x12, 0x55, 0x0a, 0x14, 0x69, 0x6e, 0x76, 0x6f, 0x6b,
+	0x65, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x22, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x5f, 0x65,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x49, 0x6e, 0x76, 0x6f, 0x6b, 0x65, 0x48, 0x6f, 0x73, 0x74,
+	0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x12, 0x69, 0x6e, 0x76, 0x6f,
+	0x6b, 0x65, 0x48, 0x6f, 0x73, 0x74, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x55,
+	0x0a, 0x14, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x5f, 0x66, 0x6f, 0x6f, 0x74, 0x70, 0x72, 0x69,
+	0x6e, 0x74, 0x5f, 0x74, 0x74, 0x6c, 0x18, 0x23, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6c,
+	0x65, 0x64, 0x67, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x45, 0x78, 0x74,
+	0x65, 0x6e, 0x64, 0x46, 0x6f, 0x6f, 0x74, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x54, 0x74, 0x6c, 0x48,
+	0x00, 0x52, 0x12, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x46, 0x6f, 0x6f, 0x74, 0x70, 0x72, 0x69,
+	0x6e, 0x74, 0x54, 0x74, 0x6c, 0x12, 0x4e, 0x0a, 0x11, 0x72, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x5f, 0x66, 0x6f, 0x6f, 0x74, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x18, 0x24, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1f, 0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x2e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x46, 0x6f, 0x6f, 0x74, 0x70, 0x72, 0x69, 0x6e,
+	0x74, 0x48, 0x00, 0x52, 0x10, 0x72, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x46, 0x6f, 0x6f, 0x74,
+	0x70, 0x72, 0x69, 0x6e, 0x74, 0x42, 0x06, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x22, 0x23, 0x0a,
+	0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x01, 0x6e, 0x12, 0x0c, 0x0a, 0x01, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x01, 0x64, 0x22, 0x5c, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x29, 0x0a, 0x10, 0x73, 0x74, 0x61, 0x72, 0x74, 0x69, 0x6e,
+	0x67, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65,
+	0x22, 0x67, 0x0a, 0x07, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x64,
+	0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a,
+	0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61,
+	0x73, 0x73, 0x65, 0x74, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x52, 0x05, 0x61, 0x73, 0x73, 0x65,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xf4, 0x01, 0x0a, 0x18, 0x50, 0x61,
+	0x74, 0x68, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x69, 0x63, 0x74, 0x52,
+	0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x12, 0x2b, 0x0a, 0x0a, 0x73, 0x65, 0x6e, 0x64, 0x5f, 0x61,
+	0x73, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61, 0x73, 0x73,
+	0x65, 0x74, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x52, 0x09, 0x73, 0x65, 0x6e, 0x64, 0x41, 0x73,
+	0x73, 0x65, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x5f, 0x6d, 0x61, 0x78, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x73, 0x65, 0x6e, 0x64, 0x4d, 0x61, 0x78, 0x12, 0x20,
+	0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x2b, 0x0a, 0x0a, 0x64, 0x65, 0x73, 0x74, 0x5f, 0x61, 0x73, 0x73, 0x65, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61, 0x73, 0x73, 0x65, 0x74, 0x2e, 0x41, 0x73, 0x73,
+	0x65, 0x74, 0x52, 0x09, 0x64, 0x65, 0x73, 0x74, 0x41, 0x73, 0x73, 0x65, 0x74, 0x12, 0x1f, 0x0a,
+	0x0b, 0x64, 0x65, 0x73, 0x74, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0a, 0x64, 0x65, 0x73, 0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x20,
+	0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61,
+	0x73, 0x73, 0x65, 0x74, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68,
+	0x22, 0xbe, 0x01, 0x0a, 0x0f, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x53, 0x65, 0x6c, 0x6c, 0x4f,
+	0x66, 0x66, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x07, 0x73, 0x65, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61, 0x73, 0x73, 0x65, 0x74, 0x2e, 0x41, 0x73,
+	0x73, 0x65, 0x74, 0x52, 0x07, 0x73, 0x65, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x12, 0x24, 0x0a, 0x06,
+	0x62, 0x75, 0x79, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61,
+	0x73, 0x73, 0x65, 0x74, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x52, 0x06, 0x62, 0x75, 0x79, 0x69,
+	0x6e, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2a, 0x0a, 0x05, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6c, 0x65, 0x64, 0x67,
+	0x65, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x52,
+	0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x49,
+	0x64, 0x22, 0xaa, 0x01, 0x0a, 0x16, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x61, 0x73, 0x73,
+	0x69, 0x76, 0x65, 0x53, 0x65, 0x6c, 0x6c, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x07,
+	0x73, 0x65, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e,
+	0x61, 0x73, 0x73, 0x65, 0x74, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x52, 0x07, 0x73, 0x65, 0x6c,
+	0x6c, 0x69, 0x6e, 0x67, 0x12, 0x24, 0x0a, 0x06, 0x62, 0x75, 0x79, 0x69, 0x6e, 0x67, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61, 0x73, 0x73, 0x65, 0x74, 0x2e, 0x41, 0x73, 0x73,
+	0x65, 0x74, 0x52, 0x06, 0x62, 0x75, 0x79, 0x69, 0x6e, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x2a, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72,
+	0x74, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x22, 0x32,
+	0x0a, 0x06, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x22, 0x9e, 0x04, 0x0a, 0x0a, 0x53, 0x65, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x38, 0x0a, 0x15, 0x69, 0x6e, 0x66, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x64,
+	0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x00, 0x52, 0x14, 0x69, 0x6e, 0x66, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73,
+	0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x24, 0x0a, 0x0b, 0x63,
+	0x6c, 0x65, 0x61, 0x72, 0x5f, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x48, 0x01, 0x52, 0x0a, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x88, 0x01,
+	0x01, 0x12, 0x20, 0x0a, 0x09, 0x73, 0x65, 0x74, 0x5f, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0d, 0x48, 0x02, 0x52, 0x08, 0x73, 0x65, 0x74, 0x46, 0x6c, 0x61, 0x67, 0x73,
+	0x88, 0x01, 0x01, 0x12, 0x28, 0x0a, 0x0d, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x77, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x03, 0x52, 0x0c, 0x6d, 0x61,
+	0x73, 0x74, 0x65, 0x72, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x88, 0x01, 0x01, 0x12, 0x28, 0x0a,
+	0x0d, 0x6c, 0x6f, 0x77, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0d, 0x48, 0x04, 0x52, 0x0c, 0x6c, 0x6f, 0x77, 0x54, 0x68, 0x72, 0x65, 0x73,
+	0x68, 0x6f, 0x6c, 0x64, 0x88, 0x01, 0x01, 0x12, 0x28, 0x0a, 0x0d, 0x6d, 0x65, 0x64, 0x5f, 0x74,
+	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x05,
+	0x52, 0x0c, 0x6d, 0x65, 0x64, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x88, 0x01,
+	0x01, 0x12, 0x2a, 0x0a, 0x0e, 0x68, 0x69, 0x67, 0x68, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68,
+	0x6f, 0x6c, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x06, 0x52, 0x0d, 0x68, 0x69, 0x67,
+	0x68, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x88, 0x01, 0x01, 0x12, 0x24, 0x0a,
+	0x0b, 0x68, 0x6f, 0x6d, 0x65, 0x5f, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x07, 0x52, 0x0a, 0x68, 0x6f, 0x6d, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
+	0x88, 0x01, 0x01, 0x12, 0x2d, 0x0a, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x52, 0x06, 0x73, 0x69, 0x67, 0x6e,
+	0x65, 0x72, 0x42, 0x18, 0x0a, 0x16, 0x5f, 0x69, 0x6e, 0x66, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x0e, 0x0a, 0x0c,
+	0x5f, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x5f, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x42, 0x0c, 0x0a, 0x0a,
+	0x5f, 0x73, 0x65, 0x74, 0x5f, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x6d,
+	0x61, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x42, 0x10, 0x0a, 0x0e,
+	0x5f, 0x6c, 0x6f, 0x77, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x42, 0x10,
+	0x0a, 0x0e, 0x5f, 0x6d, 0x65, 0x64, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64,
+	0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x68, 0x69, 0x67, 0x68, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68,
+	0x6f, 0x6c, 0x64, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x68, 0x6f, 0x6d, 0x65, 0x5f, 0x64, 0x6f, 0x6d,
+	0x61, 0x69, 0x6e, 0x22, 0x79, 0x0a, 0x17, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79,
+	0x50, 0x6f, 0x6f, 0x6c, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x25,
+	0x0a, 0x07, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0c, 0x2e, 0x61, 0x73, 0x73, 0x65, 0x74, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x52, 0x06, 0x61,
+	0x73, 0x73, 0x65, 0x74, 0x41, 0x12, 0x25, 0x0a, 0x07, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x62,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61, 0x73, 0x73, 0x65, 0x74, 0x2e, 0x41,
+	0x73, 0x73, 0x65, 0x74, 0x52, 0x06, 0x61, 0x73, 0x73, 0x65, 0x74, 0x42, 0x12, 0x10, 0x0a, 0x03,
+	0x66, 0x65, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x66, 0x65, 0x65, 0x22, 0xa2,
+	0x01, 0x0a, 0x0b, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x54, 0x72, 0x75, 0x73, 0x74, 0x12, 0x24,
+	0x0a, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e,
+	0x61, 0x73, 0x73, 0x65, 0x74, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x48, 0x00, 0x52, 0x05, 0x61,
+	0x73, 0x73, 0x65, 0x74, 0x12, 0x4f, 0x0a, 0x0e, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74,
+	0x79, 0x5f, 0x70, 0x6f, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x6c,
+	0x65, 0x64, 0x67, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x4c, 0x69, 0x71,
+	0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x6f, 0x6f, 0x6c, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65,
+	0x74, 0x65, 0x72, 0x73, 0x48, 0x00, 0x52, 0x0d, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74,
+	0x79, 0x50, 0x6f, 0x6f, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x42, 0x06, 0x0a, 0x04, 0x6c,
+	0x69, 0x6e, 0x65, 0x22, 0x63, 0x0a, 0x0a, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x54, 0x72, 0x75, 0x73,
+	0x74, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x72, 0x75, 0x73, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x74, 0x72, 0x75, 0x73, 0x74, 0x6f, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x61,
+	0x73, 0x73, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x61, 0x73, 0x73, 0x65, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x75,
+	0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x61,
+	0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x65, 0x22, 0x30, 0x0a, 0x0c, 0x41, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74,
+	0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64,
+	0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x0b, 0x0a, 0x09, 0x49, 0x6e,
+	0x66, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x45, 0x0a, 0x0a, 0x4d, 0x61, 0x6e, 0x61, 0x67,
+	0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x27,
+	0x0a, 0x0c, 0x42, 0x75, 0x6d, 0x70, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x17,
+	0x0a, 0x07, 0x62, 0x75, 0x6d, 0x70, 0x5f, 0x74, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x06, 0x62, 0x75, 0x6d, 0x70, 0x54, 0x6f, 0x22, 0xc4, 0x01, 0x0a, 0x0e, 0x4d, 0x61, 0x6e, 0x61,
+	0x67, 0x65, 0x42, 0x75, 0x79, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x07, 0x73, 0x65,
+	0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61, 0x73,
+	0x73, 0x65, 0x74, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x52, 0x07, 0x73, 0x65, 0x6c, 0x6c, 0x69,
+	0x6e, 0x67, 0x12, 0x24, 0x0a, 0x06, 0x62, 0x75, 0x79, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61, 0x73, 0x73, 0x65, 0x74, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74,
+	0x52, 0x06, 0x62, 0x75, 0x79, 0x69, 0x6e, 0x67, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x75, 0x79, 0x5f,
+	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x62, 0x75,
+	0x79, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2a, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x5f,
+	0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x52, 0x05, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x49, 0x64, 0x22, 0xf1,
+	0x01, 0x0a, 0x15, 0x50, 0x61, 0x74, 0x68, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74,
+	0x72, 0x69, 0x63, 0x74, 0x53, 0x65, 0x6e, 0x64, 0x12, 0x2b, 0x0a, 0x0a, 0x73, 0x65, 0x6e, 0x64,
+	0x5f, 0x61, 0x73, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61,
+	0x73, 0x73, 0x65, 0x74, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x52, 0x09, 0x73, 0x65, 0x6e, 0x64,
+	0x41, 0x73, 0x73, 0x65, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x6e, 0x64, 0x5f, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x65, 0x6e, 0x64,
+	0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73,
+	0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x0a, 0x64, 0x65, 0x73, 0x74,
+	0x5f, 0x61, 0x73, 0x73, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61,
+	0x73, 0x73, 0x65, 0x74, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x52, 0x09, 0x64, 0x65, 0x73, 0x74,
+	0x41, 0x73, 0x73, 0x65, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x64, 0x65, 0x73, 0x74, 0x5f, 0x6d, 0x69,
+	0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x64, 0x65, 0x73, 0x74, 0x4d, 0x69, 0x6e,
+	0x12, 0x20, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c,
+	0x2e, 0x61, 0x73, 0x73, 0x65, 0x74, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x52, 0x04, 0x70, 0x61,
+	0x74, 0x68, 0x22, 0x4a, 0x0a, 0x08, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x61, 0x6e, 0x74, 0x12, 0x20,
+	0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x22, 0x8b,
+	0x01, 0x0a, 0x16, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x61, 0x62,
+	0x6c, 0x65, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x61, 0x73, 0x73,
+	0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61, 0x73, 0x73, 0x65, 0x74,
+	0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x52, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x61,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x35, 0x0a, 0x09, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x61, 0x6e,
+	0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65,
+	0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x61, 0x6e,
+	0x74, 0x52, 0x09, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x61, 0x6e, 0x74, 0x73, 0x22, 0x36, 0x0a, 0x15,
+	0x43, 0x6c, 0x61, 0x69, 0x6d, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x61, 0x62, 0x6c, 0x65, 0x42, 0x61,
+	0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x61, 0x6c, 0x61, 0x6e,
+	0x63, 0x65, 0x49, 0x64, 0x22, 0x42, 0x0a, 0x1d, 0x42, 0x65, 0x67, 0x69, 0x6e, 0x53, 0x70, 0x6f,
+	0x6e, 0x73, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x46, 0x75, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x6f, 0x72,
+	0x65, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x6f, 0x72, 0x65, 0x64, 0x49, 0x64, 0x22, 0x1d, 0x0a, 0x1b, 0x45, 0x6e, 0x64, 0x53,
+	0x70, 0x6f, 0x6e, 0x73, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x46, 0x75, 0x74, 0x75, 0x72, 0x65, 0x52,
+	0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x73, 0x22, 0x57, 0x0a, 0x17, 0x52, 0x65, 0x76, 0x6f, 0x6b,
+	0x65, 0x53, 0x70, 0x6f, 0x6e, 0x73, 0x6f, 0x72, 0x73, 0x68, 0x69, 0x70, 0x53, 0x69, 0x67, 0x6e,
+	0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49,
+	0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x5f, 0x6b, 0x65, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x4b, 0x65, 0x79,
+	0x22, 0x80, 0x01, 0x0a, 0x11, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x53, 0x70, 0x6f, 0x6e, 0x73,
+	0x6f, 0x72, 0x73, 0x68, 0x69, 0x70, 0x12, 0x1f, 0x0a, 0x0a, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72,
+	0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x09, 0x6c, 0x65,
+	0x64, 0x67, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x12, 0x40, 0x0a, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x65,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72,
+	0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x53, 0x70,
+	0x6f, 0x6e, 0x73, 0x6f, 0x72, 0x73, 0x68, 0x69, 0x70, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x48,
+	0x00, 0x52, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x42, 0x08, 0x0a, 0x06, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x22, 0x5a, 0x0a, 0x08, 0x43, 0x6c, 0x61, 0x77, 0x62, 0x61, 0x63, 0x6b, 0x12,
+	0x22, 0x0a, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c,
+	0x2e, 0x61, 0x73, 0x73, 0x65, 0x74, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x52, 0x05, 0x61, 0x73,
+	0x73, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22,
+	0x39, 0x0a, 0x18, 0x43, 0x6c, 0x61, 0x77, 0x62, 0x61, 0x63, 0x6b, 0x43, 0x6c, 0x61, 0x69, 0x6d,
+	0x61, 0x62, 0x6c, 0x65, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x62,
+	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x22, 0x8f, 0x01, 0x0a, 0x11, 0x53,
+	0x65, 0x74, 0x54, 0x72, 0x75, 0x73, 0x74, 0x4c, 0x69, 0x6e, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x73,
+	0x12, 0x18, 0x0a, 0x07, 0x74, 0x72, 0x75, 0x73, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x74, 0x72, 0x75, 0x73, 0x74, 0x6f, 0x72, 0x12, 0x22, 0x0a, 0x05, 0x61, 0x73,
+	0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61, 0x73, 0x73, 0x65,
+	0x74, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x52, 0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x12, 0x1f,
+	0x0a, 0x0b, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x5f, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0a, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x12,
+	0x1b, 0x0a, 0x09, 0x73, 0x65, 0x74, 0x5f, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x08, 0x73, 0x65, 0x74, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x22, 0xec, 0x01, 0x0a,
+	0x14, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x6f, 0x6f, 0x6c, 0x44, 0x65,
+	0x70, 0x6f, 0x73, 0x69, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69,
+	0x74, 0x79, 0x5f, 0x70, 0x6f, 0x6f, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0f, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x6f, 0x6f, 0x6c, 0x49,
+	0x64, 0x12, 0x20, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f,
+	0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x41, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x41, 0x12, 0x20, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x5f, 0x62, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x41, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x42, 0x12, 0x31, 0x0a, 0x09, 0x6d, 0x69, 0x6e, 0x5f, 0x70, 0x72, 0x69,
+	0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6c, 0x65, 0x64, 0x67, 0x65,
+	0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x52, 0x08,
+	0x6d, 0x69, 0x6e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x31, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6c, 0x65,
+	0x64, 0x67, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x50, 0x72, 0x69, 0x63,
+	0x65, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x50, 0x72, 0x69, 0x63, 0x65, 0x22, 0x9f, 0x01, 0x0a, 0x15,
+	0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x6f, 0x6f, 0x6c, 0x57, 0x69, 0x74,
+	0x68, 0x64, 0x72, 0x61, 0x77, 0x12, 0x2a, 0x0a, 0x11, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69,
+	0x74, 0x79, 0x5f, 0x70, 0x6f, 0x6f, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0f, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x6f, 0x6f, 0x6c, 0x49,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0c, 0x6d, 0x69, 0x6e,
+	0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0a, 0x6d, 0x69, 0x6e, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x41, 0x12, 0x20, 0x0a, 0x0c, 0x6d,
+	0x69, 0x6e, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x62, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0a, 0x6d, 0x69, 0x6e, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x22, 0x4d, 0x0a,
+	0x12, 0x49, 0x6e, 0x76, 0x6f, 0x6b, 0x65, 0x48, 0x6f, 0x73, 0x74, 0x46, 0x75, 0x6e, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x66, 0x75, 0x6e, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x68, 0x6f, 0x73, 0x74,
+	0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x75, 0x74, 0x68,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x04, 0x61, 0x75, 0x74, 0x68, 0x22, 0x31, 0x0a, 0x12,
+	0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x46, 0x6f, 0x6f, 0x74, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x54,
+	0x74, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x6f, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x54, 0x6f, 0x22,
+	0x12, 0x0a, 0x10, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x46, 0x6f, 0x6f, 0x74, 0x70, 0x72,
+	0x69, 0x6e, 0x74, 0x2a, 0x8e, 0x01, 0x0a, 0x0a, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x1b, 0x0a, 0x17, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x5f, 0x54, 0x59, 0x50,
+	0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x17, 0x0a, 0x13, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43,
+	0x52, 0x45, 0x41, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x48, 0x41, 0x4e,
+	0x47, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x44, 0x10,
+	0x02, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x52, 0x45, 0x4d, 0x4f, 0x56, 0x45, 0x44, 0x10, 0x03, 0x12, 0x18, 0x0a, 0x14, 0x43, 0x48,
+	0x41, 0x4e, 0x47, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x52, 0x45, 0x53, 0x54, 0x4f, 0x52,
+	0x45, 0x44, 0x10, 0x04, 0x2a, 0xb5, 0x01, 0x0a, 0x0c, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x15, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x5f,
+	0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00,
+	0x12, 0x1b, 0x0a, 0x17, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f,
+	0x4e, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x01, 0x12, 0x1d, 0x0a,
+	0x19, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x54,
+	0x52, 0x41, 0x4e, 0x53, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x02, 0x12, 0x15, 0x0a, 0x11,
+	0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x46, 0x45,
+	0x45, 0x10, 0x03, 0x12, 0x1c, 0x0a, 0x18, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x5f, 0x52, 0x45,
+	0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x46, 0x45, 0x45, 0x5f, 0x52, 0x45, 0x46, 0x55, 0x4e, 0x44, 0x10,
+	0x04, 0x12, 0x19, 0x0a, 0x15, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53,
+	0x4f, 0x4e, 0x5f, 0x55, 0x50, 0x47, 0x52, 0x41, 0x44, 0x45, 0x10, 0x05, 0x2a, 0x87, 0x03, 0x0a,
+	0x0f, 0x4c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x21, 0x0a, 0x1d, 0x4c, 0x45, 0x44, 0x47, 0x45, 0x52, 0x5f, 0x45, 0x4e, 0x54, 0x52, 0x59,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x1d, 0x0a, 0x19, 0x4c, 0x45, 0x44, 0x47, 0x45, 0x52, 0x5f, 0x45, 0x4e,
+	0x54, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x41, 0x43, 0x43, 0x4f, 0x55, 0x4e, 0x54,
+	0x10, 0x01, 0x12, 0x1f, 0x0a, 0x1b, 0x4c, 0x45, 0x44, 0x47, 0x45, 0x52, 0x5f, 0x45, 0x4e, 0x54,
+	0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x54, 0x52, 0x55, 0x53, 0x54, 0x4c, 0x49, 0x4e,
+	0x45, 0x10, 0x02, 0x12, 0x1b, 0x0a, 0x17, 0x4c, 0x45, 0x44, 0x47, 0x45, 0x52, 0x5f, 0x45, 0x4e,
+	0x54, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4f, 0x46, 0x46, 0x45, 0x52, 0x10, 0x03,
+	0x12, 0x1a, 0x0a, 0x16, 0x4c, 0x45, 0x44, 0x47, 0x45, 0x52, 0x5f, 0x45, 0x4e, 0x54, 0x52, 0x59,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x44, 0x41, 0x54, 0x41, 0x10, 0x04, 0x12, 0x27, 0x0a, 0x23,
+	0x4c, 0x45, 0x44, 0x47, 0x45, 0x52, 0x5f, 0x45, 0x4e, 0x54, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50,
+	0x45, 0x5f, 0x43, 0x4c, 0x41, 0x49, 0x4d, 0x41, 0x42, 0x4c, 0x45, 0x5f, 0x42, 0x41, 0x4c, 0x41,
+	0x4e, 0x43, 0x45, 0x10, 0x05, 0x12, 0x24, 0x0a, 0x20, 0x4c, 0x45, 0x44, 0x47, 0x45, 0x52, 0x5f,
+	0x45, 0x4e, 0x54, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4c, 0x49, 0x51, 0x55, 0x49,
+	0x44, 0x49, 0x54, 0x59, 0x5f, 0x50, 0x4f, 0x4f, 0x4c, 0x10, 0x06, 0x12, 0x23, 0x0a, 0x1f, 0x4c,
+	0x45, 0x44, 0x47, 0x45, 0x52, 0x5f, 0x45, 0x4e, 0x54, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x41, 0x43, 0x54, 0x5f, 0x44, 0x41, 0x54, 0x41, 0x10, 0x07,
+	0x12, 0x23, 0x0a, 0x1f, 0x4c, 0x45, 0x44, 0x47, 0x45, 0x52, 0x5f, 0x45, 0x4e, 0x54, 0x52, 0x59,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x41, 0x43, 0x54, 0x5f, 0x43,
+	0x4f, 0x44, 0x45, 0x10, 0x08, 0x12, 0x24, 0x0a, 0x20, 0x4c, 0x45, 0x44, 0x47, 0x45, 0x52, 0x5f,
+	0x45, 0x4e, 0x54, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x49,
+	0x47, 0x5f, 0x53, 0x45, 0x54, 0x54, 0x49, 0x4e, 0x47, 0x10, 0x09, 0x12, 0x19, 0x0a, 0x15, 0x4c,
+	0x45, 0x44, 0x47, 0x45, 0x52, 0x5f, 0x45, 0x4e, 0x54, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x54, 0x54, 0x4c, 0x10, 0x0a, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_processors_ledger_export_ledger_export_proto_rawDescOnce sync.Once
+	file_processors_ledger_export_ledger_export_proto_rawDescData = file_processors_ledger_export_ledger_export_proto_rawDesc
+)
+
+func file_processors_ledger_export_ledger_export_proto_rawDescGZIP() []byte {
+	file_processors_ledger_export_ledger_export_proto_rawDescOnce.Do(func() {
+		file_processors_ledger_export_ledger_export_proto_rawDescData = protoimpl.X.CompressGZIP(file_processors_ledger_export_ledger_export_proto_rawDescData)
+	})
+	return file_processors_ledger_export_ledger_export_proto_rawDescData
+}
+
+var file_processors_ledger_export_ledger_export_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_processors_ledger_export_ledger_export_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
+var file_processors_ledger_export_ledger_export_proto_goTypes = []any{
+	(ChangeType)(0),                       // 0: ledger_export.ChangeType
+	(ChangeReason)(0),                     // 1: ledger_export.ChangeReason
+	(LedgerEntryType)(0),                  // 2: ledger_export.LedgerEntryType
+	(*LedgerCloseMeta)(nil),               // 3: ledger_export.LedgerCloseMeta
+	(*LedgerTransaction)(nil),             // 4: ledger_export.LedgerTransaction
+	(*Memo)(nil),                          // 5: ledger_export.Memo
+	(*Change)(nil),                        // 6: ledger_export.Change
+	(*Operation)(nil),                     // 7: ledger_export.Operation
+	(*Price)(nil),                         // 8: ledger_export.Price
+	(*CreateAccount)(nil),                 // 9: ledger_export.CreateAccount
+	(*Payment)(nil),                       // 10: ledger_export.Payment
+	(*PathPaymentStrictReceive)(nil),      // 11: ledger_export.PathPaymentStrictReceive
+	(*ManageSellOffer)(nil),               // 12: ledger_export.ManageSellOffer
+	(*CreatePassiveSellOffer)(nil),        // 13: ledger_export.CreatePassiveSellOffer
+	(*Signer)(nil),                        // 14: ledger_export.Signer
+	(*SetOptions)(nil),                    // 15: ledger_export.SetOptions
+	(*LiquidityPoolParameters)(nil),       // 16: ledger_export.LiquidityPoolParameters
+	(*ChangeTrust)(nil),                   // 17: ledger_export.ChangeTrust
+	(*AllowTrust)(nil),                    // 18: ledger_export.AllowTrust
+	(*AccountMerge)(nil),                  // 19: ledger_export.AccountMerge
+	(*Inflation)(nil),                     // 20: ledger_export.Inflation
+	(*ManageData)(nil),                    // 21: ledger_export.ManageData
+	(*BumpSequence)(nil),                  // 22: ledger_export.BumpSequence
+	(*ManageBuyOffer)(nil),                // 23: ledger_export.ManageBuyOffer
+	(*PathPaymentStrictSend)(nil),         // 24: ledger_export.PathPaymentStrictSend
+	(*Claimant)(nil),                      // 25: ledger_export.Claimant
+	(*CreateClaimableBalance)(nil),        // 26: ledger_export.CreateClaimableBalance
+	(*ClaimClaimableBalance)(nil),         // 27: ledger_export.ClaimClaimableBalance
+	(*BeginSponsoringFutureReserves)(nil), // 28: ledger_export.BeginSponsoringFutureReserves
+	(*EndSponsoringFutureReserves)(nil),   // 29: ledger_export.EndSponsoringFutureReserves
+	(*RevokeSponsorshipSigner)(nil),       // 30: ledger_export.RevokeSponsorshipSigner
+	(*RevokeSponsorship)(nil),             // 31: ledger_export.RevokeSponsorship
+	(*Clawback)(nil),                      // 32: ledger_export.Clawback
+	(*ClawbackClaimableBalance)(nil),      // 33: ledger_export.ClawbackClaimableBalance
+	(*SetTrustLineFlags)(nil),             // 34: ledger_export.SetTrustLineFlags
+	(*LiquidityPoolDeposit)(nil),          // 35: ledger_export.LiquidityPoolDeposit
+	(*LiquidityPoolWithdraw)(nil),         // 36: ledger_export.LiquidityPoolWithdraw
+	(*InvokeHostFunction)(nil),            // 37: ledger_export.InvokeHostFunction
+	(*ExtendFootprintTtl)(nil),            // 38: ledger_export.ExtendFootprintTtl
+	(*RestoreFootprint)(nil),              // 39: ledger_export.RestoreFootprint
+	(*timestamppb.Timestamp)(nil),         // 40: google.protobuf.Timestamp
+	(*asset.Asset)(nil),                   // 41: asset.Asset
+}
+var file_processors_ledger_export_ledger_export_proto_depIdxs = []int32{
+	40, // 0: ledger_export.LedgerCloseMeta.closed_at:type_name -> google.protobuf.Timestamp
+	4,  // 1: ledger_export.LedgerCloseMeta.transactions:type_name -> ledger_export.LedgerTransaction
+	6,  // 2: ledger_export.LedgerCloseMeta.upgrade_changes:type_name -> ledger_export.Change
+	5,  // 3: ledger_export.LedgerTransaction.memo:type_name -> ledger_export.Memo
+	7,  // 4: ledger_export.LedgerTransaction.operations:type_name -> ledger_export.Operation
+	6,  // 5: ledger_export.LedgerTransaction.fee_changes:type_name -> ledger_export.Change
+	6,  // 6: ledger_export.LedgerTransaction.changes:type_name -> ledger_export.Change
+	0,  // 7: ledger_export.Change.change_type:type_name -> ledger_export.ChangeType
+	1,  // 8: ledger_export.Change.reason:type_name -> ledger_export.ChangeReason
+	2,  // 9: ledger_export.Change.entry_type:type_name -> ledger_export.LedgerEntryType
+	9,  // 10: ledger_export.Operation.create_account:type_name -> ledger_export.CreateAccount
+	10, // 11: ledger_export.Operation.payment:type_name -> ledger_export.Payment
+	11, // 12: ledger_export.Operation.path_payment_strict_receive:type_name -> ledger_export.PathPaymentStrictReceive
+	12, // 13: ledger_export.Operation.manage_sell_offer:type_name -> ledger_export.ManageSellOffer
+	13, // 14: ledger_export.Operation.create_passive_sell_offer:type_name -> ledger_export.CreatePassiveSellOffer
+	15, // 15: ledger_export.Operation.set_options:type_name -> ledger_export.SetOptions
+	17, // 16: ledger_export.Operation.change_trust:type_name -> ledger_export.ChangeTrust
+	18, // 17: ledger_export.Operation.allow_trust:type_name -> ledger_export.AllowTrust
+	19, // 18: ledger_export.Operation.account_merge:type_name -> ledger_export.AccountMerge
+	20, // 19: ledger_export.Operation.inflation:type_name -> ledger_export.Inflation
+	21, // 20: ledger_export.Operation.manage_data:type_name -> ledger_export.ManageData
+	22, // 21: ledger_export.Operation.bump_sequence:type_name -> ledger_export.BumpSequence
+	23, // 22: ledger_export.Operation.manage_buy_offer:type_name -> ledger_export.ManageBuyOffer
+	24, // 23: ledger_export.Operation.path_payment_strict_send:type_name -> ledger_export.PathPaymentStrictSend
+	26, // 24: ledger_export.Operation.create_claimable_balance:type_name -> ledger_export.CreateClaimableBalance
+	27, // 25: ledger_export.Operation.claim_claimable_balance:type_name -> ledger_export.ClaimClaimableBalance
+	28, // 26: ledger_export.Operation.begin_sponsoring_future_reserves:type_name -> ledger_export.BeginSponsoringFutureReserves
+	29, // 27: ledger_export.Operation.end_sponsoring_future_reserves:type_name -> ledger_export.EndSponsoringFutureReserves
+	31, // 28: ledger_export.Operation.revoke_sponsorship:type_name -> ledger_export.RevokeSponsorship
+	32, // 29: ledger_export.Operation.clawback:type_name -> ledger_export.Clawback
+	33, // 30: ledger_export.Operation.clawback_claimable_balance:type_name -> ledger_export.ClawbackClaimableBalance
+	34, // 31: ledger_export.Operation.set_trust_line_flags:type_name -> ledger_export.SetTrustLineFlags
+	35, // 32: ledger_export.Operation.liquidity_pool_deposit:type_name -> ledger_export.LiquidityPoolDeposit
+	36, // 33: ledger_export.Operation.liquidity_pool_withdraw:type_name -> ledger_export.LiquidityPoolWithdraw
+	37, // 34: ledger_export.Operation.invoke_host_function:type_name -> ledger_export.InvokeHostFunction
+	38, // 35: ledger_export.Operation.extend_footprint_ttl:type_name -> ledger_export.ExtendFootprintTtl
+	39, // 36: ledger_export.Operation.restore_footprint:type_name -> ledger_export.RestoreFootprint
+	41, // 37: ledger_export.Payment.asset:type_name -> asset.Asset
+	41, // 38: ledger_export.PathPaymentStrictReceive.send_asset:type_name -> asset.Asset
+	41, // 39: ledger_export.PathPaymentStrictReceive.dest_asset:type_name -> asset.Asset
+	41, // 40: ledger_export.PathPaymentStrictReceive.path:type_name -> asset.Asset
+	41, // 41: ledger_export.ManageSellOffer.selling:type_name -> asset.Asset
+	41, // 42: ledger_export.ManageSellOffer.buying:type_name -> asset.Asset
+	8,  // 43: ledger_export.ManageSellOffer.price:type_name -> ledger_export.Price
+	41, // 44: ledger_export.CreatePassiveSellOffer.selling:type_name -> asset.Asset
+	41, // 45: ledger_export.CreatePassiveSellOffer.buying:type_name -> asset.Asset
+	8,  // 46: ledger_export.CreatePassiveSellOffer.price:type_name -> ledger_export.Price
+	14, // 47: ledger_export.SetOptions.signer:type_name -> ledger_export.Signer
+	41, // 48: ledger_export.LiquidityPoolParameters.asset_a:type_name -> asset.Asset
+	41, // 49: ledger_export.LiquidityPoolParameters.asset_b:type_name -> asset.Asset
+	41, // 50: ledger_export.ChangeTrust.asset:type_name -> asset.Asset
+	16, // 51: ledger_export.ChangeTrust.liquidity_pool:type_name -> ledger_export.LiquidityPoolParameters
+	41, // 52: ledger_export.ManageBuyOffer.selling:type_name -> asset.Asset
+	41, // 53: ledger_export.ManageBuyOffer.buying:type_name -> asset.Asset
+	8,  // 54: ledger_export.ManageBuyOffer.price:type_name -> ledger_export.Price
+	41, // 55: ledger_export.PathPaymentStrictSend.send_asset:type_name -> asset.Asset
+	41, // 56: ledger_export.PathPaymentStrictSend.dest_asset:type_name -> asset.Asset
+	41, // 57: ledger_export.PathPaymentStrictSend.path:type_name -> asset.Asset
+	41, // 58: ledger_export.CreateClaimableBalance.asset:type_name -> asset.Asset
+	25, // 59: ledger_export.CreateClaimableBalance.claimants:type_name -> ledger_export.Claimant
+	30, // 60: ledger_export.RevokeSponsorship.signer:type_name -> ledger_export.RevokeSponsorshipSigner
+	41, // 61: ledger_export.Clawback.asset:type_name -> asset.Asset
+	41, // 62: ledger_export.SetTrustLineFlags.asset:type_name -> asset.Asset
+	8,  // 63: ledger_export.LiquidityPoolDeposit.min_price:type_name -> ledger_export.Price
+	8,  // 64: ledger_export.LiquidityPoolDeposit.max_price:type_name -> ledger_export.Price
+	65, // [65:65] is the sub-list for method output_type
+	65, // [65:65] is the sub-list for method input_type
+	65, // [65:65] is the sub-list for extension type_name
+	65, // [65:65] is the sub-list for extension extendee
+	0,  // [0:65] is the sub-list for field type_name
+}
+
+func init() { file_processors_ledger_export_ledger_export_proto_init() }
+func file_processors_ledger_export_ledger_export_proto_init() {
+	if File_processors_ledger_export_ledger_export_proto != nil {
+		return
+	}
+	file_processors_ledger_export_ledger_export_proto_msgTypes[2].OneofWrappers = []any{
+		(*Memo_Text)(nil),
+		(*Memo_Id)(nil),
+		(*Memo_Hash)(nil),
+		(*Memo_ReturnHash)(nil),
+	}
+	file_processors_ledger_export_ledger_export_proto_msgTypes[3].OneofWrappers = []any{}
+	file_processors_ledger_export_ledger_export_proto_msgTypes[4].OneofWrappers = []any{
+		(*Operation_CreateAccount)(nil),
+		(*Operation_Payment)(nil),
+		(*Operation_PathPaymentStrictReceive)(nil),
+		(*Operation_ManageSellOffer)(nil),
+		(*Operation_CreatePassiveSellOffer)(nil),
+		(*Operation_SetOptions)(nil),
+		(*Operation_ChangeTrust)(nil),
+		(*Operation_AllowTrust)(nil),
+		(*Operation_AccountMerge)(nil),
+		(*Operation_Inflation)(nil),
+		(*Operation_ManageData)(nil),
+		(*Operation_BumpSequence)(nil),
+		(*Operation_ManageBuyOffer)(nil),
+		(*Operation_PathPaymentStrictSend)(nil),
+		(*Operation_CreateClaimableBalance)(nil),
+		(*Operation_ClaimClaimableBalance)(nil),
+		(*Operation_BeginSponsoringFutureReserves)(nil),
+		(*Operation_EndSponsoringFutureReserves)(nil),
+		(*Operation_RevokeSponsorship)(nil),
+		(*Operation_Clawback)(nil),
+		(*Operation_ClawbackClaimableBalance)(nil),
+		(*Operation_SetTrustLineFlags)(nil),
+		(*Operation_LiquidityPoolDeposit)(nil),
+		(*Operation_LiquidityPoolWithdraw)(nil),
+		(*Operation_InvokeHostFunction)(nil),
+		(*Operation_ExtendFootprintTtl)(nil),
+		(*Operation_RestoreFootprint)(nil),
+	}
+	file_processors_ledger_export_ledger_export_proto_msgTypes[12].OneofWrappers = []any{}
+	file_processors_ledger_export_ledger_export_proto_msgTypes[14].OneofWrappers = []any{
+		(*ChangeTrust_Asset)(nil),
+		(*ChangeTrust_LiquidityPool)(nil),
+	}
+	file_processors_ledger_export_ledger_export_proto_msgTypes[18].OneofWrappers = []any{}
+	file_processors_ledger_export_ledger_export_proto_msgTypes[28].OneofWrappers = []any{
+		(*RevokeSponsorship_LedgerKey)(nil),
+		(*RevokeSponsorship_Signer)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_processors_ledger_export_ledger_export_proto_rawDesc,
+			NumEnums:      3,
+			NumMessages:   37,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_processors_ledger_export_ledger_export_proto_goTypes,
+		DependencyIndexes: file_processors_ledger_export_ledger_export_proto_depIdxs,
+		EnumInfos:         file_processors_ledger_export_ledger_export_proto_enumTypes,
+		MessageInfos:      file_processors_ledger_export_ledger_export_proto_msgTypes,
+	}.Build()
+	File_processors_ledger_export_ledger_export_proto = out.File
+	file_processors_ledger_export_ledger_export_proto_rawDesc = nil
+	file_processors_ledger_export_ledger_export_proto_goTypes = nil
+	file_processors_ledger_export_ledger_export_proto_depIdxs = nil
+}
@@ -0,0 +1,626 @@
+package ledger_export
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/stellar/go/ingest"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/xdr"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+const (
+	sourceAccount      = "GBXGQJWVLWOYHFLVTKWV5FGHA3LNYY2JQKM7OAJAUEQFU6LPCSEFVXON"
+	destinationAccount = "GC3C4AKRBQLHOJ45U4XG35ESVWRDECWO5XLDGYADO6DPR3L7KIDVUMML"
+	issuerAccount      = "GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU"
+)
+
+var (
+	usd = xdr.MustNewCreditAsset("USD", issuerAccount)
+	eur = xdr.MustNewCreditAsset("EURO12345", issuerAccount)
+)
+
+func mustOperation(t *testing.T, opType xdr.OperationType, value interface{}) xdr.Operation {
+	body, err := xdr.NewOperationBody(opType, value)
+	require.NoError(t, err)
+	return xdr.Operation{Body: body}
+}
+
+// allOperations returns an operation of each type.
+func allOperations(t *testing.T) []xdr.Operation {
+	muxedDestination := xdr.MuxedAccount{
+		Type: xdr.CryptoKeyTypeKeyTypeMuxedEd25519,
+		Med25519: &xdr.MuxedAccountMed25519{
+			Id:      42,
+			Ed25519: *xdr.MustAddress(destinationAccount).Ed25519,
+		},
+	}
+	destination := xdr.MustAddress(destinationAccount)
+	inflationDestination := xdr.MustAddress(issuerAccount)
+	setFlags := xdr.Uint32(xdr.AccountFlagsAuthRequiredFlag)
+	homeDomain := xdr.String32("example.com")
+	dataValue := xdr.DataValue("value")
+	accountKey := xdr.LedgerKey{
+		Type:    xdr.LedgerEntryTypeAccount,
+		Account: &xdr.LedgerKeyAccount{AccountId: destination},
+	}
+	balanceID := xdr.ClaimableBalanceId{
+		Type: xdr.ClaimableBalanceIdTypeClaimableBalanceIdTypeV0,
+		V0:   &xdr.Hash{1, 2, 3},
+	}
+	poolID := xdr.PoolId{4, 5, 6}
+	amount := xdr.Uint32(100)
+	contract := xdr.ContractId{7, 8, 9}
+
+	payment := mustOperation(t, xdr.OperationTypePayment, xdr.PaymentOp{
+		Destination: muxedDestination,
+		Asset:       usd,
+		Amount:      100_0000000,
+	})
+	// the source account of an operation overrides the one of its transaction
+	payment.SourceAccount = xdr.MustMuxedAddressPtr(issuerAccount)
+
+	return []xdr.Operation{
+		mustOperation(t, xdr.OperationTypeCreateAccount, xdr.CreateAccountOp{
+			Destination:     destination,
+			StartingBalance: 10_0000000,
+		}),
+		payment,
+		mustOperation(t, xdr.OperationTypePathPaymentStrictReceive, xdr.PathPaymentStrictReceiveOp{
+			SendAsset:   xdr.MustNewNativeAsset(),
+			SendMax:     50_0000000,
+			Destination: muxedDestination,
+			DestAsset:   usd,
+			DestAmount:  10_0000000,
+			Path:        []xdr.Asset{eur},
+		}),
+		mustOperation(t, xdr.OperationTypeManageSellOffer, xdr.ManageSellOfferOp{
+			Selling: xdr.MustNewNativeAsset(),
+			Buying:  usd,
+			Amount:  20_0000000,
+			Price:   xdr.Price{N: 1, D: 2},
+			OfferId: 12,
+		}),
+		mustOperation(t, xdr.OperationTypeCreatePassiveSellOffer, xdr.CreatePassiveSellOfferOp{
+			Selling: usd,
+			Buying:  eur,
+			Amount:  30_0000000,
+			Price:   xdr.Price{N: 3, D: 4},
+		}),
+		mustOperation(t, xdr.OperationTypeSetOptions, xdr.SetOptionsOp{
+			InflationDest: &inflationDestination,
+			SetFlags:      &setFlags,
+			HomeDomain:    &homeDomain,
+			Signer: &xdr.Signer{
+				Key: xdr.SignerKey{
+					Type:    xdr.SignerKeyTypeSignerKeyTypeEd25519,
+					Ed25519: &xdr.Uint256{1},
+				},
+				Weight: 5,
+			},
+		}),
+		mustOperation(t, xdr.OperationTypeChangeTrust, xdr.ChangeTrustOp{
+			Line:  usd.ToChangeTrustAsset(),
+			Limit: 1000_0000000,
+		}),
+		mustOperation(t, xdr.OperationTypeChangeTrust, xdr.ChangeTrustOp{
+			Line: xdr.ChangeTrustAsset{
+				Type: xdr.AssetTypeAssetTypePoolShare,
+				LiquidityPool: &xdr.LiquidityPoolParameters{
+					Type: xdr.LiquidityPoolTypeLiquidityPoolConstantProduct,
+					ConstantProduct: &xdr.LiquidityPoolConstantProductParameters{
+						AssetA: xdr.MustNewNativeAsset(),
+						AssetB: usd,
+						Fee:    xdr.LiquidityPoolFeeV18,
+					},
+				},
+			},
+			Limit: 500_0000000,
+		}),
+		mustOperation(t, xdr.OperationTypeAllowTrust, xdr.AllowTrustOp{
+			Trustor: destination,
+			Asset: xdr.AssetCode{
+				Type:       xdr.AssetTypeAssetTypeCreditAlphanum4,
+				AssetCode4: &xdr.AssetCode4{'U', 'S', 'D'},
+			},
+			Authorize: xdr.Uint32(xdr.TrustLineFlagsAuthorizedFlag),
+		}),
+		mustOperation(t, xdr.OperationTypeAccountMerge, muxedDestination),
+		mustOperation(t, xdr.OperationTypeInflation, nil),
+		mustOperation(t, xdr.OperationTypeManageData, xdr.ManageDataOp{
+			DataName:  "name",
+			DataValue: &dataValue,
+		}),
+		mustOperation(t, xdr.OperationTypeBumpSequence, xdr.BumpSequenceOp{BumpTo: 1000}),
+		mustOperation(t, xdr.OperationTypeManageBuyOffer, xdr.ManageBuyOfferOp{
+			Selling:   usd,
+			Buying:    xdr.MustNewNativeAsset(),
+			BuyAmount: 40_0000000,
+			Price:     xdr.Price{N: 5, D: 6},
+		}),
+		mustOperation(t, xdr.OperationTypePathPaymentStrictSend, xdr.PathPaymentStrictSendOp{
+			SendAsset:   usd,
+			SendAmount:  10_0000000,
+			Destination: muxedDestination,
+			DestAsset:   xdr.MustNewNativeAsset(),
+			DestMin:     5_0000000,
+		}),
+		mustOperation(t, xdr.OperationTypeCreateClaimableBalance, xdr.CreateClaimableBalanceOp{
+			Asset:  usd,
+			Amount: 60_0000000,
+			Claimants: []xdr.Claimant{{
+				Type: xdr.ClaimantTypeClaimantTypeV0,
+				V0: &xdr.ClaimantV0{
+					Destination: destination,
+					Predicate:   xdr.ClaimPredicate{Type: xdr.ClaimPredicateTypeClaimPredicateUnconditional},
+				},
+			}},
+		}),
+		mustOperation(t, xdr.OperationTypeClaimClaimableBalance, xdr.ClaimClaimableBalanceOp{BalanceId: balanceID}),
+		mustOperation(t, xdr.OperationTypeBeginSponsoringFutureReserves, xdr.BeginSponsoringFutureReservesOp{
+			SponsoredId: destination,
+		}),
+		mustOperation(t, xdr.OperationTypeEndSponsoringFutureReserves, nil),
+		mustOperation(t, xdr.OperationTypeRevokeSponsorship, xdr.RevokeSponsorshipOp{
+			Type:      xdr.RevokeSponsorshipTypeRevokeSponsorshipLedgerEntry,
+			LedgerKey: &accountKey,
+		}),
+		mustOperation(t, xdr.OperationTypeRevokeSponsorship, xdr.RevokeSponsorshipOp{
+			Type: xdr.RevokeSponsorshipTypeRevokeSponsorshipSigner,
+			Signer: &xdr.RevokeSponsorshipOpSigner{
+				AccountId: destination,
+				SignerKey: xdr.SignerKey{
+					Type:    xdr.SignerKeyTypeSignerKeyTypeEd25519,
+					Ed25519: &xdr.Uint256{2},
+				},
+			},
+		}),
+		mustOperation(t, xdr.OperationTypeClawback, xdr.ClawbackOp{
+			Asset:  usd,
+			From:   muxedDestination,
+			Amount: 70_0000000,
+		}),
+		mustOperation(t, xdr.OperationTypeClawbackClaimableBalance, xdr.ClawbackClaimableBalanceOp{BalanceId: balanceID}),
+		mustOperation(t, xdr.OperationTypeSetTrustLineFlags, xdr.SetTrustLineFlagsOp{
+			Trustor:    destination,
+			Asset:      usd,
+			ClearFlags: xdr.Uint32(xdr.TrustLineFlagsAuthorizedFlag),
+			SetFlags:   xdr.Uint32(xdr.TrustLineFlagsAuthorizedToMaintainLiabilitiesFlag),
+		}),
+		mustOperation(t, xdr.OperationTypeLiquidityPoolDeposit, xdr.LiquidityPoolDepositOp{
+			LiquidityPoolId: poolID,
+			MaxAmountA:      80_0000000,
+			MaxAmountB:      90_0000000,
+			MinPrice:        xdr.Price{N: 1, D: 3},
+			MaxPrice:        xdr.Price{N: 3, D: 1},
+		}),
+		mustOperation(t, xdr.OperationTypeLiquidityPoolWithdraw, xdr.LiquidityPoolWithdrawOp{
+			LiquidityPoolId: poolID,
+			Amount:          10_0000000,
+			MinAmountA:      1_0000000,
+			MinAmountB:      2_0000000,
+		}),
+		mustOperation(t, xdr.OperationTypeInvokeHostFunction, xdr.InvokeHostFunctionOp{
+			HostFunction: xdr.HostFunction{
+				Type: xdr.HostFunctionTypeHostFunctionTypeInvokeContract,
+				InvokeContract: &xdr.InvokeContractArgs{
+					ContractAddress: xdr.ScAddress{
+						Type:       xdr.ScAddressTypeScAddressTypeContract,
+						ContractId: &contract,
+					},
+					FunctionName: "transfer",
+					Args:         []xdr.ScVal{{Type: xdr.ScValTypeScvU32, U32: &amount}},
+				},
+			},
+		}),
+		mustOperation(t, xdr.OperationTypeExtendFootprintTtl, xdr.ExtendFootprintTtlOp{ExtendTo: 1000}),
+		mustOperation(t, xdr.OperationTypeRestoreFootprint, xdr.RestoreFootprintOp{}),
+	}
+}
+
+func transactionEnvelope(sequence xdr.SequenceNumber, memo xdr.Memo, operations ...xdr.Operation) xdr.TransactionEnvelope {
+	return xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1: &xdr.TransactionV1Envelope{
+			Tx: xdr.Transaction{
+				SourceAccount: xdr.MustMuxedAddress(sourceAccount),
+				Fee:           xdr.Uint32(100 * len(operations)),
+				SeqNum:        sequence,
+				Cond:          xdr.Preconditions{Type: xdr.PreconditionTypePrecondNone},
+				Memo:          memo,
+				Operations:    operations,
+			},
+		},
+	}
+}
+
+func transactionHash(t *testing.T, envelope xdr.TransactionEnvelope) xdr.Hash {
+	hash, err := network.HashTransactionInEnvelope(envelope, network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	return hash
+}
+
+func accountEntry(address string, balance xdr.Int64, sequence xdr.SequenceNumber) xdr.LedgerEntry {
+	return xdr.LedgerEntry{
+		LastModifiedLedgerSeq: 10,
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeAccount,
+			Account: &xdr.AccountEntry{
+				AccountId:  xdr.MustAddress(address),
+				Balance:    balance,
+				SeqNum:     sequence,
+				Thresholds: xdr.Thresholds{1, 0, 0, 0},
+			},
+		},
+	}
+}
+
+func updatedEntry(pre, post xdr.LedgerEntry) xdr.LedgerEntryChanges {
+	return xdr.LedgerEntryChanges{
+		{Type: xdr.LedgerEntryChangeTypeLedgerEntryState, State: &pre},
+		{Type: xdr.LedgerEntryChangeTypeLedgerEntryUpdated, Updated: &post},
+	}
+}
+
+func ledgerHeader(sequence, protocolVersion uint32) xdr.LedgerHeaderHistoryEntry {
+	return xdr.LedgerHeaderHistoryEntry{
+		Hash: xdr.Hash{byte(sequence), 1},
+		Header: xdr.LedgerHeader{
+			LedgerVersion:      xdr.Uint32(protocolVersion),
+			PreviousLedgerHash: xdr.Hash{byte(sequence - 1), 1},
+			ScpValue:           xdr.StellarValue{CloseTime: xdr.TimePoint(1700000000 + sequence*5)},
+			BucketListHash:     xdr.Hash{byte(sequence), 2},
+			LedgerSeq:          xdr.Uint32(sequence),
+			TotalCoins:         1000000000000000000,
+			FeePool:            1234567,
+			BaseFee:            100,
+			BaseReserve:        5000000,
+			MaxTxSetSize:       1000,
+		},
+	}
+}
+
+func evictedKey(t *testing.T) xdr.LedgerKey {
+	contract := xdr.ContractId{10}
+	sym := xdr.ScSymbol("counter")
+	entry := xdr.LedgerEntry{
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeContractData,
+			ContractData: &xdr.ContractDataEntry{
+				Contract:   xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &contract},
+				Key:        xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym},
+				Durability: xdr.ContractDataDurabilityTemporary,
+				Val:        xdr.ScVal{Type: xdr.ScValTypeScvVoid},
+			},
+		},
+	}
+	key, err := entry.LedgerKey()
+	require.NoError(t, err)
+	return key
+}
+
+// ledgerV0 contains a successful payment and a base fee upgrade.
+func ledgerV0(t *testing.T) xdr.LedgerCloseMeta {
+	envelope := transactionEnvelope(101, xdr.MemoID(7), xdr.Operation{
+		Body: xdr.OperationBody{
+			Type: xdr.OperationTypePayment,
+			PaymentOp: &xdr.PaymentOp{
+				Destination: xdr.MustMuxedAddress(destinationAccount),
+				Asset:       xdr.MustNewNativeAsset(),
+				Amount:      25_0000000,
+			},
+		},
+	})
+	results := []xdr.OperationResult{{
+		Code: xdr.OperationResultCodeOpInner,
+		Tr: &xdr.OperationResultTr{
+			Type:          xdr.OperationTypePayment,
+			PaymentResult: &xdr.PaymentResult{Code: xdr.PaymentResultCodePaymentSuccess},
+		},
+	}}
+
+	newBaseFee := xdr.Uint32(200)
+
+	return xdr.LedgerCloseMeta{
+		V: 0,
+		V0: &xdr.LedgerCloseMetaV0{
+			LedgerHeader: ledgerHeader(30, 19),
+			TxSet:        xdr.TransactionSet{Txs: []xdr.TransactionEnvelope{envelope}},
+			TxProcessing: []xdr.TransactionResultMeta{{
+				Result: xdr.TransactionResultPair{
+					TransactionHash: transactionHash(t, envelope),
+					Result: xdr.TransactionResult{
+						FeeCharged: 100,
+						Result: xdr.TransactionResultResult{
+							Code:    xdr.TransactionResultCodeTxSuccess,
+							Results: &results,
+						},
+					},
+				},
+				FeeProcessing: updatedEntry(
+					accountEntry(sourceAccount, 100_0000000, 100),
+					accountEntry(sourceAccount, 100_0000000-100, 100),
+				),
+				TxApplyProcessing: xdr.TransactionMeta{
+					V: 1,
+					V1: &xdr.TransactionMetaV1{
+						TxChanges: updatedEntry(
+							accountEntry(sourceAccount, 100_0000000-100, 100),
+							accountEntry(sourceAccount, 100_0000000-100, 101),
+						),
+						Operations: []xdr.OperationMeta{{
+							Changes: append(
+								updatedEntry(
+									accountEntry(sourceAccount, 100_0000000-100, 101),
+									accountEntry(sourceAccount, 75_0000000-100, 101),
+								),
+								updatedEntry(
+									accountEntry(destinationAccount, 10_0000000, 5),
+									accountEntry(destinationAccount, 35_0000000, 5),
+								)...,
+							),
+						}},
+					},
+				},
+			}},
+			UpgradesProcessing: []xdr.UpgradeEntryMeta{{
+				Upgrade: xdr.LedgerUpgrade{
+					Type:       xdr.LedgerUpgradeTypeLedgerUpgradeBaseFee,
+					NewBaseFee: &newBaseFee,
+				},
+				Changes: updatedEntry(
+					accountEntry(issuerAccount, 1_0000000, 1),
+					accountEntry(issuerAccount, 2_0000000, 1),
+				),
+			}},
+		},
+	}
+}
+
+// ledgerV1 contains a transaction with an operation of each type, which
+// failed before applying them, and an eviction.
+func ledgerV1(t *testing.T) xdr.LedgerCloseMeta {
+	envelope := transactionEnvelope(102, xdr.MemoText("all operations"), allOperations(t)...)
+	baseFee := xdr.Int64(100)
+
+	return xdr.LedgerCloseMeta{
+		V: 1,
+		V1: &xdr.LedgerCloseMetaV1{
+			LedgerHeader: ledgerHeader(31, 22),
+			TxSet: xdr.GeneralizedTransactionSet{
+				V: 1,
+				V1TxSet: &xdr.TransactionSetV1{
+					Phases: []xdr.TransactionPhase{{
+						V: 0,
+						V0Components: &[]xdr.TxSetComponent{{
+							Type: xdr.TxSetComponentTypeTxsetCompTxsMaybeDiscountedFee,
+							TxsMaybeDiscountedFee: &xdr.TxSetComponentTxsMaybeDiscountedFee{
+								BaseFee: &baseFee,
+								Txs:     []xdr.TransactionEnvelope{envelope},
+							},
+						}},
+					}},
+				},
+			},
+			TxProcessing: []xdr.TransactionResultMeta{{
+				Result: xdr.TransactionResultPair{
+					TransactionHash: transactionHash(t, envelope),
+					Result: xdr.TransactionResult{
+						FeeCharged: 2800,
+						Result:     xdr.TransactionResultResult{Code: xdr.TransactionResultCodeTxBadSeq},
+					},
+				},
+				FeeProcessing: updatedEntry(
+					accountEntry(sourceAccount, 100_0000000, 101),
+					accountEntry(sourceAccount, 100_0000000-2800, 101),
+				),
+				TxApplyProcessing: xdr.TransactionMeta{V: 2, V2: &xdr.TransactionMetaV2{}},
+			}},
+			EvictedKeys: []xdr.LedgerKey{evictedKey(t)},
+		},
+	}
+}
+
+// ledgerV2 contains a successful fee bump transaction invoking a contract,
+// which was refunded part of its fee.
+func ledgerV2(t *testing.T) xdr.LedgerCloseMeta {
+	operations := allOperations(t)
+	inner := transactionEnvelope(103, xdr.Memo{Type: xdr.MemoTypeMemoHash, Hash: &xdr.Hash{3}}, operations[len(operations)-3])
+	envelope := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTxFeeBump,
+		FeeBump: &xdr.FeeBumpTransactionEnvelope{
+			Tx: xdr.FeeBumpTransaction{
+				FeeSource: xdr.MustMuxedAddress(issuerAccount),
+				Fee:       5000,
+				InnerTx: xdr.FeeBumpTransactionInnerTx{
+					Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+					V1:   inner.V1,
+				},
+			},
+		},
+	}
+	results := []xdr.OperationResult{{
+		Code: xdr.OperationResultCodeOpInner,
+		Tr: &xdr.OperationResultTr{
+			Type: xdr.OperationTypeInvokeHostFunction,
+			InvokeHostFunctionResult: &xdr.InvokeHostFunctionResult{
+				Code:    xdr.InvokeHostFunctionResultCodeInvokeHostFunctionSuccess,
+				Success: &xdr.Hash{4},
+			},
+		},
+	}}
+	contract := xdr.ContractId{7, 8, 9}
+	sym := xdr.ScSymbol("balance")
+	balance := xdr.Int64(5)
+	contractData := func(value xdr.Int64) xdr.LedgerEntry {
+		return xdr.LedgerEntry{
+			LastModifiedLedgerSeq: 20,
+			Data: xdr.LedgerEntryData{
+				Type: xdr.LedgerEntryTypeContractData,
+				ContractData: &xdr.ContractDataEntry{
+					Contract:   xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &contract},
+					Key:        xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym},
+					Durability: xdr.ContractDataDurabilityPersistent,
+					Val:        xdr.ScVal{Type: xdr.ScValTypeScvI64, I64: &value},
+				},
+			},
+		}
+	}
+
+	return xdr.LedgerCloseMeta{
+		V: 2,
+		V2: &xdr.LedgerCloseMetaV2{
+			LedgerHeader: ledgerHeader(32, 23),
+			TxSet: xdr.GeneralizedTransactionSet{
+				V: 1,
+				V1TxSet: &xdr.TransactionSetV1{
+					Phases: []xdr.TransactionPhase{{
+						V: 0,
+						V0Components: &[]xdr.TxSetComponent{{
+							Type:                  xdr.TxSetComponentTypeTxsetCompTxsMaybeDiscountedFee,
+							TxsMaybeDiscountedFee: &xdr.TxSetComponentTxsMaybeDiscountedFee{Txs: []xdr.TransactionEnvelope{envelope}},
+						}},
+					}},
+				},
+			},
+			TxProcessing: []xdr.TransactionResultMetaV1{{
+				Result: xdr.TransactionResultPair{
+					TransactionHash: transactionHash(t, envelope),
+					Result: xdr.TransactionResult{
+						FeeCharged: 3000,
+						Result: xdr.TransactionResultResult{
+							Code: xdr.TransactionResultCodeTxFeeBumpInnerSuccess,
+							InnerResultPair: &xdr.InnerTransactionResultPair{
+								TransactionHash: transactionHash(t, inner),
+								Result: xdr.InnerTransactionResult{
+									Result: xdr.InnerTransactionResultResult{
+										Code:    xdr.TransactionResultCodeTxSuccess,
+										Results: &results,
+									},
+								},
+							},
+						},
+					},
+				},
+				FeeProcessing: updatedEntry(
+					accountEntry(issuerAccount, 100_0000000, 1),
+					accountEntry(issuerAccount, 100_0000000-5000, 1),
+				),
+				TxApplyProcessing: xdr.TransactionMeta{
+					V: 4,
+					V4: &xdr.TransactionMetaV4{
+						Operations: []xdr.OperationMetaV2{{
+							Changes: append(
+								xdr.LedgerEntryChanges{{Type: xdr.LedgerEntryChangeTypeLedgerEntryState, State: &[]xdr.LedgerEntry{contractData(balance)}[0]}},
+								xdr.LedgerEntryChange{Type: xdr.LedgerEntryChangeTypeLedgerEntryUpdated, Updated: &[]xdr.LedgerEntry{contractData(balance + 1)}[0]},
+							),
+						}},
+					},
+				},
+				PostTxApplyFeeProcessing: updatedEntry(
+					accountEntry(issuerAccount, 100_0000000-5000, 1),
+					accountEntry(issuerAccount, 100_0000000-3000, 1),
+				),
+			}},
+			EvictedKeys: []xdr.LedgerKey{evictedKey(t)},
+		},
+	}
+}
+
+// assertGolden checks the canonical JSON form of message against the golden
+// file with the given name, and that both encodings of message round trip.
+// Run the tests with -update to rewrite the golden files.
+func assertGolden(t *testing.T, name string, message proto.Message) {
+	encoded, err := MarshalJSON(message)
+	require.NoError(t, err)
+	var indented bytes.Buffer
+	require.NoError(t, json.Indent(&indented, encoded, "", "  "))
+	indented.WriteByte('\n')
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		require.NoError(t, os.WriteFile(path, indented.Bytes(), 0644))
+	}
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), indented.String())
+
+	decoded := message.ProtoReflect().New().Interface()
+	require.NoError(t, protojson.Unmarshal(encoded, decoded))
+	assert.True(t, proto.Equal(message, decoded))
+
+	binary, err := Marshal(message)
+	require.NoError(t, err)
+	decoded = message.ProtoReflect().New().Interface()
+	require.NoError(t, proto.Unmarshal(binary, decoded))
+	assert.True(t, proto.Equal(message, decoded))
+}
+
+func TestEncodeLedgerCloseMeta(t *testing.T) {
+	for _, testCase := range []struct {
+		golden string
+		ledger xdr.LedgerCloseMeta
+	}{
+		{"ledger_v0.json", ledgerV0(t)},
+		{"ledger_v1.json", ledgerV1(t)},
+		{"ledger_v2.json", ledgerV2(t)},
+	} {
+		t.Run(testCase.golden, func(t *testing.T) {
+			encoded, err := EncodeLedgerCloseMeta(network.TestNetworkPassphrase, testCase.ledger)
+			require.NoError(t, err)
+			assert.Equal(t, SchemaVersion, encoded.SchemaVersion)
+			assert.Equal(t, uint32(testCase.ledger.V), encoded.MetaVersion)
+			assertGolden(t, testCase.golden, encoded)
+		})
+	}
+}
+
+func TestEncodeAllOperationTypes(t *testing.T) {
+	encoded, err := EncodeLedgerCloseMeta(network.TestNetworkPassphrase, ledgerV1(t))
+	require.NoError(t, err)
+	require.Len(t, encoded.Transactions, 1)
+
+	encodedTypes := map[xdr.OperationType]bool{}
+	for _, op := range encoded.Transactions[0].Operations {
+		require.NotNil(t, op.Body)
+		// the field number of each body is 10 more than the XDR operation type
+		field := op.ProtoReflect().WhichOneof(op.ProtoReflect().Descriptor().Oneofs().ByName("body"))
+		encodedTypes[xdr.OperationType(field.Number()-10)] = true
+	}
+	for value := range xdr.OperationTypeToStringMap {
+		assert.True(t, encodedTypes[xdr.OperationType(value)], xdr.OperationType(value).String())
+	}
+}
+
+func TestEncodeLedgerTransactionAndChange(t *testing.T) {
+	reader, err := ingest.NewLedgerTransactionReaderFromLedgerCloseMeta(network.TestNetworkPassphrase, ledgerV0(t))
+	require.NoError(t, err)
+	tx, err := reader.Read()
+	require.NoError(t, err)
+
+	encodedTx, err := EncodeLedgerTransaction(tx)
+	require.NoError(t, err)
+	assert.Equal(t, SchemaVersion, encodedTx.SchemaVersion)
+	assertGolden(t, "transaction.json", encodedTx)
+
+	changes, err := tx.GetChanges()
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+	encodedChange, err := EncodeChange(changes[2])
+	require.NoError(t, err)
+	assert.Equal(t, SchemaVersion, encodedChange.SchemaVersion)
+	assert.Equal(t, ChangeReason_CHANGE_REASON_OPERATION, encodedChange.Reason)
+	assert.Equal(t, uint32(0), encodedChange.GetOperationIndex())
+	assertGolden(t, "change.json", encodedChange)
+}
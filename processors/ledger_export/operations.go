@@ -0,0 +1,298 @@
+package ledger_export
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/stellar/go/asset"
+	"github.com/stellar/go/xdr"
+)
+
+func encodeOperation(index uint32, op xdr.Operation, txSourceAccount xdr.MuxedAccount, result *xdr.OperationResult) (*Operation, error) {
+	sourceAccount := txSourceAccount
+	if op.SourceAccount != nil {
+		sourceAccount = *op.SourceAccount
+	}
+	encoded := &Operation{
+		Index:         index,
+		SourceAccount: sourceAccount.Address(),
+	}
+	if result != nil {
+		var err error
+		if encoded.Result, err = result.MarshalBinary(); err != nil {
+			return nil, fmt.Errorf("failed to marshal operation result: %w", err)
+		}
+	}
+
+	var err error
+	encoded.Body, err = encodeOperationBody(op.Body)
+	if err != nil {
+		return nil, err
+	}
+	return encoded, nil
+}
+
+func encodeOperationBody(body xdr.OperationBody) (isOperation_Body, error) {
+	switch body.Type {
+	case xdr.OperationTypeCreateAccount:
+		op := body.MustCreateAccountOp()
+		return &Operation_CreateAccount{CreateAccount: &CreateAccount{
+			Destination:     op.Destination.Address(),
+			StartingBalance: int64(op.StartingBalance),
+		}}, nil
+	case xdr.OperationTypePayment:
+		op := body.MustPaymentOp()
+		return &Operation_Payment{Payment: &Payment{
+			Destination: op.Destination.Address(),
+			Asset:       asset.NewProtoAsset(op.Asset),
+			Amount:      int64(op.Amount),
+		}}, nil
+	case xdr.OperationTypePathPaymentStrictReceive:
+		op := body.MustPathPaymentStrictReceiveOp()
+		return &Operation_PathPaymentStrictReceive{PathPaymentStrictReceive: &PathPaymentStrictReceive{
+			SendAsset:   asset.NewProtoAsset(op.SendAsset),
+			SendMax:     int64(op.SendMax),
+			Destination: op.Destination.Address(),
+			DestAsset:   asset.NewProtoAsset(op.DestAsset),
+			DestAmount:  int64(op.DestAmount),
+			Path:        encodeAssets(op.Path),
+		}}, nil
+	case xdr.OperationTypeManageSellOffer:
+		op := body.MustManageSellOfferOp()
+		return &Operation_ManageSellOffer{ManageSellOffer: &ManageSellOffer{
+			Selling: asset.NewProtoAsset(op.Selling),
+			Buying:  asset.NewProtoAsset(op.Buying),
+			Amount:  int64(op.Amount),
+			Price:   encodePrice(op.Price),
+			OfferId: int64(op.OfferId),
+		}}, nil
+	case xdr.OperationTypeCreatePassiveSellOffer:
+		op := body.MustCreatePassiveSellOfferOp()
+		return &Operation_CreatePassiveSellOffer{CreatePassiveSellOffer: &CreatePassiveSellOffer{
+			Selling: asset.NewProtoAsset(op.Selling),
+			Buying:  asset.NewProtoAsset(op.Buying),
+			Amount:  int64(op.Amount),
+			Price:   encodePrice(op.Price),
+		}}, nil
+	case xdr.OperationTypeSetOptions:
+		return &Operation_SetOptions{SetOptions: encodeSetOptions(body.MustSetOptionsOp())}, nil
+	case xdr.OperationTypeChangeTrust:
+		op := body.MustChangeTrustOp()
+		changeTrust := &ChangeTrust{Limit: int64(op.Limit)}
+		if op.Line.Type == xdr.AssetTypeAssetTypePoolShare {
+			params := op.Line.LiquidityPool.MustConstantProduct()
+			changeTrust.Line = &ChangeTrust_LiquidityPool{LiquidityPool: &LiquidityPoolParameters{
+				AssetA: asset.NewProtoAsset(params.AssetA),
+				AssetB: asset.NewProtoAsset(params.AssetB),
+				Fee:    int32(params.Fee),
+			}}
+		} else {
+			changeTrust.Line = &ChangeTrust_Asset{Asset: asset.NewProtoAsset(op.Line.ToAsset())}
+		}
+		return &Operation_ChangeTrust{ChangeTrust: changeTrust}, nil
+	case xdr.OperationTypeAllowTrust:
+		op := body.MustAllowTrustOp()
+		trustLineAsset := op.Asset.ToAsset(op.Trustor)
+		return &Operation_AllowTrust{AllowTrust: &AllowTrust{
+			Trustor:   op.Trustor.Address(),
+			AssetCode: strings.TrimRight(trustLineAsset.GetCode(), "\x00"),
+			Authorize: uint32(op.Authorize),
+		}}, nil
+	case xdr.OperationTypeAccountMerge:
+		destination := body.MustDestination()
+		return &Operation_AccountMerge{AccountMerge: &AccountMerge{
+			Destination: destination.Address(),
+		}}, nil
+	case xdr.OperationTypeInflation:
+		return &Operation_Inflation{Inflation: &Inflation{}}, nil
+	case xdr.OperationTypeManageData:
+		op := body.MustManageDataOp()
+		manageData := &ManageData{Name: string(op.DataName)}
+		if op.DataValue != nil {
+			manageData.Value = []byte(*op.DataValue)
+		}
+		return &Operation_ManageData{ManageData: manageData}, nil
+	case xdr.OperationTypeBumpSequence:
+		op := body.MustBumpSequenceOp()
+		return &Operation_BumpSequence{BumpSequence: &BumpSequence{BumpTo: int64(op.BumpTo)}}, nil
+	case xdr.OperationTypeManageBuyOffer:
+		op := body.MustManageBuyOfferOp()
+		return &Operation_ManageBuyOffer{ManageBuyOffer: &ManageBuyOffer{
+			Selling:   asset.NewProtoAsset(op.Selling),
+			Buying:    asset.NewProtoAsset(op.Buying),
+			BuyAmount: int64(op.BuyAmount),
+			Price:     encodePrice(op.Price),
+			OfferId:   int64(op.OfferId),
+		}}, nil
+	case xdr.OperationTypePathPaymentStrictSend:
+		op := body.MustPathPaymentStrictSendOp()
+		return &Operation_PathPaymentStrictSend{PathPaymentStrictSend: &PathPaymentStrictSend{
+			SendAsset:   asset.NewProtoAsset(op.SendAsset),
+			SendAmount:  int64(op.SendAmount),
+			Destination: op.Destination.Address(),
+			DestAsset:   asset.NewProtoAsset(op.DestAsset),
+			DestMin:     int64(op.DestMin),
+			Path:        encodeAssets(op.Path),
+		}}, nil
+	case xdr.OperationTypeCreateClaimableBalance:
+		op := body.MustCreateClaimableBalanceOp()
+		createClaimableBalance := &CreateClaimableBalance{
+			Asset:  asset.NewProtoAsset(op.Asset),
+			Amount: int64(op.Amount),
+		}
+		for _, claimant := range op.Claimants {
+			v0 := claimant.MustV0()
+			predicate, err := v0.Predicate.MarshalBinary()
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal claim predicate: %w", err)
+			}
+			createClaimableBalance.Claimants = append(createClaimableBalance.Claimants, &Claimant{
+				Destination: v0.Destination.Address(),
+				Predicate:   predicate,
+			})
+		}
+		return &Operation_CreateClaimableBalance{CreateClaimableBalance: createClaimableBalance}, nil
+	case xdr.OperationTypeClaimClaimableBalance:
+		balanceID, err := xdr.MarshalHex(body.MustClaimClaimableBalanceOp().BalanceId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal claimable balance id: %w", err)
+		}
+		return &Operation_ClaimClaimableBalance{ClaimClaimableBalance: &ClaimClaimableBalance{
+			BalanceId: balanceID,
+		}}, nil
+	case xdr.OperationTypeBeginSponsoringFutureReserves:
+		op := body.MustBeginSponsoringFutureReservesOp()
+		return &Operation_BeginSponsoringFutureReserves{BeginSponsoringFutureReserves: &BeginSponsoringFutureReserves{
+			SponsoredId: op.SponsoredId.Address(),
+		}}, nil
+	case xdr.OperationTypeEndSponsoringFutureReserves:
+		return &Operation_EndSponsoringFutureReserves{EndSponsoringFutureReserves: &EndSponsoringFutureReserves{}}, nil
+	case xdr.OperationTypeRevokeSponsorship:
+		op := body.MustRevokeSponsorshipOp()
+		revokeSponsorship := &RevokeSponsorship{}
+		switch op.Type {
+		case xdr.RevokeSponsorshipTypeRevokeSponsorshipLedgerEntry:
+			ledgerKey, err := op.LedgerKey.MarshalBinary()
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal ledger key: %w", err)
+			}
+			revokeSponsorship.Target = &RevokeSponsorship_LedgerKey{LedgerKey: ledgerKey}
+		case xdr.RevokeSponsorshipTypeRevokeSponsorshipSigner:
+			revokeSponsorship.Target = &RevokeSponsorship_Signer{Signer: &RevokeSponsorshipSigner{
+				AccountId: op.Signer.AccountId.Address(),
+				SignerKey: op.Signer.SignerKey.Address(),
+			}}
+		}
+		return &Operation_RevokeSponsorship{RevokeSponsorship: revokeSponsorship}, nil
+	case xdr.OperationTypeClawback:
+		op := body.MustClawbackOp()
+		return &Operation_Clawback{Clawback: &Clawback{
+			Asset:  asset.NewProtoAsset(op.Asset),
+			From:   op.From.Address(),
+			Amount: int64(op.Amount),
+		}}, nil
+	case xdr.OperationTypeClawbackClaimableBalance:
+		balanceID, err := xdr.MarshalHex(body.MustClawbackClaimableBalanceOp().BalanceId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal claimable balance id: %w", err)
+		}
+		return &Operation_ClawbackClaimableBalance{ClawbackClaimableBalance: &ClawbackClaimableBalance{
+			BalanceId: balanceID,
+		}}, nil
+	case xdr.OperationTypeSetTrustLineFlags:
+		op := body.MustSetTrustLineFlagsOp()
+		return &Operation_SetTrustLineFlags{SetTrustLineFlags: &SetTrustLineFlags{
+			Trustor:    op.Trustor.Address(),
+			Asset:      asset.NewProtoAsset(op.Asset),
+			ClearFlags: uint32(op.ClearFlags),
+			SetFlags:   uint32(op.SetFlags),
+		}}, nil
+	case xdr.OperationTypeLiquidityPoolDeposit:
+		op := body.MustLiquidityPoolDepositOp()
+		return &Operation_LiquidityPoolDeposit{LiquidityPoolDeposit: &LiquidityPoolDeposit{
+			LiquidityPoolId: hex.EncodeToString(op.LiquidityPoolId[:]),
+			MaxAmountA:      int64(op.MaxAmountA),
+			MaxAmountB:      int64(op.MaxAmountB),
+			MinPrice:        encodePrice(op.MinPrice),
+			MaxPrice:        encodePrice(op.MaxPrice),
+		}}, nil
+	case xdr.OperationTypeLiquidityPoolWithdraw:
+		op := body.MustLiquidityPoolWithdrawOp()
+		return &Operation_LiquidityPoolWithdraw{LiquidityPoolWithdraw: &LiquidityPoolWithdraw{
+			LiquidityPoolId: hex.EncodeToString(op.LiquidityPoolId[:]),
+			Amount:          int64(op.Amount),
+			MinAmountA:      int64(op.MinAmountA),
+			MinAmountB:      int64(op.MinAmountB),
+		}}, nil
+	case xdr.OperationTypeInvokeHostFunction:
+		op := body.MustInvokeHostFunctionOp()
+		hostFunction, err := op.HostFunction.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal host function: %w", err)
+		}
+		invokeHostFunction := &InvokeHostFunction{HostFunction: hostFunction}
+		for _, auth := range op.Auth {
+			encodedAuth, err := auth.MarshalBinary()
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal authorization entry: %w", err)
+			}
+			invokeHostFunction.Auth = append(invokeHostFunction.Auth, encodedAuth)
+		}
+		return &Operation_InvokeHostFunction{InvokeHostFunction: invokeHostFunction}, nil
+	case xdr.OperationTypeExtendFootprintTtl:
+		op := body.MustExtendFootprintTtlOp()
+		return &Operation_ExtendFootprintTtl{ExtendFootprintTtl: &ExtendFootprintTtl{
+			ExtendTo: uint32(op.ExtendTo),
+		}}, nil
+	case xdr.OperationTypeRestoreFootprint:
+		return &Operation_RestoreFootprint{RestoreFootprint: &RestoreFootprint{}}, nil
+	default:
+		return nil, fmt.Errorf("unknown operation type %d", body.Type)
+	}
+}
+
+func encodeSetOptions(op xdr.SetOptionsOp) *SetOptions {
+	setOptions := &SetOptions{}
+	if op.InflationDest != nil {
+		inflationDestination := op.InflationDest.Address()
+		setOptions.InflationDestination = &inflationDestination
+	}
+	setOptions.ClearFlags = optionalUint32(op.ClearFlags)
+	setOptions.SetFlags = optionalUint32(op.SetFlags)
+	setOptions.MasterWeight = optionalUint32(op.MasterWeight)
+	setOptions.LowThreshold = optionalUint32(op.LowThreshold)
+	setOptions.MedThreshold = optionalUint32(op.MedThreshold)
+	setOptions.HighThreshold = optionalUint32(op.HighThreshold)
+	if op.HomeDomain != nil {
+		homeDomain := string(*op.HomeDomain)
+		setOptions.HomeDomain = &homeDomain
+	}
+	if op.Signer != nil {
+		setOptions.Signer = &Signer{
+			Key:    op.Signer.Key.Address(),
+			Weight: uint32(op.Signer.Weight),
+		}
+	}
+	return setOptions
+}
+
+func optionalUint32(value *xdr.Uint32) *uint32 {
+	if value == nil {
+		return nil
+	}
+	v := uint32(*value)
+	return &v
+}
+
+func encodePrice(price xdr.Price) *Price {
+	return &Price{N: int32(price.N), D: int32(price.D)}
+}
+
+func encodeAssets(assets []xdr.Asset) []*asset.Asset {
+	var encoded []*asset.Asset
+	for _, a := range assets {
+		encoded = append(encoded, asset.NewProtoAsset(a))
+	}
+	return encoded
+}
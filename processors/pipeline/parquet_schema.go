@@ -0,0 +1,268 @@
+package pipeline
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+type columnKind int
+
+const (
+	booleanColumn columnKind = iota
+	int64Column
+	uint64Column
+	doubleColumn
+	stringColumn
+	bytesColumn
+	timestampColumn
+	jsonColumn
+	// valuerColumn holds the value of a driver.Valuer such as null.String,
+	// whose type is the one of the sql.Null* type it embeds
+	valuerColumn
+)
+
+var (
+	timeType   = reflect.TypeOf(time.Time{})
+	valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+
+	nullTypes = map[reflect.Type]columnKind{
+		reflect.TypeOf(sql.NullBool{}):    booleanColumn,
+		reflect.TypeOf(sql.NullInt16{}):   int64Column,
+		reflect.TypeOf(sql.NullInt32{}):   int64Column,
+		reflect.TypeOf(sql.NullInt64{}):   int64Column,
+		reflect.TypeOf(sql.NullFloat64{}): doubleColumn,
+		reflect.TypeOf(sql.NullString{}):  stringColumn,
+		reflect.TypeOf(sql.NullTime{}):    timestampColumn,
+	}
+)
+
+type parquetColumn struct {
+	name     string
+	field    []int
+	kind     columnKind
+	optional bool
+}
+
+// parquetSchema is the Parquet schema of the records of a processor, derived
+// from the fields of their struct type named after their JSON tags.
+type parquetSchema struct {
+	recordType reflect.Type
+	schema     *parquet.Schema
+	// columns in the order of the columns of schema
+	columns []parquetColumn
+}
+
+// newParquetSchema derives the Parquet schema of a struct type. Fields of
+// scalar types and time.Time are mapped to the corresponding Parquet types,
+// types embedding a sql.Null* type, like null.String, to optional columns of
+// the corresponding Parquet type, and fields of any other type to JSON
+// columns. Pointer fields are optional.
+func newParquetSchema(name string, recordType reflect.Type) (*parquetSchema, error) {
+	if recordType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("records of type %s are not structs", recordType)
+	}
+
+	group := parquet.Group{}
+	var columns []parquetColumn
+	for i := 0; i < recordType.NumField(); i++ {
+		field := recordType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		columnName := field.Name
+		if tag, ok := field.Tag.Lookup("json"); ok {
+			tagName, _, _ := strings.Cut(tag, ",")
+			if tagName == "-" {
+				continue
+			}
+			if tagName != "" {
+				columnName = tagName
+			}
+		}
+		if _, ok := group[columnName]; ok {
+			return nil, fmt.Errorf("duplicate column %s in records of type %s", columnName, recordType)
+		}
+
+		column := parquetColumn{name: columnName, field: field.Index}
+		node := column.derive(field.Type)
+		if column.optional {
+			node = parquet.Optional(node)
+		} else {
+			node = parquet.Required(node)
+		}
+		group[columnName] = node
+		columns = append(columns, column)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("records of type %s have no exported fields", recordType)
+	}
+
+	// parquet.Group orders its columns by name
+	sort.Slice(columns, func(i, j int) bool { return columns[i].name < columns[j].name })
+	return &parquetSchema{
+		recordType: recordType,
+		schema:     parquet.NewSchema(name, group),
+		columns:    columns,
+	}, nil
+}
+
+// derive sets the kind of the column of a field of type fieldType, and returns
+// its Parquet node.
+func (c *parquetColumn) derive(fieldType reflect.Type) parquet.Node {
+	if fieldType.Kind() == reflect.Pointer {
+		c.optional = true
+		fieldType = fieldType.Elem()
+	}
+
+	if fieldType.Implements(valuerType) && fieldType.Kind() == reflect.Struct &&
+		fieldType.NumField() > 0 && fieldType.Field(0).Anonymous {
+		if kind, ok := nullTypes[fieldType.Field(0).Type]; ok {
+			c.kind, c.optional = valuerColumn, true
+			return kindNode(kind)
+		}
+	}
+
+	switch {
+	case fieldType == timeType:
+		// the zero time is written as null
+		c.kind, c.optional = timestampColumn, true
+	case fieldType.Kind() == reflect.Bool:
+		c.kind = booleanColumn
+	case fieldType.Kind() >= reflect.Int && fieldType.Kind() <= reflect.Int64,
+		fieldType.Kind() >= reflect.Uint8 && fieldType.Kind() <= reflect.Uint32:
+		c.kind = int64Column
+	case fieldType.Kind() == reflect.Uint || fieldType.Kind() == reflect.Uint64:
+		c.kind = uint64Column
+	case fieldType.Kind() == reflect.Float32 || fieldType.Kind() == reflect.Float64:
+		c.kind = doubleColumn
+	case fieldType.Kind() == reflect.String:
+		c.kind = stringColumn
+	case fieldType.Kind() == reflect.Slice && fieldType.Elem().Kind() == reflect.Uint8:
+		c.kind, c.optional = bytesColumn, true
+	default:
+		c.kind, c.optional = jsonColumn, true
+	}
+	return kindNode(c.kind)
+}
+
+func kindNode(kind columnKind) parquet.Node {
+	switch kind {
+	case booleanColumn:
+		return parquet.Leaf(parquet.BooleanType)
+	case int64Column:
+		return parquet.Int(64)
+	case uint64Column:
+		return parquet.Uint(64)
+	case doubleColumn:
+		return parquet.Leaf(parquet.DoubleType)
+	case stringColumn:
+		return parquet.String()
+	case bytesColumn:
+		return parquet.Leaf(parquet.ByteArrayType)
+	case timestampColumn:
+		return parquet.Timestamp(parquet.Microsecond)
+	default:
+		return parquet.JSON()
+	}
+}
+
+// row returns the Parquet row of a record.
+func (s *parquetSchema) row(record any) (parquet.Row, error) {
+	value := reflect.ValueOf(record)
+	if value.Type() != s.recordType {
+		return nil, fmt.Errorf("record of type %s does not have type %s", value.Type(), s.recordType)
+	}
+
+	row := make(parquet.Row, len(s.columns))
+	for i, column := range s.columns {
+		parquetValue, err := column.value(value.FieldByIndex(column.field))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of column %s: %w", column.name, err)
+		}
+		definitionLevel := 0
+		if column.optional && !parquetValue.IsNull() {
+			definitionLevel = 1
+		}
+		row[i] = parquetValue.Level(0, definitionLevel, i)
+	}
+	return row, nil
+}
+
+func (c parquetColumn) value(field reflect.Value) (parquet.Value, error) {
+	if field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			return parquet.NullValue(), nil
+		}
+		field = field.Elem()
+	}
+
+	switch c.kind {
+	case booleanColumn:
+		return parquet.BooleanValue(field.Bool()), nil
+	case int64Column:
+		if field.CanUint() {
+			return parquet.Int64Value(int64(field.Uint())), nil
+		}
+		return parquet.Int64Value(field.Int()), nil
+	case uint64Column:
+		return parquet.Int64Value(int64(field.Uint())), nil
+	case doubleColumn:
+		return parquet.DoubleValue(field.Float()), nil
+	case stringColumn:
+		return parquet.ByteArrayValue([]byte(field.String())), nil
+	case bytesColumn:
+		if field.IsNil() {
+			return parquet.NullValue(), nil
+		}
+		return parquet.ByteArrayValue(field.Bytes()), nil
+	case timestampColumn:
+		t := field.Interface().(time.Time)
+		if t.IsZero() {
+			return parquet.NullValue(), nil
+		}
+		return parquet.Int64Value(t.UnixMicro()), nil
+	case valuerColumn:
+		return valuerValue(field.Interface().(driver.Valuer))
+	default:
+		switch field.Kind() {
+		case reflect.Slice, reflect.Map, reflect.Interface:
+			if field.IsNil() {
+				return parquet.NullValue(), nil
+			}
+		}
+		encoded, err := json.Marshal(field.Interface())
+		if err != nil {
+			return parquet.Value{}, err
+		}
+		return parquet.ByteArrayValue(encoded), nil
+	}
+}
+
+func valuerValue(valuer driver.Valuer) (parquet.Value, error) {
+	value, err := valuer.Value()
+	if err != nil || value == nil {
+		return parquet.NullValue(), err
+	}
+	switch v := value.(type) {
+	case bool:
+		return parquet.BooleanValue(v), nil
+	case int64:
+		return parquet.Int64Value(v), nil
+	case float64:
+		return parquet.DoubleValue(v), nil
+	case string:
+		return parquet.ByteArrayValue([]byte(v)), nil
+	case time.Time:
+		return parquet.Int64Value(v.UnixMicro()), nil
+	default:
+		return parquet.Value{}, fmt.Errorf("unexpected value %v of type %T", value, value)
+	}
+}
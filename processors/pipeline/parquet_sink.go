@@ -0,0 +1,186 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"reflect"
+
+	"github.com/parquet-go/parquet-go"
+
+	"github.com/stellar/go/support/datastore"
+)
+
+// DefaultParquetRowGroupSize is the default number of rows of the row groups
+// of the files written by ParquetSink.
+const DefaultParquetRowGroupSize = 128 * 1024
+
+type ParquetSinkConfig struct {
+	// DataStore, required, is the datastore the Parquet files are written to.
+	DataStore datastore.DataStore
+	// Prefix, optional, is prepended to the paths of the files.
+	Prefix string
+	// RowGroupSize is the maximum number of rows of a row group, defaults to
+	// DefaultParquetRowGroupSize.
+	RowGroupSize int64
+	// MaxFileRows, optional, is the number of rows after which a file is
+	// written to the datastore, once the records of the current ledger are
+	// written: files can exceed it by the records of a ledger. By default
+	// files are written when the sink is flushed or closed, and when the
+	// ledger close date changes.
+	MaxFileRows int64
+}
+
+// ParquetSink is a Sink writing the records of every processor to Parquet
+// files, partitioned by the close date of their ledgers:
+//
+//	<prefix>/<processor>/date=<YYYY-MM-DD>/<processor>-<first ledger>-<last ledger>.parquet
+//
+// The records of a processor must be structs of the same type, the schema of
+// the files is derived from it: columns are named after the JSON tags of the
+// fields and have the Parquet type corresponding to the Go type of the fields.
+// Fields of types without a Parquet equivalent, like slices and structs, are
+// written as JSON.
+//
+// Files are buffered in memory until they are written to the datastore, when
+// the sink is flushed, e.g. before the Pipeline commits its cursor, when the
+// ledger close date changes and when MaxFileRows is reached at the end of a
+// ledger.
+type ParquetSink struct {
+	config  ParquetSinkConfig
+	writers map[string]*parquetFileWriter
+}
+
+type parquetFileWriter struct {
+	processor string
+	schema    *parquetSchema
+
+	// the file being written, nil until the next record
+	buffer      *bytes.Buffer
+	writer      *parquet.Writer
+	date        string
+	firstLedger uint32
+	lastLedger  uint32
+	rows        int64
+}
+
+// NewParquetSink returns a ParquetSink configured by config.
+func NewParquetSink(config ParquetSinkConfig) (*ParquetSink, error) {
+	if config.DataStore == nil {
+		return nil, fmt.Errorf("a datastore is required")
+	}
+	if config.RowGroupSize <= 0 {
+		config.RowGroupSize = DefaultParquetRowGroupSize
+	}
+	return &ParquetSink{config: config, writers: map[string]*parquetFileWriter{}}, nil
+}
+
+func (s *ParquetSink) Write(ctx context.Context, batch Batch) error {
+	sequence := batch.Ledger.LedgerSequence()
+	date := batch.Ledger.ClosedAt().UTC().Format("2006-01-02")
+
+	for _, record := range batch.Records {
+		writer, err := s.writer(record)
+		if err != nil {
+			return err
+		}
+		if writer.buffer != nil && writer.date != date {
+			if err = s.writeFile(ctx, writer); err != nil {
+				return err
+			}
+		}
+		if writer.buffer == nil {
+			writer.open(date, sequence, s.config.RowGroupSize)
+		}
+
+		row, err := writer.schema.row(record.Value)
+		if err != nil {
+			return fmt.Errorf("invalid record of processor %s: %w", record.Processor, err)
+		}
+		if _, err = writer.writer.WriteRows([]parquet.Row{row}); err != nil {
+			return fmt.Errorf("failed to write record of processor %s: %w", record.Processor, err)
+		}
+		writer.lastLedger = sequence
+		writer.rows++
+	}
+
+	// files are only rotated between ledgers, so the ledger ranges in their
+	// names don't overlap
+	if s.config.MaxFileRows > 0 {
+		for _, writer := range s.writers {
+			if writer.buffer != nil && writer.rows >= s.config.MaxFileRows {
+				if err := s.writeFile(ctx, writer); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// writer returns the writer of the processor of a record, deriving the schema
+// of the processor from the first record.
+func (s *ParquetSink) writer(record Record) (*parquetFileWriter, error) {
+	if writer, ok := s.writers[record.Processor]; ok {
+		return writer, nil
+	}
+	schema, err := newParquetSchema(record.Processor, reflect.TypeOf(record.Value))
+	if err != nil {
+		return nil, fmt.Errorf("invalid records of processor %s: %w", record.Processor, err)
+	}
+	writer := &parquetFileWriter{processor: record.Processor, schema: schema}
+	s.writers[record.Processor] = writer
+	return writer, nil
+}
+
+func (w *parquetFileWriter) open(date string, sequence uint32, rowGroupSize int64) {
+	w.buffer = &bytes.Buffer{}
+	w.writer = parquet.NewWriter(w.buffer,
+		w.schema.schema,
+		parquet.MaxRowsPerRowGroup(rowGroupSize),
+		parquet.Compression(&parquet.Snappy),
+	)
+	w.date = date
+	w.firstLedger = sequence
+	w.lastLedger = sequence
+	w.rows = 0
+}
+
+// writeFile closes the file being written by writer and writes it to the datastore.
+func (s *ParquetSink) writeFile(ctx context.Context, writer *parquetFileWriter) error {
+	if err := writer.writer.Close(); err != nil {
+		return fmt.Errorf("failed to close file of processor %s: %w", writer.processor, err)
+	}
+	filePath := path.Join(
+		s.config.Prefix,
+		writer.processor,
+		"date="+writer.date,
+		fmt.Sprintf("%s-%d-%d.parquet", writer.processor, writer.firstLedger, writer.lastLedger),
+	)
+	if err := s.config.DataStore.PutFile(ctx, filePath, writer.buffer, nil); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", filePath, err)
+	}
+	writer.buffer = nil
+	writer.writer = nil
+	return nil
+}
+
+// Flush writes the files being written to the datastore.
+func (s *ParquetSink) Flush(ctx context.Context) error {
+	for _, writer := range s.writers {
+		if writer.buffer == nil {
+			continue
+		}
+		if err := s.writeFile(ctx, writer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close writes the files being written to the datastore. It doesn't close the
+// datastore, which is owned by the caller.
+func (s *ParquetSink) Close() error {
+	return s.Flush(context.Background())
+}
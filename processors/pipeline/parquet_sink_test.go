@@ -0,0 +1,196 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/guregu/null"
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/support/datastore"
+	"github.com/stellar/go/xdr"
+)
+
+type parquetTestOutput struct {
+	Name      string            `json:"name"`
+	Amount    int64             `json:"amount"`
+	Ledger    uint32            `json:"ledger_sequence"`
+	Total     uint64            `json:"total"`
+	Price     float64           `json:"price"`
+	Success   bool              `json:"successful"`
+	ClosedAt  time.Time         `json:"closed_at"`
+	Sponsor   null.String       `json:"sponsor"`
+	Memo      *string           `json:"memo,omitempty"`
+	Signers   []string          `json:"signers"`
+	Details   map[string]string `json:"details"`
+	Ignored   string            `json:"-"`
+	NoTag     string
+	unexposed string
+}
+
+func closedAtLedger(sequence uint32, closedAt time.Time) xdr.LedgerCloseMeta {
+	return xdr.LedgerCloseMeta{
+		V: 0,
+		V0: &xdr.LedgerCloseMetaV0{
+			LedgerHeader: xdr.LedgerHeaderHistoryEntry{
+				Header: xdr.LedgerHeader{
+					LedgerSeq: xdr.Uint32(sequence),
+					ScpValue:  xdr.StellarValue{CloseTime: xdr.TimePoint(closedAt.Unix())},
+				},
+			},
+		},
+	}
+}
+
+func readParquetFile(t *testing.T, path string) (*parquet.File, []parquet.Row) {
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	file, err := parquet.OpenFile(bytes.NewReader(contents), int64(len(contents)))
+	require.NoError(t, err)
+
+	reader := parquet.NewReader(file)
+	defer reader.Close()
+	rows := make([]parquet.Row, file.NumRows())
+	n, err := reader.ReadRows(rows)
+	if err != io.EOF {
+		require.NoError(t, err)
+	}
+	require.Equal(t, len(rows), n)
+	return file, rows
+}
+
+func TestParquetSchema(t *testing.T) {
+	schema, err := newParquetSchema("test", reflect.TypeOf(parquetTestOutput{}))
+	require.NoError(t, err)
+
+	var names []string
+	for _, column := range schema.schema.Columns() {
+		names = append(names, column[0])
+	}
+	assert.Equal(t, []string{
+		"NoTag", "amount", "closed_at", "details", "ledger_sequence", "memo", "name",
+		"price", "signers", "sponsor", "successful", "total",
+	}, names)
+	for i, column := range schema.columns {
+		assert.Equal(t, names[i], column.name)
+	}
+
+	_, err = newParquetSchema("test", reflect.TypeOf(""))
+	assert.EqualError(t, err, "records of type string are not structs")
+}
+
+func TestParquetSink(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	store, err := datastore.FromFilesystemPath(root)
+	require.NoError(t, err)
+
+	sink, err := NewParquetSink(ParquetSinkConfig{DataStore: store, Prefix: "exports", RowGroupSize: 1})
+	require.NoError(t, err)
+
+	day := time.Date(2024, 5, 1, 23, 59, 0, 0, time.UTC)
+	memo := "hello"
+	first := parquetTestOutput{
+		Name: "first", Amount: -5, Ledger: 10, Total: 1 << 63, Price: 1.5, Success: true,
+		ClosedAt: day, Sponsor: null.StringFrom("GABC"), Memo: &memo,
+		Signers: []string{"a", "b"}, Details: map[string]string{"k": "v"}, NoTag: "x",
+	}
+	second := parquetTestOutput{Name: "second", Ledger: 11}
+	third := parquetTestOutput{Name: "third", Ledger: 12}
+
+	require.NoError(t, sink.Write(ctx, Batch{
+		Ledger:  closedAtLedger(10, day),
+		Records: []Record{{Processor: "outputs", Value: first}},
+	}))
+	require.NoError(t, sink.Write(ctx, Batch{
+		Ledger:  closedAtLedger(11, day),
+		Records: []Record{{Processor: "outputs", Value: second}},
+	}))
+	// the close date changes, the file of the previous date is written
+	require.NoError(t, sink.Write(ctx, Batch{
+		Ledger:  closedAtLedger(12, day.Add(time.Minute)),
+		Records: []Record{{Processor: "outputs", Value: third}},
+	}))
+	_, err = os.Stat(filepath.Join(root, "exports/outputs/date=2024-05-01/outputs-10-11.parquet"))
+	require.NoError(t, err)
+	_, err = os.Stat(filepath.Join(root, "exports/outputs/date=2024-05-02/outputs-12-12.parquet"))
+	assert.True(t, os.IsNotExist(err))
+
+	require.NoError(t, sink.Close())
+
+	file, rows := readParquetFile(t, filepath.Join(root, "exports/outputs/date=2024-05-01/outputs-10-11.parquet"))
+	assert.Len(t, file.RowGroups(), 2)
+	require.Len(t, rows, 2)
+	values := map[string]parquet.Value{}
+	for i, column := range file.Schema().Columns() {
+		values[column[0]] = rows[0][i]
+	}
+	assert.Equal(t, "first", values["name"].String())
+	assert.Equal(t, int64(-5), values["amount"].Int64())
+	assert.Equal(t, int64(10), values["ledger_sequence"].Int64())
+	assert.Equal(t, uint64(1<<63), values["total"].Uint64())
+	assert.Equal(t, 1.5, values["price"].Double())
+	assert.True(t, values["successful"].Boolean())
+	assert.Equal(t, day.UnixMicro(), values["closed_at"].Int64())
+	assert.Equal(t, "GABC", values["sponsor"].String())
+	assert.Equal(t, "hello", values["memo"].String())
+	assert.JSONEq(t, `["a","b"]`, values["signers"].String())
+	assert.JSONEq(t, `{"k":"v"}`, values["details"].String())
+	assert.Equal(t, "x", values["NoTag"].String())
+
+	for i, column := range file.Schema().Columns() {
+		values[column[0]] = rows[1][i]
+	}
+	for _, column := range []string{"closed_at", "sponsor", "memo", "signers", "details"} {
+		assert.True(t, values[column].IsNull(), column)
+	}
+
+	_, rows = readParquetFile(t, filepath.Join(root, "exports/outputs/date=2024-05-02/outputs-12-12.parquet"))
+	assert.Len(t, rows, 1)
+}
+
+func TestParquetSinkMaxFileRows(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	store, err := datastore.FromFilesystemPath(root)
+	require.NoError(t, err)
+
+	sink, err := NewParquetSink(ParquetSinkConfig{DataStore: store, MaxFileRows: 2})
+	require.NoError(t, err)
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	for sequence := uint32(2); sequence <= 4; sequence++ {
+		require.NoError(t, sink.Write(ctx, Batch{
+			Ledger:  closedAtLedger(sequence, day),
+			Records: []Record{{Processor: "outputs", Value: parquetTestOutput{Ledger: sequence}}},
+		}))
+	}
+	require.NoError(t, sink.Flush(ctx))
+
+	_, rows := readParquetFile(t, filepath.Join(root, "outputs/date=2024-05-01/outputs-2-3.parquet"))
+	assert.Len(t, rows, 2)
+	_, rows = readParquetFile(t, filepath.Join(root, "outputs/date=2024-05-01/outputs-4-4.parquet"))
+	assert.Len(t, rows, 1)
+
+	// the records of a ledger exceeding MaxFileRows are written to one file
+	records := make([]Record, 3)
+	for i := range records {
+		records[i] = Record{Processor: "outputs", Value: parquetTestOutput{Ledger: 5}}
+	}
+	require.NoError(t, sink.Write(ctx, Batch{Ledger: closedAtLedger(5, day), Records: records}))
+	_, rows = readParquetFile(t, filepath.Join(root, "outputs/date=2024-05-01/outputs-5-5.parquet"))
+	assert.Len(t, rows, 3)
+
+	err = sink.Write(ctx, Batch{
+		Ledger:  closedAtLedger(6, day),
+		Records: []Record{{Processor: "outputs", Value: "not a struct"}},
+	})
+	assert.EqualError(t, err, "invalid record of processor outputs: record of type string does not have type pipeline.parquetTestOutput")
+}
@@ -0,0 +1,340 @@
+// Package pipeline runs the processors of the processors/ packages over a
+// range of ledgers and routes the records they emit to sinks.
+//
+// A Pipeline reads every ledger of the range from a LedgerBackend, fans the
+// ledger, its transactions and its changes out to the registered processors,
+// which run in parallel, and writes their records, in the order the processors
+// were registered, to every sink:
+//
+//	p, err := pipeline.New(pipeline.Config{
+//		Backend:           backend,
+//		NetworkPassphrase: network.PublicNetworkPassphrase,
+//		Processors: []pipeline.Processor{
+//			pipeline.TransactionTransform("transactions", transaction.TransformTransaction),
+//			pipeline.ChangeTransform("accounts", account.TransformAccount,
+//				ingest.WithEntryTypes(xdr.LedgerEntryTypeAccount)),
+//		},
+//		Sinks: []pipeline.Sink{pipeline.NewJSONLinesSink(os.Stdout)},
+//	})
+//	...
+//	err = p.Run(ctx, ledgerbackend.BoundedRange(from, to))
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+
+	"github.com/stellar/go/ingest"
+	"github.com/stellar/go/ingest/cdp"
+	"github.com/stellar/go/ingest/ledgerbackend"
+	"github.com/stellar/go/support/log"
+	"github.com/stellar/go/xdr"
+)
+
+// Record is an output of a processor.
+type Record struct {
+	// Processor is the name of the processor which emitted the record.
+	Processor string
+	Value     any
+}
+
+// Batch holds the records emitted by the processors for a ledger.
+type Batch struct {
+	Ledger  xdr.LedgerCloseMeta
+	Records []Record
+}
+
+type Config struct {
+	// Backend, required, is the backend the ledgers are read from.
+	Backend ledgerbackend.LedgerBackend
+	// NetworkPassphrase, required, is the passphrase of the network of the ledgers.
+	NetworkPassphrase string
+	// Processors, required, are the processors invoked for every ledger.
+	Processors []Processor
+	// Sinks, required, are the sinks every batch is written to.
+	Sinks []Sink
+	// Concurrency is the number of processors run in parallel, defaults to the
+	// number of CPUs.
+	Concurrency int
+	// CursorStore, optional, is committed the cursor of the ledgers whose batch
+	// is written, and lets Run resume after the committed cursor.
+	CursorStore ingest.CursorStore
+	// CommitInterval is the number of ledgers between two commits of the
+	// cursor, defaults to 1. The sinks implementing Flusher are flushed before
+	// every commit.
+	CommitInterval uint32
+	// Log, optional, defaults to the default logger.
+	Log *log.Entry
+}
+
+// Pipeline runs processors over the ledgers of a LedgerBackend.
+type Pipeline struct {
+	config Config
+	log    *log.Entry
+
+	hasTransactionProcessors bool
+	hasChangeProcessors      bool
+}
+
+// New returns a Pipeline configured by config.
+func New(config Config) (*Pipeline, error) {
+	if config.Backend == nil {
+		return nil, fmt.Errorf("a ledger backend is required")
+	}
+	if config.NetworkPassphrase == "" {
+		return nil, fmt.Errorf("a network passphrase is required")
+	}
+	if len(config.Processors) == 0 {
+		return nil, fmt.Errorf("at least one processor is required")
+	}
+	if len(config.Sinks) == 0 {
+		return nil, fmt.Errorf("at least one sink is required")
+	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = runtime.NumCPU()
+	}
+	if config.CommitInterval == 0 {
+		config.CommitInterval = 1
+	}
+
+	p := &Pipeline{config: config, log: config.Log}
+	names := map[string]bool{}
+	for _, processor := range config.Processors {
+		if names[processor.Name()] {
+			return nil, fmt.Errorf("duplicate processor name %s", processor.Name())
+		}
+		names[processor.Name()] = true
+
+		_, isLedgerProcessor := processor.(LedgerProcessor)
+		_, isTransactionProcessor := processor.(TransactionProcessor)
+		_, isChangeProcessor := processor.(ChangeProcessor)
+		if !isLedgerProcessor && !isTransactionProcessor && !isChangeProcessor {
+			return nil, fmt.Errorf("processor %s does not process ledgers, transactions or changes", processor.Name())
+		}
+		p.hasTransactionProcessors = p.hasTransactionProcessors || isTransactionProcessor
+		p.hasChangeProcessors = p.hasChangeProcessors || isChangeProcessor
+	}
+	if p.log == nil {
+		p.log = log.DefaultLogger
+	}
+	p.log = p.log.WithField("service", "pipeline")
+	return p, nil
+}
+
+// Run processes the ledgers of ledgerRange and writes their batches to the
+// sinks. It returns when a bounded range is completed, the ctx is canceled or
+// an error occurs, and closes the sinks before returning.
+//
+// If a CursorStore is configured and holds a cursor within or past the start
+// of the range, Run resumes with the ledger following the cursor, which must
+// be built on the cursor ledger.
+func (p *Pipeline) Run(ctx context.Context, ledgerRange ledgerbackend.Range) (err error) {
+	defer func() {
+		if closeErr := p.closeSinks(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+
+	resumption, err := cdp.ResumeRange(ctx, p.config.CursorStore, ledgerRange, p.log)
+	if err != nil {
+		return err
+	}
+	if resumption.Done {
+		return nil
+	}
+	ledgerRange = resumption.Range
+
+	if err = p.config.Backend.PrepareRange(ctx, ledgerRange); err != nil {
+		return fmt.Errorf("failed to prepare range %v: %w", ledgerRange, err)
+	}
+
+	for sequence := ledgerRange.From(); sequence <= ledgerRange.To() || !ledgerRange.Bounded(); sequence++ {
+		ledger, err := p.config.Backend.GetLedger(ctx, sequence)
+		if err != nil {
+			return fmt.Errorf("failed to get ledger %d: %w", sequence, err)
+		}
+		if err = resumption.Verify(ledger); err != nil {
+			return err
+		}
+
+		batch, err := p.ProcessLedger(ctx, ledger)
+		if err != nil {
+			return err
+		}
+		for _, sink := range p.config.Sinks {
+			if err = sink.Write(ctx, batch); err != nil {
+				return fmt.Errorf("failed to write batch of ledger %d: %w", sequence, err)
+			}
+		}
+		p.log.WithFields(log.F{"sequence": sequence, "records": len(batch.Records)}).Debug("Processed ledger")
+
+		last := ledgerRange.Bounded() && sequence == ledgerRange.To()
+		if p.config.CursorStore != nil && (sequence%p.config.CommitInterval == 0 || last) {
+			if err = p.commit(ctx, ingest.CursorOf(ledger)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p *Pipeline) commit(ctx context.Context, cursor ingest.Cursor) error {
+	for _, sink := range p.config.Sinks {
+		if flusher, ok := sink.(Flusher); ok {
+			if err := flusher.Flush(ctx); err != nil {
+				return fmt.Errorf("failed to flush sink: %w", err)
+			}
+		}
+	}
+	if err := p.config.CursorStore.Commit(ctx, cursor); err != nil {
+		return fmt.Errorf("failed to commit cursor: %w", err)
+	}
+	return nil
+}
+
+func (p *Pipeline) closeSinks() error {
+	var firstErr error
+	for _, sink := range p.config.Sinks {
+		if err := sink.Close(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to close sink: %w", err)
+		}
+	}
+	return firstErr
+}
+
+// ProcessLedger runs the processors over a ledger, in parallel, and returns
+// their records in the order the processors were registered.
+func (p *Pipeline) ProcessLedger(ctx context.Context, ledger xdr.LedgerCloseMeta) (Batch, error) {
+	var transactions []ingest.LedgerTransaction
+	var changes []ingest.Change
+	var err error
+	if p.hasTransactionProcessors {
+		if transactions, err = p.readTransactions(ledger); err != nil {
+			return Batch{}, err
+		}
+	}
+	if p.hasChangeProcessors {
+		if changes, err = p.readChanges(ledger); err != nil {
+			return Batch{}, err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	outputs := make([][]any, len(p.config.Processors))
+	errs := make([]error, len(p.config.Processors))
+	semaphore := make(chan struct{}, p.config.Concurrency)
+	var wg sync.WaitGroup
+	for i, processor := range p.config.Processors {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			outputs[i], errs[i] = runProcessor(ctx, processor, ledger, transactions, changes)
+			if errs[i] != nil {
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+
+	batch := Batch{Ledger: ledger}
+	for i, processor := range p.config.Processors {
+		if errs[i] != nil {
+			return Batch{}, fmt.Errorf("processor %s failed on ledger %d: %w",
+				processor.Name(), ledger.LedgerSequence(), errs[i])
+		}
+		for _, value := range outputs[i] {
+			batch.Records = append(batch.Records, Record{Processor: processor.Name(), Value: value})
+		}
+	}
+	return batch, nil
+}
+
+func runProcessor(
+	ctx context.Context,
+	processor Processor,
+	ledger xdr.LedgerCloseMeta,
+	transactions []ingest.LedgerTransaction,
+	changes []ingest.Change,
+) ([]any, error) {
+	var outputs []any
+	if ledgerProcessor, ok := processor.(LedgerProcessor); ok {
+		records, err := ledgerProcessor.ProcessLedger(ctx, ledger)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, records...)
+	}
+	if transactionProcessor, ok := processor.(TransactionProcessor); ok {
+		for _, tx := range transactions {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			records, err := transactionProcessor.ProcessTransaction(ctx, ledger, tx)
+			if err != nil {
+				return nil, fmt.Errorf("transaction %s: %w", tx.Hash.HexString(), err)
+			}
+			outputs = append(outputs, records...)
+		}
+	}
+	if changeProcessor, ok := processor.(ChangeProcessor); ok {
+		for _, change := range changes {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			records, err := changeProcessor.ProcessChange(ctx, ledger, change)
+			if err != nil {
+				return nil, err
+			}
+			outputs = append(outputs, records...)
+		}
+	}
+	return outputs, nil
+}
+
+func (p *Pipeline) readTransactions(ledger xdr.LedgerCloseMeta) ([]ingest.LedgerTransaction, error) {
+	reader, err := ingest.NewLedgerTransactionReaderFromLedgerCloseMeta(p.config.NetworkPassphrase, ledger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction reader for ledger %d: %w", ledger.LedgerSequence(), err)
+	}
+	defer reader.Close()
+
+	var transactions []ingest.LedgerTransaction
+	for {
+		tx, err := reader.Read()
+		if err == io.EOF {
+			return transactions, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read transaction from ledger %d: %w", ledger.LedgerSequence(), err)
+		}
+		transactions = append(transactions, tx)
+	}
+}
+
+func (p *Pipeline) readChanges(ledger xdr.LedgerCloseMeta) ([]ingest.Change, error) {
+	reader, err := ingest.NewLedgerChangeReaderFromLedgerCloseMeta(p.config.NetworkPassphrase, ledger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create change reader for ledger %d: %w", ledger.LedgerSequence(), err)
+	}
+	defer reader.Close()
+
+	var changes []ingest.Change
+	for {
+		change, err := reader.Read()
+		if err == io.EOF {
+			return changes, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read change from ledger %d: %w", ledger.LedgerSequence(), err)
+		}
+		changes = append(changes, change)
+	}
+}
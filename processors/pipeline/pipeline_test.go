@@ -0,0 +1,296 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/ingest"
+	"github.com/stellar/go/ingest/ledgerbackend"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/processors/account"
+	"github.com/stellar/go/processors/transaction"
+	"github.com/stellar/go/xdr"
+)
+
+const (
+	sourceAccount      = "GAHK7EEG2WWHVKDNT4CEQFZGKF2LGDSW2IVM4S5DP42RBW3K6BTODB4A"
+	destinationAccount = "GBXGQJWVLWOYHFLVTKWV5FGHA3LNYY2JQKM7OAJAUEQFU6LPCSEFVXON"
+)
+
+func accountEntry(address string, balance xdr.Int64, sequence xdr.SequenceNumber) xdr.LedgerEntry {
+	return xdr.LedgerEntry{
+		LastModifiedLedgerSeq: 10,
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeAccount,
+			Account: &xdr.AccountEntry{
+				AccountId:  xdr.MustAddress(address),
+				Balance:    balance,
+				SeqNum:     sequence,
+				Thresholds: xdr.Thresholds{1, 0, 0, 0},
+			},
+		},
+	}
+}
+
+func updatedEntry(pre, post xdr.LedgerEntry) xdr.LedgerEntryChanges {
+	return xdr.LedgerEntryChanges{
+		{Type: xdr.LedgerEntryChangeTypeLedgerEntryState, State: &pre},
+		{Type: xdr.LedgerEntryChangeTypeLedgerEntryUpdated, Updated: &post},
+	}
+}
+
+// testLedger returns a ledger with a payment of amount from sourceAccount to
+// destinationAccount.
+func testLedger(t *testing.T, sequence uint32, amount xdr.Int64) xdr.LedgerCloseMeta {
+	envelope := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1: &xdr.TransactionV1Envelope{
+			Tx: xdr.Transaction{
+				SourceAccount: xdr.MustMuxedAddress(sourceAccount),
+				Fee:           100,
+				SeqNum:        xdr.SequenceNumber(sequence),
+				Cond:          xdr.Preconditions{Type: xdr.PreconditionTypePrecondNone},
+				Operations: []xdr.Operation{{
+					Body: xdr.OperationBody{
+						Type: xdr.OperationTypePayment,
+						PaymentOp: &xdr.PaymentOp{
+							Destination: xdr.MustMuxedAddress(destinationAccount),
+							Asset:       xdr.MustNewNativeAsset(),
+							Amount:      amount,
+						},
+					},
+				}},
+			},
+		},
+	}
+	hash, err := network.HashTransactionInEnvelope(envelope, network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	results := []xdr.OperationResult{{
+		Code: xdr.OperationResultCodeOpInner,
+		Tr: &xdr.OperationResultTr{
+			Type:          xdr.OperationTypePayment,
+			PaymentResult: &xdr.PaymentResult{Code: xdr.PaymentResultCodePaymentSuccess},
+		},
+	}}
+
+	return xdr.LedgerCloseMeta{
+		V: 0,
+		V0: &xdr.LedgerCloseMetaV0{
+			LedgerHeader: xdr.LedgerHeaderHistoryEntry{
+				Hash: xdr.Hash{byte(sequence)},
+				Header: xdr.LedgerHeader{
+					LedgerSeq:          xdr.Uint32(sequence),
+					LedgerVersion:      19,
+					PreviousLedgerHash: xdr.Hash{byte(sequence - 1)},
+					ScpValue:           xdr.StellarValue{CloseTime: xdr.TimePoint(1700000000 + sequence*5)},
+				},
+			},
+			TxSet: xdr.TransactionSet{Txs: []xdr.TransactionEnvelope{envelope}},
+			TxProcessing: []xdr.TransactionResultMeta{{
+				Result: xdr.TransactionResultPair{
+					TransactionHash: hash,
+					Result: xdr.TransactionResult{
+						FeeCharged: 100,
+						Result: xdr.TransactionResultResult{
+							Code:    xdr.TransactionResultCodeTxSuccess,
+							Results: &results,
+						},
+					},
+				},
+				TxApplyProcessing: xdr.TransactionMeta{
+					V: 1,
+					V1: &xdr.TransactionMetaV1{
+						Operations: []xdr.OperationMeta{{
+							Changes: append(
+								updatedEntry(
+									accountEntry(sourceAccount, 1000_0000000, xdr.SequenceNumber(sequence)),
+									accountEntry(sourceAccount, 1000_0000000-amount, xdr.SequenceNumber(sequence)),
+								),
+								updatedEntry(
+									accountEntry(destinationAccount, 10_0000000, 5),
+									accountEntry(destinationAccount, 10_0000000+amount, 5),
+								)...,
+							),
+						}},
+					},
+				},
+			}},
+		},
+	}
+}
+
+type ledgerCounter struct{}
+
+func (ledgerCounter) Name() string {
+	return "ledgers"
+}
+
+func (ledgerCounter) ProcessLedger(ctx context.Context, ledger xdr.LedgerCloseMeta) ([]any, error) {
+	return []any{map[string]uint32{"sequence": ledger.LedgerSequence()}}, nil
+}
+
+type failingProcessor struct{}
+
+func (failingProcessor) Name() string {
+	return "failing"
+}
+
+func (failingProcessor) ProcessChange(ctx context.Context, ledger xdr.LedgerCloseMeta, change ingest.Change) ([]any, error) {
+	return nil, fmt.Errorf("uhoh")
+}
+
+type recordingSink struct {
+	batches []Batch
+	flushes int
+	closed  bool
+}
+
+func (s *recordingSink) Write(ctx context.Context, batch Batch) error {
+	s.batches = append(s.batches, batch)
+	return nil
+}
+
+func (s *recordingSink) Flush(ctx context.Context) error {
+	s.flushes++
+	return nil
+}
+
+func (s *recordingSink) Close() error {
+	s.closed = true
+	return nil
+}
+
+func mockBackend(t *testing.T, ledgerRange ledgerbackend.Range, ledgers ...xdr.LedgerCloseMeta) *ledgerbackend.MockDatabaseBackend {
+	backend := &ledgerbackend.MockDatabaseBackend{}
+	backend.On("PrepareRange", mock.Anything, ledgerRange).Return(nil).Once()
+	for _, ledger := range ledgers {
+		backend.On("GetLedger", mock.Anything, ledger.LedgerSequence()).Return(ledger, nil).Once()
+	}
+	t.Cleanup(func() {
+		backend.AssertExpectations(t)
+	})
+	return backend
+}
+
+func TestNewInvalidConfig(t *testing.T) {
+	backend := &ledgerbackend.MockDatabaseBackend{}
+	sinks := []Sink{&recordingSink{}}
+	for _, testCase := range []struct {
+		config Config
+		err    string
+	}{
+		{Config{}, "a ledger backend is required"},
+		{Config{Backend: backend}, "a network passphrase is required"},
+		{Config{Backend: backend, NetworkPassphrase: network.TestNetworkPassphrase, Sinks: sinks},
+			"at least one processor is required"},
+		{Config{Backend: backend, NetworkPassphrase: network.TestNetworkPassphrase, Processors: []Processor{ledgerCounter{}}},
+			"at least one sink is required"},
+		{Config{Backend: backend, NetworkPassphrase: network.TestNetworkPassphrase, Sinks: sinks,
+			Processors: []Processor{ledgerCounter{}, ledgerCounter{}}}, "duplicate processor name ledgers"},
+	} {
+		_, err := New(testCase.config)
+		assert.EqualError(t, err, testCase.err)
+	}
+}
+
+func TestRun(t *testing.T) {
+	ledgers := []xdr.LedgerCloseMeta{testLedger(t, 2, 1_0000000), testLedger(t, 3, 2_0000000)}
+	backend := mockBackend(t, ledgerbackend.BoundedRange(2, 3), ledgers...)
+	sink := &recordingSink{}
+	var out bytes.Buffer
+
+	p, err := New(Config{
+		Backend:           backend,
+		NetworkPassphrase: network.TestNetworkPassphrase,
+		Processors: []Processor{
+			ledgerCounter{},
+			TransactionTransform("transactions", transaction.TransformTransaction),
+			ChangeTransform("accounts", account.TransformAccount, ingest.WithAccounts(destinationAccount)),
+		},
+		Sinks:       []Sink{sink, NewJSONLinesSink(&out)},
+		Concurrency: 2,
+	})
+	require.NoError(t, err)
+	require.NoError(t, p.Run(context.Background(), ledgerbackend.BoundedRange(2, 3)))
+
+	assert.True(t, sink.closed)
+	require.Len(t, sink.batches, 2)
+	for i, batch := range sink.batches {
+		assert.Equal(t, ledgers[i], batch.Ledger)
+		require.Len(t, batch.Records, 3)
+		assert.Equal(t, "ledgers", batch.Records[0].Processor)
+		assert.Equal(t, "transactions", batch.Records[1].Processor)
+		assert.IsType(t, transaction.TransactionOutput{}, batch.Records[1].Value)
+		assert.Equal(t, "accounts", batch.Records[2].Processor)
+		require.IsType(t, account.AccountOutput{}, batch.Records[2].Value)
+		assert.Equal(t, destinationAccount, batch.Records[2].Value.(account.AccountOutput).AccountID)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 6)
+	var line struct {
+		Processor      string          `json:"processor"`
+		LedgerSequence uint32          `json:"ledger_sequence"`
+		Record         json.RawMessage `json:"record"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(lines[3]), &line))
+	assert.Equal(t, "ledgers", line.Processor)
+	assert.Equal(t, uint32(3), line.LedgerSequence)
+	assert.JSONEq(t, `{"sequence":3}`, string(line.Record))
+}
+
+func TestRunResumesFromCursor(t *testing.T) {
+	ctx := context.Background()
+	cursorStore := ingest.NewFileCursorStore(filepath.Join(t.TempDir(), "cursor.json"))
+	require.NoError(t, cursorStore.Commit(ctx, ingest.Cursor{Ledger: 2, LedgerHash: xdr.Hash{2}}))
+
+	ledgers := []xdr.LedgerCloseMeta{testLedger(t, 3, 1), testLedger(t, 4, 1), testLedger(t, 5, 1)}
+	backend := mockBackend(t, ledgerbackend.BoundedRange(3, 5), ledgers...)
+	sink := &recordingSink{}
+	p, err := New(Config{
+		Backend:           backend,
+		NetworkPassphrase: network.TestNetworkPassphrase,
+		Processors:        []Processor{ledgerCounter{}},
+		Sinks:             []Sink{sink},
+		CursorStore:       cursorStore,
+		CommitInterval:    2,
+	})
+	require.NoError(t, err)
+	require.NoError(t, p.Run(ctx, ledgerbackend.BoundedRange(2, 5)))
+
+	require.Len(t, sink.batches, 3)
+	assert.Equal(t, uint32(3), sink.batches[0].Ledger.LedgerSequence())
+	// the cursor is committed on ledger 4 and at the end of the range
+	assert.Equal(t, 2, sink.flushes)
+	cursor, found, err := cursorStore.Load(ctx)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, ingest.Cursor{Ledger: 5, LedgerHash: xdr.Hash{5}}, cursor)
+}
+
+func TestRunProcessorError(t *testing.T) {
+	backend := mockBackend(t, ledgerbackend.UnboundedRange(2), testLedger(t, 2, 1))
+	sink := &recordingSink{}
+	p, err := New(Config{
+		Backend:           backend,
+		NetworkPassphrase: network.TestNetworkPassphrase,
+		Processors:        []Processor{ledgerCounter{}, failingProcessor{}},
+		Sinks:             []Sink{sink},
+	})
+	require.NoError(t, err)
+	assert.EqualError(t,
+		p.Run(context.Background(), ledgerbackend.UnboundedRange(2)),
+		"processor failing failed on ledger 2: uhoh",
+	)
+	assert.Empty(t, sink.batches)
+	assert.True(t, sink.closed)
+}
@@ -0,0 +1,123 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/stellar/go/support/db"
+)
+
+var tableNamePattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// postgresMaxInsertRows is the maximum number of rows inserted by a statement,
+// keeping the two parameters of every row within the Postgres limit.
+const postgresMaxInsertRows = db.PostgresQueryMaxParams / 2
+
+// PostgresSink is a Sink writing the records of every processor to a table
+// named after the processor, prefixed by the configured prefix, with the
+// columns:
+//
+//	ledger_sequence bigint NOT NULL
+//	record jsonb NOT NULL
+//
+// The records of a ledger are written in a single transaction, which replaces
+// the records previously written for the ledger, so ledgers processed again
+// after a restart aren't duplicated. The records of a processor are inserted
+// by as many statements as required to stay within the Postgres limit on the
+// number of parameters of a query. The tables are created when the first
+// record of their processor is written.
+type PostgresSink struct {
+	session db.SessionInterface
+	prefix  string
+	created map[string]bool
+}
+
+// NewPostgresSink returns a PostgresSink writing to the database of session,
+// in tables whose name starts with prefix. The session must not be used
+// concurrently by other goroutines.
+func NewPostgresSink(session db.SessionInterface, prefix string) *PostgresSink {
+	return &PostgresSink{session: session, prefix: prefix, created: map[string]bool{}}
+}
+
+func (s *PostgresSink) Write(ctx context.Context, batch Batch) error {
+	if len(batch.Records) == 0 {
+		return nil
+	}
+	sequence := batch.Ledger.LedgerSequence()
+
+	inserts := map[string][]sq.InsertBuilder{}
+	rows := map[string]int{}
+	var tables []string
+	for _, record := range batch.Records {
+		table := s.prefix + record.Processor
+		if !tableNamePattern.MatchString(table) {
+			return fmt.Errorf("invalid table name %q for processor %s", table, record.Processor)
+		}
+		value, err := json.Marshal(record.Value)
+		if err != nil {
+			return fmt.Errorf("failed to encode record of processor %s: %w", record.Processor, err)
+		}
+		if _, ok := inserts[table]; !ok {
+			tables = append(tables, table)
+		}
+		if rows[table]%postgresMaxInsertRows == 0 {
+			inserts[table] = append(inserts[table], sq.Insert(table).Columns("ledger_sequence", "record"))
+		}
+		last := len(inserts[table]) - 1
+		inserts[table][last] = inserts[table][last].Values(sequence, string(value))
+		rows[table]++
+	}
+
+	if err := s.session.Begin(ctx); err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer s.session.Rollback()
+
+	for _, table := range tables {
+		if !s.created[table] {
+			if err := s.createTable(ctx, table); err != nil {
+				return err
+			}
+		}
+		if _, err := s.session.Exec(ctx, sq.Delete(table).Where(sq.Eq{"ledger_sequence": sequence})); err != nil {
+			return fmt.Errorf("failed to delete records of ledger %d from %s: %w", sequence, table, err)
+		}
+		for _, insert := range inserts[table] {
+			if _, err := s.session.Exec(ctx, insert); err != nil {
+				return fmt.Errorf("failed to insert records of ledger %d into %s: %w", sequence, table, err)
+			}
+		}
+	}
+	if err := s.session.Commit(); err != nil {
+		return fmt.Errorf("failed to commit records of ledger %d: %w", sequence, err)
+	}
+	for _, table := range tables {
+		s.created[table] = true
+	}
+	return nil
+}
+
+func (s *PostgresSink) createTable(ctx context.Context, table string) error {
+	_, err := s.session.ExecRaw(ctx, fmt.Sprintf(
+		"CREATE TABLE IF NOT EXISTS %s (ledger_sequence bigint NOT NULL, record jsonb NOT NULL)", table,
+	))
+	if err != nil {
+		return fmt.Errorf("failed to create table %s: %w", table, err)
+	}
+	_, err = s.session.ExecRaw(ctx, fmt.Sprintf(
+		"CREATE INDEX IF NOT EXISTS %s_ledger_sequence ON %s (ledger_sequence)", table, table,
+	))
+	if err != nil {
+		return fmt.Errorf("failed to create index on table %s: %w", table, err)
+	}
+	return nil
+}
+
+// Close doesn't close the session, which is owned by the caller.
+func (s *PostgresSink) Close() error {
+	return nil
+}
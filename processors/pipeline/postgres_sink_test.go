@@ -0,0 +1,46 @@
+package pipeline
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/support/db"
+)
+
+func TestPostgresSinkSplitsInserts(t *testing.T) {
+	ctx := context.Background()
+	session := &db.MockSession{}
+	defer session.AssertExpectations(t)
+
+	var inserted []int
+	isInsert := func(query sq.Sqlizer) bool {
+		_, ok := query.(sq.InsertBuilder)
+		return ok
+	}
+	session.On("Begin", ctx).Return(nil).Once()
+	session.On("ExecRaw", ctx, mock.Anything, mock.Anything).Return(driver.RowsAffected(0), nil).Twice()
+	session.On("Exec", ctx, mock.AnythingOfType("squirrel.DeleteBuilder")).Return(driver.RowsAffected(0), nil).Once()
+	session.On("Exec", ctx, mock.MatchedBy(isInsert)).Return(driver.RowsAffected(0), nil).Run(func(args mock.Arguments) {
+		_, params, err := args.Get(1).(sq.InsertBuilder).ToSql()
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(params), db.PostgresQueryMaxParams)
+		inserted = append(inserted, len(params)/2)
+	}).Twice()
+	session.On("Commit").Return(nil).Once()
+	session.On("Rollback").Return(nil).Once()
+
+	records := make([]Record, postgresMaxInsertRows+1)
+	for i := range records {
+		records[i] = Record{Processor: "outputs", Value: i}
+	}
+	sink := NewPostgresSink(session, "exports_")
+	require.NoError(t, sink.Write(ctx, Batch{Ledger: closedAtLedger(10, time.Now()), Records: records}))
+	assert.Equal(t, []int{postgresMaxInsertRows, 1}, inserted)
+}
@@ -0,0 +1,131 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/stellar/go/ingest"
+	"github.com/stellar/go/xdr"
+)
+
+// Processor transforms the data of a ledger into records. A processor must
+// implement at least one of LedgerProcessor, TransactionProcessor and
+// ChangeProcessor.
+type Processor interface {
+	// Name identifies the processor, sinks use it to route its records, e.g. to
+	// a table or a file.
+	Name() string
+}
+
+// LedgerProcessor is a Processor invoked once for every ledger.
+type LedgerProcessor interface {
+	Processor
+	ProcessLedger(ctx context.Context, ledger xdr.LedgerCloseMeta) ([]any, error)
+}
+
+// TransactionProcessor is a Processor invoked for every transaction of a
+// ledger, in the order they were applied.
+type TransactionProcessor interface {
+	Processor
+	ProcessTransaction(ctx context.Context, ledger xdr.LedgerCloseMeta, tx ingest.LedgerTransaction) ([]any, error)
+}
+
+// ChangeProcessor is a Processor invoked for every change of a ledger, in the
+// order returned by ingest.LedgerChangeReader.
+type ChangeProcessor interface {
+	Processor
+	ProcessChange(ctx context.Context, ledger xdr.LedgerCloseMeta, change ingest.Change) ([]any, error)
+}
+
+type transactionTransform[T any] struct {
+	name      string
+	transform func(ingest.LedgerTransaction, xdr.LedgerHeaderHistoryEntry) ([]T, error)
+}
+
+func (p transactionTransform[T]) Name() string {
+	return p.name
+}
+
+func (p transactionTransform[T]) ProcessTransaction(ctx context.Context, ledger xdr.LedgerCloseMeta, tx ingest.LedgerTransaction) ([]any, error) {
+	outputs, err := p.transform(tx, ledger.LedgerHeaderHistoryEntry())
+	if err != nil {
+		return nil, err
+	}
+	return toRecords(outputs), nil
+}
+
+// TransactionTransform returns a TransactionProcessor emitting the output of a
+// transform function of the processors packages, e.g.
+// transaction.TransformTransaction.
+func TransactionTransform[T any](name string, transform func(ingest.LedgerTransaction, xdr.LedgerHeaderHistoryEntry) (T, error)) TransactionProcessor {
+	return transactionTransform[T]{
+		name: name,
+		transform: func(tx ingest.LedgerTransaction, header xdr.LedgerHeaderHistoryEntry) ([]T, error) {
+			output, err := transform(tx, header)
+			if err != nil {
+				return nil, err
+			}
+			return []T{output}, nil
+		},
+	}
+}
+
+// TransactionTransformMany is like TransactionTransform for transform functions
+// returning several outputs per transaction, e.g. contract.TransformContractEvent.
+func TransactionTransformMany[T any](name string, transform func(ingest.LedgerTransaction, xdr.LedgerHeaderHistoryEntry) ([]T, error)) TransactionProcessor {
+	return transactionTransform[T]{name: name, transform: transform}
+}
+
+type changeTransform[T any] struct {
+	name      string
+	filter    *ingest.ChangeFilter
+	transform func(ingest.Change, xdr.LedgerHeaderHistoryEntry) ([]T, error)
+}
+
+func (p changeTransform[T]) Name() string {
+	return p.name
+}
+
+func (p changeTransform[T]) ProcessChange(ctx context.Context, ledger xdr.LedgerCloseMeta, change ingest.Change) ([]any, error) {
+	if !p.filter.Matches(change) {
+		return nil, nil
+	}
+	outputs, err := p.transform(change, ledger.LedgerHeaderHistoryEntry())
+	if err != nil {
+		return nil, err
+	}
+	return toRecords(outputs), nil
+}
+
+// ChangeTransform returns a ChangeProcessor emitting the output of a transform
+// function of the processors packages for the changes selected by options,
+// e.g. account.TransformAccount with ingest.WithEntryTypes(xdr.LedgerEntryTypeAccount).
+func ChangeTransform[T any](name string, transform func(ingest.Change, xdr.LedgerHeaderHistoryEntry) (T, error), options ...ingest.ChangeFilterOption) ChangeProcessor {
+	return changeTransform[T]{
+		name:   name,
+		filter: ingest.NewChangeFilter(options...),
+		transform: func(change ingest.Change, header xdr.LedgerHeaderHistoryEntry) ([]T, error) {
+			output, err := transform(change, header)
+			if err != nil {
+				return nil, err
+			}
+			return []T{output}, nil
+		},
+	}
+}
+
+// ChangeTransformMany is like ChangeTransform for transform functions returning
+// several outputs per change, e.g. account.TransformAccountSigners.
+func ChangeTransformMany[T any](name string, transform func(ingest.Change, xdr.LedgerHeaderHistoryEntry) ([]T, error), options ...ingest.ChangeFilterOption) ChangeProcessor {
+	return changeTransform[T]{name: name, filter: ingest.NewChangeFilter(options...), transform: transform}
+}
+
+func toRecords[T any](outputs []T) []any {
+	if len(outputs) == 0 {
+		return nil
+	}
+	records := make([]any, len(outputs))
+	for i, output := range outputs {
+		records[i] = output
+	}
+	return records
+}
@@ -0,0 +1,68 @@
+package pipeline
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Sink receives the batches of a Pipeline.
+type Sink interface {
+	// Write writes the records of a ledger. Batches are written in the order of
+	// their ledgers.
+	Write(ctx context.Context, batch Batch) error
+	// Close flushes the records written and releases the resources of the sink.
+	Close() error
+}
+
+// Flusher is implemented by the sinks buffering records. The Pipeline flushes
+// them before committing its cursor, so the ledgers up to the cursor are never
+// processed again after a restart.
+type Flusher interface {
+	Flush(ctx context.Context) error
+}
+
+// JSONLinesSink is a Sink writing every record as a line of JSON with the
+// name of the processor and the sequence of the ledger:
+//
+//	{"processor":"trades","ledger_sequence":123,"record":{...}}
+type JSONLinesSink struct {
+	writer *bufio.Writer
+}
+
+type jsonLine struct {
+	Processor      string `json:"processor"`
+	LedgerSequence uint32 `json:"ledger_sequence"`
+	Record         any    `json:"record"`
+}
+
+// NewJSONLinesSink returns a JSONLinesSink writing to w. Closing the sink
+// doesn't close w.
+func NewJSONLinesSink(w io.Writer) *JSONLinesSink {
+	return &JSONLinesSink{writer: bufio.NewWriter(w)}
+}
+
+func (s *JSONLinesSink) Write(ctx context.Context, batch Batch) error {
+	encoder := json.NewEncoder(s.writer)
+	for _, record := range batch.Records {
+		line := jsonLine{
+			Processor:      record.Processor,
+			LedgerSequence: batch.Ledger.LedgerSequence(),
+			Record:         record.Value,
+		}
+		if err := encoder.Encode(line); err != nil {
+			return fmt.Errorf("failed to encode record of processor %s: %w", record.Processor, err)
+		}
+	}
+	return nil
+}
+
+func (s *JSONLinesSink) Flush(ctx context.Context) error {
+	return s.writer.Flush()
+}
+
+func (s *JSONLinesSink) Close() error {
+	return s.writer.Flush()
+}
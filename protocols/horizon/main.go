@@ -441,6 +441,78 @@ func (res Trade) PagingToken() string {
 	return res.PT
 }
 
+// AccountBalances represents the balances of an account as of a past ledger.
+// Only the balances and assets are set in Balances, the other trust line
+// details are not part of the balance history.
+type AccountBalances struct {
+	Links struct {
+		Self    hal.Link `json:"self"`
+		Account hal.Link `json:"account"`
+		Ledger  hal.Link `json:"ledger"`
+	} `json:"_links"`
+
+	AccountID       string    `json:"account_id"`
+	Ledger          int32     `json:"ledger"`
+	LedgerCloseTime time.Time `json:"ledger_close_time"`
+	Balances        []Balance `json:"balances"`
+}
+
+// ContractEvent represents an event emitted by a contract, or by the host on
+// behalf of a contract, during a Soroban invocation.
+type ContractEvent struct {
+	Links struct {
+		Transaction hal.Link `json:"transaction"`
+		Operation   hal.Link `json:"operation"`
+	} `json:"_links"`
+
+	ID              string    `json:"id"`
+	PT              string    `json:"paging_token"`
+	TransactionHash string    `json:"transaction_hash"`
+	LedgerCloseTime time.Time `json:"ledger_close_time"`
+	Type            string    `json:"type"`
+	ContractID      string    `json:"contract_id,omitempty"`
+	// Topic and Value are base64 encoded xdr.ScVals.
+	Topic []string `json:"topic"`
+	Value string   `json:"value"`
+}
+
+// PagingToken implementation for hal.Pageable
+func (res ContractEvent) PagingToken() string {
+	return res.PT
+}
+
+// TokenTransfer represents a transfer, mint, burn, clawback or fee of a
+// token: a Stellar asset, through its Stellar Asset Contract, or a SEP-41
+// token.
+type TokenTransfer struct {
+	Links struct {
+		Transaction hal.Link  `json:"transaction"`
+		Operation   *hal.Link `json:"operation,omitempty"`
+	} `json:"_links"`
+
+	ID              string    `json:"id"`
+	PT              string    `json:"paging_token"`
+	TransactionHash string    `json:"transaction_hash"`
+	LedgerCloseTime time.Time `json:"ledger_close_time"`
+	Type            string    `json:"type"`
+	From            string    `json:"from,omitempty"`
+	To              string    `json:"to,omitempty"`
+	ToMuxedIDType   string    `json:"to_muxed_id_type,omitempty"`
+	ToMuxedID       string    `json:"to_muxed_id,omitempty"`
+	ContractID      string    `json:"contract_id"`
+	AssetType       string    `json:"asset_type,omitempty"`
+	AssetCode       string    `json:"asset_code,omitempty"`
+	AssetIssuer     string    `json:"asset_issuer,omitempty"`
+	// Amount is in the smallest unit of the token, i.e. stroops for Stellar
+	// assets. Fee refunds have a negative amount.
+	Amount string `json:"amount"`
+}
+
+// PagingToken implementation for hal.Pageable
+func (res TokenTransfer) PagingToken() string {
+	return res.PT
+}
+
 // TradeEffect represents a trade effect resource.
 type TradeEffect struct {
 	Links struct {
@@ -913,3 +985,58 @@ func (f *AssetFilterConfig) UnmarshalJSON(data []byte) error {
 	*f = AssetFilterConfig(config)
 	return nil
 }
+
+type ContractFilterConfig struct {
+	Whitelist    []string `json:"whitelist"`
+	Enabled      *bool    `json:"enabled"`
+	LastModified int64    `json:"last_modified,omitempty"`
+}
+
+func (f *ContractFilterConfig) UnmarshalJSON(data []byte) error {
+	type contractFilterConfig ContractFilterConfig
+	var config = contractFilterConfig{}
+
+	if err := json.Unmarshal(data, &config); err != nil {
+		return err
+	}
+
+	if config.Whitelist == nil {
+		return errors.New("missing required whitelist")
+	}
+
+	if config.Enabled == nil {
+		return errors.New("missing required enabled")
+	}
+
+	*f = ContractFilterConfig(config)
+	return nil
+}
+
+// OperationFilterConfig lists the operation types filtered during ingestion.
+// Mode is either "whitelist" (the default) or "blacklist".
+type OperationFilterConfig struct {
+	OperationTypes []string `json:"operation_types"`
+	Mode           string   `json:"mode,omitempty"`
+	Enabled        *bool    `json:"enabled"`
+	LastModified   int64    `json:"last_modified,omitempty"`
+}
+
+func (f *OperationFilterConfig) UnmarshalJSON(data []byte) error {
+	type operationFilterConfig OperationFilterConfig
+	var config = operationFilterConfig{}
+
+	if err := json.Unmarshal(data, &config); err != nil {
+		return err
+	}
+
+	if config.OperationTypes == nil {
+		return errors.New("missing required operation_types")
+	}
+
+	if config.Enabled == nil {
+		return errors.New("missing required enabled")
+	}
+
+	*f = OperationFilterConfig(config)
+	return nil
+}
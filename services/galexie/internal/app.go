@@ -86,6 +86,15 @@ type App struct {
 	exportManager *ExportManager
 	uploader      Uploader
 	adminServer   *http.Server
+
+	// sourceDataStore is the data store read by the compact command.
+	sourceDataStore datastore.DataStore
+	// compactRange is the range of the ledgers compacted into dataStore by the
+	// compact command.
+	compactRange ledgerbackend.Range
+	// parallelExporter replaces ledgerBackend, exportManager and uploader
+	// when the range is exported by several workers.
+	parallelExporter *parallelExporter
 }
 
 func NewApp() *App {
@@ -100,11 +109,7 @@ func (a *App) init(ctx context.Context, runtimeSettings RuntimeSettings) error {
 
 	logger.Infof("Starting Galexie with version %s", version)
 
-	registry := prometheus.NewRegistry()
-	registry.MustRegister(
-		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{Namespace: nameSpace}),
-		collectors.NewGoCollector(),
-	)
+	registry := newRegistry()
 
 	if a.config, err = NewConfig(runtimeSettings, nil); err != nil {
 		return errors.Wrap(err, "Could not load configuration")
@@ -124,6 +129,12 @@ func (a *App) init(ctx context.Context, runtimeSettings RuntimeSettings) error {
 		return err
 	}
 
+	compressor, err := a.config.DataStoreConfig.NewCompressor()
+	if err != nil {
+		return fmt.Errorf("invalid datastore compression config %w", err)
+	}
+	a.config.DataStoreConfig.Schema.FileExtension = compressor.Name()
+
 	logger.Infof("Attempting to configure datastore...")
 	manifest, created, err := datastore.PublishConfig(ctx, a.dataStore, a.config.DataStoreConfig)
 	if err != nil {
@@ -136,6 +147,16 @@ func (a *App) init(ctx context.Context, runtimeSettings RuntimeSettings) error {
 		logger.WithField("manifest", manifest).Infof("Datastore config manifest already exists.")
 	}
 
+	if a.config.Parallel() {
+		if a.parallelExporter, err = a.initParallelExporter(ctx, registry, compressor); err != nil {
+			return err
+		}
+		if a.config.AdminPort != 0 {
+			a.adminServer = newAdminServer(a.config.AdminPort, registry)
+		}
+		return nil
+	}
+
 	if a.config.Resumable() {
 		if err = a.applyResumability(ctx,
 			datastore.NewResumableManager(a.dataStore, a.config.DataStoreConfig.Schema, archive)); err != nil {
@@ -146,7 +167,7 @@ func (a *App) init(ctx context.Context, runtimeSettings RuntimeSettings) error {
 	logger.Infof("Final computed ledger range for backend retrieval and export, start=%d, end=%d",
 		a.config.StartLedger, a.config.EndLedger)
 
-	if a.ledgerBackend, err = newLedgerBackend(a.config, registry); err != nil {
+	if a.ledgerBackend, err = a.newLedgerBackend(ctx, registry); err != nil {
 		return err
 	}
 
@@ -157,7 +178,9 @@ func (a *App) init(ctx context.Context, runtimeSettings RuntimeSettings) error {
 		a.config.CoreVersion); err != nil {
 		return err
 	}
-	a.uploader = NewUploader(a.dataStore, queue, registry)
+	a.uploader = NewUploader(a.dataStore, compressor, queue, registry)
+	// the pointer is only meaningful when the ledgers are uploaded in order at the tip of the data store
+	a.uploader.publishLatestLedger = a.config.PublishLatestLedger && a.config.Mode == Append
 
 	if a.config.AdminPort != 0 {
 		a.adminServer = newAdminServer(a.config.AdminPort, registry)
@@ -169,14 +192,16 @@ func validateExistingFileExtension(ctx context.Context, ds datastore.DataStore)
 	fileExt, err := datastore.GetLedgerFileExtension(ctx, ds)
 	if err != nil {
 		if errors.Is(err, datastore.ErrNoLedgerFiles) {
-			// Empty data lake is OK. will bootstrap with .zst going forward.
+			// Empty data lake is OK. will bootstrap with the configured codec going forward.
 			log.Infof("no existing ledger files found in data store")
 			return nil
 		}
 		return fmt.Errorf("unable to determine ledger file extension from data store: %w", err)
 	}
 
-	if fileExt != compressxdr.DefaultCompressor.Name() {
+	// Files written with any registered codec can be read back, but the legacy
+	// ".zstd" extension resolves to a codec with a different name and is rejected.
+	if compressor, err := compressxdr.NewCompressorFromExtension(fileExt, compressxdr.CompressorOptions{}); err != nil || compressor.Name() != fileExt {
 		return fmt.Errorf("detected older incompatible ledger files in the data store (extension %q). "+
 			"Galexie v23.0+ requires starting with an empty datastore", fileExt)
 	}
@@ -195,8 +220,9 @@ func (a *App) applyResumability(ctx context.Context, resumableManager datastore.
 
 	// TODO - evaluate a more robust validation of remote data for ledgers-per-file consistency
 	// this assumes ValidateAndSetLedgerRange() has conditioned the a.config.StartLedger to be at least > 1
-	if absentLedger > 2 && absentLedger != a.config.DataStoreConfig.Schema.GetSequenceNumberStartBoundary(absentLedger) {
-		return NewInvalidDataStoreError(absentLedger, a.config.DataStoreConfig.Schema.LedgersPerFile)
+	schema := a.config.exportDataStoreConfig().Schema
+	if absentLedger > 2 && absentLedger != schema.GetSequenceNumberStartBoundary(absentLedger) {
+		return NewInvalidDataStoreError(absentLedger, schema.LedgersPerFile)
 	}
 	logger.Infof("For export ledger range start=%d, end=%d, the remote storage has some of this data already, will resume at later start ledger of %d", a.config.StartLedger, a.config.EndLedger, absentLedger)
 	a.config.StartLedger = absentLedger
@@ -208,11 +234,29 @@ func (a *App) close() {
 	if err := a.dataStore.Close(); err != nil {
 		logger.WithError(err).Error("Error closing datastore")
 	}
+	if a.sourceDataStore != nil {
+		if err := a.sourceDataStore.Close(); err != nil {
+			logger.WithError(err).Error("Error closing source datastore")
+		}
+	}
+	if a.parallelExporter != nil {
+		a.parallelExporter.close()
+		return
+	}
 	if err := a.ledgerBackend.Close(); err != nil {
 		logger.WithError(err).Error("Error closing ledgerBackend")
 	}
 }
 
+func newRegistry() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{Namespace: nameSpace}),
+		collectors.NewGoCollector(),
+	)
+	return registry
+}
+
 func newAdminServer(adminPort int, prometheusRegistry *prometheus.Registry) *http.Server {
 	mux := supporthttp.NewMux(logger)
 	mux.Handle("/metrics", promhttp.HandlerFor(prometheusRegistry, promhttp.HandlerOpts{}))
@@ -228,10 +272,24 @@ func (a *App) Run(runtimeSettings RuntimeSettings) error {
 	ctx, cancel := context.WithCancel(runtimeSettings.Ctx)
 	defer cancel()
 
-	if err := a.init(ctx, runtimeSettings); err != nil {
+	if runtimeSettings.Mode == Verify {
+		return a.verify(ctx, runtimeSettings)
+	}
+
+	init := a.init
+	if runtimeSettings.Mode == Compact {
+		init = a.initCompact
+	}
+	if err := init(ctx, runtimeSettings); err != nil {
 		var dataAlreadyExported *DataAlreadyExportedError
 		if errors.As(err, &dataAlreadyExported) {
 			logger.Info(err.Error())
+			if runtimeSettings.Mode == Compact {
+				// a previous compaction may have been interrupted before
+				// publishing the manifest
+				defer a.close()
+				return a.finishCompact(ctx)
+			}
 			logger.Info("Shutting down Galexie")
 			return nil
 		}
@@ -241,27 +299,40 @@ func (a *App) Run(runtimeSettings RuntimeSettings) error {
 	defer a.close()
 
 	var wg sync.WaitGroup
-	wg.Add(2)
+	if a.parallelExporter != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
 
-	go func() {
-		defer wg.Done()
+			err := a.parallelExporter.Run(ctx)
+			if err != nil && !errors.Is(err, context.Canceled) {
+				logger.WithError(err).Error("Error executing parallel export")
+				cancel()
+			}
+		}()
+	} else {
+		wg.Add(2)
 
-		err := a.uploader.Run(ctx, uploadShutdownTimeout)
-		if err != nil && !errors.Is(err, context.Canceled) {
-			logger.WithError(err).Error("Error executing Uploader")
-			cancel()
-		}
-	}()
+		go func() {
+			defer wg.Done()
 
-	go func() {
-		defer wg.Done()
+			err := a.uploader.Run(ctx, uploadShutdownTimeout)
+			if err != nil && !errors.Is(err, context.Canceled) {
+				logger.WithError(err).Error("Error executing Uploader")
+				cancel()
+			}
+		}()
 
-		err := a.exportManager.Run(ctx, a.config.StartLedger, a.config.EndLedger)
-		if err != nil && !errors.Is(err, context.Canceled) {
-			logger.WithError(err).Error("Error executing ExportManager")
-			cancel()
-		}
-	}()
+		go func() {
+			defer wg.Done()
+
+			err := a.exportManager.Run(ctx, a.config.StartLedger, a.config.EndLedger)
+			if err != nil && !errors.Is(err, context.Canceled) {
+				logger.WithError(err).Error("Error executing ExportManager")
+				cancel()
+			}
+		}()
+	}
 
 	if a.adminServer != nil {
 		// no need to include this goroutine in the wait group
@@ -299,12 +370,72 @@ func (a *App) Run(runtimeSettings RuntimeSettings) error {
 			logger.WithError(err).Warn("error in internalServer.Shutdown")
 		}
 	}
+
+	// the compaction completed unless it failed or was interrupted
+	if runtimeSettings.Mode == Compact && ctx.Err() == nil {
+		return a.finishCompact(ctx)
+	}
 	return nil
 }
 
-// newLedgerBackend Creates and initializes captive core ledger backend
-// Currently, only supports captive-core as ledger backend
-func newLedgerBackend(config *Config, prometheusRegistry *prometheus.Registry) (ledgerbackend.LedgerBackend, error) {
+// verify checks the integrity of the exported files covering the requested range,
+// it does not need stellar-core or the history archives.
+func (a *App) verify(ctx context.Context, runtimeSettings RuntimeSettings) error {
+	var err error
+	if a.config, err = NewConfig(runtimeSettings, nil); err != nil {
+		return errors.Wrap(err, "Could not load configuration")
+	}
+	if a.config.StartLedger < 2 {
+		return errors.New("invalid start value, must be greater than one.")
+	}
+	if a.config.EndLedger < a.config.StartLedger {
+		return errors.New("invalid end value, must be greater than or equal to start")
+	}
+
+	if a.dataStore, err = datastore.NewDataStore(ctx, a.config.DataStoreConfig); err != nil {
+		return fmt.Errorf("could not connect to destination data store %w", err)
+	}
+	defer a.dataStore.Close()
+
+	schema, err := datastore.LoadSchema(ctx, a.dataStore, a.config.DataStoreConfig)
+	if err != nil {
+		return fmt.Errorf("could not load datastore schema %w", err)
+	}
+	compressorOptions, err := a.config.DataStoreConfig.CompressorOptions()
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Verifying ledgers start=%d, end=%d", a.config.StartLedger, a.config.EndLedger)
+	if err = NewVerifier(a.dataStore, schema, compressorOptions).Verify(ctx, a.config.StartLedger, a.config.EndLedger); err != nil {
+		logger.WithError(err).Error("Verification failed")
+		return err
+	}
+	logger.Infof("Verified ledgers start=%d, end=%d", a.config.StartLedger, a.config.EndLedger)
+	return nil
+}
+
+// newLedgerBackend Creates and initializes the ledger backend of the configured source,
+// captive core by default, a Stellar RPC server or another data store.
+func (a *App) newLedgerBackend(ctx context.Context, prometheusRegistry prometheus.Registerer) (ledgerbackend.LedgerBackend, error) {
+	var backend ledgerbackend.LedgerBackend
+	var err error
+	switch a.config.Source {
+	case SourceRPC:
+		backend, err = a.newRPCBackend(ctx)
+	case SourceDataStore:
+		backend, err = a.newDataStoreBackend(ctx)
+	default:
+		backend, err = newCaptiveCoreBackend(a.config)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ledgerbackend.WithMetrics(backend, prometheusRegistry, nameSpace), nil
+}
+
+// newCaptiveCoreBackend Creates and initializes captive core ledger backend
+func newCaptiveCoreBackend(config *Config) (ledgerbackend.LedgerBackend, error) {
 	// best effort check on a core bin available from PATH to provide as default if
 	// no core bin is provided from config.
 	coreBinFromPath, _ := exec.LookPath("stellar-core")
@@ -313,13 +444,10 @@ func newLedgerBackend(config *Config, prometheusRegistry *prometheus.Registry) (
 		return nil, err
 	}
 
-	var backend ledgerbackend.LedgerBackend
 	// Create a new captive core backend
-	backend, err = ledgerbackend.NewCaptive(captiveConfig)
+	backend, err := ledgerbackend.NewCaptive(captiveConfig)
 	if err != nil {
 		return nil, errors.Wrap(err, "Failed to create captive-core instance")
 	}
-	backend = ledgerbackend.WithMetrics(backend, prometheusRegistry, nameSpace)
-
 	return backend, nil
 }
@@ -143,7 +143,12 @@ func TestValidateExistingFileExtension(t *testing.T) {
 			expectedErr: nil,
 		},
 		{
-			name:  "valid schema filename with non-default extension returns error",
+			name:        "valid schema filename with another registered codec, no error",
+			files:       []string{".config.json", "ledger/FFFFFFFF--0.xdr.gz"},
+			expectedErr: nil,
+		},
+		{
+			name:  "valid schema filename with legacy extension returns error",
 			files: []string{".config.json", "ledger/FFFFFFFE--0-999.xdr.zstd"},
 			expectedErr: fmt.Errorf("detected older incompatible ledger files in the data store (extension %q). "+
 				"Galexie v23.0+ requires starting with an empty datastore", "zstd"),
@@ -0,0 +1,131 @@
+package galexie
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/stellar/go/ingest/ledgerbackend"
+	"github.com/stellar/go/support/datastore"
+)
+
+// initCompact configures the App to re-export the ledgers of the source data store
+// (datastore_config) into the destination data store (destination_datastore_config),
+// using the destination's schema and compression. The ledgers are read with a
+// BufferedStorageBackend instead of captive core, and the export resumes from the
+// first file missing in the destination.
+func (a *App) initCompact(ctx context.Context, runtimeSettings RuntimeSettings) error {
+	var err error
+
+	logger.Infof("Starting Galexie with version %s", version)
+
+	registry := newRegistry()
+
+	if a.config, err = NewConfig(runtimeSettings, nil); err != nil {
+		return errors.Wrap(err, "Could not load configuration")
+	}
+	if a.config.StartLedger < 2 {
+		return errors.New("invalid start value, must be greater than one.")
+	}
+	if a.config.EndLedger <= a.config.StartLedger {
+		return errors.New("invalid end value, unbounded mode not supported, end must be greater than start.")
+	}
+	destinationConfig := &a.config.DestinationDataStoreConfig
+	if destinationConfig.Type == "" {
+		return errors.New("invalid config, destination_datastore_config must be set to compact a data store")
+	}
+	if destinationConfig.Schema.LedgersPerFile == 0 || destinationConfig.Schema.FilesPerPartition == 0 {
+		return errors.New("invalid config, destination_datastore_config.schema must set ledgers_per_file and files_per_partition")
+	}
+
+	if a.sourceDataStore, err = datastore.NewDataStore(ctx, a.config.DataStoreConfig); err != nil {
+		return fmt.Errorf("could not connect to source data store %w", err)
+	}
+	backend, sourceSchema, err := newBufferedStorageBackend(ctx, a.sourceDataStore, a.config.DataStoreConfig)
+	if err != nil {
+		return err
+	}
+	a.ledgerBackend = ledgerbackend.WithMetrics(backend, registry, nameSpace)
+
+	if a.dataStore, err = datastore.NewDataStore(ctx, *destinationConfig); err != nil {
+		return fmt.Errorf("could not connect to destination data store %w", err)
+	}
+	if err = validateExistingFileExtension(ctx, a.dataStore); err != nil {
+		return err
+	}
+	compressor, err := destinationConfig.NewCompressor()
+	if err != nil {
+		return fmt.Errorf("invalid destination datastore compression config %w", err)
+	}
+	destinationConfig.Schema.FileExtension = compressor.Name()
+
+	// The manifest is published by finishCompact once the ledgers are compacted, an
+	// existing one is only checked against the destination config.
+	manifest, exists, err := datastore.ValidateConfig(ctx, a.dataStore, *destinationConfig)
+	if err != nil {
+		return fmt.Errorf("could not configure destination datastore %w", err)
+	}
+	if exists {
+		logger.WithField("manifest", manifest).Infof("Destination datastore config manifest already exists.")
+	}
+
+	// Align the range to the destination files, the source must contain all of its ledgers.
+	a.config.StartLedger = max(2, destinationConfig.Schema.GetSequenceNumberStartBoundary(a.config.StartLedger))
+	a.config.EndLedger = destinationConfig.Schema.GetSequenceNumberEndBoundary(a.config.EndLedger)
+	a.compactRange = ledgerbackend.BoundedRange(a.config.StartLedger, a.config.EndLedger)
+	logger.Infof("Computed effective compaction boundary ledger range: start=%d, end=%d",
+		a.config.StartLedger, a.config.EndLedger)
+
+	// the history archive is only needed by the resumable manager for unbounded ranges
+	if err = a.applyResumability(ctx,
+		datastore.NewResumableManager(a.dataStore, destinationConfig.Schema, nil)); err != nil {
+		return err
+	}
+
+	if a.config.CoreVersion, err = sourceCoreVersion(ctx, a.sourceDataStore, sourceSchema, a.config.StartLedger); err != nil {
+		return err
+	}
+
+	queue := NewUploadQueue(uploadQueueCapacity, registry)
+	if a.exportManager, err = NewExportManager(destinationConfig.Schema,
+		a.ledgerBackend, queue, registry,
+		a.config.StellarCoreConfig.NetworkPassphrase,
+		a.config.CoreVersion); err != nil {
+		return err
+	}
+	a.uploader = NewUploader(a.dataStore, compressor, queue, registry)
+
+	if a.config.AdminPort != 0 {
+		a.adminServer = newAdminServer(a.config.AdminPort, registry)
+	}
+	return nil
+}
+
+// finishCompact verifies the compacted ledgers in the destination data store and
+// publishes its manifest, so that the manifest never describes a partially written
+// destination, e.g. after an interrupted compaction.
+func (a *App) finishCompact(ctx context.Context) error {
+	destinationConfig := a.config.DestinationDataStoreConfig
+	compressorOptions, err := destinationConfig.CompressorOptions()
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Verifying compacted ledgers start=%d, end=%d", a.compactRange.From(), a.compactRange.To())
+	verifier := NewVerifier(a.dataStore, destinationConfig.Schema, compressorOptions)
+	if err = verifier.Verify(ctx, a.compactRange.From(), a.compactRange.To()); err != nil {
+		return fmt.Errorf("could not verify compacted ledgers %w", err)
+	}
+
+	manifest, created, err := datastore.PublishConfig(ctx, a.dataStore, destinationConfig)
+	if err != nil {
+		return fmt.Errorf("could not configure destination datastore %w", err)
+	}
+	if created {
+		logger.WithField("manifest", manifest).Infof("Successfully created destination datastore config manifest.")
+	} else {
+		logger.WithField("manifest", manifest).Infof("Destination datastore config manifest already exists.")
+	}
+	return nil
+}
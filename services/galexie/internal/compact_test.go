@@ -0,0 +1,131 @@
+package galexie
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/support/compressxdr"
+	"github.com/stellar/go/support/datastore"
+)
+
+func writeCompactConfig(t *testing.T, sourcePath, destinationPath string) string {
+	configPath := filepath.Join(t.TempDir(), "compact.toml")
+	require.NoError(t, os.WriteFile(configPath, []byte(fmt.Sprintf(`
+[datastore_config]
+type = "Filesystem"
+[datastore_config.params]
+destination_path = %q
+[datastore_config.schema]
+ledgers_per_file = 1
+files_per_partition = 10
+
+[destination_datastore_config]
+type = "Filesystem"
+compression = "gzip"
+[destination_datastore_config.params]
+destination_path = %q
+[destination_datastore_config.schema]
+ledgers_per_file = 4
+files_per_partition = 2
+
+[stellar_core_config]
+network = "testnet"
+`, sourcePath, destinationPath)), 0o644))
+	return configPath
+}
+
+func TestCompact(t *testing.T) {
+	ctx := context.Background()
+	sourcePath, destinationPath := t.TempDir(), t.TempDir()
+	sourceSchema := datastore.DataStoreSchema{LedgersPerFile: 1, FilesPerPartition: 10}
+	source, err := datastore.FromFilesystemPath(sourcePath)
+	require.NoError(t, err)
+	exportTestLedgers(t, source, sourceSchema, compressxdr.DefaultCompressor, 2, 19, nil)
+
+	settings := RuntimeSettings{
+		StartLedger:    3,
+		EndLedger:      14,
+		ConfigFilePath: writeCompactConfig(t, sourcePath, destinationPath),
+		Mode:           Compact,
+		Ctx:            ctx,
+	}
+	require.NoError(t, NewApp().Run(settings))
+
+	destination, err := datastore.FromFilesystemPath(destinationPath)
+	require.NoError(t, err)
+	destinationSchema := datastore.DataStoreSchema{LedgersPerFile: 4, FilesPerPartition: 2, FileExtension: "gz"}
+	require.NoError(t, NewVerifier(destination, destinationSchema, compressxdr.CompressorOptions{}).Verify(ctx, 2, 15))
+
+	// the range is aligned to the destination files
+	exists, err := destination.Exists(ctx, destinationSchema.GetObjectKeyFromSequenceNumber(16))
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	metaDataMap, err := destination.GetFileMetadata(ctx, destinationSchema.GetObjectKeyFromSequenceNumber(8))
+	require.NoError(t, err)
+	metaData, err := datastore.NewMetaDataFromMap(metaDataMap)
+	require.NoError(t, err)
+	require.Equal(t, "v1.2.3", metaData.CoreVersion)
+	require.Equal(t, network.TestNetworkPassphrase, metaData.NetworkPassPhrase)
+	require.Equal(t, "gz", metaData.CompressionType)
+
+	loadedSchema, err := datastore.LoadSchema(ctx, destination, datastore.DataStoreConfig{})
+	require.NoError(t, err)
+	require.Equal(t, destinationSchema, loadedSchema)
+
+	// resumes after the last compacted file
+	settings.EndLedger = 18
+	require.NoError(t, NewApp().Run(settings))
+	require.NoError(t, NewVerifier(destination, destinationSchema, compressxdr.CompressorOptions{}).Verify(ctx, 2, 19))
+
+	// the manifest isn't published before the ledgers are compacted
+	require.NoError(t, os.Remove(filepath.Join(destinationPath, ".config.json")))
+	exportTestLedgers(t, source, sourceSchema, compressxdr.DefaultCompressor, 20, 27, nil)
+	settings.EndLedger = 26
+	app := NewApp()
+	require.NoError(t, app.initCompact(ctx, settings))
+	app.close()
+	exists, err = destination.Exists(ctx, ".config.json")
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	// the manifest of a compaction interrupted before publishing it is published
+	// once the ledgers are found to be compacted
+	settings.EndLedger = 18
+	require.NoError(t, NewApp().Run(settings))
+	loadedSchema, err = datastore.LoadSchema(ctx, destination, datastore.DataStoreConfig{})
+	require.NoError(t, err)
+	require.Equal(t, destinationSchema, loadedSchema)
+}
+
+func TestCompactInvalidConfig(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		name     string
+		settings RuntimeSettings
+		expected string
+	}{
+		{
+			name:     "no destination",
+			settings: RuntimeSettings{StartLedger: 2, EndLedger: 10, ConfigFilePath: "test/test.toml"},
+			expected: "destination_datastore_config must be set",
+		},
+		{
+			name:     "unbounded",
+			settings: RuntimeSettings{StartLedger: 2, ConfigFilePath: "test/test.toml"},
+			expected: "unbounded mode not supported",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.settings.Mode = Compact
+			tc.settings.Ctx = ctx
+			require.ErrorContains(t, NewApp().Run(tc.settings), tc.expected)
+		})
+	}
+}
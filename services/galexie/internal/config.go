@@ -13,6 +13,7 @@ import (
 
 	"github.com/pelletier/go-toml"
 
+	"github.com/stellar/go/support/compressxdr"
 	"github.com/stellar/go/support/datastore"
 	"github.com/stellar/go/support/errors"
 	"github.com/stellar/go/support/storage"
@@ -24,12 +25,21 @@ const (
 	UserAgent = "galexie"
 )
 
+// Ledger sources galexie can export from.
+const (
+	SourceCaptiveCore = "captive-core"
+	SourceRPC         = "rpc"
+	SourceDataStore   = "datastore"
+)
+
 type Mode int
 
 const (
 	_        Mode = iota
 	ScanFill Mode = iota
 	Append
+	Verify
+	Compact
 )
 
 func (mode Mode) Name() string {
@@ -38,13 +48,14 @@ func (mode Mode) Name() string {
 		return "Scan and Fill"
 	case Append:
 		return "Append"
+	case Verify:
+		return "Verify"
+	case Compact:
+		return "Compact"
 	}
 	return "none"
 }
 
-// user-configurable in the future.
-const compressionType = "zstd"
-
 type RuntimeSettings struct {
 	StartLedger    uint32
 	EndLedger      uint32
@@ -63,13 +74,39 @@ type StellarCoreConfig struct {
 	StoragePath           string   `toml:"storage_path"`
 }
 
+type RPCSourceConfig struct {
+	URL        string `toml:"url"`
+	BufferSize uint32 `toml:"buffer_size"`
+}
+
 type Config struct {
 	AdminPort int `toml:"admin_port"`
 
+	// Source is where the exported ledgers are read from, one of
+	// SourceCaptiveCore (default), SourceRPC or SourceDataStore.
+	Source                string                    `toml:"source"`
+	RPCSourceConfig       RPCSourceConfig           `toml:"rpc_source_config"`
+	SourceDataStoreConfig datastore.DataStoreConfig `toml:"source_datastore_config"`
+
+	// ParallelWorkers is the number of ledger backends the scan-and-fill command
+	// exports with concurrently, each one exporting its own sub-ranges of the range.
+	ParallelWorkers uint32 `toml:"parallel_workers"`
+	// ParallelSubRangeSize is the number of ledgers of each sub-range, it is rounded up
+	// to be checkpoint and file aligned. Defaults to splitting the range evenly between the workers.
+	ParallelSubRangeSize uint32 `toml:"parallel_sub_range_size"`
+
+	// PublishLatestLedger makes the append command update a small pointer object holding
+	// the latest ledger uploaded, which readers poll to tail the data store cheaply.
+	PublishLatestLedger bool `toml:"publish_latest_ledger"`
+
 	DataStoreConfig   datastore.DataStoreConfig `toml:"datastore_config"`
 	StellarCoreConfig StellarCoreConfig         `toml:"stellar_core_config"`
 	UserAgent         string                    `toml:"user_agent"`
 
+	// DestinationDataStoreConfig is the data store written by the compact command,
+	// which reads the ledgers from DataStoreConfig.
+	DestinationDataStoreConfig datastore.DataStoreConfig `toml:"destination_datastore_config"`
+
 	StartLedger uint32
 	EndLedger   uint32
 	Mode        Mode
@@ -110,7 +147,20 @@ func NewConfig(settings RuntimeSettings, getCoreVersionFn ledgerbackend.CoreBuil
 }
 
 func (config *Config) Resumable() bool {
-	return config.Mode == Append
+	return config.Mode == Append || config.Mode == Compact
+}
+
+// Parallel returns true if the requested range is exported by several workers concurrently.
+func (config *Config) Parallel() bool {
+	return config.Mode == ScanFill && config.ParallelWorkers > 1
+}
+
+// exportDataStoreConfig returns the config of the data store ledgers are exported to.
+func (config *Config) exportDataStoreConfig() *datastore.DataStoreConfig {
+	if config.Mode == Compact {
+		return &config.DestinationDataStoreConfig
+	}
+	return &config.DataStoreConfig
 }
 
 // Validates requested ledger range, and will automatically adjust it
@@ -191,15 +241,11 @@ func (config *Config) GenerateCaptiveCoreConfig(coreBinFromPath string) (ledgerb
 		return ledgerbackend.CaptiveCoreConfig{}, errors.Wrap(err, "Failed to create captive-core toml")
 	}
 
-	checkpointFrequency := historyarchive.DefaultCheckpointFrequency
-	if config.StellarCoreConfig.CheckpointFrequency > 0 {
-		checkpointFrequency = config.StellarCoreConfig.CheckpointFrequency
-	}
 	return ledgerbackend.CaptiveCoreConfig{
 		BinaryPath:          config.StellarCoreConfig.StellarCoreBinaryPath,
 		NetworkPassphrase:   params.NetworkPassphrase,
 		HistoryArchiveURLs:  params.HistoryArchiveURLs,
-		CheckpointFrequency: checkpointFrequency,
+		CheckpointFrequency: config.checkpointFrequency(),
 		Log:                 logger.WithField("subservice", "stellar-core"),
 		Toml:                captiveCoreToml,
 		UserAgent:           config.UserAgent,
@@ -207,6 +253,13 @@ func (config *Config) GenerateCaptiveCoreConfig(coreBinFromPath string) (ledgerb
 	}, nil
 }
 
+func (config *Config) checkpointFrequency() uint32 {
+	if config.StellarCoreConfig.CheckpointFrequency > 0 {
+		return config.StellarCoreConfig.CheckpointFrequency
+	}
+	return historyarchive.DefaultCheckpointFrequency
+}
+
 func (c *Config) setCoreVersionInfo() (err error) {
 	c.CoreVersion, err = c.CoreBuildVersionFn(c.StellarCoreConfig.StellarCoreBinaryPath)
 	if err != nil {
@@ -232,9 +285,37 @@ func (config *Config) processToml(tomlPath string) error {
 		config.UserAgent = UserAgent
 	}
 
-	if config.StellarCoreConfig.Network == "" && (len(config.StellarCoreConfig.HistoryArchiveUrls) == 0 || config.StellarCoreConfig.NetworkPassphrase == "" || config.StellarCoreConfig.CaptiveCoreTomlPath == "") {
-		return errors.New("Invalid captive core config, the 'network' parameter must be set to pubnet or testnet or " +
-			"'stellar_core_config.history_archive_urls' and 'stellar_core_config.network_passphrase' and 'stellar_core_config.captive_core_toml_path' must be set.")
+	switch config.Source {
+	case "":
+		config.Source = SourceCaptiveCore
+	case SourceCaptiveCore:
+	case SourceRPC:
+		if config.RPCSourceConfig.URL == "" {
+			return errors.New("invalid source config, 'rpc_source_config.url' must be set when source is 'rpc'")
+		}
+	case SourceDataStore:
+		if config.SourceDataStoreConfig.Type == "" {
+			return errors.New("invalid source config, 'source_datastore_config' must be set when source is 'datastore'")
+		}
+	default:
+		return errors.Errorf("invalid source %q, must be one of '%s', '%s' or '%s'",
+			config.Source, SourceCaptiveCore, SourceRPC, SourceDataStore)
+	}
+
+	// Only the captive-core source needs a captive-core toml, the other sources only need
+	// the network passphrase and history archives to publish the datastore manifest and
+	// validate the ledger range.
+	captiveCore := config.Source == SourceCaptiveCore
+	manualNetwork := len(config.StellarCoreConfig.HistoryArchiveUrls) > 0 && config.StellarCoreConfig.NetworkPassphrase != ""
+	if config.StellarCoreConfig.Network == "" {
+		if captiveCore && (!manualNetwork || config.StellarCoreConfig.CaptiveCoreTomlPath == "") {
+			return errors.New("Invalid captive core config, the 'network' parameter must be set to pubnet or testnet or " +
+				"'stellar_core_config.history_archive_urls' and 'stellar_core_config.network_passphrase' and 'stellar_core_config.captive_core_toml_path' must be set.")
+		}
+		if !manualNetwork {
+			return errors.New("Invalid network config, the 'network' parameter must be set to pubnet or testnet or " +
+				"'stellar_core_config.history_archive_urls' and 'stellar_core_config.network_passphrase' must be set.")
+		}
 	}
 
 	// network config values are an overlay, with network preconfigured values being first if network is present
@@ -247,12 +328,16 @@ func (config *Config) processToml(tomlPath string) error {
 	case Pubnet:
 		networkPassPhrase = network.PublicNetworkPassphrase
 		networkArchiveUrls = network.PublicNetworkhistoryArchiveURLs
-		config.SerializedCaptiveCoreToml = ledgerbackend.PubnetDefaultConfig
+		if captiveCore {
+			config.SerializedCaptiveCoreToml = ledgerbackend.PubnetDefaultConfig
+		}
 
 	case Testnet:
 		networkPassPhrase = network.TestNetworkPassphrase
 		networkArchiveUrls = network.TestNetworkhistoryArchiveURLs
-		config.SerializedCaptiveCoreToml = ledgerbackend.TestnetDefaultConfig
+		if captiveCore {
+			config.SerializedCaptiveCoreToml = ledgerbackend.TestnetDefaultConfig
+		}
 
 	default:
 		return errors.New("invalid captive core config, " +
@@ -268,7 +353,7 @@ func (config *Config) processToml(tomlPath string) error {
 		config.StellarCoreConfig.HistoryArchiveUrls = networkArchiveUrls
 	}
 
-	if config.StellarCoreConfig.CaptiveCoreTomlPath != "" {
+	if captiveCore && config.StellarCoreConfig.CaptiveCoreTomlPath != "" {
 		if config.SerializedCaptiveCoreToml, err = os.ReadFile(config.StellarCoreConfig.CaptiveCoreTomlPath); err != nil {
 			return errors.Wrap(err, "Failed to load captive-core-toml-path file")
 		}
@@ -276,7 +361,18 @@ func (config *Config) processToml(tomlPath string) error {
 
 	// Populate the datastore config with the network passphrase for datastore manifest.
 	config.DataStoreConfig.NetworkPassphrase = config.StellarCoreConfig.NetworkPassphrase
-	config.DataStoreConfig.Compression = compressionType
+	if config.DataStoreConfig.Compression == "" {
+		config.DataStoreConfig.Compression = compressxdr.Zstd
+	}
+	if config.SourceDataStoreConfig.Type != "" {
+		config.SourceDataStoreConfig.NetworkPassphrase = config.StellarCoreConfig.NetworkPassphrase
+	}
+	if config.DestinationDataStoreConfig.Type != "" {
+		config.DestinationDataStoreConfig.NetworkPassphrase = config.StellarCoreConfig.NetworkPassphrase
+		if config.DestinationDataStoreConfig.Compression == "" {
+			config.DestinationDataStoreConfig.Compression = compressxdr.Zstd
+		}
+	}
 
 	return nil
 }
@@ -65,8 +65,56 @@ func TestNewConfigUserAgent(t *testing.T) {
 	require.Equal(t, config.UserAgent, "useragent_x")
 }
 
+func TestSourceConfig(t *testing.T) {
+	config, err := NewConfig(RuntimeSettings{ConfigFilePath: "test/useragent.toml"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, SourceCaptiveCore, config.Source)
+
+	config, err = NewConfig(RuntimeSettings{ConfigFilePath: "test/rpc_source.toml"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, SourceRPC, config.Source)
+	require.Equal(t, RPCSourceConfig{URL: "http://localhost:8000", BufferSize: 25}, config.RPCSourceConfig)
+
+	config, err = NewConfig(RuntimeSettings{ConfigFilePath: "test/datastore_source.toml"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, SourceDataStore, config.Source)
+	require.Equal(t, "GCS", config.SourceDataStoreConfig.Type)
+	require.Equal(t, network.TestNetworkPassphrase, config.SourceDataStoreConfig.NetworkPassphrase)
+
+	_, err = NewConfig(RuntimeSettings{ConfigFilePath: "test/invalid_rpc_source.toml"}, nil)
+	require.ErrorContains(t, err, "'rpc_source_config.url' must be set")
+
+	_, err = NewConfig(RuntimeSettings{ConfigFilePath: "test/invalid_source.toml"}, nil)
+	require.ErrorContains(t, err, `invalid source "horizon"`)
+}
+
+func TestNonCaptiveCoreSourceConfig(t *testing.T) {
+	for _, testCase := range []struct {
+		configFile string
+		source     string
+	}{
+		{"test/rpc_source_manual_network.toml", SourceRPC},
+		{"test/datastore_source_manual_network.toml", SourceDataStore},
+	} {
+		config, err := NewConfig(RuntimeSettings{ConfigFilePath: testCase.configFile}, nil)
+		require.NoError(t, err, testCase.configFile)
+		require.Equal(t, testCase.source, config.Source)
+		require.Equal(t, "test", config.StellarCoreConfig.NetworkPassphrase)
+		require.Equal(t, "test", config.DataStoreConfig.NetworkPassphrase)
+		require.Equal(t, []string{"http://testarchive"}, config.StellarCoreConfig.HistoryArchiveUrls)
+		require.Empty(t, config.SerializedCaptiveCoreToml)
+	}
+
+	config, err := NewConfig(RuntimeSettings{ConfigFilePath: "test/rpc_source.toml"}, nil)
+	require.NoError(t, err)
+	require.Empty(t, config.SerializedCaptiveCoreToml)
+
+	_, err = NewConfig(RuntimeSettings{ConfigFilePath: "test/invalid_rpc_source_network.toml"}, nil)
+	require.ErrorContains(t, err, "Invalid network config")
+}
+
 func TestResumeDisabled(t *testing.T) {
-	// resumable is only enabled when mode is Append
+	// resumable is only enabled when mode is Append or Compact
 	config, err := NewConfig(
 		RuntimeSettings{StartLedger: 2, EndLedger: 3, ConfigFilePath: "test/test.toml", Mode: ScanFill}, nil)
 	require.NoError(t, err)
@@ -26,7 +26,7 @@ type ExportManager struct {
 func NewExportManager(dataStoreSchema datastore.DataStoreSchema,
 	backend ledgerbackend.LedgerBackend,
 	queue UploadQueue,
-	prometheusRegistry *prometheus.Registry,
+	prometheusRegistry prometheus.Registerer,
 	networkPassPhrase string,
 	coreVersion string) (*ExportManager, error) {
 	if dataStoreSchema.LedgersPerFile < 1 {
@@ -75,7 +75,8 @@ func (e *ExportManager) AddLedgerCloseMeta(ctx context.Context, ledgerCloseMeta
 	}
 
 	if ledgerSeq >= uint32(e.currentMetaArchive.EndSequence) {
-		ledgerMetaArchive, err := NewLedgerMetaArchiveFromXDR(e.networkPassPhrase, e.coreVersion, objectKey, *e.currentMetaArchive)
+		ledgerMetaArchive, err := NewLedgerMetaArchiveFromXDR(e.networkPassPhrase, e.coreVersion, objectKey,
+			e.dataStoreSchema.FileExtension, *e.currentMetaArchive)
 		if err != nil {
 			return err
 		}
@@ -93,6 +94,12 @@ func (e *ExportManager) AddLedgerCloseMeta(ctx context.Context, ledgerCloseMeta
 // signal is received.
 func (e *ExportManager) Run(ctx context.Context, startLedger, endLedger uint32) error {
 	defer e.queue.Close()
+	return e.exportRange(ctx, startLedger, endLedger)
+}
+
+// exportRange exports the specified range of ledgers without closing the upload queue,
+// so that several ranges can be exported through the same queue.
+func (e *ExportManager) exportRange(ctx context.Context, startLedger, endLedger uint32) error {
 	labels := prometheus.Labels{
 		"start_ledger": strconv.FormatUint(uint64(startLedger), 10),
 		"end_ledger":   strconv.FormatUint(uint64(endLedger), 10),
@@ -1,6 +1,9 @@
 package galexie
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+
 	"github.com/stellar/go/support/compressxdr"
 	"github.com/stellar/go/support/datastore"
 	"github.com/stellar/go/xdr"
@@ -14,7 +17,8 @@ type LedgerMetaArchive struct {
 }
 
 // NewLedgerMetaArchiveFromXDR creates a new LedgerMetaArchive instance.
-func NewLedgerMetaArchiveFromXDR(networkPassPhrase string, coreVersion string, key string, data xdr.LedgerCloseMetaBatch) (*LedgerMetaArchive, error) {
+// compressionType is the name of the compressor the archive is uploaded with, zstd if empty.
+func NewLedgerMetaArchiveFromXDR(networkPassPhrase string, coreVersion string, key string, compressionType string, data xdr.LedgerCloseMetaBatch) (*LedgerMetaArchive, error) {
 	startLedger, err := data.GetLedger(uint32(data.StartSequence))
 	if err != nil {
 		return &LedgerMetaArchive{}, err
@@ -25,19 +29,32 @@ func NewLedgerMetaArchiveFromXDR(networkPassPhrase string, coreVersion string, k
 		return &LedgerMetaArchive{}, err
 	}
 
+	// The checksum covers the uncompressed XDR so it is independent of the codec.
+	digest := sha256.New()
+	if _, err = xdr.Marshal(digest, data); err != nil {
+		return &LedgerMetaArchive{}, err
+	}
+
+	if compressionType == "" {
+		compressionType = compressxdr.DefaultCompressor.Name()
+	}
+
 	return &LedgerMetaArchive{
 		ObjectKey: key,
 		Data:      data,
 		metaData: datastore.MetaData{
-			StartLedger:          startLedger.LedgerSequence(),
-			EndLedger:            endLedger.LedgerSequence(),
-			StartLedgerCloseTime: startLedger.LedgerCloseTime(),
-			EndLedgerCloseTime:   endLedger.LedgerCloseTime(),
-			NetworkPassPhrase:    networkPassPhrase,
-			CompressionType:      compressxdr.DefaultCompressor.Name(),
-			ProtocolVersion:      endLedger.ProtocolVersion(),
-			CoreVersion:          coreVersion,
-			Version:              version,
+			StartLedger:             startLedger.LedgerSequence(),
+			EndLedger:               endLedger.LedgerSequence(),
+			StartLedgerCloseTime:    startLedger.LedgerCloseTime(),
+			EndLedgerCloseTime:      endLedger.LedgerCloseTime(),
+			NetworkPassPhrase:       networkPassPhrase,
+			CompressionType:         compressionType,
+			ProtocolVersion:         endLedger.ProtocolVersion(),
+			CoreVersion:             coreVersion,
+			Version:                 version,
+			BatchSHA256:             hex.EncodeToString(digest.Sum(nil)),
+			StartLedgerPreviousHash: startLedger.PreviousLedgerHash().HexString(),
+			EndLedgerHash:           endLedger.LedgerHash().HexString(),
 		},
 	}, nil
 }
@@ -1,6 +1,8 @@
 package galexie
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -18,22 +20,28 @@ func TestNewLedgerMetaArchiveFromXDR(t *testing.T) {
 		},
 	}
 
-	archive, err := NewLedgerMetaArchiveFromXDR("testnet", "v1.2.3", "key", data)
+	data.LedgerCloseMetas[0].V0.LedgerHeader.Hash = xdr.Hash{0xaa}
+	data.LedgerCloseMetas[0].V0.LedgerHeader.Header.PreviousLedgerHash = xdr.Hash{0xbb}
+
+	archive, err := NewLedgerMetaArchiveFromXDR("testnet", "v1.2.3", "key", "", data)
 
 	require.NoError(t, err)
 	require.NotNil(t, archive)
 
 	// Check if the metadata fields are correctly populated
 	expectedMetaData := datastore.MetaData{
-		StartLedger:          1234,
-		EndLedger:            1234,
-		StartLedgerCloseTime: 1234 * 100,
-		EndLedgerCloseTime:   1234 * 100,
-		NetworkPassPhrase:    "testnet",
-		CompressionType:      "zst",
-		ProtocolVersion:      21,
-		CoreVersion:          "v1.2.3",
-		Version:              "develop",
+		StartLedger:             1234,
+		EndLedger:               1234,
+		StartLedgerCloseTime:    1234 * 100,
+		EndLedgerCloseTime:      1234 * 100,
+		NetworkPassPhrase:       "testnet",
+		CompressionType:         "zst",
+		ProtocolVersion:         21,
+		CoreVersion:             "v1.2.3",
+		Version:                 "develop",
+		BatchSHA256:             batchSHA256(t, data),
+		StartLedgerPreviousHash: xdr.Hash{0xbb}.HexString(),
+		EndLedgerHash:           xdr.Hash{0xaa}.HexString(),
 	}
 
 	require.Equal(t, expectedMetaData, archive.metaData)
@@ -49,23 +57,33 @@ func TestNewLedgerMetaArchiveFromXDR(t *testing.T) {
 		},
 	}
 
-	archive, err = NewLedgerMetaArchiveFromXDR("testnet", "v1.2.3", "key", data)
+	archive, err = NewLedgerMetaArchiveFromXDR("testnet", "v1.2.3", "key", "gz", data)
 
 	require.NoError(t, err)
 	require.NotNil(t, archive)
 
 	// Check if the metadata fields are correctly populated
 	expectedMetaData = datastore.MetaData{
-		StartLedger:          1234,
-		EndLedger:            1237,
-		StartLedgerCloseTime: 1234 * 100,
-		EndLedgerCloseTime:   1237 * 100,
-		NetworkPassPhrase:    "testnet",
-		CompressionType:      "zst",
-		ProtocolVersion:      21,
-		CoreVersion:          "v1.2.3",
-		Version:              "develop",
+		StartLedger:             1234,
+		EndLedger:               1237,
+		StartLedgerCloseTime:    1234 * 100,
+		EndLedgerCloseTime:      1237 * 100,
+		NetworkPassPhrase:       "testnet",
+		CompressionType:         "gz",
+		ProtocolVersion:         21,
+		CoreVersion:             "v1.2.3",
+		Version:                 "develop",
+		BatchSHA256:             batchSHA256(t, data),
+		StartLedgerPreviousHash: xdr.Hash{}.HexString(),
+		EndLedgerHash:           xdr.Hash{}.HexString(),
 	}
 
 	require.Equal(t, expectedMetaData, archive.metaData)
 }
+
+func batchSHA256(t *testing.T, data xdr.LedgerCloseMetaBatch) string {
+	raw, err := data.MarshalBinary()
+	require.NoError(t, err)
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
@@ -67,8 +67,49 @@ func defineCommands() *cobra.Command {
 		},
 	}
 
+	var verifyCmd = &cobra.Command{
+		Use:   "verify",
+		Short: "verifies the checksums and ledger hash chain of the exported files between 'start' and 'end' flags.",
+		Long: "re-downloads the exported files containing ledgers between 'start' and 'end' flags and verifies their checksums, " +
+			"recorded hashes and the PreviousLedgerHash continuity of the ledgers across file boundaries.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			settings := bindCliParameters(cmd.PersistentFlags().Lookup("start"),
+				cmd.PersistentFlags().Lookup("end"),
+				cmd.PersistentFlags().Lookup("config-file"),
+			)
+			settings.Mode = Verify
+			settings.Ctx = cmd.Context()
+			if settings.Ctx == nil {
+				settings.Ctx = context.Background()
+			}
+			return galexieCmdRunner(settings)
+		},
+	}
+
+	var compactCmd = &cobra.Command{
+		Use:   "compact",
+		Short: "re-exports the ledgers between 'start' and 'end' flags from the data store into the destination data store using its schema and compression.",
+		Long: "reads the ledgers between 'start' and 'end' flags from the data store configured by 'datastore_config' and exports them " +
+			"into the data store configured by 'destination_datastore_config', changing the ledgers per file, files per partition " +
+			"and compression codec. Resumes from the first file missing in the destination data store.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			settings := bindCliParameters(cmd.PersistentFlags().Lookup("start"),
+				cmd.PersistentFlags().Lookup("end"),
+				cmd.PersistentFlags().Lookup("config-file"),
+			)
+			settings.Mode = Compact
+			settings.Ctx = cmd.Context()
+			if settings.Ctx == nil {
+				settings.Ctx = context.Background()
+			}
+			return galexieCmdRunner(settings)
+		},
+	}
+
 	rootCmd.AddCommand(scanAndFillCmd)
 	rootCmd.AddCommand(appendCmd)
+	rootCmd.AddCommand(verifyCmd)
+	rootCmd.AddCommand(compactCmd)
 
 	scanAndFillCmd.PersistentFlags().Uint32P("start", "s", 0, "Starting ledger (inclusive), must be set to a value greater than 1")
 	scanAndFillCmd.PersistentFlags().Uint32P("end", "e", 0, "Ending ledger (inclusive), must be set to value greater than 'start' and less than the network's current ledger")
@@ -82,6 +123,17 @@ func defineCommands() *cobra.Command {
 	appendCmd.PersistentFlags().String("config-file", "config.toml", "Path to the TOML config file. Defaults to 'config.toml' on runtime working directory path.")
 	viper.BindPFlags(appendCmd.PersistentFlags())
 
+	verifyCmd.PersistentFlags().Uint32P("start", "s", 0, "Starting ledger (inclusive), must be set to a value greater than 1")
+	verifyCmd.PersistentFlags().Uint32P("end", "e", 0, "Ending ledger (inclusive), must be set to value greater than or equal to 'start'")
+	verifyCmd.PersistentFlags().String("config-file", "config.toml", "Path to the TOML config file. Defaults to 'config.toml' on runtime working directory path.")
+	viper.BindPFlags(verifyCmd.PersistentFlags())
+
+	compactCmd.PersistentFlags().Uint32P("start", "s", 0, "Starting ledger (inclusive), must be set to a value greater than 1")
+	compactCmd.PersistentFlags().Uint32P("end", "e", 0, "Ending ledger (inclusive), must be set to value greater than 'start', "+
+		"the source data store must contain all the ledgers of the destination files covering the range")
+	compactCmd.PersistentFlags().String("config-file", "config.toml", "Path to the TOML config file. Defaults to 'config.toml' on runtime working directory path.")
+	viper.BindPFlags(compactCmd.PersistentFlags())
+
 	return rootCmd
 }
 
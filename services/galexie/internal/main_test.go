@@ -93,6 +93,32 @@ func TestFlagsOutput(t *testing.T) {
 				Ctx:            ctx,
 			},
 		},
+		{
+			name:              "verify sub-command with start and end present",
+			commandArgs:       []string{"verify", "--start", "4", "--end", "5", "--config-file", "myfile"},
+			expectedErrOutput: "",
+			appRunner:         appRunnerSuccess,
+			expectedSettings: RuntimeSettings{
+				StartLedger:    4,
+				EndLedger:      5,
+				ConfigFilePath: "myfile",
+				Mode:           Verify,
+				Ctx:            ctx,
+			},
+		},
+		{
+			name:              "compact sub-command with start and end present",
+			commandArgs:       []string{"compact", "--start", "4", "--end", "5", "--config-file", "myfile"},
+			expectedErrOutput: "",
+			appRunner:         appRunnerSuccess,
+			expectedSettings: RuntimeSettings{
+				StartLedger:    4,
+				EndLedger:      5,
+				ConfigFilePath: "myfile",
+				Mode:           Compact,
+				Ctx:            ctx,
+			},
+		},
 		{
 			name:              "scanfill sub-command prints app error",
 			commandArgs:       []string{"scan-and-fill", "--start", "4", "--end", "5", "--config-file", "myfile"},
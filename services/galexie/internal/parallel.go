@@ -0,0 +1,263 @@
+package galexie
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stellar/go/ingest/ledgerbackend"
+	"github.com/stellar/go/support/compressxdr"
+	"github.com/stellar/go/support/datastore"
+)
+
+// progressDirectory is where the markers of the completely uploaded sub-ranges
+// of parallel exports are stored in the data store.
+const progressDirectory = ".progress"
+
+// ledgerRange is an inclusive range of ledgers.
+type ledgerRange struct {
+	Start uint32 `json:"start"`
+	End   uint32 `json:"end"`
+}
+
+// splitLedgerRange splits [start, end] into contiguous sub-ranges of at most size ledgers.
+// size is rounded up to a multiple of alignment and the sub-ranges are split on multiples
+// of size, so the same sub-ranges are computed for overlapping requested ranges.
+func splitLedgerRange(start, end, size, alignment uint32) []ledgerRange {
+	alignedSize := uint64(max(size, 1)+alignment-1) / uint64(alignment) * uint64(alignment)
+
+	var ranges []ledgerRange
+	for from := uint64(start); from <= uint64(end); {
+		to := min(uint64(end), (from/alignedSize+1)*alignedSize-1)
+		ranges = append(ranges, ledgerRange{Start: uint32(from), End: uint32(to)})
+		from = to + 1
+	}
+	return ranges
+}
+
+func gcd(a, b uint32) uint32 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// lcm returns the least common multiple of a and b, which must be greater than zero.
+func lcm(a, b uint32) uint32 {
+	return a / gcd(a, b) * b
+}
+
+// rangeProgress records the sub-ranges of a parallel export which have been completely
+// uploaded as marker objects in the data store, an interrupted export only exports
+// the sub-ranges without a marker when it is restarted.
+type rangeProgress struct {
+	dataStore datastore.DataStore
+	ranges    []ledgerRange
+	// byEnd indexes ranges by their end ledger
+	byEnd map[uint32]ledgerRange
+}
+
+func newRangeProgress(dataStore datastore.DataStore, ranges []ledgerRange) *rangeProgress {
+	progress := &rangeProgress{
+		dataStore: dataStore,
+		ranges:    ranges,
+		byEnd:     make(map[uint32]ledgerRange, len(ranges)),
+	}
+	for _, r := range ranges {
+		progress.byEnd[r.End] = r
+	}
+	return progress
+}
+
+func progressObjectKey(r ledgerRange) string {
+	return fmt.Sprintf("%s/%d-%d.json", progressDirectory, r.Start, r.End)
+}
+
+// remaining returns the sub-ranges which have not been completely uploaded.
+func (p *rangeProgress) remaining(ctx context.Context) ([]ledgerRange, error) {
+	var remaining []ledgerRange
+	for _, r := range p.ranges {
+		exists, err := p.dataStore.Exists(ctx, progressObjectKey(r))
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to check progress of ledgers %d-%d", r.Start, r.End)
+		}
+		if !exists {
+			remaining = append(remaining, r)
+		}
+	}
+	return remaining, nil
+}
+
+// uploaded records the sub-range ending at ledger end as complete, if there is one.
+// Each sub-range is exported in order, so all of its files have been uploaded by then.
+func (p *rangeProgress) uploaded(ctx context.Context, end uint32) error {
+	r, ok := p.byEnd[end]
+	if !ok {
+		return nil
+	}
+	content, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if err = p.dataStore.PutFile(ctx, progressObjectKey(r), bytes.NewReader(content), nil); err != nil {
+		return errors.Wrapf(err, "unable to record progress of ledgers %d-%d", r.Start, r.End)
+	}
+	logger.Infof("Completed export of ledgers %d-%d", r.Start, r.End)
+	return nil
+}
+
+// exportWorker exports sub-ranges with its own ledger backend and upload pipeline.
+type exportWorker struct {
+	ledgerBackend ledgerbackend.LedgerBackend
+	queue         UploadQueue
+	exportManager *ExportManager
+	uploader      Uploader
+}
+
+// parallelExporter exports sub-ranges of a bounded range with several workers concurrently,
+// each worker picks the next sub-range to export once it is done with its current one.
+type parallelExporter struct {
+	workers   []exportWorker
+	subRanges []ledgerRange
+}
+
+// workerRegisterer registers the metrics of worker i with a "worker" label.
+func workerRegisterer(prometheusRegistry prometheus.Registerer, i int) prometheus.Registerer {
+	return prometheus.WrapRegistererWith(prometheus.Labels{"worker": strconv.Itoa(i)}, prometheusRegistry)
+}
+
+// newParallelExporter creates a worker for each of the backends, their metrics are
+// registered with a "worker" label. progress is updated as the sub-ranges are uploaded.
+func newParallelExporter(
+	dataStore datastore.DataStore,
+	schema datastore.DataStoreSchema,
+	compressor compressxdr.Compressor,
+	progress *rangeProgress,
+	subRanges []ledgerRange,
+	backends []ledgerbackend.LedgerBackend,
+	prometheusRegistry prometheus.Registerer,
+	networkPassPhrase string,
+	coreVersion string,
+) (*parallelExporter, error) {
+	exporter := &parallelExporter{subRanges: subRanges}
+	for i, backend := range backends {
+		registry := workerRegisterer(prometheusRegistry, i)
+		queue := NewUploadQueue(uploadQueueCapacity, registry)
+		exportManager, err := NewExportManager(schema, backend, queue, registry, networkPassPhrase, coreVersion)
+		if err != nil {
+			return nil, err
+		}
+		uploader := NewUploader(dataStore, compressor, queue, registry)
+		uploader.progress = progress
+		exporter.workers = append(exporter.workers, exportWorker{
+			ledgerBackend: backend,
+			queue:         queue,
+			exportManager: exportManager,
+			uploader:      uploader,
+		})
+	}
+	return exporter, nil
+}
+
+// initParallelExporter splits the configured range into sub-ranges aligned to both the
+// checkpoints and the files, and creates a ledger backend of the configured source for each worker.
+func (a *App) initParallelExporter(ctx context.Context, registry *prometheus.Registry, compressor compressxdr.Compressor) (*parallelExporter, error) {
+	schema := a.config.DataStoreConfig.Schema
+	subRangeSize := a.config.ParallelSubRangeSize
+	if subRangeSize == 0 {
+		subRangeSize = (a.config.EndLedger - a.config.StartLedger + a.config.ParallelWorkers) / a.config.ParallelWorkers
+	}
+	// captive core replays a bounded range from the checkpoint preceding its start,
+	// the other sources don't need the alignment but it doesn't hurt them either
+	alignment := lcm(a.config.checkpointFrequency(), schema.LedgersPerFile)
+	progress := newRangeProgress(a.dataStore,
+		splitLedgerRange(a.config.StartLedger, a.config.EndLedger, subRangeSize, alignment))
+	subRanges, err := progress.remaining(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(subRanges) == 0 {
+		return nil, NewDataAlreadyExportedError(a.config.StartLedger, a.config.EndLedger)
+	}
+	workers := min(int(a.config.ParallelWorkers), len(subRanges))
+	logger.Infof("Exporting %d of %d sub-ranges of ledger range start=%d, end=%d with %d workers",
+		len(subRanges), len(progress.ranges), a.config.StartLedger, a.config.EndLedger, workers)
+
+	var backends []ledgerbackend.LedgerBackend
+	for i := 0; i < workers; i++ {
+		backend, err := a.newLedgerBackend(ctx, workerRegisterer(registry, i))
+		if err != nil {
+			for _, created := range backends {
+				created.Close()
+			}
+			return nil, err
+		}
+		backends = append(backends, backend)
+	}
+
+	return newParallelExporter(a.dataStore, schema, compressor, progress, subRanges, backends, registry,
+		a.config.StellarCoreConfig.NetworkPassphrase, a.config.CoreVersion)
+}
+
+// Run exports all the sub-ranges and returns once they are uploaded,
+// the first error cancels the export of the remaining sub-ranges.
+func (p *parallelExporter) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	subRanges := make(chan ledgerRange, len(p.subRanges))
+	for _, r := range p.subRanges {
+		subRanges <- r
+	}
+	close(subRanges)
+
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	var runErr error
+	fail := func(err error) {
+		errOnce.Do(func() { runErr = err })
+		cancel()
+	}
+
+	for i, worker := range p.workers {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if err := worker.uploader.Run(ctx, uploadShutdownTimeout); err != nil {
+				fail(errors.Wrapf(err, "worker %d failed to upload", i))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			defer worker.queue.Close()
+			for r := range subRanges {
+				if ctx.Err() != nil {
+					return
+				}
+				logger.Infof("Worker %d exporting ledgers %d-%d", i, r.Start, r.End)
+				if err := worker.exportManager.exportRange(ctx, r.Start, r.End); err != nil {
+					fail(errors.Wrapf(err, "worker %d failed to export ledgers %d-%d", i, r.Start, r.End))
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	return runErr
+}
+
+// close closes the ledger backends of the workers.
+func (p *parallelExporter) close() {
+	for i, worker := range p.workers {
+		if err := worker.ledgerBackend.Close(); err != nil {
+			logger.WithError(err).Errorf("Error closing ledgerBackend of worker %d", i)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package galexie
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/ingest/cdp"
+	"github.com/stellar/go/ingest/ledgerbackend"
+	"github.com/stellar/go/support/compressxdr"
+	"github.com/stellar/go/support/datastore"
+)
+
+func TestSplitLedgerRange(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		start     uint32
+		end       uint32
+		size      uint32
+		alignment uint32
+		expected  []ledgerRange
+	}{
+		{
+			name: "aligned", start: 0, end: 191, size: 64, alignment: 64,
+			expected: []ledgerRange{{0, 63}, {64, 127}, {128, 191}},
+		},
+		{
+			name: "unaligned start and end", start: 2, end: 150, size: 64, alignment: 64,
+			expected: []ledgerRange{{2, 63}, {64, 127}, {128, 150}},
+		},
+		{
+			name: "size rounded up to alignment", start: 2, end: 255, size: 100, alignment: 64,
+			expected: []ledgerRange{{2, 127}, {128, 255}},
+		},
+		{
+			name: "single range", start: 70, end: 80, size: 64, alignment: 64,
+			expected: []ledgerRange{{70, 80}},
+		},
+		{
+			name: "near max ledger", start: 4294967232, end: 4294967295, size: 32, alignment: 32,
+			expected: []ledgerRange{{4294967232, 4294967263}, {4294967264, 4294967295}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, splitLedgerRange(tc.start, tc.end, tc.size, tc.alignment))
+		})
+	}
+	require.Equal(t, uint32(192), lcm(64, 48))
+	require.Equal(t, uint32(64), lcm(64, 1))
+}
+
+func TestParallelExporter(t *testing.T) {
+	ctx := context.Background()
+	sourceSchema := datastore.DataStoreSchema{LedgersPerFile: 1, FilesPerPartition: 10}
+	source := newVerifierTestDataStore(t)
+	exportTestLedgers(t, source, sourceSchema, compressxdr.DefaultCompressor, 2, 99, nil)
+	sourceSchema.FileExtension = compressxdr.DefaultCompressor.Name()
+
+	schema := datastore.DataStoreSchema{LedgersPerFile: 4, FilesPerPartition: 8,
+		FileExtension: compressxdr.DefaultCompressor.Name()}
+	destination := newVerifierTestDataStore(t)
+	progress := newRangeProgress(destination, splitLedgerRange(2, 99, 16, 16))
+	require.Len(t, progress.ranges, 7)
+
+	// simulate an interrupted export which had completed ledgers 32-47
+	require.NoError(t, destination.PutFile(ctx, progressObjectKey(ledgerRange{32, 47}), bytes.NewReader(nil), nil))
+	subRanges, err := progress.remaining(ctx)
+	require.NoError(t, err)
+	require.Len(t, subRanges, 6)
+	require.NotContains(t, subRanges, ledgerRange{32, 47})
+
+	var backends []ledgerbackend.LedgerBackend
+	for i := 0; i < 3; i++ {
+		backend, err := ledgerbackend.NewBufferedStorageBackend(cdp.DefaultBufferedStorageBackendConfig(1), source, sourceSchema)
+		require.NoError(t, err)
+		backends = append(backends, backend)
+	}
+	exporter, err := newParallelExporter(destination, schema, compressxdr.DefaultCompressor, progress,
+		subRanges, backends, prometheus.NewRegistry(), "testnet", "v1.2.3")
+	require.NoError(t, err)
+	defer exporter.close()
+	require.NoError(t, exporter.Run(ctx))
+
+	verifier := NewVerifier(destination, schema, compressxdr.CompressorOptions{})
+	require.NoError(t, verifier.Verify(ctx, 2, 31))
+	require.NoError(t, verifier.Verify(ctx, 48, 99))
+	for seq := uint32(32); seq <= 47; seq += schema.LedgersPerFile {
+		exists, err := destination.Exists(ctx, schema.GetObjectKeyFromSequenceNumber(seq))
+		require.NoError(t, err)
+		require.False(t, exists)
+	}
+
+	subRanges, err = progress.remaining(ctx)
+	require.NoError(t, err)
+	require.Empty(t, subRanges)
+}
+
+func TestParallelExporterFailure(t *testing.T) {
+	ctx := context.Background()
+	schema := datastore.DataStoreSchema{LedgersPerFile: 4, FilesPerPartition: 8,
+		FileExtension: compressxdr.DefaultCompressor.Name()}
+	destination := newVerifierTestDataStore(t)
+	subRanges := splitLedgerRange(2, 31, 16, 16)
+	progress := newRangeProgress(destination, subRanges)
+
+	backend := &ledgerbackend.MockDatabaseBackend{}
+	backend.On("PrepareRange", mock.Anything, ledgerbackend.BoundedRange(2, 15)).Return(context.DeadlineExceeded).Once()
+	exporter, err := newParallelExporter(destination, schema, compressxdr.DefaultCompressor, progress,
+		subRanges, []ledgerbackend.LedgerBackend{backend}, prometheus.NewRegistry(), "testnet", "v1.2.3")
+	require.NoError(t, err)
+
+	require.ErrorContains(t, exporter.Run(ctx), "worker 0 failed to export ledgers 2-15")
+	remaining, err := progress.remaining(ctx)
+	require.NoError(t, err)
+	require.Equal(t, subRanges, remaining)
+	backend.AssertExpectations(t)
+}
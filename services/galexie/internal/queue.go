@@ -13,7 +13,7 @@ type UploadQueue struct {
 }
 
 // NewUploadQueue constructs a new UploadQueue
-func NewUploadQueue(size int, prometheusRegistry *prometheus.Registry) UploadQueue {
+func NewUploadQueue(size int, prometheusRegistry prometheus.Registerer) UploadQueue {
 	queueLengthMetric := prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace: nameSpace,
 		Subsystem: "upload_queue",
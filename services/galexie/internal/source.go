@@ -0,0 +1,105 @@
+package galexie
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	rpc "github.com/stellar/stellar-rpc/client"
+
+	"github.com/stellar/go/ingest/cdp"
+	"github.com/stellar/go/ingest/ledgerbackend"
+	"github.com/stellar/go/support/datastore"
+)
+
+// newRPCBackend creates a backend which reads the ledgers from a Stellar RPC server,
+// the server must be on the configured network and retain the requested ledgers.
+func (a *App) newRPCBackend(ctx context.Context) (ledgerbackend.LedgerBackend, error) {
+	client := rpc.NewClient(a.config.RPCSourceConfig.URL, nil)
+	defer client.Close()
+
+	network, err := client.GetNetwork(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to retrieve network from rpc source")
+	}
+	if network.Passphrase != a.config.StellarCoreConfig.NetworkPassphrase {
+		return nil, errors.Errorf("rpc source network passphrase %q does not match configured network passphrase %q",
+			network.Passphrase, a.config.StellarCoreConfig.NetworkPassphrase)
+	}
+
+	versionInfo, err := client.GetVersionInfo(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to retrieve version info from rpc source")
+	}
+	a.config.CoreVersion = versionInfo.CaptiveCoreVersion
+	logger.Infof("rpc source stellar-core version: %s", a.config.CoreVersion)
+
+	return ledgerbackend.NewRPCLedgerBackend(ledgerbackend.RPCLedgerBackendOptions{
+		RPCServerURL: a.config.RPCSourceConfig.URL,
+		BufferSize:   a.config.RPCSourceConfig.BufferSize,
+	}), nil
+}
+
+// newDataStoreBackend creates a backend which reads the ledgers from the files of
+// another data store, the source data store is shared by all the backends created.
+func (a *App) newDataStoreBackend(ctx context.Context) (ledgerbackend.LedgerBackend, error) {
+	var err error
+	if a.sourceDataStore == nil {
+		if a.sourceDataStore, err = datastore.NewDataStore(ctx, a.config.SourceDataStoreConfig); err != nil {
+			return nil, fmt.Errorf("could not connect to source data store %w", err)
+		}
+	}
+
+	backend, schema, err := newBufferedStorageBackend(ctx, a.sourceDataStore, a.config.SourceDataStoreConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	coreVersion, err := sourceCoreVersion(ctx, a.sourceDataStore, schema, a.config.StartLedger)
+	if errors.Is(err, os.ErrNotExist) {
+		// the source may not have exported the start ledger yet when exporting an unbounded range
+		logger.Warnf("Source data store has no file for ledger %d, stellar-core version will not be recorded", a.config.StartLedger)
+	} else if err != nil {
+		backend.Close()
+		return nil, err
+	}
+	a.config.CoreVersion = coreVersion
+	return backend, nil
+}
+
+// newBufferedStorageBackend creates a BufferedStorageBackend reading the ledger files of dataStore
+// with the schema published in its manifest. The codec of each file is detected from its extension.
+func newBufferedStorageBackend(ctx context.Context, dataStore datastore.DataStore, cfg datastore.DataStoreConfig) (ledgerbackend.LedgerBackend, datastore.DataStoreSchema, error) {
+	cfg.Compression = ""
+	schema, err := datastore.LoadSchema(ctx, dataStore, cfg)
+	if err != nil {
+		return nil, datastore.DataStoreSchema{}, fmt.Errorf("could not load source datastore schema %w", err)
+	}
+
+	bufferedConfig := cdp.DefaultBufferedStorageBackendConfig(schema.LedgersPerFile)
+	bufferedConfig.CompressionDictionaryPath = cfg.CompressionDictionaryPath
+	backend, err := ledgerbackend.NewBufferedStorageBackend(bufferedConfig, dataStore, schema)
+	if err != nil {
+		return nil, datastore.DataStoreSchema{}, errors.Wrap(err, "Failed to create buffered storage backend for source data store")
+	}
+	return backend, schema, nil
+}
+
+// sourceCoreVersion returns the stellar-core version recorded in the metadata of the
+// source file containing ledger seq, it is carried over to the exported files.
+func sourceCoreVersion(ctx context.Context, ds datastore.DataStore, schema datastore.DataStoreSchema, seq uint32) (string, error) {
+	objectKey, err := datastore.FindLedgerObjectKey(ctx, ds, schema, seq)
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to find source file for ledger %d", seq)
+	}
+	metaDataMap, err := ds.GetFileMetadata(ctx, objectKey)
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to retrieve metadata: %s", objectKey)
+	}
+	metaData, err := datastore.NewMetaDataFromMap(metaDataMap)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid metadata: %s", objectKey)
+	}
+	return metaData.CoreVersion, nil
+}
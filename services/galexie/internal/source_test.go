@@ -0,0 +1,104 @@
+package galexie
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/ingest/ledgerbackend"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/support/compressxdr"
+	"github.com/stellar/go/support/datastore"
+)
+
+func TestDataStoreSource(t *testing.T) {
+	ctx := context.Background()
+	sourcePath := t.TempDir()
+	source, err := datastore.FromFilesystemPath(sourcePath)
+	require.NoError(t, err)
+	schema := datastore.DataStoreSchema{LedgersPerFile: 2, FilesPerPartition: 10}
+	exportTestLedgers(t, source, schema, compressxdr.DefaultCompressor, 2, 9, nil)
+
+	app := &App{config: &Config{
+		Source:      SourceDataStore,
+		StartLedger: 4,
+		SourceDataStoreConfig: datastore.DataStoreConfig{
+			Type:   "Filesystem",
+			Params: map[string]string{"destination_path": sourcePath},
+			Schema: schema,
+		},
+	}}
+	backend, err := app.newLedgerBackend(ctx, prometheus.NewRegistry())
+	require.NoError(t, err)
+	defer backend.Close()
+	defer app.sourceDataStore.Close()
+	require.Equal(t, "v1.2.3", app.config.CoreVersion)
+
+	require.NoError(t, backend.PrepareRange(ctx, ledgerbackend.BoundedRange(4, 9)))
+	for seq := uint32(4); seq <= 9; seq++ {
+		lcm, err := backend.GetLedger(ctx, seq)
+		require.NoError(t, err)
+		require.Equal(t, seq, lcm.LedgerSequence())
+	}
+}
+
+func newTestRPCServer(t *testing.T, passphrase string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		var result interface{}
+		switch request.Method {
+		case "getNetwork":
+			result = map[string]interface{}{"passphrase": passphrase, "protocolVersion": 22}
+		case "getVersionInfo":
+			result = map[string]interface{}{"captiveCoreVersion": "stellar-core 22.1.0"}
+		default:
+			t.Fatalf("unexpected rpc method %s", request.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      request.ID,
+			"result":  result,
+		}))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRPCSource(t *testing.T) {
+	ctx := context.Background()
+	server := newTestRPCServer(t, network.TestNetworkPassphrase)
+
+	app := &App{config: &Config{
+		Source:            SourceRPC,
+		RPCSourceConfig:   RPCSourceConfig{URL: server.URL},
+		StellarCoreConfig: StellarCoreConfig{NetworkPassphrase: network.TestNetworkPassphrase},
+	}}
+	backend, err := app.newLedgerBackend(ctx, prometheus.NewRegistry())
+	require.NoError(t, err)
+	require.NoError(t, backend.Close())
+	require.Equal(t, "stellar-core 22.1.0", app.config.CoreVersion)
+}
+
+func TestRPCSourceNetworkMismatch(t *testing.T) {
+	ctx := context.Background()
+	server := newTestRPCServer(t, network.PublicNetworkPassphrase)
+
+	app := &App{config: &Config{
+		Source:            SourceRPC,
+		RPCSourceConfig:   RPCSourceConfig{URL: server.URL},
+		StellarCoreConfig: StellarCoreConfig{NetworkPassphrase: network.TestNetworkPassphrase},
+	}}
+	_, err := app.newLedgerBackend(ctx, prometheus.NewRegistry())
+	require.ErrorContains(t, err, "does not match configured network passphrase")
+}
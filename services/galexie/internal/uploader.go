@@ -16,17 +16,24 @@ import (
 // Uploader is responsible for uploading data to a storage destination.
 type Uploader struct {
 	dataStore            datastore.DataStore
+	compressor           compressxdr.Compressor
 	queue                UploadQueue
 	uploadDurationMetric *prometheus.SummaryVec
 	objectSizeMetrics    *prometheus.SummaryVec
 	latestLedgerMetric   prometheus.Gauge
+	// progress, optional, records the sub-ranges of a parallel export which are completely uploaded
+	progress *rangeProgress
+	// publishLatestLedger updates the data store's latest ledger pointer after each upload,
+	// see datastore.LatestLedgerPointerNotifier
+	publishLatestLedger bool
 }
 
 // NewUploader constructs a new Uploader instance
 func NewUploader(
 	destination datastore.DataStore,
+	compressor compressxdr.Compressor,
 	queue UploadQueue,
-	prometheusRegistry *prometheus.Registry,
+	prometheusRegistry prometheus.Registerer,
 ) Uploader {
 	uploadDurationMetric := prometheus.NewSummaryVec(
 		prometheus.SummaryOpts{
@@ -51,6 +58,7 @@ func NewUploader(
 	prometheusRegistry.MustRegister(uploadDurationMetric, objectSizeMetrics, latestLedgerMetric)
 	return Uploader{
 		dataStore:            destination,
+		compressor:           compressor,
 		queue:                queue,
 		uploadDurationMetric: uploadDurationMetric,
 		objectSizeMetrics:    objectSizeMetrics,
@@ -90,7 +98,7 @@ func (u Uploader) Upload(ctx context.Context, metaArchive *LedgerMetaArchive) er
 	startTime := time.Now()
 	numLedgers := strconv.FormatUint(uint64(len(metaArchive.Data.LedgerCloseMetas)), 10)
 
-	xdrEncoder := compressxdr.NewXDREncoder(compressxdr.DefaultCompressor, &metaArchive.Data)
+	xdrEncoder := compressxdr.NewXDREncoder(u.compressor, &metaArchive.Data)
 
 	writerTo := &writerToRecorder{
 		WriterTo: xdrEncoder,
@@ -118,6 +126,15 @@ func (u Uploader) Upload(ctx context.Context, metaArchive *LedgerMetaArchive) er
 		"already_exists": alreadyExists,
 	}).Observe(float64(writerTo.totalCompressed))
 	u.latestLedgerMetric.Set(float64(metaArchive.Data.EndSequence))
+
+	if u.publishLatestLedger {
+		if err = datastore.WriteLatestLedger(ctx, u.dataStore, uint32(metaArchive.Data.EndSequence)); err != nil {
+			return err
+		}
+	}
+	if u.progress != nil {
+		return u.progress.uploaded(ctx, uint32(metaArchive.Data.EndSequence))
+	}
 	return nil
 }
 
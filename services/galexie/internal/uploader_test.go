@@ -74,7 +74,7 @@ func (s *UploaderSuite) TestUploadWithMetadata() {
 
 	registry := prometheus.NewRegistry()
 	queue := NewUploadQueue(1, registry)
-	dataUploader := NewUploader(&s.mockDataStore, queue, registry)
+	dataUploader := NewUploader(&s.mockDataStore, compressxdr.DefaultCompressor, queue, registry)
 	s.Require().NoError(dataUploader.Upload(context.Background(), archive))
 
 }
@@ -97,7 +97,7 @@ func (s *UploaderSuite) testUpload(putOkReturnVal bool) {
 
 	registry := prometheus.NewRegistry()
 	queue := NewUploadQueue(1, registry)
-	dataUploader := NewUploader(&s.mockDataStore, queue, registry)
+	dataUploader := NewUploader(&s.mockDataStore, compressxdr.DefaultCompressor, queue, registry)
 	s.Require().NoError(dataUploader.Upload(context.Background(), archive))
 
 	expectedCompressedLength := capturedBuf.Len()
@@ -205,7 +205,7 @@ func (s *UploaderSuite) testUploadPutError(putOkReturnVal bool) {
 
 	registry := prometheus.NewRegistry()
 	queue := NewUploadQueue(1, registry)
-	dataUploader := NewUploader(&s.mockDataStore, queue, registry)
+	dataUploader := NewUploader(&s.mockDataStore, compressxdr.DefaultCompressor, queue, registry)
 	err := dataUploader.Upload(context.Background(), archive)
 	s.Require().Equal(fmt.Sprintf("error uploading %s: error in PutFileIfNotExists", key), err.Error())
 
@@ -262,7 +262,7 @@ func (s *UploaderSuite) TestRunUntilQueueClose() {
 		queue.Close()
 	}()
 
-	dataUploader := NewUploader(&s.mockDataStore, queue, registry)
+	dataUploader := NewUploader(&s.mockDataStore, compressxdr.DefaultCompressor, queue, registry)
 	s.Require().NoError(dataUploader.Run(context.Background(), testShutdownDelayTime))
 
 	s.Require().Equal(
@@ -271,6 +271,26 @@ func (s *UploaderSuite) TestRunUntilQueueClose() {
 	)
 }
 
+func (s *UploaderSuite) TestUploadPublishLatestLedger() {
+	ds, err := datastore.FromFilesystemPath(s.T().TempDir())
+	s.Require().NoError(err)
+	defer ds.Close()
+
+	registry := prometheus.NewRegistry()
+	queue := NewUploadQueue(1, registry)
+	dataUploader := NewUploader(ds, compressxdr.DefaultCompressor, queue, registry)
+	dataUploader.publishLatestLedger = true
+
+	for _, end := range []uint32{9, 19} {
+		archive := NewLedgerMetaArchive(fmt.Sprintf("test-%d", end), end-9, end)
+		s.Require().NoError(dataUploader.Upload(s.ctx, archive))
+
+		latest, err := datastore.ReadLatestLedger(s.ctx, ds)
+		s.Require().NoError(err)
+		s.Require().Equal(end, latest)
+	}
+}
+
 func (s *UploaderSuite) TestRunContextCancel() {
 	ctx, cancel := context.WithCancel(context.Background())
 	registry := prometheus.NewRegistry()
@@ -291,7 +311,7 @@ func (s *UploaderSuite) TestRunContextCancel() {
 		s.Require().NoError(queue.Enqueue(s.ctx, NewLedgerMetaArchive("test1", 2, 2)))
 	}()
 
-	dataUploader := NewUploader(&s.mockDataStore, queue, registry)
+	dataUploader := NewUploader(&s.mockDataStore, compressxdr.DefaultCompressor, queue, registry)
 	s.Require().EqualError(dataUploader.Run(ctx, testShutdownDelayTime), "context canceled")
 	s.Require().Equal(
 		float64(2),
@@ -309,7 +329,7 @@ func (s *UploaderSuite) TestRunUploadError() {
 	s.mockDataStore.On("PutFileIfNotExists", mock.Anything, "test",
 		mock.Anything, mock.Anything).Return(false, errors.New("Put error")).Once()
 
-	dataUploader := NewUploader(&s.mockDataStore, queue, registry)
+	dataUploader := NewUploader(&s.mockDataStore, compressxdr.DefaultCompressor, queue, registry)
 	err := dataUploader.Run(context.Background(), testShutdownDelayTime)
 	s.Require().Equal("error uploading test: Put error", err.Error())
 }
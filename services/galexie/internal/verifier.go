@@ -0,0 +1,188 @@
+package galexie
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/stellar/go/support/compressxdr"
+	"github.com/stellar/go/support/datastore"
+	"github.com/stellar/go/xdr"
+)
+
+// VerificationError is returned by Verifier.Verify when the data store content
+// does not pass the integrity checks. Problems lists each issue found.
+type VerificationError struct {
+	Problems []string
+}
+
+func (e *VerificationError) Error() string {
+	return fmt.Sprintf("verification found %d problem(s): %s", len(e.Problems), strings.Join(e.Problems, "; "))
+}
+
+// Verifier re-downloads exported ledger files and checks them against the
+// integrity metadata recorded by the Uploader.
+type Verifier struct {
+	dataStore         datastore.DataStore
+	schema            datastore.DataStoreSchema
+	compressorOptions compressxdr.CompressorOptions
+}
+
+// NewVerifier constructs a new Verifier instance. compressorOptions are used to
+// decompress the files, whichever codec they were written with.
+func NewVerifier(dataStore datastore.DataStore, schema datastore.DataStoreSchema, compressorOptions compressxdr.CompressorOptions) *Verifier {
+	return &Verifier{
+		dataStore:         dataStore,
+		schema:            schema,
+		compressorOptions: compressorOptions,
+	}
+}
+
+// Verify checks every file containing ledgers in the range [start, end]:
+//   - the file exists, decompresses and decodes to a batch of the expected ledgers
+//   - the SHA-256 of the uncompressed batch and its hash chain endpoints match the file metadata
+//   - each ledger's PreviousLedgerHash matches the hash of the ledger before it,
+//     including across file boundaries
+//
+// Files exported before checksums were recorded are only checked for content and continuity.
+// A *VerificationError is returned if any check fails, other errors abort the verification.
+func (v *Verifier) Verify(ctx context.Context, start, end uint32) error {
+	var problems []string
+	var previous *xdr.LedgerCloseMeta
+
+	for fileStart := v.schema.GetSequenceNumberStartBoundary(start); fileStart <= end; fileStart += v.schema.LedgersPerFile {
+		batch, fileProblems, err := v.verifyFile(ctx, fileStart)
+		if err != nil {
+			return err
+		}
+		problems = append(problems, fileProblems...)
+		if batch == nil {
+			// continuity can't be checked against a file which couldn't be read
+			previous = nil
+			continue
+		}
+
+		first := batch.LedgerCloseMetas[0]
+		if previous != nil && first.PreviousLedgerHash() != previous.LedgerHash() {
+			problems = append(problems, fmt.Sprintf(
+				"ledger %d previous ledger hash %s does not match hash %s of ledger %d",
+				first.LedgerSequence(), first.PreviousLedgerHash().HexString(),
+				previous.LedgerHash().HexString(), previous.LedgerSequence()))
+		}
+		previous = &batch.LedgerCloseMetas[len(batch.LedgerCloseMetas)-1]
+
+		logger.Debugf("Verified ledgers %d-%d", batch.StartSequence, batch.EndSequence)
+	}
+
+	if len(problems) > 0 {
+		return &VerificationError{Problems: problems}
+	}
+	return nil
+}
+
+// verifyFile checks the file starting at ledger fileStart and returns its decoded
+// batch, or nil if the file is missing or unreadable.
+func (v *Verifier) verifyFile(ctx context.Context, fileStart uint32) (*xdr.LedgerCloseMetaBatch, []string, error) {
+	objectKey := v.schema.GetObjectKeyFromSequenceNumber(fileStart)
+	exists, err := v.dataStore.Exists(ctx, objectKey)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "unable to check file: %s", objectKey)
+	}
+	if !exists {
+		objectKey, err = datastore.FindLedgerObjectKey(ctx, v.dataStore, v.schema, fileStart)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, []string{fmt.Sprintf("file for ledger %d is missing", max(fileStart, 2))}, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	metaDataMap, err := v.dataStore.GetFileMetadata(ctx, objectKey)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "unable to retrieve metadata: %s", objectKey)
+	}
+	metaData, err := datastore.NewMetaDataFromMap(metaDataMap)
+	if err != nil {
+		return nil, []string{fmt.Sprintf("%s: invalid metadata: %v", objectKey, err)}, nil
+	}
+
+	compressor, err := compressxdr.NewCompressorFromExtension(
+		strings.TrimPrefix(path.Ext(objectKey), "."), v.compressorOptions)
+	if err != nil {
+		return nil, []string{fmt.Sprintf("%s: %v", objectKey, err)}, nil
+	}
+
+	reader, err := v.dataStore.GetFile(ctx, objectKey)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "unable to retrieve file: %s", objectKey)
+	}
+	raw, err := decompress(reader, compressor)
+	if err != nil {
+		return nil, []string{fmt.Sprintf("%s: unable to decompress: %v", objectKey, err)}, nil
+	}
+
+	var problems []string
+	if metaData.BatchSHA256 == "" {
+		logger.Warnf("%s has no checksum recorded, skipping checksum verification", objectKey)
+	} else if sum := sha256.Sum256(raw); hex.EncodeToString(sum[:]) != metaData.BatchSHA256 {
+		problems = append(problems, fmt.Sprintf("%s: sha256 %x does not match recorded %s",
+			objectKey, sum, metaData.BatchSHA256))
+	}
+
+	var batch xdr.LedgerCloseMetaBatch
+	if err = batch.UnmarshalBinary(raw); err != nil {
+		return nil, append(problems, fmt.Sprintf("%s: unable to decode batch: %v", objectKey, err)), nil
+	}
+
+	expectedStart := max(fileStart, 2)
+	expectedEnd := v.schema.GetSequenceNumberEndBoundary(fileStart)
+	if uint32(batch.StartSequence) != expectedStart || uint32(batch.EndSequence) != expectedEnd ||
+		len(batch.LedgerCloseMetas) != int(expectedEnd-expectedStart+1) {
+		return nil, append(problems, fmt.Sprintf("%s: contains ledgers %d-%d (%d ledgers), expected %d-%d",
+			objectKey, batch.StartSequence, batch.EndSequence, len(batch.LedgerCloseMetas),
+			expectedStart, expectedEnd)), nil
+	}
+
+	for i, lcm := range batch.LedgerCloseMetas {
+		if lcm.LedgerSequence() != expectedStart+uint32(i) {
+			problems = append(problems, fmt.Sprintf("%s: ledger %d found at position of ledger %d",
+				objectKey, lcm.LedgerSequence(), expectedStart+uint32(i)))
+		} else if i > 0 && lcm.PreviousLedgerHash() != batch.LedgerCloseMetas[i-1].LedgerHash() {
+			problems = append(problems, fmt.Sprintf("%s: ledger %d previous ledger hash does not match hash of ledger %d",
+				objectKey, lcm.LedgerSequence(), lcm.LedgerSequence()-1))
+		}
+	}
+
+	first, last := batch.LedgerCloseMetas[0], batch.LedgerCloseMetas[len(batch.LedgerCloseMetas)-1]
+	if metaData.StartLedgerPreviousHash != "" && metaData.StartLedgerPreviousHash != first.PreviousLedgerHash().HexString() {
+		problems = append(problems, fmt.Sprintf("%s: previous ledger hash %s does not match recorded %s",
+			objectKey, first.PreviousLedgerHash().HexString(), metaData.StartLedgerPreviousHash))
+	}
+	if metaData.EndLedgerHash != "" && metaData.EndLedgerHash != last.LedgerHash().HexString() {
+		problems = append(problems, fmt.Sprintf("%s: end ledger hash %s does not match recorded %s",
+			objectKey, last.LedgerHash().HexString(), metaData.EndLedgerHash))
+	}
+
+	return &batch, problems, nil
+}
+
+// decompress reads and closes reader, returning its uncompressed content.
+func decompress(reader io.ReadCloser, compressor compressxdr.Compressor) ([]byte, error) {
+	defer reader.Close()
+
+	zr, err := compressor.NewReader(reader)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	return io.ReadAll(zr)
+}
@@ -0,0 +1,171 @@
+package galexie
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/support/compressxdr"
+	"github.com/stellar/go/support/datastore"
+	"github.com/stellar/go/xdr"
+)
+
+func createChainedLedgerCloseMeta(ledgerSeq uint32) xdr.LedgerCloseMeta {
+	lcm := createLedgerCloseMeta(ledgerSeq)
+	lcm.V0.LedgerHeader.Hash = xdr.Hash{byte(ledgerSeq)}
+	lcm.V0.LedgerHeader.Header.PreviousLedgerHash = xdr.Hash{byte(ledgerSeq - 1)}
+	return lcm
+}
+
+// exportTestLedgers uploads ledgers [start, end] in files of schema.LedgersPerFile ledgers,
+// modify is applied to each batch after its metadata has been computed.
+func exportTestLedgers(t *testing.T, ds datastore.DataStore, schema datastore.DataStoreSchema,
+	compressor compressxdr.Compressor, start, end uint32, modify func(*LedgerMetaArchive)) {
+	uploader := NewUploader(ds, compressor, NewUploadQueue(1, prometheus.NewRegistry()), prometheus.NewRegistry())
+	schema.FileExtension = compressor.Name()
+	for fileStart := start; fileStart <= end; fileStart = schema.GetSequenceNumberEndBoundary(fileStart) + 1 {
+		batch := xdr.LedgerCloseMetaBatch{
+			StartSequence: xdr.Uint32(fileStart),
+			EndSequence:   xdr.Uint32(schema.GetSequenceNumberEndBoundary(fileStart)),
+		}
+		for seq := fileStart; seq <= uint32(batch.EndSequence); seq++ {
+			require.NoError(t, batch.AddLedger(createChainedLedgerCloseMeta(seq)))
+		}
+		archive, err := NewLedgerMetaArchiveFromXDR("testnet", "v1.2.3",
+			schema.GetObjectKeyFromSequenceNumber(fileStart), compressor.Name(), batch)
+		require.NoError(t, err)
+		if modify != nil {
+			modify(archive)
+		}
+		require.NoError(t, uploader.Upload(context.Background(), archive))
+	}
+}
+
+// rebuildMetaData returns the metadata matching the archive's modified data,
+// as the Uploader would have recorded it.
+func rebuildMetaData(t *testing.T, archive *LedgerMetaArchive) datastore.MetaData {
+	rebuilt, err := NewLedgerMetaArchiveFromXDR("testnet", "v1.2.3", archive.ObjectKey,
+		archive.metaData.CompressionType, archive.Data)
+	require.NoError(t, err)
+	return rebuilt.metaData
+}
+
+func newVerifierTestDataStore(t *testing.T) datastore.DataStore {
+	ds, err := datastore.FromFilesystemPath(t.TempDir())
+	require.NoError(t, err)
+	return ds
+}
+
+func TestVerify(t *testing.T) {
+	ctx := context.Background()
+	schema := datastore.DataStoreSchema{LedgersPerFile: 4, FilesPerPartition: 2}
+	ds := newVerifierTestDataStore(t)
+	exportTestLedgers(t, ds, schema, compressxdr.DefaultCompressor, 2, 11, nil)
+
+	verifier := NewVerifier(ds, schema, compressxdr.CompressorOptions{})
+	require.NoError(t, verifier.Verify(ctx, 2, 11))
+	require.NoError(t, verifier.Verify(ctx, 5, 9))
+}
+
+func TestVerifyMixedCodecs(t *testing.T) {
+	ctx := context.Background()
+	schema := datastore.DataStoreSchema{LedgersPerFile: 4, FilesPerPartition: 2}
+	ds := newVerifierTestDataStore(t)
+	gzipCompressor, err := compressxdr.NewCompressor(compressxdr.Gzip, compressxdr.CompressorOptions{})
+	require.NoError(t, err)
+	exportTestLedgers(t, ds, schema, compressxdr.DefaultCompressor, 2, 7, nil)
+	exportTestLedgers(t, ds, schema, gzipCompressor, 8, 11, nil)
+
+	require.NoError(t, NewVerifier(ds, schema, compressxdr.CompressorOptions{}).Verify(ctx, 2, 11))
+}
+
+func TestVerifyDetectsProblems(t *testing.T) {
+	ctx := context.Background()
+	schema := datastore.DataStoreSchema{LedgersPerFile: 4, FilesPerPartition: 2}
+
+	for _, tc := range []struct {
+		name     string
+		modify   func(*LedgerMetaArchive)
+		expected string
+	}{
+		{
+			name: "checksum mismatch",
+			modify: func(archive *LedgerMetaArchive) {
+				if archive.Data.StartSequence == 4 {
+					archive.metaData.BatchSHA256 = "00"
+				}
+			},
+			expected: "FFFFFFFB--4-7.xdr.zst: sha256",
+		},
+		{
+			name: "broken hash chain across files",
+			modify: func(archive *LedgerMetaArchive) {
+				if archive.Data.StartSequence == 8 {
+					archive.Data.LedgerCloseMetas[0].V0.LedgerHeader.Header.PreviousLedgerHash = xdr.Hash{0xff}
+					archive.metaData = rebuildMetaData(t, archive)
+				}
+			},
+			expected: "ledger 8 previous ledger hash",
+		},
+		{
+			name: "broken hash chain within file",
+			modify: func(archive *LedgerMetaArchive) {
+				if archive.Data.StartSequence == 4 {
+					archive.Data.LedgerCloseMetas[2].V0.LedgerHeader.Header.PreviousLedgerHash = xdr.Hash{0xff}
+					archive.metaData = rebuildMetaData(t, archive)
+				}
+			},
+			expected: "ledger 6 previous ledger hash does not match hash of ledger 5",
+		},
+		{
+			name: "recorded end ledger hash mismatch",
+			modify: func(archive *LedgerMetaArchive) {
+				if archive.Data.StartSequence == 8 {
+					archive.metaData.EndLedgerHash = xdr.Hash{0xff}.HexString()
+				}
+			},
+			expected: "end ledger hash",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ds := newVerifierTestDataStore(t)
+			exportTestLedgers(t, ds, schema, compressxdr.DefaultCompressor, 2, 11, tc.modify)
+
+			err := NewVerifier(ds, schema, compressxdr.CompressorOptions{}).Verify(ctx, 2, 11)
+			var verificationErr *VerificationError
+			require.ErrorAs(t, err, &verificationErr)
+			require.Len(t, verificationErr.Problems, 1)
+			require.Contains(t, verificationErr.Problems[0], tc.expected)
+		})
+	}
+}
+
+func TestVerifyDetectsMissingAndTruncatedFiles(t *testing.T) {
+	ctx := context.Background()
+	schema := datastore.DataStoreSchema{LedgersPerFile: 4, FilesPerPartition: 2}
+	ds := newVerifierTestDataStore(t)
+	exportTestLedgers(t, ds, schema, compressxdr.DefaultCompressor, 2, 3, nil)
+	exportTestLedgers(t, ds, schema, compressxdr.DefaultCompressor, 8, 11, nil)
+
+	// truncate the file containing ledgers 8-11, keeping its metadata
+	key := schema.GetObjectKeyFromSequenceNumber(8)
+	metaData, err := ds.GetFileMetadata(ctx, key)
+	require.NoError(t, err)
+	reader, err := ds.GetFile(ctx, key)
+	require.NoError(t, err)
+	var content bytes.Buffer
+	_, err = content.ReadFrom(reader)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+	require.NoError(t, ds.PutFile(ctx, key, bytes.NewReader(content.Bytes()[:content.Len()/2]), metaData))
+
+	err = NewVerifier(ds, schema, compressxdr.CompressorOptions{}).Verify(ctx, 2, 11)
+	var verificationErr *VerificationError
+	require.ErrorAs(t, err, &verificationErr)
+	require.Len(t, verificationErr.Problems, 2)
+	require.Equal(t, "file for ledger 4 is missing", verificationErr.Problems[0])
+	require.Contains(t, verificationErr.Problems[1], "FFFFFFF7--8-11.xdr.zst: unable to decompress")
+}
@@ -11,7 +11,6 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/pelletier/go-toml"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
@@ -464,7 +463,7 @@ func DefineDBCommands(rootCmd *cobra.Command, horizonConfig *horizon.Config, hor
 			var storageBackendConfig ingest.StorageBackendConfig
 			options := horizon.ApplyOptions{RequireCaptiveCoreFullConfig: false}
 			if ledgerBackendType == ingest.BufferedStorageBackend {
-				if storageBackendConfig, err = loadStorageBackendConfig(storageBackendConfigPath); err != nil {
+				if storageBackendConfig, err = ingest.LoadStorageBackendConfig(storageBackendConfigPath); err != nil {
 					return err
 				}
 				options.NoCaptiveCore = true
@@ -525,7 +524,7 @@ func DefineDBCommands(rootCmd *cobra.Command, horizonConfig *horizon.Config, hor
 			var storageBackendConfig ingest.StorageBackendConfig
 			options := horizon.ApplyOptions{RequireCaptiveCoreFullConfig: false}
 			if ledgerBackendType == ingest.BufferedStorageBackend {
-				if storageBackendConfig, err = loadStorageBackendConfig(storageBackendConfigPath); err != nil {
+				if storageBackendConfig, err = ingest.LoadStorageBackendConfig(storageBackendConfigPath); err != nil {
 					return err
 				}
 				options.NoCaptiveCore = true
@@ -611,22 +610,6 @@ func DefineDBCommands(rootCmd *cobra.Command, horizonConfig *horizon.Config, hor
 	dbReingestCmd.AddCommand(dbReingestRangeCmd)
 }
 
-func loadStorageBackendConfig(storageBackendConfigPath string) (ingest.StorageBackendConfig, error) {
-	if storageBackendConfigPath == "" {
-		return ingest.StorageBackendConfig{}, errors.New("datastore config file is required for datastore ledgerbackend type")
-	}
-	cfg, err := toml.LoadFile(storageBackendConfigPath)
-	if err != nil {
-		return ingest.StorageBackendConfig{}, fmt.Errorf("failed to load datastore ledgerbackend config file %v: %w", storageBackendConfigPath, err)
-	}
-	var storageBackendConfig ingest.StorageBackendConfig
-	if err = cfg.Unmarshal(&storageBackendConfig); err != nil {
-		return ingest.StorageBackendConfig{}, fmt.Errorf("error unmarshalling datastore ledgerbackend TOML config: %w", err)
-	}
-
-	return storageBackendConfig, nil
-}
-
 func init() {
 	DefineDBCommands(RootCmd, globalConfig, globalFlags)
 }
@@ -152,7 +152,7 @@ func DefineIngestCommands(rootCmd *cobra.Command, horizonConfig *horizon.Config,
 					return fmt.Errorf("datastore-config file path is required with datastore backend")
 				}
 				var err error
-				if storageBackendConfig, err = loadStorageBackendConfig(ingestVerifyStorageBackendConfigPath); err != nil {
+				if storageBackendConfig, err = ingest.LoadStorageBackendConfig(ingestVerifyStorageBackendConfigPath); err != nil {
 					return err
 				}
 				noCaptiveCore = true
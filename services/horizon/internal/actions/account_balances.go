@@ -0,0 +1,110 @@
+package actions
+
+import (
+	"net/http"
+	"time"
+
+	protocol "github.com/stellar/go/protocols/horizon"
+	horizonContext "github.com/stellar/go/services/horizon/internal/context"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/ledger"
+	hProblem "github.com/stellar/go/services/horizon/internal/render/problem"
+	"github.com/stellar/go/services/horizon/internal/resourceadapter"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/render/problem"
+)
+
+// AccountBalancesQuery query struct for the accounts/{account_id}/balances
+// end-point
+type AccountBalancesQuery struct {
+	AccountID string `schema:"account_id" valid:"accountID"`
+	Ledger    uint32 `schema:"ledger" valid:"-"`
+	At        string `schema:"at" valid:"-"`
+}
+
+// Validate runs custom validations.
+func (q AccountBalancesQuery) Validate() error {
+	if (q.Ledger == 0) == (q.At == "") {
+		return problem.MakeInvalidFieldProblem(
+			"ledger",
+			errors.New("exactly one of ledger or at is required"),
+		)
+	}
+	if q.At != "" {
+		if _, err := time.Parse(time.RFC3339, q.At); err != nil {
+			return problem.MakeInvalidFieldProblem(
+				"at",
+				errors.New("at must be an RFC 3339 timestamp"),
+			)
+		}
+	}
+	return nil
+}
+
+// GetAccountBalancesHandler is the action handler for the
+// /accounts/{account_id}/balances endpoint
+type GetAccountBalancesHandler struct {
+	LedgerState *ledger.State
+}
+
+// GetResource returns the balances of an account as of the requested ledger,
+// or as of the latest ledger closed at or before the requested time. Ledgers
+// before the history retention window or before the balance history start
+// ledger are rejected.
+func (handler GetAccountBalancesHandler) GetResource(w HeaderWriter, r *http.Request) (interface{}, error) {
+	ctx := r.Context()
+	qp := AccountBalancesQuery{}
+	if err := getParams(&qp, r); err != nil {
+		return nil, err
+	}
+
+	historyQ, err := horizonContext.HistoryQFromRequest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	status := handler.LedgerState.CurrentStatus()
+	elder := status.HistoryElder
+	var record history.Ledger
+	if qp.Ledger > 0 {
+		if int32(qp.Ledger) < elder {
+			return nil, hProblem.BeforeHistory
+		}
+		err = historyQ.LedgerBySequence(ctx, &record, int32(qp.Ledger))
+	} else {
+		at, _ := time.Parse(time.RFC3339, qp.At)
+		err = historyQ.LatestLedgerClosedAt(ctx, &record, at.UTC(), elder, status.HistoryLatest)
+		if historyQ.NoRows(err) {
+			return nil, hProblem.BeforeHistory
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if record.Sequence < elder {
+		return nil, hProblem.BeforeHistory
+	}
+
+	start, err := historyQ.GetBalanceHistoryStartLedger(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if start == 0 || uint32(record.Sequence) < start {
+		return nil, hProblem.BeforeHistory
+	}
+
+	balances, err := historyQ.AccountBalancesAtLedger(ctx, qp.AccountID, uint32(record.Sequence))
+	if err != nil {
+		return nil, err
+	}
+	if len(balances) == 0 {
+		// the account did not exist as of the ledger
+		return nil, problem.NotFound
+	}
+
+	var result protocol.AccountBalances
+	if err = resourceadapter.PopulateAccountBalances(ctx, &result, qp.AccountID, record, balances); err != nil {
+		return nil, errors.Wrap(err, "populating account balances")
+	}
+	return result, nil
+}
@@ -0,0 +1,129 @@
+package actions
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/guregu/null"
+	"github.com/stretchr/testify/assert"
+
+	protocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/ledger"
+	hProblem "github.com/stellar/go/services/horizon/internal/render/problem"
+	"github.com/stellar/go/services/horizon/internal/test"
+	"github.com/stellar/go/support/render/problem"
+	"github.com/stellar/go/toid"
+	"github.com/stellar/go/xdr"
+)
+
+func TestGetAccountBalancesHandler(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &history.Q{tt.HorizonSession()}
+
+	tt.Assert.NoError(q.UpsertAccounts(tt.Ctx, []history.AccountEntry{account1}))
+
+	ledgerNineCloseTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tt.Assert.NoError(q.Begin(tt.Ctx))
+	ledgerBatch := q.NewLedgerBatchInsertBuilder()
+	for i, sequence := range []uint32{9, 10} {
+		tt.Assert.NoError(ledgerBatch.Add(xdr.LedgerHeaderHistoryEntry{
+			Hash: xdr.Hash{byte(sequence)},
+			Header: xdr.LedgerHeader{
+				PreviousLedgerHash: xdr.Hash{byte(sequence - 1)},
+				LedgerSeq:          xdr.Uint32(sequence),
+				ScpValue: xdr.StellarValue{
+					CloseTime: xdr.TimePoint(ledgerNineCloseTime.Add(time.Duration(i) * 5 * time.Second).Unix()),
+				},
+			},
+		}, 0, 0, 0, 0, 0))
+	}
+	tt.Assert.NoError(ledgerBatch.Exec(tt.Ctx, q))
+	balanceChanges := q.NewBalanceChangesBatchInsertBuilder()
+	tt.Assert.NoError(balanceChanges.Add(history.BalanceChange{
+		LedgerToid:      toid.New(10, 0, 0).ToInt64(),
+		AccountID:       account1.AccountID,
+		AssetType:       xdr.AssetTypeAssetTypeNative,
+		PreviousBalance: null.IntFrom(5000),
+		Balance:         null.IntFrom(account1.Balance),
+	}))
+	tt.Assert.NoError(balanceChanges.Exec(tt.Ctx))
+	tt.Assert.NoError(q.UpdateBalanceHistoryStartLedger(tt.Ctx, 9))
+	tt.Assert.NoError(q.Commit())
+
+	ledgerState := &ledger.State{}
+	ledgerState.SetHorizonStatus(ledger.HorizonStatus{
+		HistoryLatest:         10,
+		HistoryLatestClosedAt: ledgerNineCloseTime.Add(5 * time.Second),
+		HistoryElder:          9,
+		ExpHistoryLatest:      10,
+	})
+	handler := GetAccountBalancesHandler{LedgerState: ledgerState}
+
+	for _, testCase := range []struct {
+		params  map[string]string
+		ledger  int32
+		balance string
+	}{
+		{map[string]string{"ledger": "9"}, 9, "0.0005000"},
+		{map[string]string{"ledger": "10"}, 10, "0.0020000"},
+		{map[string]string{"at": "2024-01-01T00:00:04Z"}, 9, "0.0005000"},
+		{map[string]string{"at": "2024-01-02T00:00:00Z"}, 10, "0.0020000"},
+	} {
+		response, err := handler.GetResource(
+			httptest.NewRecorder(),
+			makeRequest(t, testCase.params, map[string]string{"account_id": account1.AccountID}, q),
+		)
+		tt.Assert.NoError(err)
+		resource := response.(protocol.AccountBalances)
+		tt.Assert.Equal(testCase.ledger, resource.Ledger)
+		if tt.Assert.Len(resource.Balances, 1) {
+			tt.Assert.Equal("native", resource.Balances[0].Type)
+			tt.Assert.Equal(testCase.balance, resource.Balances[0].Balance)
+		}
+	}
+
+	for _, params := range []map[string]string{
+		{"ledger": "8"},
+		{"at": "2023-12-31T00:00:00Z"},
+	} {
+		_, err := handler.GetResource(
+			httptest.NewRecorder(),
+			makeRequest(t, params, map[string]string{"account_id": account1.AccountID}, q),
+		)
+		tt.Assert.Equal(hProblem.BeforeHistory, err)
+	}
+
+	_, err := handler.GetResource(
+		httptest.NewRecorder(),
+		makeRequest(t, map[string]string{"ledger": "9"}, map[string]string{"account_id": accountTwo}, q),
+	)
+	tt.Assert.Equal(problem.NotFound, err)
+}
+
+func TestAccountBalancesQueryValidation(t *testing.T) {
+	for _, testCase := range []struct {
+		params map[string]string
+		field  string
+	}{
+		{map[string]string{}, "ledger"},
+		{map[string]string{"ledger": "9", "at": "2024-01-01T00:00:00Z"}, "ledger"},
+		{map[string]string{"at": "1704067200"}, "at"},
+		{map[string]string{"ledger": "-1"}, "ledger"},
+	} {
+		qp := AccountBalancesQuery{}
+		err := getParams(&qp, makeRequest(t, testCase.params, map[string]string{"account_id": accountOne}, nil))
+		if assert.IsType(t, &problem.P{}, err, testCase.params) {
+			assert.Equal(t, testCase.field, err.(*problem.P).Extras["invalid_field"], testCase.params)
+		}
+	}
+
+	qp := AccountBalancesQuery{}
+	err := getParams(&qp, makeRequest(t, map[string]string{"ledger": "9"}, map[string]string{"account_id": "GABC"}, nil))
+	if assert.IsType(t, &problem.P{}, err) {
+		assert.Equal(t, "account_id", err.(*problem.P).Extras["invalid_field"])
+	}
+}
@@ -0,0 +1,125 @@
+package actions
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/stellar/go/protocols/horizon"
+	horizonContext "github.com/stellar/go/services/horizon/internal/context"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/ledger"
+	"github.com/stellar/go/services/horizon/internal/resourceadapter"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/render/hal"
+	"github.com/stellar/go/support/render/problem"
+	"github.com/stellar/go/xdr"
+)
+
+// contractEventTrailingWildcard matches any topics following the other
+// segments of a topic filter.
+const contractEventTrailingWildcard = "**"
+
+// ContractEventsQuery query struct for contract events end-points.
+//
+// Topic is a comma separated list of segments matching the topics of the
+// events, each a base64 encoded xdr.ScVal, `*` matching any topic, or `**`
+// as the last segment matching any following topics.
+type ContractEventsQuery struct {
+	ContractID string `schema:"contract_id" valid:"contractID,optional"`
+	Topic      string `schema:"topic" valid:"-"`
+}
+
+// Validate runs extra validations on query parameters
+func (qp ContractEventsQuery) Validate() error {
+	_, err := qp.Filter()
+	return err
+}
+
+// Filter returns the filter of the contract events matching the query.
+func (qp ContractEventsQuery) Filter() (history.ContractEventsFilter, error) {
+	filter := history.ContractEventsFilter{ContractID: qp.ContractID}
+	if qp.Topic == "" {
+		return filter, nil
+	}
+
+	segments := strings.Split(qp.Topic, ",")
+	if segments[len(segments)-1] == contractEventTrailingWildcard {
+		filter.AnyTrailingTopics = true
+		segments = segments[:len(segments)-1]
+	}
+	if len(segments) > history.MaxContractEventTopics {
+		return filter, problem.MakeInvalidFieldProblem(
+			"topic",
+			errors.Errorf("topic can have at most %d segments before `**`", history.MaxContractEventTopics),
+		)
+	}
+	for _, segment := range segments {
+		if segment == history.ContractEventTopicWildcard {
+			filter.Topics = append(filter.Topics, segment)
+			continue
+		}
+		// topics are stored in their canonical encoding
+		var topic xdr.ScVal
+		if err := xdr.SafeUnmarshalBase64(segment, &topic); err != nil {
+			return filter, problem.MakeInvalidFieldProblem(
+				"topic",
+				errors.Errorf("%q is not `*`, `**` as the last segment, or a base64 encoded ScVal", segment),
+			)
+		}
+		encoded, err := xdr.MarshalBase64(topic)
+		if err != nil {
+			return filter, errors.Wrap(err, "could not encode topic")
+		}
+		filter.Topics = append(filter.Topics, encoded)
+	}
+	return filter, nil
+}
+
+// GetContractEventsHandler is the action handler for all end-points returning
+// a list of contract events.
+type GetContractEventsHandler struct {
+	LedgerState *ledger.State
+}
+
+// GetResourcePage returns a page of contract events.
+func (handler GetContractEventsHandler) GetResourcePage(w HeaderWriter, r *http.Request) ([]hal.Pageable, error) {
+	ctx := r.Context()
+
+	pq, err := GetPageQuery(handler.LedgerState, r)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateAndAdjustCursor(handler.LedgerState, &pq)
+	if err != nil {
+		return nil, err
+	}
+
+	qp := ContractEventsQuery{}
+	if err = getParams(&qp, r); err != nil {
+		return nil, err
+	}
+	filter, err := qp.Filter()
+	if err != nil {
+		return nil, err
+	}
+
+	historyQ, err := horizonContext.HistoryQFromRequest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := historyQ.ContractEvents(ctx, filter, pq, handler.LedgerState.CurrentStatus().HistoryElder)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading contract event records")
+	}
+
+	var response []hal.Pageable
+	for _, record := range records {
+		var res horizon.ContractEvent
+		resourceadapter.PopulateContractEvent(ctx, &res, record)
+		response = append(response, res)
+	}
+
+	return response, nil
+}
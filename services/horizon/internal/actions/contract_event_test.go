@@ -0,0 +1,63 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/support/render/problem"
+	"github.com/stellar/go/xdr"
+)
+
+func TestContractEventsQueryFilter(t *testing.T) {
+	transfer := xdr.ScSymbol("transfer")
+	topic, err := xdr.MarshalBase64(xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &transfer})
+	require.NoError(t, err)
+	contract := "CDLZFC3SYJYDZT7K67VZ75HPJVIEUVNIXF47ZG2FB2RMQQVU2HHGCYSC"
+
+	for _, testCase := range []struct {
+		name     string
+		query    ContractEventsQuery
+		expected history.ContractEventsFilter
+	}{
+		{
+			"no topic",
+			ContractEventsQuery{ContractID: contract},
+			history.ContractEventsFilter{ContractID: contract},
+		},
+		{
+			"exact topics",
+			ContractEventsQuery{Topic: topic + ",*"},
+			history.ContractEventsFilter{Topics: []string{topic, "*"}},
+		},
+		{
+			"trailing wildcard",
+			ContractEventsQuery{ContractID: contract, Topic: topic + ",**"},
+			history.ContractEventsFilter{ContractID: contract, Topics: []string{topic}, AnyTrailingTopics: true},
+		},
+		{
+			"only trailing wildcard",
+			ContractEventsQuery{Topic: "**"},
+			history.ContractEventsFilter{AnyTrailingTopics: true},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			filter, err := testCase.query.Filter()
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expected, filter)
+		})
+	}
+
+	for _, invalid := range []string{
+		"**," + topic,
+		"not base64",
+		"*,*,*,*,*",
+	} {
+		err := ContractEventsQuery{Topic: invalid}.Validate()
+		if assert.IsType(t, &problem.P{}, err, invalid) {
+			assert.Equal(t, "topic", err.(*problem.P).Extras["invalid_field"])
+		}
+	}
+}
@@ -6,8 +6,10 @@ import (
 	"net/http"
 
 	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/protocols/horizon/operations"
 	horizonContext "github.com/stellar/go/services/horizon/internal/context"
 	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/strkey"
 	"github.com/stellar/go/support/render/problem"
 )
 
@@ -114,6 +116,109 @@ func (handler FilterConfigHandler) UpdateAssetConfig(w http.ResponseWriter, r *h
 	}
 }
 
+func (handler FilterConfigHandler) GetContractConfig(w http.ResponseWriter, r *http.Request) {
+	historyQ, err := horizonContext.HistoryQFromRequest(r)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	config, err := historyQ.GetContractFilterConfig(r.Context())
+
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	responsePayload := handler.contractConfigResource(config)
+	enc := json.NewEncoder(w)
+	if err = enc.Encode(responsePayload); err != nil {
+		problem.Render(r.Context(), w, err)
+	}
+}
+
+func (handler FilterConfigHandler) GetOperationConfig(w http.ResponseWriter, r *http.Request) {
+	historyQ, err := horizonContext.HistoryQFromRequest(r)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	config, err := historyQ.GetOperationFilterConfig(r.Context())
+
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	responsePayload := handler.operationConfigResource(config)
+	enc := json.NewEncoder(w)
+	if err = enc.Encode(responsePayload); err != nil {
+		problem.Render(r.Context(), w, err)
+	}
+}
+
+func (handler FilterConfigHandler) UpdateContractConfig(w http.ResponseWriter, r *http.Request) {
+	historyQ, err := horizonContext.HistoryQFromRequest(r)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	filterRequest, err := handler.contractFilterResource(r)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	filterConfig := history.ContractFilterConfig{}
+	filterConfig.Enabled = *filterRequest.Enabled
+	filterConfig.Whitelist = filterRequest.Whitelist
+
+	config, err := historyQ.UpdateContractFilterConfig(r.Context(), filterConfig)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	responsePayload := handler.contractConfigResource(config)
+	enc := json.NewEncoder(w)
+	if err = enc.Encode(responsePayload); err != nil {
+		problem.Render(r.Context(), w, err)
+	}
+}
+
+func (handler FilterConfigHandler) UpdateOperationConfig(w http.ResponseWriter, r *http.Request) {
+	historyQ, err := horizonContext.HistoryQFromRequest(r)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	filterRequest, err := handler.operationFilterResource(r)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	filterConfig := history.OperationFilterConfig{}
+	filterConfig.Enabled = *filterRequest.Enabled
+	filterConfig.Mode = filterRequest.Mode
+	filterConfig.OperationTypes = filterRequest.OperationTypes
+
+	config, err := historyQ.UpdateOperationFilterConfig(r.Context(), filterConfig)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	responsePayload := handler.operationConfigResource(config)
+	enc := json.NewEncoder(w)
+	if err = enc.Encode(responsePayload); err != nil {
+		problem.Render(r.Context(), w, err)
+	}
+}
+
 func (handler FilterConfigHandler) assetFilterResource(r *http.Request) (hProtocol.AssetFilterConfig, error) {
 	var filterRequest hProtocol.AssetFilterConfig
 	dec := json.NewDecoder(r.Body)
@@ -134,6 +239,58 @@ func (handler FilterConfigHandler) accountFilterResource(r *http.Request) (hProt
 	return filterRequest, nil
 }
 
+func (handler FilterConfigHandler) contractFilterResource(r *http.Request) (hProtocol.ContractFilterConfig, error) {
+	var filterRequest hProtocol.ContractFilterConfig
+	dec := json.NewDecoder(r.Body)
+	if err := dec.Decode(&filterRequest); err != nil {
+		p := problem.NewProblemWithInvalidField(problem.BadRequest, "reason", fmt.Errorf("invalid json for contract filter config %v", err.Error()))
+		return hProtocol.ContractFilterConfig{}, p
+	}
+	for _, contractID := range filterRequest.Whitelist {
+		if !strkey.IsValidContractAddress(contractID) {
+			return hProtocol.ContractFilterConfig{}, problem.MakeInvalidFieldProblem(
+				"whitelist",
+				fmt.Errorf("%q is not a valid contract id", contractID),
+			)
+		}
+	}
+	return filterRequest, nil
+}
+
+func (handler FilterConfigHandler) operationFilterResource(r *http.Request) (hProtocol.OperationFilterConfig, error) {
+	var filterRequest hProtocol.OperationFilterConfig
+	dec := json.NewDecoder(r.Body)
+	if err := dec.Decode(&filterRequest); err != nil {
+		p := problem.NewProblemWithInvalidField(problem.BadRequest, "reason", fmt.Errorf("invalid json for operation filter config %v", err.Error()))
+		return hProtocol.OperationFilterConfig{}, p
+	}
+
+	switch filterRequest.Mode {
+	case "":
+		filterRequest.Mode = history.OperationFilterModeWhitelist
+	case history.OperationFilterModeWhitelist, history.OperationFilterModeBlacklist:
+	default:
+		return hProtocol.OperationFilterConfig{}, problem.MakeInvalidFieldProblem(
+			"mode",
+			fmt.Errorf("mode must be %q or %q", history.OperationFilterModeWhitelist, history.OperationFilterModeBlacklist),
+		)
+	}
+
+	typeNames := map[string]bool{}
+	for _, name := range operations.TypeNames {
+		typeNames[name] = true
+	}
+	for _, operationType := range filterRequest.OperationTypes {
+		if !typeNames[operationType] {
+			return hProtocol.OperationFilterConfig{}, problem.MakeInvalidFieldProblem(
+				"operation_types",
+				fmt.Errorf("%q is not a valid operation type", operationType),
+			)
+		}
+	}
+	return filterRequest, nil
+}
+
 func (handler FilterConfigHandler) assetConfigResource(config history.AssetFilterConfig) hProtocol.AssetFilterConfig {
 	return hProtocol.AssetFilterConfig{
 		Whitelist:    config.Whitelist,
@@ -149,3 +306,20 @@ func (handler FilterConfigHandler) accountConfigResource(config history.AccountF
 		LastModified: config.LastModified,
 	}
 }
+
+func (handler FilterConfigHandler) contractConfigResource(config history.ContractFilterConfig) hProtocol.ContractFilterConfig {
+	return hProtocol.ContractFilterConfig{
+		Whitelist:    config.Whitelist,
+		Enabled:      &config.Enabled,
+		LastModified: config.LastModified,
+	}
+}
+
+func (handler FilterConfigHandler) operationConfigResource(config history.OperationFilterConfig) hProtocol.OperationFilterConfig {
+	return hProtocol.OperationFilterConfig{
+		OperationTypes: config.OperationTypes,
+		Mode:           config.Mode,
+		Enabled:        &config.Enabled,
+		LastModified:   config.LastModified,
+	}
+}
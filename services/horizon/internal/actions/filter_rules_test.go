@@ -11,6 +11,8 @@ import (
 	hProtocol "github.com/stellar/go/protocols/horizon"
 	"github.com/stellar/go/services/horizon/internal/db2/history"
 	"github.com/stellar/go/services/horizon/internal/test"
+	"github.com/stellar/go/support/render/problem"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestGetAssetFilterConfig(t *testing.T) {
@@ -244,3 +246,127 @@ func TestUpdateAccountFilterConfig(t *testing.T) {
 	tt.Assert.True(filterCfgResource.LastModified > 0)
 	tt.Assert.ElementsMatch(filterCfgResource.Whitelist, []string{"4", "5", "6"})
 }
+
+func TestUpdateContractFilterConfig(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+
+	q := &history.Q{SessionInterface: tt.HorizonSession()}
+
+	handler := &FilterConfigHandler{}
+	recorder := httptest.NewRecorder()
+	request := makeRequest(
+		t,
+		map[string]string{},
+		map[string]string{},
+		q,
+	)
+
+	request.Body = ioutil.NopCloser(strings.NewReader(`
+	    {
+			"whitelist": ["CDLZFC3SYJYDZT7K67VZ75HPJVIEUVNIXF47ZG2FB2RMQQVU2HHGCYSC"],
+			"enabled": true
+		}`))
+
+	handler.UpdateContractConfig(
+		recorder,
+		request,
+	)
+
+	resp := recorder.Result()
+	tt.Assert.Equal(http.StatusOK, resp.StatusCode)
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	tt.Assert.NoError(err)
+
+	var filterCfgResource hProtocol.ContractFilterConfig
+	json.Unmarshal(raw, &filterCfgResource)
+	tt.Assert.NoError(err)
+
+	tt.Assert.Equal(*filterCfgResource.Enabled, true)
+	tt.Assert.True(filterCfgResource.LastModified > 0)
+	tt.Assert.ElementsMatch(filterCfgResource.Whitelist, []string{"CDLZFC3SYJYDZT7K67VZ75HPJVIEUVNIXF47ZG2FB2RMQQVU2HHGCYSC"})
+}
+
+func TestUpdateOperationFilterConfig(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+
+	q := &history.Q{SessionInterface: tt.HorizonSession()}
+
+	handler := &FilterConfigHandler{}
+	recorder := httptest.NewRecorder()
+	request := makeRequest(
+		t,
+		map[string]string{},
+		map[string]string{},
+		q,
+	)
+
+	request.Body = ioutil.NopCloser(strings.NewReader(`
+	    {
+			"operation_types": ["payment", "invoke_host_function"],
+			"mode": "blacklist",
+			"enabled": true
+		}`))
+
+	handler.UpdateOperationConfig(
+		recorder,
+		request,
+	)
+
+	resp := recorder.Result()
+	tt.Assert.Equal(http.StatusOK, resp.StatusCode)
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	tt.Assert.NoError(err)
+
+	var filterCfgResource hProtocol.OperationFilterConfig
+	json.Unmarshal(raw, &filterCfgResource)
+	tt.Assert.NoError(err)
+
+	tt.Assert.Equal(*filterCfgResource.Enabled, true)
+	tt.Assert.Equal(filterCfgResource.Mode, "blacklist")
+	tt.Assert.True(filterCfgResource.LastModified > 0)
+	tt.Assert.ElementsMatch(filterCfgResource.OperationTypes, []string{"payment", "invoke_host_function"})
+}
+
+func TestInvalidFilterConfigResources(t *testing.T) {
+	handler := FilterConfigHandler{}
+	for _, testCase := range []struct {
+		body  string
+		field string
+	}{
+		{`{"whitelist": ["GD6WNNTW664WH7FXC5RUMUTF7P5QSURC2IT36VOQEEGFZ4UWUEQGECAL"], "enabled": true}`, "whitelist"},
+		{`{"enabled": true}`, "reason"},
+	} {
+		request := httptest.NewRequest(http.MethodPut, "/ingestion/filters/contract", strings.NewReader(testCase.body))
+		_, err := handler.contractFilterResource(request)
+		if assert.IsType(t, &problem.P{}, err, testCase.body) {
+			assert.Equal(t, testCase.field, err.(*problem.P).Extras["invalid_field"])
+		}
+	}
+
+	for _, testCase := range []struct {
+		body  string
+		field string
+	}{
+		{`{"operation_types": ["pay"], "enabled": true}`, "operation_types"},
+		{`{"operation_types": ["payment"], "mode": "greylist", "enabled": true}`, "mode"},
+		{`{"mode": "whitelist", "enabled": true}`, "reason"},
+	} {
+		request := httptest.NewRequest(http.MethodPut, "/ingestion/filters/operation", strings.NewReader(testCase.body))
+		_, err := handler.operationFilterResource(request)
+		if assert.IsType(t, &problem.P{}, err, testCase.body) {
+			assert.Equal(t, testCase.field, err.(*problem.P).Extras["invalid_field"])
+		}
+	}
+
+	request := httptest.NewRequest(http.MethodPut, "/ingestion/filters/operation",
+		strings.NewReader(`{"operation_types": ["payment"], "enabled": false}`))
+	resource, err := handler.operationFilterResource(request)
+	assert.NoError(t, err)
+	assert.Equal(t, history.OperationFilterModeWhitelist, resource.Mode)
+}
@@ -0,0 +1,117 @@
+package actions
+
+import (
+	"net/http"
+
+	"github.com/stellar/go/protocols/horizon"
+	horizonContext "github.com/stellar/go/services/horizon/internal/context"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/ledger"
+	"github.com/stellar/go/services/horizon/internal/resourceadapter"
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/render/hal"
+	"github.com/stellar/go/support/render/problem"
+	"github.com/stellar/go/xdr"
+)
+
+// TokenTransfersQuery query struct for token transfers end-points
+type TokenTransfersQuery struct {
+	AccountID  string `schema:"account_id" valid:"accountID,optional"`
+	ContractID string `schema:"contract_id" valid:"contractID,optional"`
+	Asset      string `schema:"asset" valid:"asset,optional"`
+}
+
+// Validate runs extra validations on query parameters
+func (qp TokenTransfersQuery) Validate() error {
+	count, err := countNonEmpty(
+		qp.AccountID,
+		qp.ContractID,
+		qp.Asset,
+	)
+	if err != nil {
+		return problem.BadRequest
+	}
+
+	if count != 1 {
+		return problem.MakeInvalidFieldProblem(
+			"filters",
+			errors.New("Use a single filter for token transfers, one of account_id, contract_id or asset"),
+		)
+	}
+	return nil
+}
+
+// GetTokenTransfersHandler is the action handler for all end-points returning
+// a list of token transfers.
+type GetTokenTransfersHandler struct {
+	LedgerState       *ledger.State
+	NetworkPassphrase string
+}
+
+// GetResourcePage returns a page of token transfers.
+func (handler GetTokenTransfersHandler) GetResourcePage(w HeaderWriter, r *http.Request) ([]hal.Pageable, error) {
+	ctx := r.Context()
+
+	pq, err := GetPageQuery(handler.LedgerState, r)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateAndAdjustCursor(handler.LedgerState, &pq)
+	if err != nil {
+		return nil, err
+	}
+
+	qp := TokenTransfersQuery{}
+	if err = getParams(&qp, r); err != nil {
+		return nil, err
+	}
+
+	historyQ, err := horizonContext.HistoryQFromRequest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	oldestLedger := handler.LedgerState.CurrentStatus().HistoryElder
+	var records []history.TokenTransfer
+	switch {
+	case qp.AccountID != "":
+		records, err = historyQ.TokenTransfersForAccount(ctx, qp.AccountID, pq, oldestLedger)
+	case qp.ContractID != "":
+		records, err = historyQ.TokenTransfersForContract(ctx, qp.ContractID, pq, oldestLedger)
+	default:
+		// the transfers of a Stellar asset are the transfers of its Stellar
+		// Asset Contract
+		var contractID string
+		contractID, err = handler.assetContractID(qp.Asset)
+		if err != nil {
+			return nil, err
+		}
+		records, err = historyQ.TokenTransfersForContract(ctx, contractID, pq, oldestLedger)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "loading token transfer records")
+	}
+
+	var response []hal.Pageable
+	for _, record := range records {
+		var res horizon.TokenTransfer
+		resourceadapter.PopulateTokenTransfer(ctx, &res, record)
+		response = append(response, res)
+	}
+
+	return response, nil
+}
+
+func (handler GetTokenTransfersHandler) assetContractID(asset string) (string, error) {
+	assets, err := xdr.BuildAssets(asset)
+	if err != nil || len(assets) != 1 {
+		return "", problem.MakeInvalidFieldProblem("asset", errors.New(customTagsErrorMessages["asset"]))
+	}
+	id, err := assets[0].ContractID(handler.NetworkPassphrase)
+	if err != nil {
+		return "", errors.Wrap(err, "could not get contract id of asset")
+	}
+	return strkey.Encode(strkey.VersionByteContract, id[:])
+}
@@ -0,0 +1,43 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/support/render/problem"
+)
+
+func TestTokenTransfersQueryValidate(t *testing.T) {
+	account := "GAN4WOTCFSASG3J6SGLLQZURDDUVNBQANAHEQJ3PBNDZ74X63UZWQPZW"
+	contract := "CDLZFC3SYJYDZT7K67VZ75HPJVIEUVNIXF47ZG2FB2RMQQVU2HHGCYSC"
+
+	assert.NoError(t, TokenTransfersQuery{AccountID: account}.Validate())
+	assert.NoError(t, TokenTransfersQuery{ContractID: contract}.Validate())
+	assert.NoError(t, TokenTransfersQuery{Asset: "native"}.Validate())
+
+	for _, qp := range []TokenTransfersQuery{
+		{},
+		{AccountID: account, ContractID: contract},
+		{ContractID: contract, Asset: "native"},
+	} {
+		err := qp.Validate()
+		if assert.IsType(t, &problem.P{}, err) {
+			assert.Equal(t, "filters", err.(*problem.P).Extras["invalid_field"])
+		}
+	}
+}
+
+func TestTokenTransfersAssetContractID(t *testing.T) {
+	handler := GetTokenTransfersHandler{NetworkPassphrase: network.TestNetworkPassphrase}
+
+	contractID, err := handler.assetContractID("native")
+	assert.NoError(t, err)
+	assert.Equal(t, "CDLZFC3SYJYDZT7K67VZ75HPJVIEUVNIXF47ZG2FB2RMQQVU2HHGCYSC", contractID)
+
+	_, err = handler.assetContractID("USD")
+	if assert.IsType(t, &problem.P{}, err) {
+		assert.Equal(t, "asset", err.(*problem.P).Extras["invalid_field"])
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/stellar/go/amount"
 	"github.com/stellar/go/services/horizon/internal/assets"
+	"github.com/stellar/go/strkey"
 	"github.com/stellar/go/support/errors"
 	"github.com/stellar/go/xdr"
 )
@@ -25,6 +26,7 @@ func init() {
 	govalidator.TagMap["assetType"] = isAssetType
 	govalidator.TagMap["asset"] = isAsset
 	govalidator.TagMap["claimableBalanceID"] = isClaimableBalanceID
+	govalidator.TagMap["contractID"] = isContractID
 	govalidator.TagMap["transactionHash"] = isTransactionHash
 	govalidator.TagMap["sha256"] = govalidator.IsSHA256
 	govalidator.TagMap["tradeType"] = isTradeType
@@ -37,6 +39,7 @@ var customTagsErrorMessages = map[string]string{
 	"assetType":            "Asset type must be native, credit_alphanum4 or credit_alphanum12",
 	"bool":                 "Filter should be true or false",
 	"claimable_balance_id": "Claimable Balance ID must be the hex-encoded XDR representation of a Claimable Balance ID",
+	"contractID":           "Contract ID must start with `C` and contain 56 alphanum characters",
 	"ledger_id":            "Ledger ID must be an integer higher than 0",
 	"offer_id":             "Offer ID must be an integer higher than 0",
 	"op_id":                "Operation ID must be an integer higher than 0",
@@ -139,6 +142,10 @@ func isAccountID(str string) bool {
 	return true
 }
 
+func isContractID(str string) bool {
+	return strkey.IsValidContractAddress(str)
+}
+
 func isTransactionHash(str string) bool {
 	decoded, err := hex.DecodeString(str)
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/stellar/go/ingest/ledgerbackend"
+	"github.com/stellar/go/services/horizon/internal/ingest"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stellar/throttled"
@@ -68,6 +69,15 @@ type Config struct {
 	TLSKey string
 	// Ingest toggles whether this horizon instance should run the data ingestion subsystem.
 	Ingest bool
+	// IngestLedgerBackendType is the ledger backend the ingestion subsystem
+	// reads ledgers from.
+	IngestLedgerBackendType ingest.LedgerBackendType
+	// IngestDatastoreConfigPath is the path to the TOML config of the
+	// datastore ledger backend, loaded into IngestStorageBackendConfig.
+	IngestDatastoreConfigPath  string
+	IngestStorageBackendConfig ingest.StorageBackendConfig
+	// IngestRPCBackendConfig configures the rpc ledger backend.
+	IngestRPCBackendConfig ingest.RPCBackendConfig
 	// HistoryRetentionCount represents the minimum number of ledgers worth of
 	// history data to retain in the horizon database. For the purposes of
 	// determining a "retention duration", each ledger roughly corresponds to 10
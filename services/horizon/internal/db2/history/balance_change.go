@@ -0,0 +1,187 @@
+package history
+
+import (
+	"context"
+	"sort"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+
+	"github.com/stellar/go/support/db"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/toid"
+	"github.com/stellar/go/xdr"
+)
+
+// BalanceChange is a row of data from the `history_balance_changes` table.
+// PreviousBalance is null when the account or trust line was created by the
+// change and Balance is null when it was removed.
+type BalanceChange struct {
+	LedgerToid      int64         `db:"ledger_toid"`
+	AccountID       string        `db:"account_id"`
+	AssetType       xdr.AssetType `db:"asset_type"`
+	AssetCode       string        `db:"asset_code"`
+	AssetIssuer     string        `db:"asset_issuer"`
+	LiquidityPoolID string        `db:"liquidity_pool_id"`
+	PreviousBalance null.Int      `db:"previous_balance"`
+	Balance         null.Int      `db:"balance"`
+}
+
+// AccountBalance is the balance of an account in the native asset, a credit
+// asset or a liquidity pool.
+type AccountBalance struct {
+	AssetType       xdr.AssetType
+	AssetCode       string
+	AssetIssuer     string
+	LiquidityPoolID string
+	Balance         int64
+}
+
+type accountBalanceKey struct {
+	assetType       xdr.AssetType
+	assetCode       string
+	assetIssuer     string
+	liquidityPoolID string
+}
+
+// QBalanceChanges defines history_balance_changes related queries.
+type QBalanceChanges interface {
+	NewBalanceChangesBatchInsertBuilder() BalanceChangesBatchInsertBuilder
+	UpdateBalanceHistoryStartLedger(ctx context.Context, sequence uint32) error
+	DeleteBalanceChangesRange(ctx context.Context, start, end int64) (int64, error)
+}
+
+// BalanceChangesBatchInsertBuilder is used to insert balance changes into the
+// history_balance_changes table
+type BalanceChangesBatchInsertBuilder interface {
+	Add(change BalanceChange) error
+	Exec(ctx context.Context) error
+	Len() int
+}
+
+// balanceChangesBatchInsertBuilder is a simple wrapper around db.FastBatchInsertBuilder
+type balanceChangesBatchInsertBuilder struct {
+	session db.SessionInterface
+	builder db.FastBatchInsertBuilder
+	table   string
+}
+
+// NewBalanceChangesBatchInsertBuilder constructs a new BalanceChangesBatchInsertBuilder instance
+func (q *Q) NewBalanceChangesBatchInsertBuilder() BalanceChangesBatchInsertBuilder {
+	return &balanceChangesBatchInsertBuilder{
+		session: q,
+		builder: db.FastBatchInsertBuilder{},
+		table:   "history_balance_changes",
+	}
+}
+
+// Add adds a new balance change to the batch
+func (i *balanceChangesBatchInsertBuilder) Add(change BalanceChange) error {
+	return i.builder.RowStruct(change)
+}
+
+// Exec writes the batch of balance changes to the database.
+func (i *balanceChangesBatchInsertBuilder) Exec(ctx context.Context) error {
+	return i.builder.Exec(ctx, i.session, i.table)
+}
+
+// Len returns the number of items in the batch.
+func (i *balanceChangesBatchInsertBuilder) Len() int {
+	return i.builder.Len()
+}
+
+// AccountBalancesAtLedger returns the balances of an account as of the given
+// ledger, trust lines first and the native balance last. The ledger must not
+// be older than the balance history start ledger. The balances are the
+// previous balances of the first changes following the ledger, and the
+// current balances of the entries which have not changed since. No balances
+// are returned if the account did not exist.
+func (q *Q) AccountBalancesAtLedger(ctx context.Context, accountID string, sequence uint32) ([]AccountBalance, error) {
+	balances := map[accountBalanceKey]int64{}
+
+	account, err := q.GetAccountByID(ctx, accountID)
+	switch {
+	case q.NoRows(err):
+	case err != nil:
+		return nil, errors.Wrap(err, "could not load account")
+	default:
+		balances[accountBalanceKey{assetType: xdr.AssetTypeAssetTypeNative}] = account.Balance
+	}
+
+	trustLines, err := q.GetSortedTrustLinesByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load trust lines")
+	}
+	for _, trustLine := range trustLines {
+		balances[accountBalanceKey{
+			assetType:       trustLine.AssetType,
+			assetCode:       trustLine.AssetCode,
+			assetIssuer:     trustLine.AssetIssuer,
+			liquidityPoolID: trustLine.LiquidityPoolID,
+		}] = trustLine.Balance
+	}
+
+	var changes []BalanceChange
+	sql := sq.Select("DISTINCT ON (hbc.asset_type, hbc.asset_code, hbc.asset_issuer, hbc.liquidity_pool_id) hbc.*").
+		From("history_balance_changes hbc").
+		Where(sq.Eq{"hbc.account_id": accountID}).
+		Where(sq.GtOrEq{"hbc.ledger_toid": toid.New(int32(sequence)+1, 0, 0).ToInt64()}).
+		OrderBy("hbc.asset_type, hbc.asset_code, hbc.asset_issuer, hbc.liquidity_pool_id, hbc.ledger_toid ASC")
+	if err = q.Select(ctx, &changes, sql); err != nil {
+		return nil, errors.Wrap(err, "could not load balance changes")
+	}
+	for _, change := range changes {
+		key := accountBalanceKey{
+			assetType:       change.AssetType,
+			assetCode:       change.AssetCode,
+			assetIssuer:     change.AssetIssuer,
+			liquidityPoolID: change.LiquidityPoolID,
+		}
+		if change.PreviousBalance.Valid {
+			balances[key] = change.PreviousBalance.Int64
+		} else {
+			delete(balances, key)
+		}
+	}
+
+	result := make([]AccountBalance, 0, len(balances))
+	for key, balance := range balances {
+		result = append(result, AccountBalance{
+			AssetType:       key.assetType,
+			AssetCode:       key.assetCode,
+			AssetIssuer:     key.assetIssuer,
+			LiquidityPoolID: key.liquidityPoolID,
+			Balance:         balance,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if (a.AssetType == xdr.AssetTypeAssetTypeNative) != (b.AssetType == xdr.AssetTypeAssetTypeNative) {
+			return b.AssetType == xdr.AssetTypeAssetTypeNative
+		}
+		if a.AssetType != b.AssetType {
+			return a.AssetType < b.AssetType
+		}
+		if a.AssetCode != b.AssetCode {
+			return a.AssetCode < b.AssetCode
+		}
+		if a.AssetIssuer != b.AssetIssuer {
+			return a.AssetIssuer < b.AssetIssuer
+		}
+		return a.LiquidityPoolID < b.LiquidityPoolID
+	})
+
+	return result, nil
+}
+
+// DeleteBalanceChangesRange deletes the balance changes between `start` and
+// `end` (exclusive) toids. The balance changes are not part of DeleteRangeAll
+// because they are recorded from ledger entry changes, which are not
+// processed when reingesting history.
+func (q *Q) DeleteBalanceChangesRange(ctx context.Context, start, end int64) (int64, error) {
+	count, err := q.DeleteRange(ctx, start, end, "history_balance_changes", "ledger_toid")
+	if err != nil {
+		return 0, errors.Wrap(err, "Error clearing history_balance_changes")
+	}
+	return count, nil
+}
@@ -0,0 +1,100 @@
+package history
+
+import (
+	"testing"
+
+	"github.com/guregu/null"
+
+	"github.com/stellar/go/services/horizon/internal/test"
+	"github.com/stellar/go/toid"
+	"github.com/stellar/go/xdr"
+)
+
+func TestAccountBalancesAtLedger(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &Q{tt.HorizonSession()}
+
+	tt.Assert.NoError(q.UpsertAccounts(tt.Ctx, []AccountEntry{account1}))
+	tt.Assert.NoError(q.UpsertTrustLines(tt.Ctx, []TrustLine{eurTrustLine}))
+
+	tt.Assert.NoError(q.Begin(tt.Ctx))
+	builder := q.NewBalanceChangesBatchInsertBuilder()
+	for _, change := range []BalanceChange{
+		{
+			LedgerToid: toid.New(8, 0, 0).ToInt64(),
+			AccountID:  account1.AccountID,
+			AssetType:  xdr.AssetTypeAssetTypeNative,
+			Balance:    null.IntFrom(5000),
+		},
+		{
+			LedgerToid:      toid.New(10, 0, 0).ToInt64(),
+			AccountID:       account1.AccountID,
+			AssetType:       xdr.AssetTypeAssetTypeNative,
+			PreviousBalance: null.IntFrom(5000),
+			Balance:         null.IntFrom(account1.Balance),
+		},
+		{
+			LedgerToid:  toid.New(12, 0, 0).ToInt64(),
+			AccountID:   eurTrustLine.AccountID,
+			AssetType:   eurTrustLine.AssetType,
+			AssetCode:   eurTrustLine.AssetCode,
+			AssetIssuer: eurTrustLine.AssetIssuer,
+			Balance:     null.IntFrom(eurTrustLine.Balance),
+		},
+	} {
+		tt.Assert.NoError(builder.Add(change))
+	}
+	tt.Assert.NoError(builder.Exec(tt.Ctx))
+	tt.Assert.NoError(q.Commit())
+
+	native := func(balance int64) AccountBalance {
+		return AccountBalance{AssetType: xdr.AssetTypeAssetTypeNative, Balance: balance}
+	}
+	eur := AccountBalance{
+		AssetType:   eurTrustLine.AssetType,
+		AssetCode:   eurTrustLine.AssetCode,
+		AssetIssuer: eurTrustLine.AssetIssuer,
+		Balance:     eurTrustLine.Balance,
+	}
+
+	for _, testCase := range []struct {
+		ledger   uint32
+		expected []AccountBalance
+	}{
+		{12, []AccountBalance{eur, native(account1.Balance)}},
+		{11, []AccountBalance{native(account1.Balance)}},
+		{9, []AccountBalance{native(5000)}},
+		{7, []AccountBalance{}},
+	} {
+		balances, err := q.AccountBalancesAtLedger(tt.Ctx, account1.AccountID, testCase.ledger)
+		tt.Assert.NoError(err)
+		tt.Assert.Equal(testCase.expected, balances, "ledger %d", testCase.ledger)
+	}
+
+	count, err := q.DeleteBalanceChangesRange(tt.Ctx, toid.New(8, 0, 0).ToInt64(), toid.New(11, 0, 0).ToInt64())
+	tt.Assert.NoError(err)
+	tt.Assert.Equal(int64(2), count)
+
+	// reaping the changes before a ledger keeps the balances as of that ledger
+	balances, err := q.AccountBalancesAtLedger(tt.Ctx, account1.AccountID, 11)
+	tt.Assert.NoError(err)
+	tt.Assert.Equal([]AccountBalance{native(account1.Balance)}, balances)
+}
+
+func TestBalanceHistoryStartLedger(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &Q{tt.HorizonSession()}
+
+	start, err := q.GetBalanceHistoryStartLedger(tt.Ctx)
+	tt.Assert.NoError(err)
+	tt.Assert.Equal(uint32(0), start)
+
+	tt.Assert.NoError(q.UpdateBalanceHistoryStartLedger(tt.Ctx, 63))
+	start, err = q.GetBalanceHistoryStartLedger(tt.Ctx)
+	tt.Assert.NoError(err)
+	tt.Assert.Equal(uint32(63), start)
+}
@@ -0,0 +1,193 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+
+	"github.com/stellar/go/services/horizon/internal/db2"
+	"github.com/stellar/go/support/db"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/toid"
+	"github.com/stellar/go/xdr"
+)
+
+// MaxContractEventTopics is the maximum number of topics of a contract event.
+const MaxContractEventTopics = 4
+
+// ContractEventTypeNames maps contract event types to the names used in the
+// API.
+var ContractEventTypeNames = map[xdr.ContractEventType]string{
+	xdr.ContractEventTypeSystem:     "system",
+	xdr.ContractEventTypeContract:   "contract",
+	xdr.ContractEventTypeDiagnostic: "diagnostic",
+}
+
+// ContractEvent is a row of data from the `history_contract_events` table.
+// Topics and values are base64 encoded xdr.ScVals.
+type ContractEvent struct {
+	HistoryOperationID int64                 `db:"history_operation_id"`
+	Order              int32                 `db:"order"`
+	TransactionHash    string                `db:"transaction_hash"`
+	LedgerCloseTime    time.Time             `db:"ledger_closed_at"`
+	ContractID         null.String           `db:"contract_id"`
+	Type               xdr.ContractEventType `db:"type"`
+	TopicCount         int16                 `db:"topic_count"`
+	Topic1             null.String           `db:"topic_1"`
+	Topic2             null.String           `db:"topic_2"`
+	Topic3             null.String           `db:"topic_3"`
+	Topic4             null.String           `db:"topic_4"`
+	Value              string                `db:"value"`
+}
+
+// PagingToken returns a cursor for this contract event
+func (r *ContractEvent) PagingToken() string {
+	return fmt.Sprintf("%d-%d", r.HistoryOperationID, r.Order)
+}
+
+// LedgerSequence returns the ledger of the contract event.
+func (r *ContractEvent) LedgerSequence() int32 {
+	return toid.Parse(r.HistoryOperationID).LedgerSequence
+}
+
+// Topics returns the topics of the contract event.
+func (r *ContractEvent) Topics() []string {
+	topics := []string{r.Topic1.String, r.Topic2.String, r.Topic3.String, r.Topic4.String}
+	return topics[:r.TopicCount]
+}
+
+// SetTopics sets the topics of the contract event.
+func (r *ContractEvent) SetTopics(topics []string) error {
+	if len(topics) > MaxContractEventTopics {
+		return errors.Errorf("contract event has %d topics, more than %d", len(topics), MaxContractEventTopics)
+	}
+	columns := []*null.String{&r.Topic1, &r.Topic2, &r.Topic3, &r.Topic4}
+	for i, column := range columns {
+		*column = null.String{}
+		if i < len(topics) {
+			*column = null.StringFrom(topics[i])
+		}
+	}
+	r.TopicCount = int16(len(topics))
+	return nil
+}
+
+// ContractEventTopicWildcard matches any topic in a ContractEventsFilter.
+const ContractEventTopicWildcard = "*"
+
+// ContractEventsFilter selects contract events.
+type ContractEventsFilter struct {
+	// ContractID is the id of the contract which emitted the events, if not
+	// empty.
+	ContractID string
+	// Topics are the base64 encoded xdr.ScVal topics of the events, or
+	// ContractEventTopicWildcard. Events must have as many topics, unless
+	// AnyTrailingTopics is true.
+	Topics []string
+	// AnyTrailingTopics matches events with any topics following Topics.
+	AnyTrailingTopics bool
+}
+
+// QContractEvents defines history_contract_events related queries.
+type QContractEvents interface {
+	NewContractEventBatchInsertBuilder() ContractEventBatchInsertBuilder
+}
+
+// ContractEventBatchInsertBuilder is used to insert contract events into the
+// history_contract_events table
+type ContractEventBatchInsertBuilder interface {
+	Add(entries ...ContractEvent) error
+	Exec(ctx context.Context, session db.SessionInterface) error
+}
+
+// contractEventBatchInsertBuilder is a simple wrapper around db.FastBatchInsertBuilder
+type contractEventBatchInsertBuilder struct {
+	builder db.FastBatchInsertBuilder
+	table   string
+}
+
+// NewContractEventBatchInsertBuilder constructs a new ContractEventBatchInsertBuilder instance
+func (q *Q) NewContractEventBatchInsertBuilder() ContractEventBatchInsertBuilder {
+	return &contractEventBatchInsertBuilder{
+		table:   "history_contract_events",
+		builder: db.FastBatchInsertBuilder{},
+	}
+}
+
+// Add adds new contract events to the batch
+func (i *contractEventBatchInsertBuilder) Add(entries ...ContractEvent) error {
+	for _, entry := range entries {
+		if err := i.builder.RowStruct(entry); err != nil {
+			return errors.Wrap(err, "failed to add contract event")
+		}
+	}
+	return nil
+}
+
+// Exec flushes all outstanding contract events to the database
+func (i *contractEventBatchInsertBuilder) Exec(ctx context.Context, session db.SessionInterface) error {
+	return i.builder.Exec(ctx, session, i.table)
+}
+
+// ContractEvents returns a page of the contract events matching filter.
+func (q *Q) ContractEvents(ctx context.Context, filter ContractEventsFilter, page db2.PageQuery, oldestLedger int32) ([]ContractEvent, error) {
+	if len(filter.Topics) > MaxContractEventTopics {
+		return nil, errors.Errorf("more than %d topics", MaxContractEventTopics)
+	}
+	op, idx, err := parseEffectsCursor(page)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse cursor")
+	}
+
+	sel := selectContractEvents
+	if filter.ContractID != "" {
+		sel = sel.Where("hce.contract_id = ?", filter.ContractID)
+	}
+	for i, topic := range filter.Topics {
+		if topic != ContractEventTopicWildcard {
+			sel = sel.Where(fmt.Sprintf("hce.topic_%d = ?", i+1), topic)
+		}
+	}
+	if filter.AnyTrailingTopics {
+		sel = sel.Where("hce.topic_count >= ?", len(filter.Topics))
+	} else if len(filter.Topics) > 0 {
+		sel = sel.Where("hce.topic_count = ?", len(filter.Topics))
+	}
+
+	// NOTE: Remember to test the queries below with EXPLAIN / EXPLAIN ANALYZE
+	// before changing them.
+	switch page.Order {
+	case "asc":
+		sel = sel.
+			Where(`(
+				hce.history_operation_id >= ?
+			AND (
+				hce.history_operation_id > ? OR
+				(hce.history_operation_id = ? AND hce.order > ?)
+			))`, op, op, op, idx).
+			OrderBy("hce.history_operation_id asc, hce.order asc")
+	case "desc":
+		if lowerBound := lowestLedgerBound(oldestLedger); lowerBound > 0 {
+			sel = sel.Where("hce.history_operation_id > ?", lowerBound)
+		}
+		sel = sel.
+			Where(`(
+				hce.history_operation_id <= ?
+			AND (
+				hce.history_operation_id < ? OR
+				(hce.history_operation_id = ? AND hce.order < ?)
+			))`, op, op, op, idx).
+			OrderBy("hce.history_operation_id desc, hce.order desc")
+	}
+
+	var rows []ContractEvent
+	if err = q.Select(ctx, &rows, sel.Limit(page.Limit)); err != nil {
+		return nil, errors.Wrap(err, "could not select contract events")
+	}
+	return rows, nil
+}
+
+var selectContractEvents = sq.Select("hce.*").From("history_contract_events hce")
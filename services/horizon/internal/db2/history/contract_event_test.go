@@ -0,0 +1,73 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/guregu/null"
+
+	"github.com/stellar/go/services/horizon/internal/db2"
+	"github.com/stellar/go/services/horizon/internal/test"
+	"github.com/stellar/go/toid"
+	"github.com/stellar/go/xdr"
+)
+
+func TestContractEvents(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &Q{tt.HorizonSession()}
+	tt.Require.NoError(q.Begin(tt.Ctx))
+
+	contract := "CDLZFC3SYJYDZT7K67VZ75HPJVIEUVNIXF47ZG2FB2RMQQVU2HHGCYSC"
+	other := "CAS3J7GYLGXMF6TDJBBYYSE3HQ6BBSMLNUQ34T6TZMYMW2EVH34XOWMA"
+	closeTime := time.Unix(1700000000, 0).UTC()
+
+	newEvent := func(sequence int32, order int32, contractID string, topics ...string) ContractEvent {
+		event := ContractEvent{
+			HistoryOperationID: toid.New(sequence, 1, 1).ToInt64(),
+			Order:              order,
+			TransactionHash:    "0100000000000000000000000000000000000000000000000000000000000000",
+			LedgerCloseTime:    closeTime,
+			ContractID:         null.StringFrom(contractID),
+			Type:               xdr.ContractEventTypeContract,
+			Value:              "AAAAAQ==",
+		}
+		tt.Require.NoError(event.SetTopics(topics))
+		return event
+	}
+	first := newEvent(56, 1, contract, "transfer", "alice", "bob")
+	second := newEvent(56, 2, contract, "mint", "alice")
+	third := newEvent(57, 1, other, "transfer", "carol", "dave", "extra")
+
+	builder := q.NewContractEventBatchInsertBuilder()
+	tt.Require.NoError(builder.Add(first, second, third))
+	tt.Require.NoError(builder.Exec(tt.Ctx, q))
+	tt.Require.NoError(q.Commit())
+
+	page := db2.PageQuery{Cursor: "", Order: "asc", Limit: 10}
+	for _, testCase := range []struct {
+		filter   ContractEventsFilter
+		expected []ContractEvent
+	}{
+		{ContractEventsFilter{}, []ContractEvent{first, second, third}},
+		{ContractEventsFilter{ContractID: contract}, []ContractEvent{first, second}},
+		{ContractEventsFilter{Topics: []string{"transfer", "*", "*"}}, []ContractEvent{first}},
+		{ContractEventsFilter{Topics: []string{"transfer"}, AnyTrailingTopics: true}, []ContractEvent{first, third}},
+		{ContractEventsFilter{Topics: []string{"*", "alice"}}, []ContractEvent{second}},
+		{ContractEventsFilter{ContractID: other, Topics: []string{"mint"}, AnyTrailingTopics: true}, nil},
+	} {
+		rows, err := q.ContractEvents(tt.Ctx, testCase.filter, page, 0)
+		tt.Require.NoError(err)
+		tt.Require.Len(rows, len(testCase.expected))
+		for i, expected := range testCase.expected {
+			tt.Assert.Equal(expected.PagingToken(), rows[i].PagingToken())
+			tt.Assert.Equal(expected.Topics(), rows[i].Topics())
+		}
+	}
+
+	rows, err := q.ContractEvents(tt.Ctx, ContractEventsFilter{}, db2.PageQuery{Cursor: third.PagingToken(), Order: "desc", Limit: 1}, 0)
+	tt.Require.NoError(err)
+	tt.Require.Len(rows, 1)
+	tt.Assert.Equal(second.PagingToken(), rows[0].PagingToken())
+}
@@ -9,11 +9,24 @@ import (
 )
 
 const (
-	assetFilterRulesTableName   = "asset_filter_rules"
-	accountFilterRulesTableName = "account_filter_rules"
-	whitelistColumnName         = "whitelist"
-	enabledColumnName           = "enabled"
-	lastModifiedColumnName      = "last_modified"
+	assetFilterRulesTableName     = "asset_filter_rules"
+	accountFilterRulesTableName   = "account_filter_rules"
+	contractFilterRulesTableName  = "contract_filter_rules"
+	operationFilterRulesTableName = "operation_filter_rules"
+	whitelistColumnName           = "whitelist"
+	enabledColumnName             = "enabled"
+	lastModifiedColumnName        = "last_modified"
+	modeColumnName                = "mode"
+	operationTypesColumnName      = "operation_types"
+)
+
+const (
+	// OperationFilterModeWhitelist ingests the transactions with at least one
+	// operation of the types of the operation filter.
+	OperationFilterModeWhitelist = "whitelist"
+	// OperationFilterModeBlacklist skips the transactions with only
+	// operations of the types of the operation filter.
+	OperationFilterModeBlacklist = "blacklist"
 )
 
 type AssetFilterConfig struct {
@@ -28,11 +41,32 @@ type AccountFilterConfig struct {
 	LastModified int64          `db:"last_modified"`
 }
 
+// ContractFilterConfig whitelists the contract IDs (strkey encoded) of the
+// Soroban contracts invoked by, or emitting events in, ingested transactions.
+type ContractFilterConfig struct {
+	Enabled      bool           `db:"enabled"`
+	Whitelist    pq.StringArray `db:"whitelist"`
+	LastModified int64          `db:"last_modified"`
+}
+
+// OperationFilterConfig lists operation types, as named in the operation
+// resources, to whitelist or blacklist depending on Mode.
+type OperationFilterConfig struct {
+	Enabled        bool           `db:"enabled"`
+	Mode           string         `db:"mode"`
+	OperationTypes pq.StringArray `db:"operation_types"`
+	LastModified   int64          `db:"last_modified"`
+}
+
 type QFilter interface {
 	GetAccountFilterConfig(ctx context.Context) (AccountFilterConfig, error)
 	GetAssetFilterConfig(ctx context.Context) (AssetFilterConfig, error)
+	GetContractFilterConfig(ctx context.Context) (ContractFilterConfig, error)
+	GetOperationFilterConfig(ctx context.Context) (OperationFilterConfig, error)
 	UpdateAssetFilterConfig(ctx context.Context, config AssetFilterConfig) (AssetFilterConfig, error)
 	UpdateAccountFilterConfig(ctx context.Context, config AccountFilterConfig) (AccountFilterConfig, error)
+	UpdateContractFilterConfig(ctx context.Context, config ContractFilterConfig) (ContractFilterConfig, error)
+	UpdateOperationFilterConfig(ctx context.Context, config OperationFilterConfig) (OperationFilterConfig, error)
 }
 
 func (q *Q) GetAccountFilterConfig(ctx context.Context) (AccountFilterConfig, error) {
@@ -51,6 +85,22 @@ func (q *Q) GetAssetFilterConfig(ctx context.Context) (AssetFilterConfig, error)
 	return filterConfig, err
 }
 
+func (q *Q) GetContractFilterConfig(ctx context.Context) (ContractFilterConfig, error) {
+	filterConfig := ContractFilterConfig{}
+	sql := sq.Select("*").From(contractFilterRulesTableName)
+	err := q.Get(ctx, &filterConfig, sql)
+
+	return filterConfig, err
+}
+
+func (q *Q) GetOperationFilterConfig(ctx context.Context) (OperationFilterConfig, error) {
+	filterConfig := OperationFilterConfig{}
+	sql := sq.Select("*").From(operationFilterRulesTableName)
+	err := q.Get(ctx, &filterConfig, sql)
+
+	return filterConfig, err
+}
+
 func (q *Q) UpdateAssetFilterConfig(ctx context.Context, config AssetFilterConfig) (AssetFilterConfig, error) {
 	updateCols := map[string]interface{}{
 		lastModifiedColumnName: sq.Expr(`extract(epoch from now() at time zone 'utc')`),
@@ -91,6 +141,47 @@ func (q *Q) UpdateAccountFilterConfig(ctx context.Context, config AccountFilterC
 	return q.GetAccountFilterConfig(ctx)
 }
 
+func (q *Q) UpdateContractFilterConfig(ctx context.Context, config ContractFilterConfig) (ContractFilterConfig, error) {
+	updateCols := map[string]interface{}{
+		lastModifiedColumnName: sq.Expr(`extract(epoch from now() at time zone 'utc')`),
+		enabledColumnName:      config.Enabled,
+		whitelistColumnName:    config.Whitelist,
+	}
+
+	sqlUpdate := sq.Update(contractFilterRulesTableName).SetMap(updateCols)
+
+	rowCnt, err := q.checkForError(sqlUpdate, ctx)
+	if err != nil {
+		return ContractFilterConfig{}, err
+	}
+
+	if rowCnt < 1 {
+		return ContractFilterConfig{}, sql.ErrNoRows
+	}
+	return q.GetContractFilterConfig(ctx)
+}
+
+func (q *Q) UpdateOperationFilterConfig(ctx context.Context, config OperationFilterConfig) (OperationFilterConfig, error) {
+	updateCols := map[string]interface{}{
+		lastModifiedColumnName:   sq.Expr(`extract(epoch from now() at time zone 'utc')`),
+		enabledColumnName:        config.Enabled,
+		modeColumnName:           config.Mode,
+		operationTypesColumnName: config.OperationTypes,
+	}
+
+	sqlUpdate := sq.Update(operationFilterRulesTableName).SetMap(updateCols)
+
+	rowCnt, err := q.checkForError(sqlUpdate, ctx)
+	if err != nil {
+		return OperationFilterConfig{}, err
+	}
+
+	if rowCnt < 1 {
+		return OperationFilterConfig{}, sql.ErrNoRows
+	}
+	return q.GetOperationFilterConfig(ctx)
+}
+
 func (q *Q) checkForError(builder sq.Sqlizer, ctx context.Context) (int64, error) {
 	result, err := q.Exec(ctx, builder)
 	if err != nil {
@@ -43,3 +43,44 @@ func TestAccountFilterConfig(t *testing.T) {
 	tt.Assert.Equal(fc1Result.Enabled, true)
 	tt.Assert.ElementsMatch(fc1Result.Whitelist, []string{"1", "2"})
 }
+
+func TestContractFilterConfig(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &Q{tt.HorizonSession()}
+
+	fc1Result, err := q.GetContractFilterConfig(tt.Ctx)
+	assert.NoError(t, err)
+	tt.Assert.Equal(fc1Result.Enabled, false)
+	tt.Assert.Len(fc1Result.Whitelist, 0)
+
+	fc1Result.Enabled = true
+	fc1Result.Whitelist = append(fc1Result.Whitelist, "1", "2")
+	fc1Result, err = q.UpdateContractFilterConfig(tt.Ctx, fc1Result)
+	assert.NoError(t, err)
+	tt.Assert.Equal(fc1Result.Enabled, true)
+	tt.Assert.ElementsMatch(fc1Result.Whitelist, []string{"1", "2"})
+}
+
+func TestOperationFilterConfig(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &Q{tt.HorizonSession()}
+
+	fc1Result, err := q.GetOperationFilterConfig(tt.Ctx)
+	assert.NoError(t, err)
+	tt.Assert.Equal(fc1Result.Enabled, false)
+	tt.Assert.Equal(fc1Result.Mode, OperationFilterModeWhitelist)
+	tt.Assert.Len(fc1Result.OperationTypes, 0)
+
+	fc1Result.Enabled = true
+	fc1Result.Mode = OperationFilterModeBlacklist
+	fc1Result.OperationTypes = append(fc1Result.OperationTypes, "payment", "manage_data")
+	fc1Result, err = q.UpdateOperationFilterConfig(tt.Ctx, fc1Result)
+	assert.NoError(t, err)
+	tt.Assert.Equal(fc1Result.Enabled, true)
+	tt.Assert.Equal(fc1Result.Mode, OperationFilterModeBlacklist)
+	tt.Assert.ElementsMatch(fc1Result.OperationTypes, []string{"payment", "manage_data"})
+}
@@ -21,6 +21,7 @@ const (
 	offerCompactionSequence         = "offer_compaction_sequence"
 	liquidityPoolCompactionSequence = "liquidity_pool_compaction_sequence"
 	lookupTableReapOffsetSuffix     = "_reap_offset"
+	balanceHistoryStartLedger       = "balance_history_start_ledger"
 )
 
 // GetLastLedgerIngestNonBlocking works like GetLastLedgerIngest but
@@ -150,6 +151,17 @@ func (q *Q) GetLiquidityPoolCompactionSequence(ctx context.Context) (uint32, err
 	return uint32(parsed), nil
 }
 
+// GetBalanceHistoryStartLedger returns the first ledger as of which account
+// balances can be derived from the history_balance_changes table. Returns
+// zero if there is no value.
+func (q *Q) GetBalanceHistoryStartLedger(ctx context.Context) (uint32, error) {
+	parsed, err := q.getIntValueFromStore(ctx, balanceHistoryStartLedger, 32)
+	if err != nil {
+		return 0, errors.Wrap(err, "Error converting sequence value")
+	}
+	return uint32(parsed), nil
+}
+
 func (q *Q) getIntValueFromStore(ctx context.Context, key string, bitSize int) (int64, error) {
 	sequence, err := q.getValueFromStore(ctx, key, false)
 	if err != nil {
@@ -184,6 +196,16 @@ func (q *Q) UpdateLiquidityPoolCompactionSequence(ctx context.Context, sequence
 	)
 }
 
+// UpdateBalanceHistoryStartLedger sets the first ledger as of which account
+// balances can be derived from the history_balance_changes table.
+func (q *Q) UpdateBalanceHistoryStartLedger(ctx context.Context, sequence uint32) error {
+	return q.updateValueInStore(
+		ctx,
+		balanceHistoryStartLedger,
+		strconv.FormatUint(uint64(sequence), 10),
+	)
+}
+
 // getValueFromStore returns a value for a given key from KV store. If value
 // is not present in the key value store "" will be returned.
 func (q *Q) getValueFromStore(ctx context.Context, key string, forUpdate bool) (string, error) {
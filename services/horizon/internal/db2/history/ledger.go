@@ -27,6 +27,41 @@ func (q *Q) LedgerBySequence(ctx context.Context, dest interface{}, seq int32) e
 	return q.Get(ctx, dest, sql)
 }
 
+// LatestLedgerClosedAt loads the latest ledger between the `from` and `to`
+// sequences closed at or before `closedAt` into `dest`. history_ledgers has no
+// index on closed_at, the close times increase with the sequences so the
+// ledgers are binary searched by sequence instead. Returns sql.ErrNoRows if no
+// ledger in the range was closed at or before `closedAt`.
+func (q *Q) LatestLedgerClosedAt(ctx context.Context, dest *Ledger, closedAt time.Time, from, to int32) error {
+	found := false
+	for from <= to {
+		mid := from + (to-from)/2
+		// the first ledger of mid..to, there may be gaps in the history
+		var ledger Ledger
+		query := selectLedger.
+			Where("hl.sequence >= ? AND hl.sequence <= ?", mid, to).
+			OrderBy("hl.sequence ASC").
+			Limit(1)
+		err := q.Get(ctx, &ledger, query)
+		switch {
+		case q.NoRows(err):
+			to = mid - 1
+		case err != nil:
+			return err
+		case ledger.ClosedAt.After(closedAt):
+			to = mid - 1
+		default:
+			*dest = ledger
+			found = true
+			from = ledger.Sequence + 1
+		}
+	}
+	if !found {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
 // Ledgers provides a helper to filter rows from the `history_ledgers` table
 // with pre-defined filters.  See `LedgersQ` methods for the available filters.
 func (q *Q) Ledgers() *LedgersQ {
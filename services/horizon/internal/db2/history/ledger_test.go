@@ -55,6 +55,60 @@ func TestLedgerQueries(t *testing.T) {
 	}
 }
 
+func TestLatestLedgerClosedAt(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &Q{tt.HorizonSession()}
+
+	// ledgers 10 to 20 closed every 5 seconds, with a gap at 14-16
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	closedAt := func(sequence int32) time.Time {
+		return start.Add(time.Duration(sequence-10) * 5 * time.Second)
+	}
+	tt.Assert.NoError(q.Begin(tt.Ctx))
+	ledgerBatch := q.NewLedgerBatchInsertBuilder()
+	for sequence := int32(10); sequence <= 20; sequence++ {
+		if sequence >= 14 && sequence <= 16 {
+			continue
+		}
+		tt.Assert.NoError(ledgerBatch.Add(xdr.LedgerHeaderHistoryEntry{
+			Hash: xdr.Hash{byte(sequence)},
+			Header: xdr.LedgerHeader{
+				PreviousLedgerHash: xdr.Hash{byte(sequence - 1)},
+				LedgerSeq:          xdr.Uint32(sequence),
+				ScpValue:           xdr.StellarValue{CloseTime: xdr.TimePoint(closedAt(sequence).Unix())},
+			},
+		}, 0, 0, 0, 0, 0))
+	}
+	tt.Assert.NoError(ledgerBatch.Exec(tt.Ctx, q))
+	tt.Assert.NoError(q.Commit())
+
+	for _, testCase := range []struct {
+		at       time.Time
+		from, to int32
+		expected int32
+	}{
+		{closedAt(10), 10, 20, 10},
+		{closedAt(12).Add(time.Second), 10, 20, 12},
+		{closedAt(15), 10, 20, 13},
+		{closedAt(17), 10, 20, 17},
+		{closedAt(30), 10, 20, 20},
+		{closedAt(30), 10, 18, 18},
+		{closedAt(15), 15, 20, 0},
+		{start.Add(-time.Second), 10, 20, 0},
+	} {
+		var ledger Ledger
+		err := q.LatestLedgerClosedAt(tt.Ctx, &ledger, testCase.at, testCase.from, testCase.to)
+		if testCase.expected == 0 {
+			tt.Assert.Equal(sql.ErrNoRows, err, "at %v", testCase.at)
+			continue
+		}
+		tt.Assert.NoError(err)
+		tt.Assert.Equal(testCase.expected, ledger.Sequence, "at %v", testCase.at)
+	}
+}
+
 func TestInsertLedger(t *testing.T) {
 	tt := test.Start(t)
 	defer tt.Finish()
@@ -264,8 +264,10 @@ type IngestionQ interface {
 	QAccounts
 	QFilter
 	QAssetStats
+	QBalanceChanges
 	QClaimableBalances
 	QHistoryClaimableBalances
+	QContractEvents
 	QData
 	QEffects
 	QLedgers
@@ -279,6 +281,7 @@ type IngestionQ interface {
 	NewTransactionParticipantsBatchInsertBuilder() TransactionParticipantsBatchInsertBuilder
 	NewOperationParticipantBatchInsertBuilder() OperationParticipantBatchInsertBuilder
 	QSigners
+	QTokenTransfers
 	//QTrades
 	NewTradeBatchInsertBuilder() TradeBatchInsertBuilder
 	RebuildTradeAggregationTimes(ctx context.Context, from, to strtime.Millis, roundingSlippageFilter int) error
@@ -1203,12 +1206,14 @@ func constructFindReapLookupTablesQuery(table string, batchSize int, offset int6
 func (q *Q) DeleteRangeAll(ctx context.Context, start, end int64) (int64, error) {
 	var total int64
 	for table, column := range map[string]string{
+		"history_contract_events":                "history_operation_id",
 		"history_effects":                        "history_operation_id",
 		"history_ledgers":                        "id",
 		"history_operation_claimable_balances":   "history_operation_id",
 		"history_operation_participants":         "history_operation_id",
 		"history_operation_liquidity_pools":      "history_operation_id",
 		"history_operations":                     "id",
+		"history_token_transfers":                "history_operation_id",
 		"history_trades":                         "history_operation_id",
 		"history_trades_60000":                   "open_ledger_toid",
 		"history_transaction_claimable_balances": "history_transaction_id",
@@ -0,0 +1,48 @@
+package history
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockQBalanceChanges is a mock implementation of the QBalanceChanges interface
+type MockQBalanceChanges struct {
+	mock.Mock
+}
+
+func (m *MockQBalanceChanges) NewBalanceChangesBatchInsertBuilder() BalanceChangesBatchInsertBuilder {
+	a := m.Called()
+	return a.Get(0).(BalanceChangesBatchInsertBuilder)
+}
+
+func (m *MockQBalanceChanges) UpdateBalanceHistoryStartLedger(ctx context.Context, sequence uint32) error {
+	a := m.Called(ctx, sequence)
+	return a.Error(0)
+}
+
+func (m *MockQBalanceChanges) DeleteBalanceChangesRange(ctx context.Context, start, end int64) (int64, error) {
+	a := m.Called(ctx, start, end)
+	return a.Get(0).(int64), a.Error(1)
+}
+
+// MockBalanceChangesBatchInsertBuilder is a mock implementation of the
+// BalanceChangesBatchInsertBuilder interface
+type MockBalanceChangesBatchInsertBuilder struct {
+	mock.Mock
+}
+
+func (m *MockBalanceChangesBatchInsertBuilder) Add(change BalanceChange) error {
+	a := m.Called(change)
+	return a.Error(0)
+}
+
+func (m *MockBalanceChangesBatchInsertBuilder) Exec(ctx context.Context) error {
+	a := m.Called(ctx)
+	return a.Error(0)
+}
+
+func (m *MockBalanceChangesBatchInsertBuilder) Len() int {
+	a := m.Called()
+	return a.Int(0)
+}
@@ -0,0 +1,35 @@
+package history
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/stellar/go/support/db"
+)
+
+// MockQContractEvents is a mock implementation of the QContractEvents interface
+type MockQContractEvents struct {
+	mock.Mock
+}
+
+func (m *MockQContractEvents) NewContractEventBatchInsertBuilder() ContractEventBatchInsertBuilder {
+	a := m.Called()
+	return a.Get(0).(ContractEventBatchInsertBuilder)
+}
+
+// MockContractEventBatchInsertBuilder is a mock implementation of the
+// ContractEventBatchInsertBuilder interface
+type MockContractEventBatchInsertBuilder struct {
+	mock.Mock
+}
+
+func (m *MockContractEventBatchInsertBuilder) Add(entries ...ContractEvent) error {
+	a := m.Called(entries)
+	return a.Error(0)
+}
+
+func (m *MockContractEventBatchInsertBuilder) Exec(ctx context.Context, session db.SessionInterface) error {
+	a := m.Called(ctx, session)
+	return a.Error(0)
+}
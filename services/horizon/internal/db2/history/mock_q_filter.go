@@ -30,3 +30,23 @@ func (m *MockQFilter) UpdateAssetFilterConfig(ctx context.Context, config AssetF
 	a := m.Called(ctx, config)
 	return a.Get(0).(AssetFilterConfig), a.Error(0)
 }
+
+func (m *MockQFilter) GetContractFilterConfig(ctx context.Context) (ContractFilterConfig, error) {
+	a := m.Called(ctx)
+	return a.Get(0).(ContractFilterConfig), a.Error(1)
+}
+
+func (m *MockQFilter) GetOperationFilterConfig(ctx context.Context) (OperationFilterConfig, error) {
+	a := m.Called(ctx)
+	return a.Get(0).(OperationFilterConfig), a.Error(1)
+}
+
+func (m *MockQFilter) UpdateContractFilterConfig(ctx context.Context, config ContractFilterConfig) (ContractFilterConfig, error) {
+	a := m.Called(ctx, config)
+	return a.Get(0).(ContractFilterConfig), a.Error(1)
+}
+
+func (m *MockQFilter) UpdateOperationFilterConfig(ctx context.Context, config OperationFilterConfig) (OperationFilterConfig, error) {
+	a := m.Called(ctx, config)
+	return a.Get(0).(OperationFilterConfig), a.Error(1)
+}
@@ -0,0 +1,35 @@
+package history
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/stellar/go/support/db"
+)
+
+// MockQTokenTransfers is a mock implementation of the QTokenTransfers interface
+type MockQTokenTransfers struct {
+	mock.Mock
+}
+
+func (m *MockQTokenTransfers) NewTokenTransferBatchInsertBuilder() TokenTransferBatchInsertBuilder {
+	a := m.Called()
+	return a.Get(0).(TokenTransferBatchInsertBuilder)
+}
+
+// MockTokenTransferBatchInsertBuilder is a mock implementation of the
+// TokenTransferBatchInsertBuilder interface
+type MockTokenTransferBatchInsertBuilder struct {
+	mock.Mock
+}
+
+func (m *MockTokenTransferBatchInsertBuilder) Add(entries ...TokenTransfer) error {
+	a := m.Called(entries)
+	return a.Error(0)
+}
+
+func (m *MockTokenTransferBatchInsertBuilder) Exec(ctx context.Context, session db.SessionInterface) error {
+	a := m.Called(ctx, session)
+	return a.Error(0)
+}
@@ -0,0 +1,212 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+
+	"github.com/stellar/go/services/horizon/internal/db2"
+	"github.com/stellar/go/support/db"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/toid"
+)
+
+// TokenTransferType is the type of a token transfer event, used as the `type`
+// field in the `history_token_transfers` table.
+type TokenTransferType int16
+
+const (
+	// TokenTransferTypeTransfer is a transfer of a token between two addresses.
+	TokenTransferTypeTransfer TokenTransferType = 1
+	// TokenTransferTypeMint is a token minted to an address.
+	TokenTransferTypeMint TokenTransferType = 2
+	// TokenTransferTypeBurn is a token burnt from an address.
+	TokenTransferTypeBurn TokenTransferType = 3
+	// TokenTransferTypeClawback is a token clawed back from an address.
+	TokenTransferTypeClawback TokenTransferType = 4
+	// TokenTransferTypeFee is a transaction fee charged to, or refunded to when
+	// negative, an address.
+	TokenTransferTypeFee TokenTransferType = 5
+)
+
+// TokenTransferTypeNames maps token transfer types to the names used in the
+// API.
+var TokenTransferTypeNames = map[TokenTransferType]string{
+	TokenTransferTypeTransfer: "transfer",
+	TokenTransferTypeMint:     "mint",
+	TokenTransferTypeBurn:     "burn",
+	TokenTransferTypeClawback: "clawback",
+	TokenTransferTypeFee:      "fee",
+}
+
+// TokenTransfer is a row of data from the `history_token_transfers` table.
+//
+// Token transfers of an operation are stored with the id of the operation,
+// fee events with the id of the transaction, which is the id its first
+// operation would have minus one.
+type TokenTransfer struct {
+	HistoryOperationID int64             `db:"history_operation_id"`
+	Order              int32             `db:"order"`
+	TransactionHash    string            `db:"transaction_hash"`
+	LedgerCloseTime    time.Time         `db:"ledger_closed_at"`
+	Type               TokenTransferType `db:"type"`
+	From               null.String       `db:"from_address"`
+	To                 null.String       `db:"to_address"`
+	ToMuxedIDType      null.String       `db:"to_muxed_id_type"`
+	ToMuxedID          null.String       `db:"to_muxed_id"`
+	ContractID         string            `db:"contract_id"`
+	AssetType          null.String       `db:"asset_type"`
+	AssetCode          null.String       `db:"asset_code"`
+	AssetIssuer        null.String       `db:"asset_issuer"`
+	// Amount is the amount in the smallest unit of the token. It is a string
+	// because SEP-41 token amounts are 128-bit integers.
+	Amount string `db:"amount"`
+}
+
+// PagingToken returns a cursor for this token transfer
+func (r *TokenTransfer) PagingToken() string {
+	return fmt.Sprintf("%d-%d", r.HistoryOperationID, r.Order)
+}
+
+// LedgerSequence returns the ledger of the token transfer.
+func (r *TokenTransfer) LedgerSequence() int32 {
+	return toid.Parse(r.HistoryOperationID).LedgerSequence
+}
+
+// QTokenTransfers defines history_token_transfers related queries.
+type QTokenTransfers interface {
+	NewTokenTransferBatchInsertBuilder() TokenTransferBatchInsertBuilder
+}
+
+// TokenTransferBatchInsertBuilder is used to insert token transfers into the
+// history_token_transfers table
+type TokenTransferBatchInsertBuilder interface {
+	Add(entries ...TokenTransfer) error
+	Exec(ctx context.Context, session db.SessionInterface) error
+}
+
+// tokenTransferBatchInsertBuilder is a simple wrapper around db.FastBatchInsertBuilder
+type tokenTransferBatchInsertBuilder struct {
+	builder db.FastBatchInsertBuilder
+	table   string
+}
+
+// NewTokenTransferBatchInsertBuilder constructs a new TokenTransferBatchInsertBuilder instance
+func (q *Q) NewTokenTransferBatchInsertBuilder() TokenTransferBatchInsertBuilder {
+	return &tokenTransferBatchInsertBuilder{
+		table:   "history_token_transfers",
+		builder: db.FastBatchInsertBuilder{},
+	}
+}
+
+// Add adds new token transfers to the batch
+func (i *tokenTransferBatchInsertBuilder) Add(entries ...TokenTransfer) error {
+	for _, entry := range entries {
+		if err := i.builder.RowStruct(entry); err != nil {
+			return errors.Wrap(err, "failed to add token transfer")
+		}
+	}
+	return nil
+}
+
+// Exec flushes all outstanding token transfers to the database
+func (i *tokenTransferBatchInsertBuilder) Exec(ctx context.Context, session db.SessionInterface) error {
+	return i.builder.Exec(ctx, session, i.table)
+}
+
+// TokenTransfersForAccount returns a page of the token transfers from or to
+// an address, of an account, a contract, a liquidity pool or a claimable
+// balance.
+func (q *Q) TokenTransfersForAccount(ctx context.Context, address string, page db2.PageQuery, oldestLedger int32) ([]TokenTransfer, error) {
+	op, idx, err := parseEffectsCursor(page)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse cursor")
+	}
+
+	fromSQL, fromArgs, err := appendTokenTransfersOrdering(
+		selectTokenTransfers.Where("htt.from_address = ?", address), oldestLedger, op, idx, page,
+	).ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "error building from query")
+	}
+	toSQL, toArgs, err := appendTokenTransfersOrdering(
+		selectTokenTransfers.Where("htt.to_address = ?", address), oldestLedger, op, idx, page,
+	).ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "error building to query")
+	}
+
+	// transfers from an address to itself are in both selects, UNION
+	// removes the duplicates
+	rawSQL := fmt.Sprintf("(%s) UNION (%s) ", fromSQL, toSQL)
+	switch page.Order {
+	case "asc":
+		rawSQL += `ORDER BY history_operation_id asc, "order" asc `
+	case "desc":
+		rawSQL += `ORDER BY history_operation_id desc, "order" desc `
+	}
+	rawSQL += fmt.Sprintf("LIMIT %d", page.Limit)
+
+	// Add explicit query type for prometheus metrics, since we use raw sql.
+	ctx = context.WithValue(ctx, &db.QueryTypeContextKey, db.SelectQueryType)
+	var rows []TokenTransfer
+	if err = q.SelectRaw(ctx, &rows, rawSQL, append(fromArgs, toArgs...)...); err != nil {
+		return nil, errors.Wrap(err, "could not select token transfers")
+	}
+	return rows, nil
+}
+
+// TokenTransfersForContract returns a page of the token transfers of the
+// token contract with the given id, a Stellar Asset Contract or a SEP-41
+// token.
+func (q *Q) TokenTransfersForContract(ctx context.Context, contractID string, page db2.PageQuery, oldestLedger int32) ([]TokenTransfer, error) {
+	op, idx, err := parseEffectsCursor(page)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse cursor")
+	}
+
+	query := appendTokenTransfersOrdering(
+		selectTokenTransfers.Where("htt.contract_id = ?", contractID), oldestLedger, op, idx, page,
+	)
+	var rows []TokenTransfer
+	if err = q.Select(ctx, &rows, query); err != nil {
+		return nil, errors.Wrap(err, "could not select token transfers")
+	}
+	return rows, nil
+}
+
+func appendTokenTransfersOrdering(sel sq.SelectBuilder, oldestLedger int32, op, idx int64, page db2.PageQuery) sq.SelectBuilder {
+	// NOTE: Remember to test the queries below with EXPLAIN / EXPLAIN ANALYZE
+	// before changing them.
+	// This condition is using multicolumn index and it's easy to write it in a way that
+	// DB will perform a full table scan.
+	switch page.Order {
+	case "asc":
+		sel = sel.
+			Where(`(
+				htt.history_operation_id >= ?
+			AND (
+				htt.history_operation_id > ? OR
+				(htt.history_operation_id = ? AND htt.order > ?)
+			))`, op, op, op, idx).
+			OrderBy("htt.history_operation_id asc, htt.order asc")
+	case "desc":
+		if lowerBound := lowestLedgerBound(oldestLedger); lowerBound > 0 {
+			sel = sel.Where("htt.history_operation_id > ?", lowerBound)
+		}
+		sel = sel.
+			Where(`(
+				htt.history_operation_id <= ?
+			AND (
+				htt.history_operation_id < ? OR
+				(htt.history_operation_id = ? AND htt.order < ?)
+			))`, op, op, op, idx).
+			OrderBy("htt.history_operation_id desc, htt.order desc")
+	}
+	return sel.Limit(page.Limit)
+}
+
+var selectTokenTransfers = sq.Select("htt.*").From("history_token_transfers htt")
@@ -0,0 +1,89 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/guregu/null"
+
+	"github.com/stellar/go/services/horizon/internal/db2"
+	"github.com/stellar/go/services/horizon/internal/test"
+	"github.com/stellar/go/toid"
+)
+
+func TestTokenTransfers(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &Q{tt.HorizonSession()}
+	tt.Require.NoError(q.Begin(tt.Ctx))
+
+	alice := "GAQAA5L65LSYH7CQ3VTJ7F3HHLGCL3DSLAR2Y47263D56MNNGHSQSTVY"
+	bob := "GAN4WOTCFSASG3J6SGLLQZURDDUVNBQANAHEQJ3PBNDZ74X63UZWQPZW"
+	native := "CDLZFC3SYJYDZT7K67VZ75HPJVIEUVNIXF47ZG2FB2RMQQVU2HHGCYSC"
+	token := "CAS3J7GYLGXMF6TDJBBYYSE3HQ6BBSMLNUQ34T6TZMYMW2EVH34XOWMA"
+	closeTime := time.Unix(1700000000, 0).UTC()
+
+	fee := TokenTransfer{
+		HistoryOperationID: toid.New(56, 1, 0).ToInt64(),
+		Order:              1,
+		TransactionHash:    "0100000000000000000000000000000000000000000000000000000000000000",
+		LedgerCloseTime:    closeTime,
+		Type:               TokenTransferTypeFee,
+		From:               null.StringFrom(alice),
+		ContractID:         native,
+		AssetType:          null.StringFrom("native"),
+		Amount:             "100",
+	}
+	transfer := TokenTransfer{
+		HistoryOperationID: toid.New(56, 1, 1).ToInt64(),
+		Order:              1,
+		TransactionHash:    fee.TransactionHash,
+		LedgerCloseTime:    closeTime,
+		Type:               TokenTransferTypeTransfer,
+		From:               null.StringFrom(alice),
+		To:                 null.StringFrom(bob),
+		ToMuxedIDType:      null.StringFrom("id"),
+		ToMuxedID:          null.StringFrom("7"),
+		ContractID:         token,
+		Amount:             "170141183460469231731687303715884105727",
+	}
+	mint := TokenTransfer{
+		HistoryOperationID: toid.New(57, 1, 1).ToInt64(),
+		Order:              1,
+		TransactionHash:    "0200000000000000000000000000000000000000000000000000000000000000",
+		LedgerCloseTime:    closeTime,
+		Type:               TokenTransferTypeMint,
+		To:                 null.StringFrom(alice),
+		ContractID:         token,
+		Amount:             "5",
+	}
+
+	builder := q.NewTokenTransferBatchInsertBuilder()
+	tt.Require.NoError(builder.Add(fee, transfer, mint))
+	tt.Require.NoError(builder.Exec(tt.Ctx, q))
+	tt.Require.NoError(q.Commit())
+
+	page := db2.PageQuery{Cursor: "", Order: "asc", Limit: 10}
+	rows, err := q.TokenTransfersForAccount(tt.Ctx, alice, page, 0)
+	tt.Require.NoError(err)
+	tt.Require.Len(rows, 3)
+	for i, expected := range []TokenTransfer{fee, transfer, mint} {
+		tt.Assert.Equal(expected.PagingToken(), rows[i].PagingToken())
+		tt.Assert.Equal(expected.Amount, rows[i].Amount)
+		tt.Assert.Equal(expected.To, rows[i].To)
+		tt.Assert.Equal(expected.ToMuxedID, rows[i].ToMuxedID)
+		tt.Assert.Equal(expected.AssetType, rows[i].AssetType)
+	}
+
+	rows, err = q.TokenTransfersForAccount(tt.Ctx, bob, page, 0)
+	tt.Require.NoError(err)
+	tt.Require.Len(rows, 1)
+	tt.Assert.Equal(transfer.PagingToken(), rows[0].PagingToken())
+
+	page = db2.PageQuery{Cursor: mint.PagingToken(), Order: "desc", Limit: 10}
+	rows, err = q.TokenTransfersForContract(tt.Ctx, token, page, 0)
+	tt.Require.NoError(err)
+	tt.Require.Len(rows, 1)
+	tt.Assert.Equal(transfer.PagingToken(), rows[0].PagingToken())
+}
@@ -68,6 +68,10 @@
 // migrations/69_add_asset_contracts_table.sql (671B)
 // migrations/6_create_assets_table.sql (366B)
 // migrations/70_replace_timestamp_trade_aggregations_brin_index.sql (317B)
+// migrations/71_add_history_token_transfers.sql (1.128kB)
+// migrations/72_add_history_contract_events.sql (1.159kB)
+// migrations/73_add_contract_and_operation_filter_rules.sql (869B)
+// migrations/74_add_history_balance_changes.sql (1.022kB)
 // migrations/7_modify_trades_table.sql (2.303kB)
 // migrations/8_add_aggregators.sql (907B)
 // migrations/8_create_asset_stats_table.sql (441B)
@@ -1500,6 +1504,86 @@ func migrations70_replace_timestamp_trade_aggregations_brin_indexSql() (*asset,
 	return a, nil
 }
 
+var _migrations71_add_history_token_transfersSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x94\xdf\x6b\xdb\x30\x10\xc7\xdf\xf5\x57\x1c\x7d\x4a\x58\x0a\xfb\xd1\x86\x95\x3c\x75\x8b\x19\x81\xe0\x6c\x5d\x0c\x7b\x13\x8a\x74\xb5\xc5\x22\x9d\x39\x9d\xd7\x66\x7f\xfd\x68\xd2\x74\x89\xed\xd2\x6c\x7d\xb5\x3e\xf7\xfd\x1c\xf2\x17\x9d\x9f\xc3\x9b\xe0\x4b\x36\x82\x50\xd4\x4a\x7d\xbe\xc9\xae\x97\x19\x2c\xaf\x3f\xcd\x33\xa8\x7c\x12\xe2\x8d\x16\xfa\x89\x51\x0b\x9b\x98\x6e\x91\x13\x0c\x14\x00\x3c\x9d\x52\x8d\x6c\xc4\x53\xd4\xde\xc1\xca\x97\x3e\x0a\xe4\x8b\x25\xe4\xc5\x7c\x3e\xda\x92\x67\xc4\x0e\xf9\x0c\x7c\x14\x2c\x91\x5b\xa7\xdb\x5c\x63\xb7\x09\x95\x49\x15\xd8\xca\xb0\xb1\x82\x0c\xbf\x0c\x6f\x7c\x2c\x07\xe3\x8b\x61\x6b\x68\x8d\xae\x44\xd6\x76\x4d\x09\x9d\x36\x02\xe2\x03\x26\x31\xa1\x86\x3b\x2f\x15\x35\xbb\x2f\xf0\x9b\x22\xb6\x7d\x9b\x1a\x21\x05\xb3\x5e\x77\x37\xbd\x65\x0a\xda\x38\xc7\x98\x52\xdf\x1e\x57\xc3\xc7\x0c\x3a\x91\x0a\xcd\x3d\x3a\xed\x9d\xde\x5a\xbb\xec\xc7\x2e\x0a\x82\xf7\xb2\xfb\x6a\x29\xca\x43\xf8\xc3\xcd\x76\x67\x2f\xc7\xed\x5b\x31\x29\xa1\x3c\xa7\x1a\x5f\x3c\xba\x76\x94\x25\xd7\x47\xbd\x7b\x7f\x44\xf9\x94\x1a\xe4\x1e\xee\x72\xbc\xe7\x02\x35\x51\x20\x36\x01\xd9\xdb\xc1\x87\xab\xd1\xdb\xbf\x6b\xa9\xe1\xe4\xa9\x53\x45\x3e\xfb\x56\x64\x30\xcb\xa7\xd9\x0f\xa8\x44\xf4\x6a\xa3\xa9\x86\x45\xfe\x6c\xcf\x8a\xef\xb3\xfc\x0b\xac\x84\x11\x61\xd0\x57\xb7\xd1\xbe\x5a\xc3\xc9\xde\x72\x14\x7f\xf4\x3b\x4f\x15\x1d\x0e\x8d\xe0\x7f\xb4\x42\xff\x2c\x15\x7a\x9d\xf2\xb0\x29\xa7\x3a\x0f\x66\x5e\x96\xaa\xc3\x97\x62\x4a\x77\x51\xa9\xe9\xcd\xe2\xeb\x0b\x2f\x85\x35\xc9\x1a\x87\x13\xf5\x67\x00\xb1\x46\xed\xfd\x68\x04\x00\x00")
+
+func migrations71_add_history_token_transfersSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations71_add_history_token_transfersSql,
+		"migrations/71_add_history_token_transfers.sql",
+	)
+}
+
+func migrations71_add_history_token_transfersSql() (*asset, error) {
+	bytes, err := migrations71_add_history_token_transfersSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/71_add_history_token_transfers.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xfd, 0x6e, 0x7d, 0x8d, 0x48, 0x2, 0x35, 0xc3, 0xcb, 0x82, 0xba, 0x98, 0xa8, 0x4d, 0xe3, 0x26, 0xe5, 0x16, 0xa8, 0xf0, 0xa9, 0x95, 0xd5, 0xf9, 0xa4, 0xcf, 0xc9, 0x1f, 0x8f, 0xbf, 0x39, 0x6a}}
+	return a, nil
+}
+
+var _migrations72_add_history_contract_eventsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x94\xcf\x8e\xda\x30\x10\xc6\xef\x7e\x8a\x11\x27\xa2\xc2\xa1\x90\x72\xe1\x44\x4b\x54\x21\xa1\xd0\x52\x22\xf5\x66\x0d\xce\x28\xb1\x94\xd8\x91\x3d\xc0\x66\x9f\x7e\xb5\x2c\x44\x59\x60\x17\x58\x8e\xfe\xe6\xcf\xcf\x33\x9f\x34\xfd\x3e\x7c\x2b\x75\xe6\x90\x09\x92\x4a\x88\x5f\xcb\x68\xb2\x8a\x60\x35\xf9\x39\x8f\x20\xd7\x9e\xad\xab\xa5\xb2\x86\x1d\x2a\x96\xb4\x25\xc3\x1e\xba\x02\x00\x9a\xa8\xad\xc8\x21\x6b\x6b\xa4\x4e\x61\xad\x33\x6d\x18\xe2\xc5\x0a\xe2\x64\x3e\xef\xed\x33\x3b\xd6\xa5\xe4\x3a\xa0\x0d\x53\x46\xee\x24\xca\x0e\x8d\x47\xb5\xef\x90\xa3\xcf\x41\xe5\xf8\x4a\x23\x07\x5b\x74\xb5\x36\x59\x77\x14\x06\x27\x45\x05\xa5\x19\x39\xa9\x0a\xeb\x29\x95\xc8\xc0\xba\x24\xcf\x58\x56\xb0\xd3\x9c\xdb\xcd\x9b\x02\xcf\xd6\xd0\x49\x69\x33\x8d\x4e\x2f\xa0\x7e\x8c\x82\xc3\xb7\xea\x8a\xc0\x97\x58\x14\xe7\x03\xb1\xad\xb4\x92\xca\x6e\x0c\x7f\x9e\xf2\x1d\x98\x9e\xb8\xad\x0c\xce\x94\xe1\x99\x12\xb6\x94\x2d\x16\x1b\xda\xbf\x1b\x80\x08\xc6\x8d\x51\x49\x3c\xfb\x9b\x44\x30\x8b\xa7\xd1\x7f\xc8\x15\xc9\x75\x2d\x6d\x05\x8b\xf8\x43\xf3\x92\x7f\xb3\xf8\x37\xac\xd9\x11\x41\xf7\x92\x87\xbd\xa3\x5f\xc1\xf8\x48\x79\xd7\xbe\xbd\xbf\x5b\x39\xad\x9a\x1e\x7c\x05\x7a\x5c\xe7\xad\xc0\x43\xfe\x23\xb0\xc1\x9d\xb0\xc1\x23\xb0\xe1\x9d\xb0\xe1\x23\xb0\xf0\x4e\x58\x78\x1d\x26\xda\x87\x64\x6a\x77\x46\x88\xe9\x72\xf1\xe7\xca\x21\x51\xe8\x15\xa6\x34\x16\x2f\x03\x00\x82\x26\x18\xfb\x87\x04\x00\x00")
+
+func migrations72_add_history_contract_eventsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations72_add_history_contract_eventsSql,
+		"migrations/72_add_history_contract_events.sql",
+	)
+}
+
+func migrations72_add_history_contract_eventsSql() (*asset, error) {
+	bytes, err := migrations72_add_history_contract_eventsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/72_add_history_contract_events.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x64, 0x43, 0x8b, 0xb9, 0x2, 0xe, 0x80, 0x71, 0xe9, 0x7c, 0x2, 0x1b, 0xe9, 0x98, 0xda, 0x46, 0xb3, 0x18, 0xee, 0xb3, 0x98, 0x8f, 0xcf, 0x9d, 0xeb, 0x20, 0xd4, 0x66, 0x15, 0xc4, 0x1, 0x46}}
+	return a, nil
+}
+
+var _migrations73_add_contract_and_operation_filter_rulesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x92\x31\x8b\xdb\x40\x10\x85\x7b\xfd\x8a\xd7\xd9\x26\x16\x24\x4d\x1a\x57\x4e\x4e\xc5\x81\xb1\x83\x4f\x4e\x13\x82\x19\xef\x8e\xac\xe5\x56\xbb\x62\x67\x1c\x73\x84\xfc\xf7\x20\x09\x2b\xca\x05\xa7\xb9\x6a\x61\x79\xf3\xcd\x9b\x99\x97\xe7\x78\xd7\xb8\x73\x22\x65\x1c\xda\x2c\xfb\xbc\x2f\xd6\x65\x81\x72\xfd\x69\x53\xc0\xc4\xa0\x89\x8c\x1e\x2b\xe7\x95\xd3\x31\x5d\x3c\x0b\xe6\x19\x00\x70\xa0\x93\x67\x8b\x53\x8c\x1e\xdb\x5d\x89\xed\x61\xb3\x81\xe5\x8a\x2e\x5e\x51\x91\x17\x5e\xf6\xc2\x6b\xed\x94\xbd\x13\xc5\x0f\x4a\xa6\xa6\xf4\xed\xfb\xa8\x1f\x14\x9e\x44\x8f\x4d\xb4\xae\x72\x1d\xd0\x9d\x5d\xd0\x51\x92\x2d\x56\xaf\x5c\xc5\x96\x13\xa9\x8b\xe1\x4d\xb6\xf2\x7c\xe2\xcc\x85\x33\x8b\x0a\x62\xf0\x2f\xd0\x9a\xa1\x89\x82\x90\xe9\xba\x08\xae\x4e\x6b\x50\xf8\xd3\x18\xb1\x42\x0c\xdc\x3d\x5a\xf3\x0d\xd7\xcd\xc8\x16\xfa\xd2\xb2\x2c\x71\xf2\x64\x9e\xbb\x2f\xc8\xb3\x6b\xe5\x0e\xb5\x6f\x38\x72\x05\xb1\xba\xd1\x3a\xfd\x94\xd8\xff\x37\xd1\xf2\x6d\x8d\xf3\x0f\x1f\x17\xff\x4e\x38\x1b\x87\x9a\x0d\xcb\x1d\xe1\xc7\xde\xd8\x1b\x8e\x90\xe7\x70\x41\x38\x69\x3f\xcb\xad\xa1\x75\x32\x6c\x5c\xb4\xcb\x50\x15\x13\x98\x4c\x0d\xb9\xb4\x6d\x4c\xca\x16\xc3\x95\xe0\x9a\xd6\x73\xc3\x41\x7b\x37\xd9\xe3\xf6\xa9\xd8\x97\x78\xdc\x96\xbb\x3b\x39\xfb\xba\xde\x1c\x8a\x27\xcc\x87\xa3\x61\xf6\xf3\xd7\x6c\x89\xf7\x8b\xd5\x5f\xa5\x77\xc2\xf0\xba\x76\xb2\x95\x09\x28\x9b\xa6\xff\x21\x5e\x43\x96\x3d\xec\x77\x5f\xfe\x9b\x7e\x43\x62\xc8\xf2\x6a\xaa\xbc\x63\xc2\x90\x18\xb2\xbc\xca\x7e\x0f\x00\xb0\xfb\x26\xf3\x65\x03\x00\x00")
+
+func migrations73_add_contract_and_operation_filter_rulesSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations73_add_contract_and_operation_filter_rulesSql,
+		"migrations/73_add_contract_and_operation_filter_rules.sql",
+	)
+}
+
+func migrations73_add_contract_and_operation_filter_rulesSql() (*asset, error) {
+	bytes, err := migrations73_add_contract_and_operation_filter_rulesSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/73_add_contract_and_operation_filter_rules.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xcb, 0x7c, 0x1e, 0x80, 0x7e, 0x89, 0x2a, 0xc6, 0x67, 0xd, 0x54, 0x6a, 0xab, 0x6a, 0xe6, 0xb2, 0x14, 0x5d, 0x85, 0x8d, 0xe5, 0xfd, 0x3e, 0x42, 0xaa, 0x83, 0xf4, 0x61, 0xe6, 0x9d, 0xf2, 0x40}}
+	return a, nil
+}
+
+var _migrations74_add_history_balance_changesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x93\x41\x8f\xda\x30\x10\x85\xef\xfe\x15\xef\x08\x6a\x58\xa9\x55\xbb\x97\x3d\x6d\x0b\xaa\x90\x50\x68\xb7\x20\xf5\x16\x39\xce\x24\x19\x29\xb5\xa9\x3d\x81\xe6\xdf\x57\x86\x98\x85\x2d\xab\xee\xcd\x9a\xf1\x7c\xf3\xe6\x79\x3c\x9b\xe1\xdd\x2f\x6e\xbc\x16\xc2\x76\xa7\xd4\x6c\x86\x96\x83\x38\x3f\x14\xa5\xee\xb4\x35\x54\x98\x56\xdb\x86\x02\x62\x94\x02\xa4\x25\xa4\x90\xab\xa1\x8d\x71\xbd\x15\x58\x2d\xbc\x27\x8c\x45\x01\xda\x56\x11\x26\xbe\x0f\x82\x8e\xed\x73\x2a\xc3\x81\xa5\x3d\x72\xc6\x10\x4a\xaa\x9d\xa7\x58\x03\x5d\x0b\x79\x90\x36\xed\xd8\x05\x13\xdb\x77\x5d\x64\x1d\x5a\xb2\xc7\x32\xb2\xe2\x07\x54\x5c\xc1\x3a\x01\xfd\xe1\x20\xd3\x3b\x6c\x2e\x80\x51\x98\x3d\x6b\xd3\x27\xa5\xe8\xa8\x6a\xc8\x83\x43\xa4\x45\xd0\xce\xd3\x9e\x5d\x1f\x2e\xeb\x62\xbc\x66\x1f\x24\xf5\xaf\x5d\xd7\xb9\x03\xdb\x06\xd2\x6a\x19\x21\x19\x9c\x8f\x5a\x22\xc9\xf4\xde\x93\x95\x33\x24\xe9\xf4\x04\x0e\xb0\x0e\xa1\x3f\x4f\x73\xa7\xbe\x3c\x2d\x1e\x37\x0b\x6c\x1e\x3f\xaf\x16\xaf\x7a\x3d\x51\x00\xc6\x56\x85\x38\xae\x50\x72\xc3\x56\x90\xaf\x37\xc8\xb7\xab\x55\x76\xbc\x30\x0e\x58\x70\x15\xf1\x5e\x9b\xe8\xdd\x5e\xfb\x81\x6d\x33\xf9\x74\x3f\x7d\x79\x3d\x04\x92\x42\x86\x1d\xe1\x06\xeb\x98\x34\xae\xa2\x1b\xac\xf7\x1f\x6e\xb3\x38\x84\x9e\xfc\x9b\x9a\x77\xfc\xbb\xe7\x8a\x65\x28\x76\xce\x75\xb7\x25\xdf\x7f\x7c\x59\x95\x5e\x28\x39\x34\xfa\x70\x4a\x5e\xc7\xd4\xf4\x41\x25\x77\xb7\xf9\xf2\xfb\x76\x81\x65\x3e\x5f\xfc\x44\x5b\x9a\xa2\x1c\x8a\xb4\x0d\xeb\xfc\x55\xdb\xb7\x3f\x96\xf9\x57\x94\xe2\x89\x30\x79\x36\x37\x1b\xa7\x8d\xce\xa5\x73\x34\x2a\x9d\x4f\x2e\x64\xff\x8e\x98\x5d\x3e\xe1\xf4\x21\xa9\xbb\x92\x35\x2e\xe5\x5b\x55\x5d\x03\xd5\xe5\xff\x9d\xbb\x83\x55\x6a\xfe\xb4\xfe\xf6\x9f\xed\x32\x3a\x18\x5d\xd1\x83\xfa\x3b\x00\xd1\x5a\xc3\x87\xfe\x03\x00\x00")
+
+func migrations74_add_history_balance_changesSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations74_add_history_balance_changesSql,
+		"migrations/74_add_history_balance_changes.sql",
+	)
+}
+
+func migrations74_add_history_balance_changesSql() (*asset, error) {
+	bytes, err := migrations74_add_history_balance_changesSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/74_add_history_balance_changes.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x60, 0xad, 0xd2, 0xca, 0xd9, 0xff, 0x18, 0xe2, 0xa8, 0x6e, 0xee, 0x9a, 0x5f, 0xb, 0x94, 0x8f, 0x2a, 0xf9, 0xc, 0x6e, 0x78, 0xdf, 0x99, 0x59, 0xe5, 0xaa, 0x81, 0x98, 0x3b, 0x76, 0xf0, 0xbc}}
+	return a, nil
+}
+
 var _migrations7_modify_trades_tableSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x54\x4d\x8f\xda\x30\x14\xbc\xe7\x57\x3c\xed\x29\x51\xc3\xaa\xad\xda\xbd\x6c\x55\x09\x58\x97\x46\x65\xc3\x36\x04\xa9\xb7\xc8\x89\xdf\x06\xab\xc1\x8e\x6c\xa7\x88\x7f\x5f\x05\x08\xcd\x27\xb0\xbb\x87\x5e\x93\x99\x79\x6f\xec\xf1\x8c\x46\xf0\x6e\xc3\x53\x45\x0d\xc2\x2a\xb7\x46\x23\x60\x4a\xe6\x60\xd6\x08\x32\x63\x60\x14\x65\xa8\xc1\xd0\x38\xc3\x5b\xc8\x0b\x03\x14\x04\x6e\x41\x0a\x04\x2e\x20\xcf\x68\x82\xd6\x43\xb0\x78\x82\x70\x3c\x99\x13\x58\x73\x6d\xa4\xda\x45\x07\xde\xbd\x35\x0d\xc8\x38\x24\xbd\x3f\xc1\xb6\x00\xe0\xf4\x51\xe6\xa8\xa8\xe1\x52\x44\x9c\xc1\xc4\x9b\x79\x7e\x08\xfe\x22\x04\x7f\x35\x9f\xbb\x7b\xe4\x8d\x54\x0c\xd5\x0d\x78\x7e\x48\x66\x24\x68\xfd\xcd\x90\xa5\xa8\xa2\x24\x93\x1a\x59\x44\x0d\x84\xde\x23\x59\x86\xe3\xc7\xa7\x16\x50\x3e\x3f\xa3\x1a\x1c\x12\x53\x8d\x11\x4d\x12\x59\x08\xd3\x03\x82\x80\x7c\x23\x01\xf1\xa7\x64\x79\xda\xfc\x88\xd6\x36\x67\x4e\x5d\x44\x6b\xbc\x5a\xa2\xc4\x76\x04\x36\xa5\x6c\x87\x3e\xfd\x4e\xa6\x3f\xc0\xae\x43\xbe\xc2\xfb\x23\x71\xbf\x09\xaa\x37\x3b\x38\xe9\xbc\xc1\xc4\x49\xe3\xac\x8f\x16\xea\x9f\x95\xbd\x41\xae\x23\x8d\x59\x86\x0a\x26\x8b\xc5\x9c\x8c\xfd\xc3\xbf\x3d\xd7\x6e\x1e\xf3\x97\xce\xd2\x8e\xe5\xdc\x5b\x55\x04\x57\xbe\xf7\x73\x45\xc0\xf3\x1f\xc8\x2f\x58\x1b\xc5\xa2\x9c\x33\x58\xf8\xed\x54\xae\x96\x9e\x3f\x83\xd8\x28\x44\xb0\xfb\xc2\xe9\x56\x41\x74\x4e\xf1\xae\x8b\x52\xae\x22\xc3\x37\x18\x65\x52\xfe\x2e\xf2\xc1\x09\x93\x30\x20\xa4\x69\xc1\xed\x38\x70\x3b\xb1\xee\x1d\x5a\xd1\xae\x1a\xd9\x39\xa5\x3e\xc5\xeb\x1d\x5c\xb5\x60\xbc\x8b\xf6\xcf\xee\xd2\x79\x57\x6f\xb3\xbc\x37\xab\x5e\x4d\x0f\x72\x2b\x1a\xe5\x24\x70\x8b\xaa\xea\x25\x85\x5c\x68\x53\xe2\xaa\xde\x92\x02\x6f\x87\x7b\x09\x12\xaa\x13\xca\xf0\xd5\xfd\x14\xf3\x94\x0b\x33\xd0\x4f\x5c\x18\x4c\x51\x0d\xd5\x4e\x2f\xf7\x10\xf2\xc1\xdf\x71\xb1\x3b\x47\x96\x19\x3b\x5e\xa7\xd9\xe5\x08\xc9\x9a\x2a\x9a\x18\x54\xf0\x87\xaa\x1d\x17\xa9\x7d\xf7\xc9\x19\xe6\x70\xad\x0b\x54\x3d\xac\xcf\x77\x67\x58\x89\x64\x7d\x93\x3e\x7c\xec\xe7\x1c\x5e\x77\x6b\xfd\xaa\x03\xea\x90\x5a\x01\xc8\x22\x5d\x9b\x97\x1a\x6b\xb0\x5e\x60\xad\xc1\xbb\xda\x5c\xc5\x3a\x6b\xaf\x09\x2a\x0d\xfe\x87\x62\x7a\xc5\x13\x6c\x8b\x94\x1a\xe5\x55\x5d\x92\x68\xe5\xd1\x6d\xc7\xc6\xed\xa6\x6f\x60\xda\xe1\xe4\x2e\xcd\xeb\x04\xc5\xed\xde\xa6\xdb\x17\x0c\xe7\xfe\x6f\x00\x00\x00\xff\xff\x2a\xff\xe8\x4a\xff\x08\x00\x00")
 
 func migrations7_modify_trades_tableSqlBytes() ([]byte, error) {
@@ -1739,6 +1823,10 @@ var _bindata = map[string]func() (*asset, error){
 	"migrations/69_add_asset_contracts_table.sql":                        migrations69_add_asset_contracts_tableSql,
 	"migrations/6_create_assets_table.sql":                               migrations6_create_assets_tableSql,
 	"migrations/70_replace_timestamp_trade_aggregations_brin_index.sql":  migrations70_replace_timestamp_trade_aggregations_brin_indexSql,
+	"migrations/71_add_history_token_transfers.sql":                      migrations71_add_history_token_transfersSql,
+	"migrations/72_add_history_contract_events.sql":                      migrations72_add_history_contract_eventsSql,
+	"migrations/73_add_contract_and_operation_filter_rules.sql":          migrations73_add_contract_and_operation_filter_rulesSql,
+	"migrations/74_add_history_balance_changes.sql":                      migrations74_add_history_balance_changesSql,
 	"migrations/7_modify_trades_table.sql":                               migrations7_modify_trades_tableSql,
 	"migrations/8_add_aggregators.sql":                                   migrations8_add_aggregatorsSql,
 	"migrations/8_create_asset_stats_table.sql":                          migrations8_create_asset_stats_tableSql,
@@ -1857,6 +1945,10 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		"69_add_asset_contracts_table.sql":                        {migrations69_add_asset_contracts_tableSql, map[string]*bintree{}},
 		"6_create_assets_table.sql":                               {migrations6_create_assets_tableSql, map[string]*bintree{}},
 		"70_replace_timestamp_trade_aggregations_brin_index.sql":  {migrations70_replace_timestamp_trade_aggregations_brin_indexSql, map[string]*bintree{}},
+		"71_add_history_token_transfers.sql":                      {migrations71_add_history_token_transfersSql, map[string]*bintree{}},
+		"72_add_history_contract_events.sql":                      {migrations72_add_history_contract_eventsSql, map[string]*bintree{}},
+		"73_add_contract_and_operation_filter_rules.sql":          {migrations73_add_contract_and_operation_filter_rulesSql, map[string]*bintree{}},
+		"74_add_history_balance_changes.sql":                      {migrations74_add_history_balance_changesSql, map[string]*bintree{}},
 		"7_modify_trades_table.sql":                               {migrations7_modify_trades_tableSql, map[string]*bintree{}},
 		"8_add_aggregators.sql":                                   {migrations8_add_aggregatorsSql, map[string]*bintree{}},
 		"8_create_asset_stats_table.sql":                          {migrations8_create_asset_stats_tableSql, map[string]*bintree{}},
@@ -0,0 +1,27 @@
+-- +migrate Up
+
+CREATE TABLE history_token_transfers (
+    history_operation_id bigint NOT NULL,
+    "order" integer NOT NULL,
+    transaction_hash character varying(64) NOT NULL,
+    ledger_closed_at timestamp without time zone NOT NULL,
+    type smallint NOT NULL,
+    from_address character varying(69),
+    to_address character varying(69),
+    to_muxed_id_type character varying(8),
+    to_muxed_id text,
+    contract_id character varying(56) NOT NULL,
+    asset_type character varying(64),
+    asset_code character varying(12),
+    asset_issuer character varying(56),
+    amount numeric(39,0) NOT NULL
+);
+
+CREATE UNIQUE INDEX htt_by_op ON history_token_transfers USING btree (history_operation_id, "order");
+CREATE INDEX htt_by_from_address ON history_token_transfers USING btree (from_address, history_operation_id, "order");
+CREATE INDEX htt_by_to_address ON history_token_transfers USING btree (to_address, history_operation_id, "order");
+CREATE INDEX htt_by_contract_id ON history_token_transfers USING btree (contract_id, history_operation_id, "order");
+
+-- +migrate Down
+
+DROP TABLE history_token_transfers cascade;
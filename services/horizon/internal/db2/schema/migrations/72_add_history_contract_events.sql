@@ -0,0 +1,27 @@
+-- +migrate Up
+
+CREATE TABLE history_contract_events (
+    history_operation_id bigint NOT NULL,
+    "order" integer NOT NULL,
+    transaction_hash character varying(64) NOT NULL,
+    ledger_closed_at timestamp without time zone NOT NULL,
+    contract_id character varying(56),
+    type smallint NOT NULL,
+    topic_count smallint NOT NULL,
+    topic_1 text,
+    topic_2 text,
+    topic_3 text,
+    topic_4 text,
+    value text NOT NULL
+);
+
+CREATE UNIQUE INDEX hce_by_op ON history_contract_events USING btree (history_operation_id, "order");
+CREATE INDEX hce_by_contract_id ON history_contract_events USING btree (contract_id, history_operation_id, "order");
+CREATE INDEX hce_by_topic_1 ON history_contract_events USING btree (topic_1, history_operation_id, "order");
+CREATE INDEX hce_by_topic_2 ON history_contract_events USING btree (topic_2, history_operation_id, "order");
+CREATE INDEX hce_by_topic_3 ON history_contract_events USING btree (topic_3, history_operation_id, "order");
+CREATE INDEX hce_by_topic_4 ON history_contract_events USING btree (topic_4, history_operation_id, "order");
+
+-- +migrate Down
+
+DROP TABLE history_contract_events cascade;
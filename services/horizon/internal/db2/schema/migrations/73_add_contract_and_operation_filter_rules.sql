@@ -0,0 +1,26 @@
+-- +migrate Up
+
+CREATE TABLE contract_filter_rules (
+    enabled bool NOT NULL default false,
+    whitelist varchar[] NOT NULL,
+    last_modified bigint NOT NULL
+);
+
+CREATE TABLE operation_filter_rules (
+    enabled bool NOT NULL default false,
+    -- whitelist ingests only the transactions with an operation of one of the
+    -- listed types, blacklist skips the transactions with only operations of
+    -- the listed types
+    mode varchar(16) NOT NULL default 'whitelist',
+    operation_types varchar[] NOT NULL,
+    last_modified bigint NOT NULL
+);
+
+-- insert the default disabled state for each supported filter implementation
+INSERT INTO contract_filter_rules VALUES (false, '{}', 0);
+INSERT INTO operation_filter_rules VALUES (false, 'whitelist', '{}', 0);
+
+-- +migrate Down
+
+DROP TABLE contract_filter_rules cascade;
+DROP TABLE operation_filter_rules cascade;
@@ -0,0 +1,24 @@
+-- +migrate Up
+
+-- history_balance_changes stores the changes of account native balances and
+-- trust line balances, with the balance before and after each change (null
+-- when the entry did not exist). The balance of an account as of a ledger is
+-- the previous balance of the first change following that ledger, or the
+-- current balance when there is no such change.
+CREATE TABLE history_balance_changes (
+    ledger_toid bigint NOT NULL,
+    account_id character varying(56) NOT NULL,
+    asset_type int NOT NULL,
+    asset_code character varying(12) NOT NULL,
+    asset_issuer character varying(56) NOT NULL,
+    liquidity_pool_id character varying(64) NOT NULL,
+    previous_balance bigint,
+    balance bigint
+);
+
+CREATE UNIQUE INDEX hbc_by_account ON history_balance_changes USING btree (account_id, asset_type, asset_code, asset_issuer, liquidity_pool_id, ledger_toid);
+CREATE INDEX hbc_by_ledger ON history_balance_changes USING btree (ledger_toid);
+
+-- +migrate Down
+
+DROP TABLE history_balance_changes cascade;
@@ -18,6 +18,7 @@ import (
 	"github.com/stellar/go/ingest/ledgerbackend"
 	"github.com/stellar/go/network"
 	"github.com/stellar/go/services/horizon/internal/db2/schema"
+	"github.com/stellar/go/services/horizon/internal/ingest"
 	apkg "github.com/stellar/go/support/app"
 	support "github.com/stellar/go/support/config"
 	"github.com/stellar/go/support/db"
@@ -60,6 +61,12 @@ const (
 	DisableTxSubFlagName = "disable-tx-sub"
 	// SkipTxmeta is the command line flag for disabling persistence of tx meta in history transaction table
 	SkipTxmeta = "skip-txmeta"
+	// IngestLedgerBackendFlagName is the command line flag for specifying the ledger backend used by ingestion
+	IngestLedgerBackendFlagName = "ingest-ledger-backend"
+	// IngestDatastoreConfigFlagName is the command line flag for specifying the datastore ledger backend config file
+	IngestDatastoreConfigFlagName = "ingest-datastore-config"
+	// IngestRPCURLFlagName is the command line flag for specifying the Stellar RPC server of the rpc ledger backend
+	IngestRPCURLFlagName = "ingest-rpc-url"
 	// EmitVerboseMeta is the command line flag for enabling all kinds of verbose events - diagnosticEvents, classicEvents during ingestion
 	EmitVerboseMeta = "emit-verbose-meta"
 
@@ -729,6 +736,50 @@ func Flags() (*Config, support.ConfigOptions) {
 			Usage:          "enables extended ledger stats in the log (ledger entry changes and operations stats)",
 			UsedInCommands: IngestionCommands,
 		},
+		&support.ConfigOption{
+			Name:        IngestLedgerBackendFlagName,
+			ConfigKey:   &config.IngestLedgerBackendType,
+			OptType:     types.String,
+			FlagDefault: ingest.CaptiveCoreBackend.String(),
+			CustomSetValue: func(co *support.ConfigOption) error {
+				backendType, err := ingest.ParseLedgerBackendType(viper.GetString(co.Name))
+				if err != nil {
+					return err
+				}
+				*co.ConfigKey.(*ingest.LedgerBackendType) = backendType
+				return nil
+			},
+			Usage: fmt.Sprintf("ledger backend ingestion reads ledgers from: '%s' (default) runs a Captive Core subprocess, "+
+				"'%s' reads ledgers exported to the datastore configured by --%s, '%s' streams ledgers from the Stellar RPC server at --%s",
+				ingest.CaptiveCoreBackend, ingest.BufferedStorageBackend, IngestDatastoreConfigFlagName,
+				ingest.RPCBackend, IngestRPCURLFlagName),
+			UsedInCommands: ApiServerCommands,
+		},
+		&support.ConfigOption{
+			Name:           IngestDatastoreConfigFlagName,
+			ConfigKey:      &config.IngestDatastoreConfigPath,
+			OptType:        types.String,
+			Required:       false,
+			Usage:          fmt.Sprintf("path to the TOML config file of the '%s' ledger backend", ingest.BufferedStorageBackend),
+			UsedInCommands: ApiServerCommands,
+		},
+		&support.ConfigOption{
+			Name:           IngestRPCURLFlagName,
+			ConfigKey:      &config.IngestRPCBackendConfig.RPCServerURL,
+			OptType:        types.String,
+			Required:       false,
+			Usage:          fmt.Sprintf("URL of the Stellar RPC server used by the '%s' ledger backend", ingest.RPCBackend),
+			UsedInCommands: ApiServerCommands,
+		},
+		&support.ConfigOption{
+			Name:           "ingest-rpc-buffer-size",
+			ConfigKey:      &config.IngestRPCBackendConfig.BufferSize,
+			OptType:        types.Uint32,
+			FlagDefault:    uint32(10),
+			Required:       false,
+			Usage:          fmt.Sprintf("number of ledgers the '%s' ledger backend requests from the Stellar RPC server at once", ingest.RPCBackend),
+			UsedInCommands: ApiServerCommands,
+		},
 		&support.ConfigOption{
 			Name:           "apply-migrations",
 			ConfigKey:      &config.ApplyMigrations,
@@ -824,7 +875,9 @@ func NewAppFromFlags(config *Config, flags support.ConfigOptions) (*App, error)
 		return nil, err
 	}
 	// Validate app-specific arguments
-	if (!config.DisableTxSub || config.Ingest) && config.StellarCoreURL == "" {
+	// only ingestion from captive core requires stellar-core
+	requiresCore := !config.DisableTxSub || (config.Ingest && config.IngestLedgerBackendType == ingest.CaptiveCoreBackend)
+	if requiresCore && config.StellarCoreURL == "" {
 		return nil, fmt.Errorf("flag --%s cannot be empty", StellarCoreURLFlagName)
 	}
 
@@ -958,10 +1011,24 @@ func ApplyFlags(config *Config, flags support.ConfigOptions, options ApplyOption
 			return err
 		}
 
-		if !options.NoCaptiveCore {
-			err := setCaptiveCoreConfiguration(config, options)
+		switch config.IngestLedgerBackendType {
+		case ingest.BufferedStorageBackend:
+			storageBackendConfig, err := ingest.LoadStorageBackendConfig(config.IngestDatastoreConfigPath)
 			if err != nil {
-				return errors.Wrap(err, "error generating captive core configuration")
+				return err
+			}
+			config.IngestStorageBackendConfig = storageBackendConfig
+		case ingest.RPCBackend:
+			if config.IngestRPCBackendConfig.RPCServerURL == "" {
+				return fmt.Errorf("invalid config: --%s is required for the '%s' ledger backend",
+					IngestRPCURLFlagName, ingest.RPCBackend)
+			}
+		default:
+			if !options.NoCaptiveCore {
+				err := setCaptiveCoreConfiguration(config, options)
+				if err != nil {
+					return errors.Wrap(err, "error generating captive core configuration")
+				}
 			}
 		}
 	}
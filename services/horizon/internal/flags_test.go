@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/stellar/go/network"
+	"github.com/stellar/go/services/horizon/internal/ingest"
 	"github.com/stellar/go/services/horizon/internal/test"
 
 	"github.com/stretchr/testify/assert"
@@ -466,3 +467,51 @@ func TestRemovedFlags(t *testing.T) {
 		})
 	}
 }
+
+func TestIngestLedgerBackendFlags(t *testing.T) {
+	for _, testCase := range []struct {
+		name     string
+		backend  string
+		expected ingest.LedgerBackendType
+		err      string
+	}{
+		{"default value", "", ingest.CaptiveCoreBackend, ""},
+		{"datastore", "datastore", ingest.BufferedStorageBackend, ""},
+		{"rpc", "rpc", ingest.RPCBackend, ""},
+		{"invalid value", "core", ingest.CaptiveCoreBackend, "invalid ledger backend: core, must be 'captive-core', 'datastore' or 'rpc'"},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			environmentVars := horizonEnvVars()
+			if testCase.backend != "" {
+				environmentVars["INGEST_LEDGER_BACKEND"] = testCase.backend
+			}
+			environmentVars["INGEST_RPC_URL"] = "http://localhost:8000"
+
+			envManager := test.NewEnvironmentManager()
+			defer func() {
+				envManager.Restore()
+			}()
+			require.NoError(t, envManager.InitializeEnvironmentVariables(environmentVars))
+
+			config, flags := Flags()
+			horizonCmd := &cobra.Command{
+				Use:           "horizon",
+				Short:         "Client-facing api server for the Stellar network",
+				SilenceErrors: true,
+				SilenceUsage:  true,
+				Long:          "Client-facing API server for the Stellar network.",
+			}
+			require.NoError(t, flags.Init(horizonCmd))
+
+			err := ApplyFlags(config, flags, ApplyOptions{RequireCaptiveCoreFullConfig: true})
+			if testCase.err != "" {
+				assert.EqualError(t, err, testCase.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, config.IngestLedgerBackendType)
+			assert.Equal(t, "http://localhost:8000", config.IngestRPCBackendConfig.RPCServerURL)
+			assert.Equal(t, uint32(10), config.IngestRPCBackendConfig.BufferSize)
+		})
+	}
+}
@@ -185,6 +185,7 @@ func (r *Router) addRoutes(config *RouterConfig, rateLimiter *throttled.HTTPRate
 					accountData,
 				))
 				r.With(stateMiddleware.Wrap).Method(http.MethodGet, "/offers", streamableStatePageHandler(ledgerState, actions.GetAccountOffersHandler{LedgerState: ledgerState}, streamHandler))
+				r.With(stateMiddleware.Wrap).Method(http.MethodGet, "/balances", ObjectActionHandler{actions.GetAccountBalancesHandler{LedgerState: ledgerState}})
 			})
 		})
 
@@ -259,6 +260,15 @@ func (r *Router) addRoutes(config *RouterConfig, rateLimiter *throttled.HTTPRate
 		}, streamHandler))
 		r.With(historyMiddleware).Method(http.MethodGet, "/accounts/{account_id:\\w+}/trades", streamableHistoryPageHandler(ledgerState, actions.GetTradesHandler{LedgerState: ledgerState, CoreStateGetter: config.CoreGetter}, streamHandler))
 		r.With(historyMiddleware).Method(http.MethodGet, "/accounts/{account_id:\\w+}/transactions", streamableHistoryPageHandler(ledgerState, actions.GetTransactionsHandler{LedgerState: ledgerState, SkipTxMeta: config.SkipTxMeta}, streamHandler))
+		r.With(historyMiddleware).Method(http.MethodGet, "/accounts/{account_id:\\w+}/token_transfers", streamableHistoryPageHandler(ledgerState, actions.GetTokenTransfersHandler{LedgerState: ledgerState, NetworkPassphrase: config.NetworkPassphrase}, streamHandler))
+	})
+
+	// contract actions, and the token transfers of the Stellar Asset Contract
+	// of an asset in the SEP-11 format
+	r.Group(func(r chi.Router) {
+		r.With(historyMiddleware).Method(http.MethodGet, "/contracts/{contract_id:\\w+}/events", streamableHistoryPageHandler(ledgerState, actions.GetContractEventsHandler{LedgerState: ledgerState}, streamHandler))
+		r.With(historyMiddleware).Method(http.MethodGet, "/contracts/{contract_id:\\w+}/token_transfers", streamableHistoryPageHandler(ledgerState, actions.GetTokenTransfersHandler{LedgerState: ledgerState, NetworkPassphrase: config.NetworkPassphrase}, streamHandler))
+		r.With(historyMiddleware).Method(http.MethodGet, "/assets/{asset:[\\w:]+}/token_transfers", streamableHistoryPageHandler(ledgerState, actions.GetTokenTransfersHandler{LedgerState: ledgerState, NetworkPassphrase: config.NetworkPassphrase}, streamHandler))
 	})
 	// ledger actions
 	r.Route("/ledgers", func(r chi.Router) {
@@ -330,6 +340,9 @@ func (r *Router) addRoutes(config *RouterConfig, rateLimiter *throttled.HTTPRate
 		// effect actions
 		r.With(historyMiddleware).Method(http.MethodGet, "/effects", streamableHistoryPageHandler(ledgerState, actions.GetEffectsHandler{LedgerState: ledgerState}, streamHandler))
 
+		// contract event actions
+		r.With(historyMiddleware).Method(http.MethodGet, "/events", streamableHistoryPageHandler(ledgerState, actions.GetContractEventsHandler{LedgerState: ledgerState}, streamHandler))
+
 		// trading related endpoints
 		r.With(historyMiddleware).Method(http.MethodGet, "/trades", streamableHistoryPageHandler(ledgerState, actions.GetTradesHandler{LedgerState: ledgerState, CoreStateGetter: config.CoreGetter}, streamHandler))
 		r.With(historyMiddleware).Method(http.MethodGet, "/trade_aggregations", ObjectActionHandler{actions.GetTradeAggregationsHandler{LedgerState: ledgerState, CoreStateGetter: config.CoreGetter}})
@@ -403,5 +416,9 @@ func (r *Router) addRoutes(config *RouterConfig, rateLimiter *throttled.HTTPRate
 		r.With(historyMiddleware).Put("/account", handler.UpdateAccountConfig)
 		r.With(historyMiddleware).Get("/asset", handler.GetAssetConfig)
 		r.With(historyMiddleware).Get("/account", handler.GetAccountConfig)
+		r.With(historyMiddleware).Put("/contract", handler.UpdateContractConfig)
+		r.With(historyMiddleware).Put("/operation", handler.UpdateOperationConfig)
+		r.With(historyMiddleware).Get("/contract", handler.GetContractConfig)
+		r.With(historyMiddleware).Get("/operation", handler.GetOperationConfig)
 	})
 }
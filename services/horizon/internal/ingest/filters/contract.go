@@ -0,0 +1,100 @@
+package filters
+
+import (
+	"context"
+
+	"github.com/stellar/go/ingest"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/ingest/processors"
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/support/collections/set"
+	"github.com/stellar/go/xdr"
+)
+
+type contractFilter struct {
+	whitelistedContractsSet set.Set[string]
+	lastModified            int64
+	enabled                 bool
+}
+
+type ContractFilter interface {
+	processors.LedgerTransactionFilterer
+	RefreshContractFilter(filterConfig *history.ContractFilterConfig) error
+}
+
+func NewContractFilter() ContractFilter {
+	return &contractFilter{
+		whitelistedContractsSet: set.Set[string]{},
+	}
+}
+
+func (f *contractFilter) Name() string {
+	return "filters.contractFilter"
+}
+
+func (f *contractFilter) RefreshContractFilter(filterConfig *history.ContractFilterConfig) error {
+	// only need to re-initialize the filter config state(rules) if its cached version(in  memory)
+	// is older than the incoming config version based on lastModified epoch timestamp
+	if filterConfig.LastModified > f.lastModified {
+		logger.Infof("New Contract Filter config detected, reloading new config %v ", *filterConfig)
+
+		f.enabled = filterConfig.Enabled
+		f.whitelistedContractsSet = listToSet(filterConfig.Whitelist)
+		f.lastModified = filterConfig.LastModified
+	}
+
+	return nil
+}
+
+// FilterTransaction includes the transactions invoking a whitelisted contract
+// or with operations emitting events of a whitelisted contract, which covers
+// the events of the Stellar Asset Contract emitted by classic operations.
+func (f *contractFilter) FilterTransaction(ctx context.Context, transaction ingest.LedgerTransaction) (bool, bool, error) {
+	if !f.isEnabled() {
+		return false, true, nil
+	}
+
+	for _, operation := range transaction.Envelope.Operations() {
+		if operation.Body.Type != xdr.OperationTypeInvokeHostFunction {
+			continue
+		}
+		hostFunction := operation.Body.MustInvokeHostFunctionOp().HostFunction
+		if invokeContract, ok := hostFunction.GetInvokeContract(); ok {
+			matched, err := f.contractMatchedFilter(invokeContract.ContractAddress.ContractId)
+			if err != nil || matched {
+				return true, matched, err
+			}
+		}
+	}
+
+	events, err := transaction.GetTransactionEvents()
+	if err != nil {
+		return true, false, err
+	}
+	for _, operationEvents := range events.OperationEvents {
+		for _, event := range operationEvents {
+			matched, err := f.contractMatchedFilter(event.ContractId)
+			if err != nil || matched {
+				return true, matched, err
+			}
+		}
+	}
+
+	return true, false, nil
+}
+
+func (f *contractFilter) contractMatchedFilter(contractID *xdr.ContractId) (bool, error) {
+	if contractID == nil {
+		return false, nil
+	}
+	address, err := strkey.Encode(strkey.VersionByteContract, contractID[:])
+	if err != nil {
+		return false, err
+	}
+	return f.whitelistedContractsSet.Contains(address), nil
+}
+
+func (f contractFilter) isEnabled() bool {
+	// filtering is disabled if the whitelist is empty, as that is the only filter rule
+	return len(f.whitelistedContractsSet) >= 1 && f.enabled
+}
@@ -0,0 +1,109 @@
+package filters
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/ingest"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/xdr"
+)
+
+func TestContractFilter(t *testing.T) {
+	ctx := context.Background()
+	invoked := xdr.ContractId{1}
+	emitting := xdr.ContractId{2}
+	other := xdr.ContractId{3}
+
+	for _, testCase := range []struct {
+		name      string
+		whitelist []xdr.ContractId
+		enabled   bool
+		tx        ingest.LedgerTransaction
+		isEnabled bool
+		include   bool
+	}{
+		{"invoked contract", []xdr.ContractId{invoked}, true, getContractTestTx(invoked, emitting), true, true},
+		{"contract emitting events", []xdr.ContractId{emitting}, true, getContractTestTx(invoked, emitting), true, true},
+		{"no match", []xdr.ContractId{other}, true, getContractTestTx(invoked, emitting), true, false},
+		{"disabled", []xdr.ContractId{other}, false, getContractTestTx(invoked, emitting), false, true},
+		{"empty whitelist", nil, true, getContractTestTx(invoked, emitting), false, true},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			whitelist := []string{}
+			for _, contractID := range testCase.whitelist {
+				address, err := strkey.Encode(strkey.VersionByteContract, contractID[:])
+				require.NoError(t, err)
+				whitelist = append(whitelist, address)
+			}
+
+			filter := NewContractFilter()
+			require.NoError(t, filter.RefreshContractFilter(&history.ContractFilterConfig{
+				Whitelist:    whitelist,
+				Enabled:      testCase.enabled,
+				LastModified: 1,
+			}))
+
+			isEnabled, include, err := filter.FilterTransaction(ctx, testCase.tx)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.isEnabled, isEnabled)
+			assert.Equal(t, testCase.include, include)
+		})
+	}
+}
+
+func getContractTestTx(invoked xdr.ContractId, emitting xdr.ContractId) ingest.LedgerTransaction {
+	return ingest.LedgerTransaction{
+		UnsafeMeta: xdr.TransactionMeta{
+			V: 4,
+			V4: &xdr.TransactionMetaV4{
+				Operations: []xdr.OperationMetaV2{{
+					Events: []xdr.ContractEvent{{
+						ContractId: &emitting,
+						Type:       xdr.ContractEventTypeContract,
+						Body: xdr.ContractEventBody{
+							V:  0,
+							V0: &xdr.ContractEventV0{Data: xdr.ScVal{Type: xdr.ScValTypeScvVoid}},
+						},
+					}},
+				}},
+			},
+		},
+		Result: xdr.TransactionResultPair{
+			Result: xdr.TransactionResult{
+				Result: xdr.TransactionResultResult{
+					Code: xdr.TransactionResultCodeTxSuccess,
+				},
+			},
+		},
+		Envelope: xdr.TransactionEnvelope{
+			Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+			V1: &xdr.TransactionV1Envelope{
+				Tx: xdr.Transaction{
+					SourceAccount: xdr.MustMuxedAddress("GD6WNNTW664WH7FXC5RUMUTF7P5QSURC2IT36VOQEEGFZ4UWUEQGECAL"),
+					Operations: []xdr.Operation{
+						{Body: xdr.OperationBody{
+							Type: xdr.OperationTypeInvokeHostFunction,
+							InvokeHostFunctionOp: &xdr.InvokeHostFunctionOp{
+								HostFunction: xdr.HostFunction{
+									Type: xdr.HostFunctionTypeHostFunctionTypeInvokeContract,
+									InvokeContract: &xdr.InvokeContractArgs{
+										ContractAddress: xdr.ScAddress{
+											Type:       xdr.ScAddressTypeScAddressTypeContract,
+											ContractId: &invoked,
+										},
+										FunctionName: "transfer",
+									},
+								},
+							},
+						}},
+					},
+				},
+			},
+		},
+	}
+}
@@ -39,6 +39,8 @@ var (
 type filtersCache struct {
 	assetFilter                    AssetFilter
 	accountFilter                  AccountFilter
+	contractFilter                 ContractFilter
+	operationFilter                OperationFilter
 	lastFilterConfigCheckUnixEpoch int64
 }
 
@@ -48,8 +50,10 @@ type Filters interface {
 
 func NewFilters() Filters {
 	return &filtersCache{
-		assetFilter:   NewAssetFilter(),
-		accountFilter: NewAccountFilter(),
+		assetFilter:     NewAssetFilter(),
+		accountFilter:   NewAccountFilter(),
+		contractFilter:  NewContractFilter(),
+		operationFilter: NewOperationFilter(),
 	}
 }
 
@@ -81,9 +85,25 @@ func (f *filtersCache) GetFilters(filterQ history.QFilter, ctx context.Context)
 		}
 	}
 
+	if filterConfig, err := filterQ.GetContractFilterConfig(ctx); err != nil {
+		LOG.Errorf("unable to refresh contract filter config %v", err)
+	} else {
+		if err := f.contractFilter.RefreshContractFilter(&filterConfig); err != nil {
+			LOG.Errorf("unable to refresh contract filter config %v", err)
+		}
+	}
+
+	if filterConfig, err := filterQ.GetOperationFilterConfig(ctx); err != nil {
+		LOG.Errorf("unable to refresh operation filter config %v", err)
+	} else {
+		if err := f.operationFilter.RefreshOperationFilter(&filterConfig); err != nil {
+			LOG.Errorf("unable to refresh operation filter config %v", err)
+		}
+	}
+
 	return f.convertCacheToList()
 }
 
 func (f *filtersCache) convertCacheToList() []processors.LedgerTransactionFilterer {
-	return []processors.LedgerTransactionFilterer{f.assetFilter, f.accountFilter}
+	return []processors.LedgerTransactionFilterer{f.assetFilter, f.accountFilter, f.contractFilter, f.operationFilter}
 }
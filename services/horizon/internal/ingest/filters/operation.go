@@ -0,0 +1,78 @@
+package filters
+
+import (
+	"context"
+
+	"github.com/stellar/go/ingest"
+	"github.com/stellar/go/protocols/horizon/operations"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/ingest/processors"
+	"github.com/stellar/go/support/collections/set"
+	"github.com/stellar/go/xdr"
+)
+
+type operationFilter struct {
+	operationTypesSet set.Set[xdr.OperationType]
+	blacklist         bool
+	lastModified      int64
+	enabled           bool
+}
+
+type OperationFilter interface {
+	processors.LedgerTransactionFilterer
+	RefreshOperationFilter(filterConfig *history.OperationFilterConfig) error
+}
+
+func NewOperationFilter() OperationFilter {
+	return &operationFilter{
+		operationTypesSet: set.Set[xdr.OperationType]{},
+	}
+}
+
+func (f *operationFilter) Name() string {
+	return "filters.operationFilter"
+}
+
+func (f *operationFilter) RefreshOperationFilter(filterConfig *history.OperationFilterConfig) error {
+	// only need to re-initialize the filter config state(rules) if its cached version(in  memory)
+	// is older than the incoming config version based on lastModified epoch timestamp
+	if filterConfig.LastModified > f.lastModified {
+		logger.Infof("New Operation Filter config detected, reloading new config %v ", *filterConfig)
+
+		operationTypes := listToSet(filterConfig.OperationTypes)
+		f.operationTypesSet = set.NewSet[xdr.OperationType](len(operationTypes))
+		for operationType, name := range operations.TypeNames {
+			if operationTypes.Contains(name) {
+				f.operationTypesSet.Add(operationType)
+			}
+		}
+		f.enabled = filterConfig.Enabled
+		f.blacklist = filterConfig.Mode == history.OperationFilterModeBlacklist
+		f.lastModified = filterConfig.LastModified
+	}
+
+	return nil
+}
+
+// FilterTransaction includes the transactions with an operation of a listed
+// type in whitelist mode, and the transactions with an operation of any other
+// type in blacklist mode.
+func (f *operationFilter) FilterTransaction(ctx context.Context, transaction ingest.LedgerTransaction) (bool, bool, error) {
+	if !f.isEnabled() {
+		return false, true, nil
+	}
+
+	for _, operation := range transaction.Envelope.Operations() {
+		if f.operationTypesSet.Contains(operation.Body.Type) != f.blacklist {
+			return true, true, nil
+		}
+	}
+
+	logger.Debugf("No match, dropped tx with seq %v ", transaction.Envelope.SeqNum())
+	return true, false, nil
+}
+
+func (f operationFilter) isEnabled() bool {
+	// filtering is disabled if no operation type is listed, as that is the only filter rule
+	return len(f.operationTypesSet) >= 1 && f.enabled
+}
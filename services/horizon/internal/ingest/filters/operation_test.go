@@ -0,0 +1,78 @@
+package filters
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/ingest"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/xdr"
+)
+
+func TestOperationFilter(t *testing.T) {
+	ctx := context.Background()
+	paymentAndData := getOperationTestTx(xdr.OperationTypePayment, xdr.OperationTypeManageData)
+	onlyData := getOperationTestTx(xdr.OperationTypeManageData)
+
+	for _, testCase := range []struct {
+		name           string
+		mode           string
+		operationTypes []string
+		enabled        bool
+		tx             ingest.LedgerTransaction
+		isEnabled      bool
+		include        bool
+	}{
+		{"whitelist match", history.OperationFilterModeWhitelist, []string{"payment"}, true, paymentAndData, true, true},
+		{"whitelist no match", history.OperationFilterModeWhitelist, []string{"payment"}, true, onlyData, true, false},
+		{"blacklist with other operations", history.OperationFilterModeBlacklist, []string{"manage_data"}, true, paymentAndData, true, true},
+		{"blacklist all operations", history.OperationFilterModeBlacklist, []string{"manage_data"}, true, onlyData, true, false},
+		{"disabled", history.OperationFilterModeWhitelist, []string{"payment"}, false, onlyData, false, true},
+		{"unknown operation types", history.OperationFilterModeWhitelist, []string{"unknown"}, true, onlyData, false, true},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			filter := NewOperationFilter()
+			require.NoError(t, filter.RefreshOperationFilter(&history.OperationFilterConfig{
+				Mode:           testCase.mode,
+				OperationTypes: testCase.operationTypes,
+				Enabled:        testCase.enabled,
+				LastModified:   1,
+			}))
+
+			isEnabled, include, err := filter.FilterTransaction(ctx, testCase.tx)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.isEnabled, isEnabled)
+			assert.Equal(t, testCase.include, include)
+		})
+	}
+}
+
+func getOperationTestTx(operationTypes ...xdr.OperationType) ingest.LedgerTransaction {
+	source := xdr.MustMuxedAddress("GD6WNNTW664WH7FXC5RUMUTF7P5QSURC2IT36VOQEEGFZ4UWUEQGECAL")
+	var operations []xdr.Operation
+	for _, operationType := range operationTypes {
+		body := xdr.OperationBody{Type: operationType}
+		switch operationType {
+		case xdr.OperationTypePayment:
+			body.PaymentOp = &xdr.PaymentOp{Destination: source, Asset: xdr.MustNewNativeAsset(), Amount: 100}
+		case xdr.OperationTypeManageData:
+			body.ManageDataOp = &xdr.ManageDataOp{DataName: "name"}
+		}
+		operations = append(operations, xdr.Operation{Body: body})
+	}
+
+	return ingest.LedgerTransaction{
+		Envelope: xdr.TransactionEnvelope{
+			Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+			V1: &xdr.TransactionV1Envelope{
+				Tx: xdr.Transaction{
+					SourceAccount: source,
+					Operations:    operations,
+				},
+			},
+		},
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/pelletier/go-toml"
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/stellar/go/clients/stellarcore"
@@ -67,7 +68,9 @@ const (
 	// - 19: Archived contract asset balances are no longer stored in the horizon db.
 	// - 20: Mapping of asset to its contract instance is stored in a new
 	//       table (asset_contracts) in the horizon db.
-	CurrentVersion = 20
+	// - 21: Trigger state rebuild to start recording the account balance
+	//       history (history_balance_changes).
+	CurrentVersion = 21
 
 	// MaxDBConnections is the size of the postgres connection pool dedicated to Horizon ingestion:
 	//  * Ledger ingestion,
@@ -91,6 +94,7 @@ type LedgerBackendType uint
 const (
 	CaptiveCoreBackend LedgerBackendType = iota
 	BufferedStorageBackend
+	RPCBackend
 )
 
 func (s LedgerBackendType) String() string {
@@ -99,10 +103,23 @@ func (s LedgerBackendType) String() string {
 		return "captive-core"
 	case BufferedStorageBackend:
 		return "datastore"
+	case RPCBackend:
+		return "rpc"
 	}
 	return ""
 }
 
+// ParseLedgerBackendType returns the LedgerBackendType named by value.
+func ParseLedgerBackendType(value string) (LedgerBackendType, error) {
+	for _, backendType := range []LedgerBackendType{CaptiveCoreBackend, BufferedStorageBackend, RPCBackend} {
+		if value == backendType.String() {
+			return backendType, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid ledger backend: %s, must be '%s', '%s' or '%s'",
+		value, CaptiveCoreBackend, BufferedStorageBackend, RPCBackend)
+}
+
 const (
 	HistoryCheckpointLedgerInterval uint = 64
 	// MinBatchSize is the minimum batch size for reingestion
@@ -118,6 +135,33 @@ type StorageBackendConfig struct {
 	BufferedStorageBackendConfig ledgerbackend.BufferedStorageBackendConfig `toml:"buffered_storage_backend_config"`
 }
 
+// LoadStorageBackendConfig loads the configuration of the datastore ledger
+// backend from the TOML file at path.
+func LoadStorageBackendConfig(path string) (StorageBackendConfig, error) {
+	if path == "" {
+		return StorageBackendConfig{}, errors.New("datastore config file is required for datastore ledgerbackend type")
+	}
+	cfg, err := toml.LoadFile(path)
+	if err != nil {
+		return StorageBackendConfig{}, fmt.Errorf("failed to load datastore ledgerbackend config file %v: %w", path, err)
+	}
+	var storageBackendConfig StorageBackendConfig
+	if err = cfg.Unmarshal(&storageBackendConfig); err != nil {
+		return StorageBackendConfig{}, fmt.Errorf("error unmarshalling datastore ledgerbackend TOML config: %w", err)
+	}
+
+	return storageBackendConfig, nil
+}
+
+// RPCBackendConfig configures the ledger backend streaming ledgers from a
+// Stellar RPC server.
+type RPCBackendConfig struct {
+	RPCServerURL string
+	// BufferSize is the number of ledgers requested from the RPC server at
+	// once, defaults to 10 when unset.
+	BufferSize uint32
+}
+
 type Config struct {
 	StellarCoreURL         string
 	CaptiveCoreBinaryPath  string
@@ -153,6 +197,7 @@ type Config struct {
 
 	LedgerBackendType    LedgerBackendType
 	StorageBackendConfig StorageBackendConfig
+	RPCBackendConfig     RPCBackendConfig
 }
 
 const (
@@ -295,52 +340,10 @@ func NewSystem(config Config) (System, error) {
 		cancel()
 		return nil, errors.Wrap(err, "error creating history archive")
 	}
-	var ledgerBackend ledgerbackend.LedgerBackend
-
-	if config.LedgerBackendType == BufferedStorageBackend {
-		// Ingest from datastore
-		var dataStore datastore.DataStore
-		dataStore, err = datastore.NewDataStore(context.Background(), config.StorageBackendConfig.DataStoreConfig)
-		if err != nil {
-			cancel()
-			return nil, fmt.Errorf("failed to create datastore: %w", err)
-		}
-
-		var schema datastore.DataStoreSchema
-		schema, err = datastore.LoadSchema(context.Background(), dataStore, config.StorageBackendConfig.DataStoreConfig)
-		if err != nil {
-			cancel()
-			return nil, fmt.Errorf("failed to retrieve datastore schema: %w", err)
-		}
-
-		ledgerBackend, err = ledgerbackend.NewBufferedStorageBackend(config.StorageBackendConfig.BufferedStorageBackendConfig, dataStore, schema)
-		if err != nil {
-			cancel()
-			return nil, fmt.Errorf("failed to create buffered storage backend: %w", err)
-		}
-	} else {
-		// Ingest from local captive core
-
-		logger := log.WithField("subservice", "stellar-core")
-		ledgerBackend, err = ledgerbackend.NewCaptive(
-			ledgerbackend.CaptiveCoreConfig{
-				BinaryPath:            config.CaptiveCoreBinaryPath,
-				StoragePath:           config.CaptiveCoreStoragePath,
-				Toml:                  config.CaptiveCoreToml,
-				NetworkPassphrase:     config.NetworkPassphrase,
-				HistoryArchiveURLs:    config.HistoryArchiveURLs,
-				CheckpointFrequency:   config.CheckpointFrequency,
-				Log:                   logger,
-				Context:               ctx,
-				UserAgent:             fmt.Sprintf("captivecore horizon/%s golang/%s", apkg.Version(), runtime.Version()),
-				CoreProtocolVersionFn: config.CoreProtocolVersionFn,
-				CoreBuildVersionFn:    config.CoreBuildVersionFn,
-			},
-		)
-		if err != nil {
-			cancel()
-			return nil, errors.Wrap(err, "error creating captive core backend")
-		}
+	ledgerBackend, err := newLedgerBackend(ctx, config)
+	if err != nil {
+		cancel()
+		return nil, err
 	}
 
 	historyQ := &history.Q{config.HistorySession.Clone()}
@@ -390,6 +393,57 @@ func NewSystem(config Config) (System, error) {
 	return system, nil
 }
 
+// newLedgerBackend creates the ledger backend ingestion reads ledgers from:
+// a captive stellar-core subprocess, a datastore of exported ledgers or a
+// Stellar RPC server.
+func newLedgerBackend(ctx context.Context, config Config) (ledgerbackend.LedgerBackend, error) {
+	switch config.LedgerBackendType {
+	case BufferedStorageBackend:
+		dataStore, err := datastore.NewDataStore(context.Background(), config.StorageBackendConfig.DataStoreConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create datastore: %w", err)
+		}
+
+		schema, err := datastore.LoadSchema(context.Background(), dataStore, config.StorageBackendConfig.DataStoreConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve datastore schema: %w", err)
+		}
+
+		ledgerBackend, err := ledgerbackend.NewBufferedStorageBackend(config.StorageBackendConfig.BufferedStorageBackendConfig, dataStore, schema)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create buffered storage backend: %w", err)
+		}
+		return ledgerBackend, nil
+	case RPCBackend:
+		if config.RPCBackendConfig.RPCServerURL == "" {
+			return nil, errors.New("rpc server url is required for rpc ledgerbackend type")
+		}
+		return newRPCLedgerBackend(config.RPCBackendConfig), nil
+	case CaptiveCoreBackend:
+		logger := log.WithField("subservice", "stellar-core")
+		ledgerBackend, err := ledgerbackend.NewCaptive(
+			ledgerbackend.CaptiveCoreConfig{
+				BinaryPath:            config.CaptiveCoreBinaryPath,
+				StoragePath:           config.CaptiveCoreStoragePath,
+				Toml:                  config.CaptiveCoreToml,
+				NetworkPassphrase:     config.NetworkPassphrase,
+				HistoryArchiveURLs:    config.HistoryArchiveURLs,
+				CheckpointFrequency:   config.CheckpointFrequency,
+				Log:                   logger,
+				Context:               ctx,
+				UserAgent:             fmt.Sprintf("captivecore horizon/%s golang/%s", apkg.Version(), runtime.Version()),
+				CoreProtocolVersionFn: config.CoreProtocolVersionFn,
+				CoreBuildVersionFn:    config.CoreBuildVersionFn,
+			},
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating captive core backend")
+		}
+		return ledgerBackend, nil
+	}
+	return nil, errors.Errorf("unsupported ledger backend type: %d", config.LedgerBackendType)
+}
+
 func ledgerEligibleForStateVerification(checkpointFrequency, stateVerificationFrequency uint32) func(ledger uint32) bool {
 	stateVerificationCheckpointManager := historyarchive.NewCheckpointManager(
 		checkpointFrequency * stateVerificationFrequency,
@@ -406,9 +406,11 @@ type mockDBQ struct {
 	mock.Mock
 
 	history.MockQAccounts
+	history.MockQBalanceChanges
 	history.MockQFilter
 	history.MockQClaimableBalances
 	history.MockQHistoryClaimableBalances
+	history.MockQContractEvents
 	history.MockQLiquidityPools
 	history.MockQHistoryLiquidityPools
 	history.MockQAssetStats
@@ -418,6 +420,7 @@ type mockDBQ struct {
 	history.MockQOffers
 	history.MockQOperations
 	history.MockQSigners
+	history.MockQTokenTransfers
 	history.MockQTransactions
 	history.MockQTrustLines
 }
@@ -550,6 +553,11 @@ func (m *mockDBQ) DeleteRangeAll(ctx context.Context, start, end int64) (int64,
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *mockDBQ) DeleteBalanceChangesRange(ctx context.Context, start, end int64) (int64, error) {
+	args := m.Called(ctx, start, end)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 // Methods from interfaces duplicating methods:
 
 func (m *mockDBQ) NewTransactionParticipantsBatchInsertBuilder() history.TransactionParticipantsBatchInsertBuilder {
@@ -134,6 +134,11 @@ func buildChangeProcessor(
 		processors.NewTrustLinesProcessor(historyQ),
 		processors.NewClaimableBalancesChangeProcessor(historyQ),
 		processors.NewLiquidityPoolsChangeProcessor(historyQ, ledgerSequence),
+		processors.NewBalanceChangesProcessor(
+			historyQ,
+			source == historyArchiveSource,
+			ledgerSequence,
+		),
 	})
 }
 
@@ -161,7 +166,9 @@ func (s *ProcessorRunner) buildTransactionProcessor(ledgersProcessor *processors
 		processors.NewClaimableBalancesTransactionProcessor(cbLoader,
 			s.historyQ.NewTransactionClaimableBalanceBatchInsertBuilder(), s.historyQ.NewOperationClaimableBalanceBatchInsertBuilder()),
 		processors.NewLiquidityPoolsTransactionProcessor(lpLoader,
-			s.historyQ.NewTransactionLiquidityPoolBatchInsertBuilder(), s.historyQ.NewOperationLiquidityPoolBatchInsertBuilder())}
+			s.historyQ.NewTransactionLiquidityPoolBatchInsertBuilder(), s.historyQ.NewOperationLiquidityPoolBatchInsertBuilder()),
+		processors.NewTokenTransferProcessor(s.historyQ.NewTokenTransferBatchInsertBuilder(), s.config.NetworkPassphrase),
+		processors.NewContractEventsProcessor(s.historyQ.NewContractEventBatchInsertBuilder())}
 
 	return loaders, newGroupTransactionProcessors(processors, statsLedgerTransactionProcessor, tradeProcessor)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math"
 	"reflect"
 	"testing"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/stellar/go/services/horizon/internal/db2/history"
 	"github.com/stellar/go/services/horizon/internal/ingest/processors"
 	"github.com/stellar/go/support/db"
+	"github.com/stellar/go/toid"
 	"github.com/stellar/go/xdr"
 )
 
@@ -86,6 +88,10 @@ func TestProcessorRunnerRunHistoryArchiveIngestionHistoryArchive(t *testing.T) {
 
 	q.MockQAssetStats.On("InsertAssetStats", ctx, []history.ExpAssetStat{}, 100000).
 		Return(nil)
+	q.On("DeleteBalanceChangesRange", ctx, toid.New(64, 0, 0).ToInt64(), int64(math.MaxInt64)).
+		Return(int64(0), nil).Once()
+	q.MockQBalanceChanges.On("UpdateBalanceHistoryStartLedger", ctx, uint32(63)).
+		Return(nil).Once()
 
 	runner := ProcessorRunner{
 		ctx:            ctx,
@@ -164,6 +170,9 @@ func TestProcessorRunnerBuildChangeProcessor(t *testing.T) {
 		Elem().FieldByName("ingestFromHistoryArchive").Bool())
 	assert.IsType(t, &processors.SignersProcessor{}, processor.processors[5])
 	assert.IsType(t, &processors.TrustLinesProcessor{}, processor.processors[6])
+	assert.IsType(t, &processors.BalanceChangesProcessor{}, processor.processors[9])
+	assert.False(t, reflect.ValueOf(processor.processors[9]).
+		Elem().FieldByName("ingestFromHistoryArchive").Bool())
 
 	runner = ProcessorRunner{
 		ctx:      ctx,
@@ -183,6 +192,9 @@ func TestProcessorRunnerBuildChangeProcessor(t *testing.T) {
 		Elem().FieldByName("ingestFromHistoryArchive").Bool())
 	assert.IsType(t, &processors.SignersProcessor{}, processor.processors[5])
 	assert.IsType(t, &processors.TrustLinesProcessor{}, processor.processors[6])
+	assert.IsType(t, &processors.BalanceChangesProcessor{}, processor.processors[9])
+	assert.True(t, reflect.ValueOf(processor.processors[9]).
+		Elem().FieldByName("ingestFromHistoryArchive").Bool())
 }
 
 func TestProcessorRunnerBuildTransactionProcessor(t *testing.T) {
@@ -212,6 +224,10 @@ func TestProcessorRunnerBuildTransactionProcessor(t *testing.T) {
 		Return(&history.MockTransactionLiquidityPoolBatchInsertBuilder{})
 	q.MockQHistoryLiquidityPools.On("NewOperationLiquidityPoolBatchInsertBuilder").
 		Return(&history.MockOperationLiquidityPoolBatchInsertBuilder{})
+	q.MockQTokenTransfers.On("NewTokenTransferBatchInsertBuilder").
+		Return(&history.MockTokenTransferBatchInsertBuilder{})
+	q.MockQContractEvents.On("NewContractEventBatchInsertBuilder").
+		Return(&history.MockContractEventBatchInsertBuilder{})
 
 	runner := ProcessorRunner{
 		ctx:      ctx,
@@ -231,6 +247,8 @@ func TestProcessorRunnerBuildTransactionProcessor(t *testing.T) {
 	assert.IsType(t, &processors.ParticipantsProcessor{}, processor.processors[5])
 	assert.IsType(t, &processors.ClaimableBalancesTransactionProcessor{}, processor.processors[7])
 	assert.IsType(t, &processors.LiquidityPoolsTransactionProcessor{}, processor.processors[8])
+	assert.IsType(t, &processors.TokenTransferProcessor{}, processor.processors[9])
+	assert.IsType(t, &processors.ContractEventsProcessor{}, processor.processors[10])
 }
 
 func TestProcessorRunnerRunAllProcessorsOnLedger(t *testing.T) {
@@ -257,9 +275,16 @@ func TestProcessorRunnerRunAllProcessorsOnLedger(t *testing.T) {
 
 	// Batches
 	defer mock.AssertExpectationsForObjects(t, mockTxProcessorBatchBuilders(q, mockSession, ctx)...)
-	defer mock.AssertExpectationsForObjects(t, mockChangeProcessorBatchBuilders(q, ctx, true)...)
+	changeBatchBuilders := mockChangeProcessorBatchBuilders(q, ctx, true)
+	defer mock.AssertExpectationsForObjects(t, changeBatchBuilders...)
 	defer mock.AssertExpectationsForObjects(t, mockFilteredOutProcessorsForNoRules(q, mockSession, ctx)...)
 
+	// balance changes are only recorded when ingesting ledgers
+	mockBalanceChangesBatchInsertBuilder := changeBatchBuilders[len(changeBatchBuilders)-1]
+	assert.IsType(t, &history.MockBalanceChangesBatchInsertBuilder{}, mockBalanceChangesBatchInsertBuilder)
+	mockBalanceChangesBatchInsertBuilder.(*history.MockBalanceChangesBatchInsertBuilder).
+		On("Exec", ctx).Return(nil).Once()
+
 	mockBatchInsertBuilder := &history.MockLedgersBatchInsertBuilder{}
 	q.MockQLedgers.On("NewLedgerBatchInsertBuilder").Return(mockBatchInsertBuilder)
 	mockBatchInsertBuilder.On(
@@ -502,6 +527,18 @@ func mockTxProcessorBatchBuilders(q *mockDBQ, mockSession *db.MockSession, ctx c
 	q.MockQHistoryLiquidityPools.On("NewOperationLiquidityPoolBatchInsertBuilder").
 		Return(mockOperationLiquidityPoolBatchInsertBuilder).Once()
 
+	// the fees of the transactions of the fake ledgers are token transfers
+	mockTokenTransferBatchInsertBuilder := &history.MockTokenTransferBatchInsertBuilder{}
+	mockTokenTransferBatchInsertBuilder.On("Add", mock.Anything).Return(nil).Maybe()
+	mockTokenTransferBatchInsertBuilder.On("Exec", ctx, mockSession).Return(nil).Once()
+	q.MockQTokenTransfers.On("NewTokenTransferBatchInsertBuilder").
+		Return(mockTokenTransferBatchInsertBuilder).Once()
+
+	mockContractEventBatchInsertBuilder := &history.MockContractEventBatchInsertBuilder{}
+	mockContractEventBatchInsertBuilder.On("Exec", ctx, mockSession).Return(nil).Once()
+	q.MockQContractEvents.On("NewContractEventBatchInsertBuilder").
+		Return(mockContractEventBatchInsertBuilder).Once()
+
 	return []interface{}{mockTradeBatchInsertBuilder,
 		mockTransactionsBatchInsertBuilder,
 		mockOperationsBatchInsertBuilder,
@@ -511,7 +548,9 @@ func mockTxProcessorBatchBuilders(q *mockDBQ, mockSession *db.MockSession, ctx c
 		mockTransactionClaimableBalanceBatchInsertBuilder,
 		mockOperationClaimableBalanceBatchInsertBuilder,
 		mockTransactionLiquidityPoolBatchInsertBuilder,
-		mockOperationLiquidityPoolBatchInsertBuilder}
+		mockOperationLiquidityPoolBatchInsertBuilder,
+		mockTokenTransferBatchInsertBuilder,
+		mockContractEventBatchInsertBuilder}
 }
 
 func mockChangeProcessorBatchBuilders(q *mockDBQ, ctx context.Context, mockExec bool) []interface{} {
@@ -567,6 +606,10 @@ func mockChangeProcessorBatchBuilders(q *mockDBQ, ctx context.Context, mockExec
 	q.MockQTrustLines.On("NewTrustLinesBatchInsertBuilder").
 		Return(mockTrustLinesBatchInsertBuilder)
 
+	mockBalanceChangesBatchInsertBuilder := &history.MockBalanceChangesBatchInsertBuilder{}
+	q.MockQBalanceChanges.On("NewBalanceChangesBatchInsertBuilder").
+		Return(mockBalanceChangesBatchInsertBuilder)
+
 	return []interface{}{mockAccountSignersBatchInsertBuilder,
 		mockAccountsBatchInsertBuilder,
 		mockClaimableBalanceBatchInsertBuilder,
@@ -574,6 +617,7 @@ func mockChangeProcessorBatchBuilders(q *mockDBQ, ctx context.Context, mockExec
 		mockOfferBatchInsertBuilder,
 		mockAccountDataBatchInsertBuilder,
 		mockTrustLinesBatchInsertBuilder,
+		mockBalanceChangesBatchInsertBuilder,
 	}
 }
 
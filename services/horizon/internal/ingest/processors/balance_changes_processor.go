@@ -0,0 +1,145 @@
+package processors
+
+import (
+	"context"
+	"math"
+
+	"github.com/guregu/null"
+
+	"github.com/stellar/go/ingest"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/toid"
+	"github.com/stellar/go/xdr"
+)
+
+// BalanceChangesProcessor records the changes of account native balances and
+// trust line balances in the history_balance_changes table. When ingesting
+// from a history archive no changes are recorded: the snapshot is the
+// baseline of the balance history which starts at the checkpoint ledger, and
+// the changes recorded after the checkpoint ledger are deleted.
+type BalanceChangesProcessor struct {
+	balanceChangesQ          history.QBalanceChanges
+	ingestFromHistoryArchive bool
+	sequence                 uint32
+
+	batchInsertBuilder history.BalanceChangesBatchInsertBuilder
+}
+
+func NewBalanceChangesProcessor(
+	balanceChangesQ history.QBalanceChanges,
+	ingestFromHistoryArchive bool,
+	sequence uint32,
+) *BalanceChangesProcessor {
+	return &BalanceChangesProcessor{
+		balanceChangesQ:          balanceChangesQ,
+		ingestFromHistoryArchive: ingestFromHistoryArchive,
+		sequence:                 sequence,
+		batchInsertBuilder:       balanceChangesQ.NewBalanceChangesBatchInsertBuilder(),
+	}
+}
+
+func (p *BalanceChangesProcessor) Name() string {
+	return "processors.BalanceChangesProcessor"
+}
+
+func (p *BalanceChangesProcessor) ProcessChange(ctx context.Context, change ingest.Change) error {
+	if p.ingestFromHistoryArchive {
+		return nil
+	}
+	if change.Type != xdr.LedgerEntryTypeAccount && change.Type != xdr.LedgerEntryTypeTrustline {
+		return nil
+	}
+
+	var entry *xdr.LedgerEntry
+	switch {
+	case change.Post != nil:
+		entry = change.Post
+	case change.Pre != nil:
+		entry = change.Pre
+	default:
+		return errors.New("Invalid io.Change: change.Pre == nil && change.Post == nil")
+	}
+
+	row, err := balanceChangeRow(*entry)
+	if err != nil {
+		return err
+	}
+	row.LedgerToid = toid.New(int32(p.sequence), 0, 0).ToInt64()
+	if change.Pre != nil {
+		row.PreviousBalance = null.IntFrom(ledgerEntryBalance(*change.Pre))
+	}
+	if change.Post != nil {
+		row.Balance = null.IntFrom(ledgerEntryBalance(*change.Post))
+	}
+	if row.PreviousBalance == row.Balance {
+		// the entry was updated without changing its balance
+		return nil
+	}
+
+	if err = p.batchInsertBuilder.Add(row); err != nil {
+		return errors.Wrap(err, "Error adding to BalanceChangesBatchInsertBuilder")
+	}
+
+	if p.batchInsertBuilder.Len() > maxBatchSize {
+		if err = p.batchInsertBuilder.Exec(ctx); err != nil {
+			return errors.Wrap(err, "Error executing BalanceChangesBatchInsertBuilder")
+		}
+		p.batchInsertBuilder = p.balanceChangesQ.NewBalanceChangesBatchInsertBuilder()
+	}
+
+	return nil
+}
+
+func balanceChangeRow(entry xdr.LedgerEntry) (history.BalanceChange, error) {
+	if entry.Data.Type == xdr.LedgerEntryTypeAccount {
+		return history.BalanceChange{
+			AccountID: entry.Data.MustAccount().AccountId.Address(),
+			AssetType: xdr.AssetTypeAssetTypeNative,
+		}, nil
+	}
+
+	trustLineEntry := entry.Data.MustTrustLine()
+	row := history.BalanceChange{
+		AccountID: trustLineEntry.AccountId.Address(),
+		AssetType: trustLineEntry.Asset.Type,
+	}
+	if row.AssetType == xdr.AssetTypeAssetTypePoolShare {
+		row.LiquidityPoolID = PoolIDToString(trustLineEntry.Asset.MustLiquidityPoolId())
+	} else if err := trustLineEntry.Asset.ToAsset().Extract(&row.AssetType, &row.AssetCode, &row.AssetIssuer); err != nil {
+		return history.BalanceChange{}, errors.Wrap(err, "Error extracting asset from trustline")
+	}
+	return row, nil
+}
+
+func ledgerEntryBalance(entry xdr.LedgerEntry) int64 {
+	if entry.Data.Type == xdr.LedgerEntryTypeAccount {
+		return int64(entry.Data.MustAccount().Balance)
+	}
+	return int64(entry.Data.MustTrustLine().Balance)
+}
+
+func (p *BalanceChangesProcessor) Commit(ctx context.Context) error {
+	if p.ingestFromHistoryArchive {
+		// the balances are derived from the state by reverting the changes
+		// following a ledger, the changes recorded after the checkpoint
+		// ledger would be reverted twice
+		_, err := p.balanceChangesQ.DeleteBalanceChangesRange(
+			ctx,
+			toid.New(int32(p.sequence+1), 0, 0).ToInt64(),
+			math.MaxInt64,
+		)
+		if err != nil {
+			return errors.Wrap(err, "Error deleting balance changes after the checkpoint ledger")
+		}
+		if err := p.balanceChangesQ.UpdateBalanceHistoryStartLedger(ctx, p.sequence); err != nil {
+			return errors.Wrap(err, "Error updating balance history start ledger")
+		}
+		return nil
+	}
+
+	if err := p.batchInsertBuilder.Exec(ctx); err != nil {
+		return errors.Wrap(err, "Error executing BalanceChangesBatchInsertBuilder")
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package processors
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/guregu/null"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stellar/go/ingest"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/toid"
+	"github.com/stellar/go/xdr"
+)
+
+func balanceChangesTestAccount(balance xdr.Int64, signers int) *xdr.LedgerEntry {
+	return &xdr.LedgerEntry{
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeAccount,
+			Account: &xdr.AccountEntry{
+				AccountId:     xdr.MustAddress("GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB"),
+				Balance:       balance,
+				NumSubEntries: xdr.Uint32(signers),
+			},
+		},
+	}
+}
+
+func balanceChangesTestTrustLine(asset xdr.TrustLineAsset, balance xdr.Int64) *xdr.LedgerEntry {
+	return &xdr.LedgerEntry{
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeTrustline,
+			TrustLine: &xdr.TrustLineEntry{
+				AccountId: xdr.MustAddress("GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB"),
+				Asset:     asset,
+				Balance:   balance,
+				Limit:     1000,
+			},
+		},
+	}
+}
+
+func TestBalanceChangesProcessor(t *testing.T) {
+	ctx := context.Background()
+	q := &history.MockQBalanceChanges{}
+	builder := &history.MockBalanceChangesBatchInsertBuilder{}
+	q.On("NewBalanceChangesBatchInsertBuilder").Return(builder).Once()
+	builder.On("Len").Return(1).Maybe()
+
+	ledgerToid := toid.New(123, 0, 0).ToInt64()
+	accountID := "GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB"
+	eur := xdr.MustNewCreditAsset("EUR", trustLineIssuer.Address()).ToTrustLineAsset()
+	poolShare := xdr.TrustLineAsset{
+		Type:            xdr.AssetTypeAssetTypePoolShare,
+		LiquidityPoolId: &xdr.PoolId{1, 2, 3, 4},
+	}
+
+	builder.On("Add", history.BalanceChange{
+		LedgerToid:      ledgerToid,
+		AccountID:       accountID,
+		AssetType:       xdr.AssetTypeAssetTypeNative,
+		PreviousBalance: null.IntFrom(100),
+		Balance:         null.IntFrom(90),
+	}).Return(nil).Once()
+	builder.On("Add", history.BalanceChange{
+		LedgerToid:  ledgerToid,
+		AccountID:   accountID,
+		AssetType:   xdr.AssetTypeAssetTypeCreditAlphanum4,
+		AssetCode:   "EUR",
+		AssetIssuer: trustLineIssuer.Address(),
+		Balance:     null.IntFrom(0),
+	}).Return(nil).Once()
+	builder.On("Add", history.BalanceChange{
+		LedgerToid:      ledgerToid,
+		AccountID:       accountID,
+		AssetType:       xdr.AssetTypeAssetTypePoolShare,
+		LiquidityPoolID: "0102030400000000000000000000000000000000000000000000000000000000",
+		PreviousBalance: null.IntFrom(50),
+	}).Return(nil).Once()
+	builder.On("Exec", ctx).Return(nil).Once()
+
+	processor := NewBalanceChangesProcessor(q, false, 123)
+	for _, change := range []ingest.Change{
+		{
+			Type: xdr.LedgerEntryTypeAccount,
+			Pre:  balanceChangesTestAccount(100, 0),
+			Post: balanceChangesTestAccount(90, 0),
+		},
+		// updates keeping the balance are not recorded
+		{
+			Type: xdr.LedgerEntryTypeAccount,
+			Pre:  balanceChangesTestAccount(90, 0),
+			Post: balanceChangesTestAccount(90, 1),
+		},
+		{
+			Type: xdr.LedgerEntryTypeTrustline,
+			Post: balanceChangesTestTrustLine(eur, 0),
+		},
+		{
+			Type: xdr.LedgerEntryTypeTrustline,
+			Pre:  balanceChangesTestTrustLine(poolShare, 50),
+		},
+		{
+			Type: xdr.LedgerEntryTypeOffer,
+			Post: &xdr.LedgerEntry{
+				Data: xdr.LedgerEntryData{
+					Type:  xdr.LedgerEntryTypeOffer,
+					Offer: &xdr.OfferEntry{},
+				},
+			},
+		},
+	} {
+		assert.NoError(t, processor.ProcessChange(ctx, change))
+	}
+	assert.NoError(t, processor.Commit(ctx))
+
+	q.AssertExpectations(t)
+	builder.AssertExpectations(t)
+}
+
+func TestBalanceChangesProcessorHistoryArchive(t *testing.T) {
+	ctx := context.Background()
+	q := &history.MockQBalanceChanges{}
+	builder := &history.MockBalanceChangesBatchInsertBuilder{}
+	q.On("NewBalanceChangesBatchInsertBuilder").Return(builder).Once()
+	q.On("DeleteBalanceChangesRange", ctx, toid.New(64, 0, 0).ToInt64(), int64(math.MaxInt64)).
+		Return(int64(0), nil).Once()
+	q.On("UpdateBalanceHistoryStartLedger", ctx, uint32(63)).Return(nil).Once()
+
+	processor := NewBalanceChangesProcessor(q, true, 63)
+	assert.NoError(t, processor.ProcessChange(ctx, ingest.Change{
+		Type: xdr.LedgerEntryTypeAccount,
+		Post: balanceChangesTestAccount(100, 0),
+	}))
+	assert.NoError(t, processor.Commit(ctx))
+
+	q.AssertExpectations(t)
+	builder.AssertExpectations(t)
+}
@@ -0,0 +1,96 @@
+package processors
+
+import (
+	"context"
+
+	"github.com/stellar/go/ingest"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/support/db"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/toid"
+	"github.com/stellar/go/xdr"
+)
+
+// ContractEventsProcessor ingests the contract and system events emitted by
+// the invocations of successful Soroban transactions into
+// history_contract_events.
+type ContractEventsProcessor struct {
+	batch history.ContractEventBatchInsertBuilder
+}
+
+func NewContractEventsProcessor(batch history.ContractEventBatchInsertBuilder) *ContractEventsProcessor {
+	return &ContractEventsProcessor{
+		batch: batch,
+	}
+}
+
+func (p *ContractEventsProcessor) Name() string {
+	return "processors.ContractEventsProcessor"
+}
+
+// ProcessTransaction adds the contract events of a transaction to the batch.
+func (p *ContractEventsProcessor) ProcessTransaction(
+	lcm xdr.LedgerCloseMeta, transaction ingest.LedgerTransaction,
+) error {
+	if !transaction.Successful() || !transaction.IsSorobanTx() {
+		return nil
+	}
+	events, err := transaction.GetContractEvents()
+	if err != nil {
+		return errors.Wrapf(err, "could not get contract events of transaction %v", transaction.Hash.HexString())
+	}
+
+	// Soroban transactions have a single operation
+	operationID := toid.New(int32(lcm.LedgerSequence()), int32(transaction.Index), 1).ToInt64()
+	for i, event := range events {
+		row, err := contractEventRow(event)
+		if err != nil {
+			return errors.Wrapf(err, "could not convert contract event %d of transaction %v", i, transaction.Hash.HexString())
+		}
+		row.HistoryOperationID = operationID
+		row.Order = int32(i + 1)
+		row.TransactionHash = transaction.Hash.HexString()
+		row.LedgerCloseTime = lcm.ClosedAt()
+
+		if err := p.batch.Add(row); err != nil {
+			return errors.Wrap(err, "error adding contract event to the batch")
+		}
+	}
+	return nil
+}
+
+func (p *ContractEventsProcessor) Flush(ctx context.Context, session db.SessionInterface) error {
+	return p.batch.Exec(ctx, session)
+}
+
+func contractEventRow(event xdr.ContractEvent) (history.ContractEvent, error) {
+	row := history.ContractEvent{Type: event.Type}
+	if event.ContractId != nil {
+		contractID, err := strkey.Encode(strkey.VersionByteContract, event.ContractId[:])
+		if err != nil {
+			return row, err
+		}
+		row.ContractID.SetValid(contractID)
+	}
+
+	body := event.Body.MustV0()
+	topics := make([]string, len(body.Topics))
+	for i, topic := range body.Topics {
+		encoded, err := xdr.MarshalBase64(topic)
+		if err != nil {
+			return row, errors.Wrap(err, "could not encode topic")
+		}
+		topics[i] = encoded
+	}
+	if err := row.SetTopics(topics); err != nil {
+		return row, err
+	}
+
+	value, err := xdr.MarshalBase64(body.Data)
+	if err != nil {
+		return row, errors.Wrap(err, "could not encode value")
+	}
+	row.Value = value
+	return row, nil
+}
@@ -0,0 +1,138 @@
+package processors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/guregu/null"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/ingest"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/support/db"
+	"github.com/stellar/go/toid"
+	"github.com/stellar/go/xdr"
+)
+
+func createContractEventsTransaction(lcm xdr.LedgerCloseMeta, successful bool, soroban bool, events []xdr.ContractEvent) ingest.LedgerTransaction {
+	code := xdr.TransactionResultCodeTxSuccess
+	if !successful {
+		code = xdr.TransactionResultCodeTxFailed
+	}
+	tx := xdr.Transaction{
+		SourceAccount: xdr.MustMuxedAddress("GAUJETIZVEP2NRYLUESJ3LS66NVCEGMON4UDCBCSBEVPIID773P2W6AY"),
+		Operations: []xdr.Operation{{
+			Body: xdr.OperationBody{
+				Type:                 xdr.OperationTypeInvokeHostFunction,
+				InvokeHostFunctionOp: &xdr.InvokeHostFunctionOp{},
+			},
+		}},
+	}
+	if soroban {
+		tx.Ext = xdr.TransactionExt{V: 1, SorobanData: &xdr.SorobanTransactionData{}}
+	}
+	return ingest.LedgerTransaction{
+		Index:  3,
+		Ledger: lcm,
+		Hash:   xdr.Hash{2},
+		Result: xdr.TransactionResultPair{
+			TransactionHash: xdr.Hash{2},
+			Result: xdr.TransactionResult{
+				Result: xdr.TransactionResultResult{
+					Code:    code,
+					Results: &[]xdr.OperationResult{},
+				},
+			},
+		},
+		Envelope: xdr.TransactionEnvelope{
+			Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+			V1:   &xdr.TransactionV1Envelope{Tx: tx},
+		},
+		UnsafeMeta: xdr.TransactionMeta{
+			V: 3,
+			V3: &xdr.TransactionMetaV3{
+				SorobanMeta: &xdr.SorobanTransactionMeta{Events: events},
+			},
+		},
+	}
+}
+
+func TestContractEventsProcessor(t *testing.T) {
+	ctx := context.Background()
+	closeTime := time.Unix(1700000000, 0).UTC()
+	lcm := xdr.LedgerCloseMeta{
+		V0: &xdr.LedgerCloseMetaV0{
+			LedgerHeader: xdr.LedgerHeaderHistoryEntry{
+				Header: xdr.LedgerHeader{
+					LedgerSeq: 20,
+					ScpValue:  xdr.StellarValue{CloseTime: xdr.TimePoint(closeTime.Unix())},
+				},
+			},
+		},
+	}
+
+	contractID := xdr.ContractId{1, 2, 3}
+	transfer := xdr.ScSymbol("transfer")
+	amount := xdr.Uint32(5)
+	topic := xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &transfer}
+	value := xdr.ScVal{Type: xdr.ScValTypeScvU32, U32: &amount}
+	events := []xdr.ContractEvent{
+		{
+			ContractId: &contractID,
+			Type:       xdr.ContractEventTypeContract,
+			Body: xdr.ContractEventBody{
+				V:  0,
+				V0: &xdr.ContractEventV0{Topics: []xdr.ScVal{topic, value}, Data: value},
+			},
+		},
+		{
+			Type: xdr.ContractEventTypeSystem,
+			Body: xdr.ContractEventBody{
+				V:  0,
+				V0: &xdr.ContractEventV0{Data: value},
+			},
+		},
+	}
+
+	encodedTopic, err := xdr.MarshalBase64(topic)
+	require.NoError(t, err)
+	encodedValue, err := xdr.MarshalBase64(value)
+	require.NoError(t, err)
+	contract, err := strkey.Encode(strkey.VersionByteContract, contractID[:])
+	require.NoError(t, err)
+
+	batch := &history.MockContractEventBatchInsertBuilder{}
+	session := &db.MockSession{}
+	batch.On("Add", []history.ContractEvent{{
+		HistoryOperationID: toid.New(20, 3, 1).ToInt64(),
+		Order:              1,
+		TransactionHash:    xdr.Hash{2}.HexString(),
+		LedgerCloseTime:    closeTime,
+		ContractID:         null.StringFrom(contract),
+		Type:               xdr.ContractEventTypeContract,
+		TopicCount:         2,
+		Topic1:             null.StringFrom(encodedTopic),
+		Topic2:             null.StringFrom(encodedValue),
+		Value:              encodedValue,
+	}}).Return(nil).Once()
+	batch.On("Add", []history.ContractEvent{{
+		HistoryOperationID: toid.New(20, 3, 1).ToInt64(),
+		Order:              2,
+		TransactionHash:    xdr.Hash{2}.HexString(),
+		LedgerCloseTime:    closeTime,
+		Type:               xdr.ContractEventTypeSystem,
+		Value:              encodedValue,
+	}}).Return(nil).Once()
+	batch.On("Exec", ctx, session).Return(nil).Once()
+
+	processor := NewContractEventsProcessor(batch)
+	assert.NoError(t, processor.ProcessTransaction(lcm, createContractEventsTransaction(lcm, true, true, events)))
+	// failed and classic transactions are skipped
+	assert.NoError(t, processor.ProcessTransaction(lcm, createContractEventsTransaction(lcm, false, true, events)))
+	assert.NoError(t, processor.ProcessTransaction(lcm, createContractEventsTransaction(lcm, true, false, events)))
+	assert.NoError(t, processor.Flush(ctx, session))
+	batch.AssertExpectations(t)
+}
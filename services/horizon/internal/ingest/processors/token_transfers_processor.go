@@ -0,0 +1,158 @@
+package processors
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+
+	"github.com/guregu/null"
+
+	"github.com/stellar/go/ingest"
+	"github.com/stellar/go/processors/token_transfer"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/support/db"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/toid"
+	"github.com/stellar/go/xdr"
+)
+
+var tokenTransferTypes = map[string]history.TokenTransferType{
+	token_transfer.TransferEvent: history.TokenTransferTypeTransfer,
+	token_transfer.MintEvent:     history.TokenTransferTypeMint,
+	token_transfer.BurnEvent:     history.TokenTransferTypeBurn,
+	token_transfer.ClawbackEvent: history.TokenTransferTypeClawback,
+	token_transfer.FeeEvent:      history.TokenTransferTypeFee,
+}
+
+// TokenTransferProcessor ingests the unified token transfer events (SEP-41
+// transfers, mints, burns, clawbacks and fees) of transactions, of both
+// classic operations and Soroban contracts, into history_token_transfers.
+type TokenTransferProcessor struct {
+	batch           history.TokenTransferBatchInsertBuilder
+	eventsProcessor *token_transfer.EventsProcessor
+}
+
+func NewTokenTransferProcessor(
+	batch history.TokenTransferBatchInsertBuilder,
+	networkPassphrase string,
+) *TokenTransferProcessor {
+	return &TokenTransferProcessor{
+		batch:           batch,
+		eventsProcessor: token_transfer.NewEventsProcessor(networkPassphrase),
+	}
+}
+
+func (p *TokenTransferProcessor) Name() string {
+	return "processors.TokenTransferProcessor"
+}
+
+// ProcessTransaction adds the token transfers of a transaction to the batch,
+// its fee events first.
+func (p *TokenTransferProcessor) ProcessTransaction(
+	lcm xdr.LedgerCloseMeta, transaction ingest.LedgerTransaction,
+) error {
+	txEvents, err := p.eventsProcessor.EventsFromTransaction(transaction)
+	if err != nil {
+		return errors.Wrapf(err, "could not get token transfer events of transaction %v", transaction.Hash.HexString())
+	}
+
+	var (
+		lastID int64
+		order  int32
+	)
+	events := append(txEvents.FeeEvents, txEvents.OperationEvents...)
+	for _, event := range events {
+		row, err := tokenTransferRow(lcm.LedgerSequence(), event)
+		if err != nil {
+			return errors.Wrapf(err, "could not convert token transfer event of transaction %v", transaction.Hash.HexString())
+		}
+		// the order of an event is 1-based within its operation, or within
+		// the fee events of its transaction
+		if row.HistoryOperationID != lastID {
+			lastID = row.HistoryOperationID
+			order = 0
+		}
+		order++
+		row.Order = order
+
+		if err := p.batch.Add(row); err != nil {
+			return errors.Wrap(err, "error adding token transfer to the batch")
+		}
+	}
+	return nil
+}
+
+func (p *TokenTransferProcessor) Flush(ctx context.Context, session db.SessionInterface) error {
+	return p.batch.Exec(ctx, session)
+}
+
+func tokenTransferRow(sequence uint32, event *token_transfer.TokenTransferEvent) (history.TokenTransfer, error) {
+	meta := event.GetMeta()
+	transferType, ok := tokenTransferTypes[event.GetEventType()]
+	if !ok {
+		return history.TokenTransfer{}, errors.Errorf("unknown token transfer event type %v", event.GetEventType())
+	}
+
+	var opIndex int32
+	if meta.OperationIndex != nil {
+		opIndex = int32(*meta.OperationIndex)
+	}
+	row := history.TokenTransfer{
+		HistoryOperationID: toid.New(int32(sequence), int32(meta.GetTransactionIndex()), opIndex).ToInt64(),
+		TransactionHash:    meta.GetTxHash(),
+		LedgerCloseTime:    meta.GetClosedAt().AsTime().UTC(),
+		Type:               transferType,
+		ContractID:         meta.GetContractAddress(),
+	}
+
+	var from, to, amount string
+	switch {
+	case event.GetTransfer() != nil:
+		from, to, amount = event.GetTransfer().GetFrom(), event.GetTransfer().GetTo(), event.GetTransfer().GetAmount()
+	case event.GetMint() != nil:
+		to, amount = event.GetMint().GetTo(), event.GetMint().GetAmount()
+	case event.GetBurn() != nil:
+		from, amount = event.GetBurn().GetFrom(), event.GetBurn().GetAmount()
+	case event.GetClawback() != nil:
+		from, amount = event.GetClawback().GetFrom(), event.GetClawback().GetAmount()
+	case event.GetFee() != nil:
+		from, amount = event.GetFee().GetFrom(), event.GetFee().GetAmount()
+	}
+	if from != "" {
+		row.From = null.StringFrom(from)
+	}
+	if to != "" {
+		row.To = null.StringFrom(to)
+	}
+	row.Amount = amount
+
+	if muxed := meta.GetToMuxedInfo(); muxed != nil {
+		switch content := muxed.GetContent().(type) {
+		case *token_transfer.MuxedInfo_Id:
+			row.ToMuxedIDType = null.StringFrom("id")
+			row.ToMuxedID = null.StringFrom(strconv.FormatUint(content.Id, 10))
+		case *token_transfer.MuxedInfo_Text:
+			row.ToMuxedIDType = null.StringFrom("text")
+			row.ToMuxedID = null.StringFrom(content.Text)
+		case *token_transfer.MuxedInfo_Hash:
+			row.ToMuxedIDType = null.StringFrom("hash")
+			row.ToMuxedID = null.StringFrom(base64.StdEncoding.EncodeToString(content.Hash))
+		}
+	}
+
+	// the asset is unknown for tokens other than Stellar Asset Contracts
+	if asset := event.GetAsset(); asset != nil {
+		if asset.GetNative() {
+			row.AssetType = null.StringFrom(xdr.AssetTypeToString[xdr.AssetTypeAssetTypeNative])
+		} else if issued := asset.GetIssuedAsset(); issued != nil {
+			assetType := xdr.AssetTypeAssetTypeCreditAlphanum4
+			if len(issued.GetAssetCode()) > 4 {
+				assetType = xdr.AssetTypeAssetTypeCreditAlphanum12
+			}
+			row.AssetType = null.StringFrom(xdr.AssetTypeToString[assetType])
+			row.AssetCode = null.StringFrom(issued.GetAssetCode())
+			row.AssetIssuer = null.StringFrom(issued.GetIssuer())
+		}
+	}
+	return row, nil
+}
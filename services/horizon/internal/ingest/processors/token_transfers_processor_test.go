@@ -0,0 +1,158 @@
+package processors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/guregu/null"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/ingest"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/support/db"
+	"github.com/stellar/go/toid"
+	"github.com/stellar/go/xdr"
+)
+
+func createTokenTransferTransaction(lcm xdr.LedgerCloseMeta, successful bool) ingest.LedgerTransaction {
+	source := xdr.MustAddress("GAUJETIZVEP2NRYLUESJ3LS66NVCEGMON4UDCBCSBEVPIID773P2W6AY")
+	destination := xdr.MuxedAccount{
+		Type: xdr.CryptoKeyTypeKeyTypeMuxedEd25519,
+		Med25519: &xdr.MuxedAccountMed25519{
+			Id:      7,
+			Ed25519: *xdr.MustAddress("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H").Ed25519,
+		},
+	}
+	code := xdr.TransactionResultCodeTxSuccess
+	if !successful {
+		code = xdr.TransactionResultCodeTxFailed
+	}
+
+	paymentResult := xdr.OperationResult{
+		Code: xdr.OperationResultCodeOpInner,
+		Tr: &xdr.OperationResultTr{
+			Type:          xdr.OperationTypePayment,
+			PaymentResult: &xdr.PaymentResult{Code: xdr.PaymentResultCodePaymentSuccess},
+		},
+	}
+	payment := xdr.Operation{
+		Body: xdr.OperationBody{
+			Type: xdr.OperationTypePayment,
+			PaymentOp: &xdr.PaymentOp{
+				Destination: destination,
+				Asset:       xdr.MustNewNativeAsset(),
+				Amount:      250,
+			},
+		},
+	}
+	return ingest.LedgerTransaction{
+		Index:  2,
+		Ledger: lcm,
+		Hash:   xdr.Hash{1},
+		Result: xdr.TransactionResultPair{
+			TransactionHash: xdr.Hash{1},
+			Result: xdr.TransactionResult{
+				FeeCharged: 100,
+				Result: xdr.TransactionResultResult{
+					Code:    code,
+					Results: &[]xdr.OperationResult{paymentResult, paymentResult},
+				},
+			},
+		},
+		Envelope: xdr.TransactionEnvelope{
+			Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+			V1: &xdr.TransactionV1Envelope{
+				Tx: xdr.Transaction{
+					SourceAccount: source.ToMuxedAccount(),
+					Operations:    []xdr.Operation{payment, payment},
+				},
+			},
+		},
+		UnsafeMeta: xdr.TransactionMeta{
+			V:  1,
+			V1: &xdr.TransactionMetaV1{Operations: make([]xdr.OperationMeta, 2)},
+		},
+	}
+}
+
+func TestTokenTransferProcessor(t *testing.T) {
+	ctx := context.Background()
+	closeTime := time.Unix(1700000000, 0).UTC()
+	lcm := xdr.LedgerCloseMeta{
+		V0: &xdr.LedgerCloseMetaV0{
+			LedgerHeader: xdr.LedgerHeaderHistoryEntry{
+				Header: xdr.LedgerHeader{
+					LedgerSeq: 20,
+					ScpValue:  xdr.StellarValue{CloseTime: xdr.TimePoint(closeTime.Unix())},
+				},
+			},
+		},
+	}
+	nativeID, err := xdr.MustNewNativeAsset().ContractID(network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	nativeContract := strkey.MustEncode(strkey.VersionByteContract, nativeID[:])
+
+	source := "GAUJETIZVEP2NRYLUESJ3LS66NVCEGMON4UDCBCSBEVPIID773P2W6AY"
+	fee := history.TokenTransfer{
+		HistoryOperationID: toid.New(20, 2, 0).ToInt64(),
+		Order:              1,
+		TransactionHash:    xdr.Hash{1}.HexString(),
+		LedgerCloseTime:    closeTime,
+		Type:               history.TokenTransferTypeFee,
+		From:               null.StringFrom(source),
+		ContractID:         nativeContract,
+		AssetType:          null.StringFrom("native"),
+		Amount:             "100",
+	}
+	// the meta of the transaction doesn't have the balance changes of the
+	// payments, the events processor reconciles them with mints
+	mint := func(opIndex int32) history.TokenTransfer {
+		return history.TokenTransfer{
+			HistoryOperationID: toid.New(20, 2, opIndex).ToInt64(),
+			Order:              1,
+			TransactionHash:    xdr.Hash{1}.HexString(),
+			LedgerCloseTime:    closeTime,
+			Type:               history.TokenTransferTypeMint,
+			To:                 null.StringFrom(source),
+			ContractID:         nativeContract,
+			AssetType:          null.StringFrom("native"),
+			Amount:             "250",
+		}
+	}
+	payment := func(opIndex int32) history.TokenTransfer {
+		return history.TokenTransfer{
+			HistoryOperationID: toid.New(20, 2, opIndex).ToInt64(),
+			Order:              2,
+			TransactionHash:    xdr.Hash{1}.HexString(),
+			LedgerCloseTime:    closeTime,
+			Type:               history.TokenTransferTypeTransfer,
+			From:               null.StringFrom(source),
+			To:                 null.StringFrom("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"),
+			ToMuxedIDType:      null.StringFrom("id"),
+			ToMuxedID:          null.StringFrom("7"),
+			ContractID:         nativeContract,
+			AssetType:          null.StringFrom("native"),
+			Amount:             "250",
+		}
+	}
+
+	batch := &history.MockTokenTransferBatchInsertBuilder{}
+	session := &db.MockSession{}
+	batch.On("Add", []history.TokenTransfer{fee}).Return(nil).Twice()
+	for _, opIndex := range []int32{1, 2} {
+		batch.On("Add", []history.TokenTransfer{mint(opIndex)}).Return(nil).Once()
+		batch.On("Add", []history.TokenTransfer{payment(opIndex)}).Return(nil).Once()
+	}
+	batch.On("Exec", ctx, session).Return(nil).Once()
+
+	processor := NewTokenTransferProcessor(batch, network.TestNetworkPassphrase)
+	assert.NoError(t, processor.ProcessTransaction(lcm, createTokenTransferTransaction(lcm, true)))
+	// only the fee of a failed transaction is transferred
+	assert.NoError(t, processor.ProcessTransaction(lcm, createTokenTransferTransaction(lcm, false)))
+	assert.NoError(t, processor.Flush(ctx, session))
+	batch.AssertExpectations(t)
+}
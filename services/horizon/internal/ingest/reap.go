@@ -229,6 +229,12 @@ func (r *Reaper) deleteBatch(ctx context.Context, batchStartSeq, batchEndSeq uin
 		return 0, errors.Wrap(err, "Error in DeleteRangeAll")
 	}
 
+	balanceChangesCount, err := r.historyQ.DeleteBalanceChangesRange(ctx, batchStart, batchEnd)
+	if err != nil {
+		return 0, errors.Wrap(err, "Error in DeleteBalanceChangesRange")
+	}
+	count += balanceChangesCount
+
 	err = r.historyQ.Commit()
 	if err != nil {
 		return 0, errors.Wrap(err, "Error in commit")
@@ -0,0 +1,104 @@
+package ingest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/stellar/go/ingest/ledgerbackend"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// rpcLedgerBackend streams ledgers from a Stellar RPC server.
+//
+// ledgerbackend.RPCLedgerBackend can only be prepared once and only returns
+// each ledger once, while the state machine prepares the backend again when
+// it restarts ingestion (ex. after an error in the resume state). To support
+// that, preparing a range not covered by the current backend replaces it
+// with a new backend.
+type rpcLedgerBackend struct {
+	newBackend func() ledgerbackend.LedgerBackend
+
+	// prepareLock serializes PrepareRange, which replaces the backend while holding it.
+	// lock only guards backend and prepared, so that the other methods don't wait for a
+	// blocked PrepareRange and Close can interrupt it.
+	prepareLock sync.Mutex
+	lock        sync.Mutex
+	backend     ledgerbackend.LedgerBackend
+	prepared    bool
+}
+
+func newRPCLedgerBackend(config RPCBackendConfig) *rpcLedgerBackend {
+	return &rpcLedgerBackend{
+		newBackend: func() ledgerbackend.LedgerBackend {
+			return ledgerbackend.NewRPCLedgerBackend(ledgerbackend.RPCLedgerBackendOptions{
+				RPCServerURL: config.RPCServerURL,
+				BufferSize:   config.BufferSize,
+			})
+		},
+	}
+}
+
+func (b *rpcLedgerBackend) current() ledgerbackend.LedgerBackend {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.backend == nil {
+		b.backend = b.newBackend()
+	}
+	return b.backend
+}
+
+func (b *rpcLedgerBackend) GetLatestLedgerSequence(ctx context.Context) (uint32, error) {
+	return b.current().GetLatestLedgerSequence(ctx)
+}
+
+func (b *rpcLedgerBackend) GetLedger(ctx context.Context, sequence uint32) (xdr.LedgerCloseMeta, error) {
+	return b.current().GetLedger(ctx, sequence)
+}
+
+func (b *rpcLedgerBackend) IsPrepared(ctx context.Context, ledgerRange ledgerbackend.Range) (bool, error) {
+	return b.current().IsPrepared(ctx, ledgerRange)
+}
+
+func (b *rpcLedgerBackend) PrepareRange(ctx context.Context, ledgerRange ledgerbackend.Range) error {
+	b.prepareLock.Lock()
+	defer b.prepareLock.Unlock()
+
+	backend := b.current()
+	prepared, err := backend.IsPrepared(ctx, ledgerRange)
+	if err != nil {
+		return err
+	}
+	if prepared {
+		return nil
+	}
+
+	// prepared is only set while holding prepareLock
+	if b.prepared {
+		if err = backend.Close(); err != nil {
+			return errors.Wrap(err, "could not close rpc ledger backend")
+		}
+		b.lock.Lock()
+		b.backend = nil
+		b.prepared = false
+		b.lock.Unlock()
+	}
+
+	if err = b.current().PrepareRange(ctx, ledgerRange); err != nil {
+		return err
+	}
+	b.lock.Lock()
+	b.prepared = true
+	b.lock.Unlock()
+	return nil
+}
+
+func (b *rpcLedgerBackend) Close() error {
+	b.lock.Lock()
+	backend := b.backend
+	b.lock.Unlock()
+	if backend == nil {
+		return nil
+	}
+	return backend.Close()
+}
@@ -0,0 +1,89 @@
+package ingest
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/stellar/go/ingest/ledgerbackend"
+)
+
+func TestRPCLedgerBackendPrepareRange(t *testing.T) {
+	ctx := context.Background()
+	first := &ledgerbackend.MockDatabaseBackend{}
+	second := &ledgerbackend.MockDatabaseBackend{}
+	backends := []ledgerbackend.LedgerBackend{first, second}
+	backend := &rpcLedgerBackend{
+		newBackend: func() ledgerbackend.LedgerBackend {
+			next := backends[0]
+			backends = backends[1:]
+			return next
+		},
+	}
+
+	first.On("IsPrepared", ctx, ledgerbackend.UnboundedRange(100)).Return(false, nil).Once()
+	first.On("PrepareRange", ctx, ledgerbackend.UnboundedRange(100)).Return(nil).Once()
+	assert.NoError(t, backend.PrepareRange(ctx, ledgerbackend.UnboundedRange(100)))
+
+	// the prepared backend keeps streaming ledgers
+	first.On("IsPrepared", ctx, ledgerbackend.UnboundedRange(101)).Return(true, nil).Once()
+	assert.NoError(t, backend.PrepareRange(ctx, ledgerbackend.UnboundedRange(101)))
+
+	// restarting from another ledger replaces the backend
+	first.On("IsPrepared", ctx, ledgerbackend.UnboundedRange(101)).Return(false, nil).Once()
+	first.On("Close").Return(nil).Once()
+	second.On("PrepareRange", ctx, ledgerbackend.UnboundedRange(101)).Return(nil).Once()
+	assert.NoError(t, backend.PrepareRange(ctx, ledgerbackend.UnboundedRange(101)))
+
+	second.On("Close").Return(nil).Once()
+	assert.NoError(t, backend.Close())
+
+	first.AssertExpectations(t)
+	second.AssertExpectations(t)
+	assert.Empty(t, backends)
+}
+
+func TestRPCLedgerBackendConcurrentPrepareRange(t *testing.T) {
+	ctx := context.Background()
+	var created []*ledgerbackend.MockDatabaseBackend
+	backend := &rpcLedgerBackend{
+		newBackend: func() ledgerbackend.LedgerBackend {
+			next := &ledgerbackend.MockDatabaseBackend{}
+			next.On("IsPrepared", ctx, mock.Anything).Return(false, nil)
+			next.On("PrepareRange", ctx, mock.Anything).Return(nil)
+			next.On("GetLatestLedgerSequence", ctx).Return(uint32(100), nil)
+			next.On("Close").Return(nil)
+			created = append(created, next)
+			return next
+		},
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := uint32(0); j < 10; j++ {
+				assert.NoError(t, backend.PrepareRange(ctx, ledgerbackend.UnboundedRange(100+j)))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				_, err := backend.GetLatestLedgerSequence(ctx)
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.NoError(t, backend.Close())
+
+	// every backend but the last one was closed when it was replaced
+	assert.Len(t, created, 40)
+	for _, b := range created {
+		b.AssertCalled(t, "Close")
+	}
+}
@@ -102,6 +102,9 @@ func initIngester(app *App) {
 		EnableExtendedLogLedgerStats:         app.config.IngestEnableExtendedLogLedgerStats,
 		RoundingSlippageFilter:               app.config.RoundingSlippageFilter,
 		SkipTxmeta:                           app.config.SkipTxmeta,
+		LedgerBackendType:                    app.config.IngestLedgerBackendType,
+		StorageBackendConfig:                 app.config.IngestStorageBackendConfig,
+		RPCBackendConfig:                     app.config.IngestRPCBackendConfig,
 		ReapConfig: ingest.ReapConfig{
 			Frequency:      app.config.ReapFrequency,
 			RetentionCount: uint32(app.config.HistoryRetentionCount),
@@ -233,3 +233,59 @@ func TestFilteringAssetAndAccountFilters(t *testing.T) {
 	_, err = itest.Client().TransactionDetail(txResp.Hash)
 	tt.NoError(err)
 }
+
+func TestFilteringOperationBlackList(t *testing.T) {
+	tt := assert.New(t)
+	const adminPort uint16 = 6000
+	itest := integration.NewTest(t, integration.Config{
+		HorizonIngestParameters: map[string]string{
+			"admin-port": strconv.Itoa(int(adminPort)),
+		},
+	})
+
+	_, accounts := itest.CreateAccounts(1, "10000")
+	destination := accounts[0]
+	enabled := true
+
+	// Setup a blacklisted operation type rule, force refresh of filter configs to be quick
+	filters.SetFilterConfigCheckIntervalSeconds(1)
+
+	expectedOperationFilter := hProtocol.OperationFilterConfig{
+		OperationTypes: []string{"manage_data"},
+		Mode:           "blacklist",
+		Enabled:        &enabled,
+	}
+	err := itest.AdminClient().SetIngestionOperationFilter(expectedOperationFilter)
+	tt.NoError(err)
+
+	operationFilter, err := itest.AdminClient().GetIngestionOperationFilter()
+	tt.NoError(err)
+
+	tt.ElementsMatch(expectedOperationFilter.OperationTypes, operationFilter.OperationTypes)
+	tt.Equal(expectedOperationFilter.Mode, operationFilter.Mode)
+	tt.Equal(expectedOperationFilter.Enabled, operationFilter.Enabled)
+
+	// Ensure the latest filter configs are reloaded by the ingestion state machine processor
+	time.Sleep(time.Duration(filters.GetFilterConfigCheckIntervalSeconds()) * time.Second)
+
+	// Make sure that a transaction with only blacklisted operations is not stored
+	txResp := itest.MustSubmitOperations(itest.MasterAccount(), itest.Master(),
+		&txnbuild.ManageData{
+			Name:  "test",
+			Value: []byte("value"),
+		},
+	)
+	_, err = itest.Client().TransactionDetail(txResp.Hash)
+	tt.True(horizonclient.IsNotFoundError(err))
+
+	// Make sure that a transaction with other operations is stored
+	txResp = itest.MustSubmitOperations(itest.MasterAccount(), itest.Master(),
+		&txnbuild.Payment{
+			Destination: destination.GetAccountID(),
+			Amount:      "10",
+			Asset:       txnbuild.NativeAsset{},
+		},
+	)
+	_, err = itest.Client().TransactionDetail(txResp.Hash)
+	tt.NoError(err)
+}
@@ -0,0 +1,51 @@
+package resourceadapter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stellar/go/amount"
+	protocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/services/horizon/internal/assets"
+	horizonContext "github.com/stellar/go/services/horizon/internal/context"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/support/render/hal"
+	"github.com/stellar/go/xdr"
+)
+
+// PopulateAccountBalances fills out the balances of an account as of the
+// given ledger.
+func PopulateAccountBalances(
+	ctx context.Context,
+	dest *protocol.AccountBalances,
+	accountID string,
+	ledger history.Ledger,
+	balances []history.AccountBalance,
+) (err error) {
+	dest.AccountID = accountID
+	dest.Ledger = ledger.Sequence
+	dest.LedgerCloseTime = ledger.ClosedAt
+	dest.Balances = make([]protocol.Balance, len(balances))
+	for i, row := range balances {
+		balance := &dest.Balances[i]
+		if row.AssetType == xdr.AssetTypeAssetTypePoolShare {
+			balance.Type = "liquidity_pool_shares"
+			balance.LiquidityPoolId = row.LiquidityPoolID
+		} else {
+			balance.Type, err = assets.String(row.AssetType)
+			if err != nil {
+				return err
+			}
+			balance.Code = row.AssetCode
+			balance.Issuer = row.AssetIssuer
+		}
+		balance.Balance = amount.StringFromInt64(row.Balance)
+	}
+
+	lb := hal.LinkBuilder{horizonContext.BaseURL(ctx)}
+	account := fmt.Sprintf("/accounts/%s", accountID)
+	dest.Links.Self = lb.Link(fmt.Sprintf("%s/balances?ledger=%d", account, ledger.Sequence))
+	dest.Links.Account = lb.Link(account)
+	dest.Links.Ledger = lb.Link(fmt.Sprintf("/ledgers/%d", ledger.Sequence))
+	return nil
+}
@@ -0,0 +1,32 @@
+package resourceadapter
+
+import (
+	"context"
+	"fmt"
+
+	protocol "github.com/stellar/go/protocols/horizon"
+	horizonContext "github.com/stellar/go/services/horizon/internal/context"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/support/render/hal"
+)
+
+// PopulateContractEvent fills out the details of a contract event using a row
+// from the history_contract_events table.
+func PopulateContractEvent(
+	ctx context.Context,
+	dest *protocol.ContractEvent,
+	row history.ContractEvent,
+) {
+	dest.ID = row.PagingToken()
+	dest.PT = row.PagingToken()
+	dest.TransactionHash = row.TransactionHash
+	dest.LedgerCloseTime = row.LedgerCloseTime
+	dest.Type = history.ContractEventTypeNames[row.Type]
+	dest.ContractID = row.ContractID.String
+	dest.Topic = row.Topics()
+	dest.Value = row.Value
+
+	lb := hal.LinkBuilder{horizonContext.BaseURL(ctx)}
+	dest.Links.Transaction = lb.Link("/transactions", row.TransactionHash)
+	dest.Links.Operation = lb.Link("/operations", fmt.Sprintf("%d", row.HistoryOperationID))
+}
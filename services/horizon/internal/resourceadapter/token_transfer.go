@@ -0,0 +1,43 @@
+package resourceadapter
+
+import (
+	"context"
+	"fmt"
+
+	protocol "github.com/stellar/go/protocols/horizon"
+	horizonContext "github.com/stellar/go/services/horizon/internal/context"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/support/render/hal"
+	"github.com/stellar/go/toid"
+)
+
+// PopulateTokenTransfer fills out the details of a token transfer using a row
+// from the history_token_transfers table.
+func PopulateTokenTransfer(
+	ctx context.Context,
+	dest *protocol.TokenTransfer,
+	row history.TokenTransfer,
+) {
+	dest.ID = row.PagingToken()
+	dest.PT = row.PagingToken()
+	dest.TransactionHash = row.TransactionHash
+	dest.LedgerCloseTime = row.LedgerCloseTime
+	dest.Type = history.TokenTransferTypeNames[row.Type]
+	dest.From = row.From.String
+	dest.To = row.To.String
+	dest.ToMuxedIDType = row.ToMuxedIDType.String
+	dest.ToMuxedID = row.ToMuxedID.String
+	dest.ContractID = row.ContractID
+	dest.AssetType = row.AssetType.String
+	dest.AssetCode = row.AssetCode.String
+	dest.AssetIssuer = row.AssetIssuer.String
+	dest.Amount = row.Amount
+
+	lb := hal.LinkBuilder{horizonContext.BaseURL(ctx)}
+	dest.Links.Transaction = lb.Link("/transactions", row.TransactionHash)
+	// fees are charged by transactions, not operations
+	if toid.Parse(row.HistoryOperationID).OperationOrder != 0 {
+		link := lb.Link("/operations", fmt.Sprintf("%d", row.HistoryOperationID))
+		dest.Links.Operation = &link
+	}
+}
@@ -1,13 +1,28 @@
 package compressxdr
 
 import (
+	"bytes"
+	"fmt"
 	"io"
+	"sort"
+	"strings"
+	"sync"
 
+	"github.com/klauspost/compress/gzip"
 	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
 )
 
 var DefaultCompressor = &ZstdCompressor{}
 
+// Names of the built-in codecs, as accepted by NewCompressor.
+const (
+	Zstd = "zstd"
+	Gzip = "gzip"
+	LZ4  = "lz4"
+	None = "none"
+)
+
 // Compressor represents a compression algorithm.
 type Compressor interface {
 	NewWriter(w io.Writer) (io.WriteCloser, error)
@@ -15,8 +30,112 @@ type Compressor interface {
 	Name() string
 }
 
+// CompressorOptions configures a Compressor created through the codec registry.
+type CompressorOptions struct {
+	// Level is the codec specific compression level, 0 selects the codec default.
+	Level int
+	// Dictionary is an optional zstd dictionary, either in the format produced by
+	// `zstd --train` or raw content. Objects compressed with a dictionary can only be
+	// read by a Compressor configured with the same dictionary. Other codecs ignore it,
+	// so readers can pass the same options whatever codec an object was written with.
+	Dictionary []byte
+}
+
+// CompressorFactory creates a Compressor from the given options.
+type CompressorFactory func(options CompressorOptions) (Compressor, error)
+
+type codec struct {
+	extension string
+	factory   CompressorFactory
+}
+
+var (
+	codecsLock sync.RWMutex
+	// codecs is keyed by codec name, codecsByExtension by the file extension
+	// written by the codec (the value returned by Compressor.Name()).
+	codecs            = map[string]codec{}
+	codecsByExtension = map[string]codec{}
+)
+
+func init() {
+	RegisterCodec(Zstd, DefaultCompressor.Name(), newZstdCompressor)
+	RegisterCodec(Gzip, GzipCompressor{}.Name(), newGzipCompressor)
+	RegisterCodec(LZ4, LZ4Compressor{}.Name(), newLZ4Compressor)
+	RegisterCodec(None, NoneCompressor{}.Name(), newNoneCompressor)
+
+	// Ledger files written by galexie before v23 use the "zstd" extension.
+	codecsByExtension["zstd"] = codecsByExtension[DefaultCompressor.Name()]
+}
+
+// RegisterCodec adds a codec to the registry. name is the value used in configuration
+// (e.g. DataStoreConfig.Compression) and extension is the file extension of objects
+// written by the codec, which must match the Name() of the compressors it creates.
+// Registering a name or extension twice replaces the previous codec.
+func RegisterCodec(name, extension string, factory CompressorFactory) {
+	codecsLock.Lock()
+	defer codecsLock.Unlock()
+
+	c := codec{extension: extension, factory: factory}
+	codecs[name] = c
+	codecsByExtension[extension] = c
+}
+
+// NewCompressor creates a Compressor for the codec registered under name.
+// An empty name selects zstd.
+func NewCompressor(name string, options CompressorOptions) (Compressor, error) {
+	if name == "" {
+		name = Zstd
+	}
+
+	codecsLock.RLock()
+	c, ok := codecs[name]
+	codecsLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown compression codec %q, supported codecs are: %s",
+			name, strings.Join(Codecs(), ", "))
+	}
+	return c.factory(options)
+}
+
+// NewCompressorFromExtension creates a Compressor able to read objects with the
+// given file extension (e.g. "zst" or "gz"). The legacy "zstd" extension is also
+// recognised, in which case the returned Compressor's Name() differs from extension.
+func NewCompressorFromExtension(extension string, options CompressorOptions) (Compressor, error) {
+	codecsLock.RLock()
+	c, ok := codecsByExtension[extension]
+	codecsLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no compression codec registered for file extension %q", extension)
+	}
+	return c.factory(options)
+}
+
+// Codecs returns the sorted names of all registered codecs.
+func Codecs() []string {
+	codecsLock.RLock()
+	defer codecsLock.RUnlock()
+
+	names := make([]string, 0, len(codecs))
+	for name := range codecs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // ZstdCompressor is an implementation of the Compressor interface for Zstd compression.
-type ZstdCompressor struct{}
+type ZstdCompressor struct {
+	// Level is the zstd compression level (1-22), 0 selects the encoder default.
+	Level      int
+	Dictionary []byte
+}
+
+func newZstdCompressor(options CompressorOptions) (Compressor, error) {
+	if options.Level < 0 || options.Level > 22 {
+		return nil, fmt.Errorf("invalid zstd compression level %d, must be between 1 and 22", options.Level)
+	}
+	return &ZstdCompressor{Level: options.Level, Dictionary: options.Dictionary}, nil
+}
 
 // GetName returns the name of the compression algorithm.
 func (z ZstdCompressor) Name() string {
@@ -25,14 +144,134 @@ func (z ZstdCompressor) Name() string {
 
 // NewWriter creates a new Zstd writer.
 func (z ZstdCompressor) NewWriter(w io.Writer) (io.WriteCloser, error) {
-	return zstd.NewWriter(w)
+	var options []zstd.EOption
+	if z.Level != 0 {
+		options = append(options, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(z.Level)))
+	}
+	if z.isRawDictionary() {
+		options = append(options, zstd.WithEncoderDictRaw(0, z.Dictionary))
+	} else if len(z.Dictionary) > 0 {
+		options = append(options, zstd.WithEncoderDict(z.Dictionary))
+	}
+	return zstd.NewWriter(w, options...)
 }
 
 // NewReader creates a new Zstd reader.
 func (z ZstdCompressor) NewReader(r io.Reader) (io.ReadCloser, error) {
-	zr, err := zstd.NewReader(r)
+	var options []zstd.DOption
+	if z.isRawDictionary() {
+		options = append(options, zstd.WithDecoderDictRaw(0, z.Dictionary))
+	} else if len(z.Dictionary) > 0 {
+		options = append(options, zstd.WithDecoderDicts(z.Dictionary))
+	}
+	zr, err := zstd.NewReader(r, options...)
 	if err != nil {
 		return nil, err
 	}
 	return zr.IOReadCloser(), err
 }
+
+// zstdDictMagic prefixes dictionaries in the zstd dictionary format.
+var zstdDictMagic = []byte{0x37, 0xa4, 0x30, 0xec}
+
+// isRawDictionary reports whether Dictionary is raw content rather than a trained dictionary.
+func (z ZstdCompressor) isRawDictionary() bool {
+	return len(z.Dictionary) > 0 && !bytes.HasPrefix(z.Dictionary, zstdDictMagic)
+}
+
+// GzipCompressor is an implementation of the Compressor interface for gzip compression.
+type GzipCompressor struct {
+	// Level is the gzip compression level (1-9), 0 selects the default level.
+	Level int
+}
+
+func newGzipCompressor(options CompressorOptions) (Compressor, error) {
+	if options.Level < 0 || options.Level > gzip.BestCompression {
+		return nil, fmt.Errorf("invalid gzip compression level %d, must be between 1 and 9", options.Level)
+	}
+	return &GzipCompressor{Level: options.Level}, nil
+}
+
+// Name returns the file extension of gzip compressed objects.
+func (g GzipCompressor) Name() string {
+	return "gz"
+}
+
+// NewWriter creates a new gzip writer.
+func (g GzipCompressor) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	level := g.Level
+	if level == 0 {
+		level = gzip.DefaultCompression
+	}
+	return gzip.NewWriterLevel(w, level)
+}
+
+// NewReader creates a new gzip reader.
+func (g GzipCompressor) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
+
+// LZ4Compressor is an implementation of the Compressor interface for LZ4 frame compression.
+type LZ4Compressor struct {
+	// Level is the lz4 compression level (1-9), 0 selects the fast default.
+	Level int
+}
+
+var lz4Levels = []lz4.CompressionLevel{
+	lz4.Fast, lz4.Level1, lz4.Level2, lz4.Level3, lz4.Level4,
+	lz4.Level5, lz4.Level6, lz4.Level7, lz4.Level8, lz4.Level9,
+}
+
+func newLZ4Compressor(options CompressorOptions) (Compressor, error) {
+	if options.Level < 0 || options.Level >= len(lz4Levels) {
+		return nil, fmt.Errorf("invalid lz4 compression level %d, must be between 1 and 9", options.Level)
+	}
+	return &LZ4Compressor{Level: options.Level}, nil
+}
+
+// Name returns the file extension of lz4 compressed objects.
+func (l LZ4Compressor) Name() string {
+	return "lz4"
+}
+
+// NewWriter creates a new lz4 writer.
+func (l LZ4Compressor) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	zw := lz4.NewWriter(w)
+	if err := zw.Apply(lz4.CompressionLevelOption(lz4Levels[l.Level])); err != nil {
+		return nil, err
+	}
+	return zw, nil
+}
+
+// NewReader creates a new lz4 reader.
+func (l LZ4Compressor) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return io.NopCloser(lz4.NewReader(r)), nil
+}
+
+// NoneCompressor is an implementation of the Compressor interface which stores data uncompressed.
+type NoneCompressor struct{}
+
+func newNoneCompressor(options CompressorOptions) (Compressor, error) {
+	return &NoneCompressor{}, nil
+}
+
+// Name returns the file extension of uncompressed objects.
+func (n NoneCompressor) Name() string {
+	return "none"
+}
+
+// NewWriter returns a writer which passes data through unchanged.
+func (n NoneCompressor) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return nopWriteCloser{w}, nil
+}
+
+// NewReader returns a reader which passes data through unchanged.
+func (n NoneCompressor) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return io.NopCloser(r), nil
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
@@ -0,0 +1,129 @@
+package compressxdr
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go/xdr"
+)
+
+func roundTrip(t *testing.T, writer, reader Compressor, data []byte) []byte {
+	var buf bytes.Buffer
+	w, err := writer.NewWriter(&buf)
+	require.NoError(t, err)
+	_, err = w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	r, err := reader.NewReader(&buf)
+	require.NoError(t, err)
+	defer r.Close()
+	decoded, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return decoded
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	data := bytes.Repeat([]byte("ledger close meta "), 1000)
+
+	for _, tc := range []struct {
+		name      string
+		level     int
+		extension string
+	}{
+		{Zstd, 0, "zst"},
+		{Zstd, 19, "zst"},
+		{Gzip, 0, "gz"},
+		{Gzip, 9, "gz"},
+		{LZ4, 0, "lz4"},
+		{LZ4, 9, "lz4"},
+		{None, 0, "none"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			compressor, err := NewCompressor(tc.name, CompressorOptions{Level: tc.level})
+			require.NoError(t, err)
+			require.Equal(t, tc.extension, compressor.Name())
+
+			reader, err := NewCompressorFromExtension(compressor.Name(), CompressorOptions{})
+			require.NoError(t, err)
+			require.Equal(t, data, roundTrip(t, compressor, reader, data))
+		})
+	}
+}
+
+func TestNewCompressorDefaultsToZstd(t *testing.T) {
+	compressor, err := NewCompressor("", CompressorOptions{})
+	require.NoError(t, err)
+	require.Equal(t, DefaultCompressor.Name(), compressor.Name())
+}
+
+func TestNewCompressorErrors(t *testing.T) {
+	_, err := NewCompressor("brotli", CompressorOptions{})
+	require.ErrorContains(t, err, `unknown compression codec "brotli"`)
+
+	_, err = NewCompressor(Gzip, CompressorOptions{Level: 10})
+	require.ErrorContains(t, err, "invalid gzip compression level")
+
+	_, err = NewCompressorFromExtension("bz2", CompressorOptions{})
+	require.ErrorContains(t, err, `no compression codec registered for file extension "bz2"`)
+}
+
+func TestLegacyZstdExtension(t *testing.T) {
+	compressor, err := NewCompressorFromExtension("zstd", CompressorOptions{})
+	require.NoError(t, err)
+	require.Equal(t, DefaultCompressor.Name(), compressor.Name())
+}
+
+func TestZstdDictionary(t *testing.T) {
+	// A raw content dictionary, anything that shares content with the payload will do.
+	dict := bytes.Repeat([]byte("stellar ledger "), 64)
+	data := bytes.Repeat([]byte("stellar ledger close meta "), 100)
+
+	withDict, err := NewCompressor(Zstd, CompressorOptions{Dictionary: dict})
+	require.NoError(t, err)
+	require.Equal(t, data, roundTrip(t, withDict, withDict, data))
+
+	var buf bytes.Buffer
+	w, err := withDict.NewWriter(&buf)
+	require.NoError(t, err)
+	_, err = w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	r, err := DefaultCompressor.NewReader(&buf)
+	require.NoError(t, err)
+	_, err = io.ReadAll(r)
+	require.Error(t, err)
+}
+
+func TestRegisterCodec(t *testing.T) {
+	RegisterCodec("test-none", "test", func(options CompressorOptions) (Compressor, error) {
+		return &NoneCompressor{}, nil
+	})
+	require.Contains(t, Codecs(), "test-none")
+
+	compressor, err := NewCompressorFromExtension("test", CompressorOptions{})
+	require.NoError(t, err)
+	require.IsType(t, &NoneCompressor{}, compressor)
+}
+
+func TestEncodeDecodeWithCodecs(t *testing.T) {
+	testData := createTestLedgerCloseMetaBatch(1000, 1005, 6)
+
+	for _, name := range []string{Zstd, Gzip, LZ4, None} {
+		compressor, err := NewCompressor(name, CompressorOptions{})
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		_, err = NewXDREncoder(compressor, testData).WriteTo(&buf)
+		require.NoError(t, err)
+
+		lcmBatch := xdr.LedgerCloseMetaBatch{}
+		_, err = NewXDRDecoder(compressor, &lcmBatch).ReadFrom(&buf)
+		require.NoError(t, err)
+		require.Equal(t, testData.StartSequence, lcmBatch.StartSequence)
+		require.Equal(t, testData.EndSequence, lcmBatch.EndSequence)
+	}
+}
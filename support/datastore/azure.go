@@ -0,0 +1,304 @@
+package datastore
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/streaming"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blockblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+
+	"github.com/stellar/go/support/log"
+	"github.com/stellar/go/support/storage"
+	"github.com/stellar/go/support/url"
+)
+
+// AzureBlobDataStore implements DataStore for Azure Blob Storage.
+type AzureBlobDataStore struct {
+	container *container.Client
+	prefix    string
+}
+
+// NewAzureBlobDataStore creates an AzureBlobDataStore from the datastore config.
+// See storage.NewAzureBlobClient for how credentials are resolved.
+func NewAzureBlobDataStore(ctx context.Context, datastoreConfig DataStoreConfig) (DataStore, error) {
+	destinationBucketPath, ok := datastoreConfig.Params["destination_bucket_path"]
+	if !ok {
+		return nil, errors.New("invalid Azure config, no destination_bucket_path")
+	}
+	accountName := datastoreConfig.Params["account_name"]
+	// account_url is optional, if not provided it is derived from account_name.
+	accountURL := datastoreConfig.Params["account_url"]
+
+	client, err := storage.NewAzureBlobClient(accountName, accountURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return FromAzureBlobClient(ctx, client, destinationBucketPath)
+}
+
+func FromAzureBlobClient(ctx context.Context, client *azblob.Client, bucketPath string) (DataStore, error) {
+	// append a scheme to enable usage of the url package reliably to
+	// get parse container name which is first path segment as URL.Host
+	azureContainerURL := fmt.Sprintf("azure://%s", bucketPath)
+	parsed, err := url.Parse(azureContainerURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// Inside a container, all blob names start _without_ the leading /
+	prefix := strings.TrimPrefix(parsed.Path, "/")
+	containerName := parsed.Host
+
+	log.Infof("creating Azure Blob client for container: %s, prefix: %s", containerName, prefix)
+	// Check the container exists
+	containerClient := client.ServiceClient().NewContainerClient(containerName)
+	if _, err := containerClient.GetProperties(ctx, nil); err != nil {
+		if bloberror.HasCode(err, bloberror.ContainerNotFound) {
+			return nil, fmt.Errorf("container '%s' does not exist (ContainerNotFound): %w", containerName, err)
+		}
+		return nil, fmt.Errorf("failed to retrieve container properties: %w", err)
+	}
+
+	return AzureBlobDataStore{container: containerClient, prefix: prefix}, nil
+}
+
+func (b AzureBlobDataStore) GetProperties(ctx context.Context, filePath string) (blob.GetPropertiesResponse, error) {
+	filePath = path.Join(b.prefix, filePath)
+	props, err := b.container.NewBlobClient(filePath).GetProperties(ctx, nil)
+	if err != nil {
+		if bloberror.HasCode(err, bloberror.BlobNotFound) {
+			return blob.GetPropertiesResponse{}, os.ErrNotExist
+		}
+		return blob.GetPropertiesResponse{}, err
+	}
+	return props, nil
+}
+
+// GetFileMetadata retrieves the metadata for the specified file in the Azure container.
+func (b AzureBlobDataStore) GetFileMetadata(ctx context.Context, filePath string) (map[string]string, error) {
+	props, err := b.GetProperties(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return fromAzureMetadata(props.Metadata), nil
+}
+
+// GetFileLastModified retrieves the last modified time of a file in the Azure container.
+func (b AzureBlobDataStore) GetFileLastModified(ctx context.Context, filePath string) (time.Time, error) {
+	props, err := b.GetProperties(ctx, filePath)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return *props.LastModified, nil
+}
+
+// GetFile retrieves a file from the Azure container.
+// If the blob has a Content-MD5 property the content is validated against it once fully read.
+func (b AzureBlobDataStore) GetFile(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	filePath = path.Join(b.prefix, filePath)
+	resp, err := b.container.NewBlobClient(filePath).DownloadStream(ctx, nil)
+	if err != nil {
+		if bloberror.HasCode(err, bloberror.BlobNotFound) {
+			return nil, os.ErrNotExist
+		}
+		return nil, fmt.Errorf("error retrieving file %s: %w", filePath, err)
+	}
+
+	log.Infof("File retrieved successfully: %s", filePath)
+	if len(resp.ContentMD5) == 0 {
+		return resp.Body, nil
+	}
+	return &md5ValidatingReader{ReadCloser: resp.Body, expected: resp.ContentMD5, digest: md5.New(), path: filePath}, nil
+}
+
+// PutFile uploads a file to the Azure container.
+func (b AzureBlobDataStore) PutFile(ctx context.Context, filePath string, in io.WriterTo, metaData map[string]string) error {
+	err := b.putFile(ctx, filePath, in, false, metaData)
+	if err != nil {
+		var respErr *azcore.ResponseError
+		if errors.As(err, &respErr) {
+			log.Errorf("Azure error: %s %d", respErr.ErrorCode, respErr.StatusCode)
+		}
+		return fmt.Errorf("error uploading file %s: %w", filePath, err)
+	}
+
+	log.Infof("File uploaded successfully: %s", filePath)
+	return nil
+}
+
+// PutFileIfNotExists uploads a file to the Azure container only if it doesn't already exist.
+func (b AzureBlobDataStore) PutFileIfNotExists(ctx context.Context, filePath string, in io.WriterTo, metaData map[string]string) (bool, error) {
+	err := b.putFile(ctx, filePath, in, true, metaData)
+	if err != nil {
+		if bloberror.HasCode(err, bloberror.BlobAlreadyExists, bloberror.ConditionNotMet) {
+			log.Infof("Precondition failed: %s already exists in the container", filePath)
+			return false, nil // Treat as success
+		}
+		var respErr *azcore.ResponseError
+		if errors.As(err, &respErr) {
+			log.Errorf("Azure error: %s %d", respErr.ErrorCode, respErr.StatusCode)
+		}
+		return false, fmt.Errorf("error uploading file %s: %w", filePath, err)
+	}
+
+	log.Infof("File uploaded successfully: %s", filePath)
+	return true, nil
+}
+
+// Exists checks if a file exists in the Azure container.
+func (b AzureBlobDataStore) Exists(ctx context.Context, filePath string) (bool, error) {
+	_, err := b.GetProperties(ctx, filePath)
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	return false, err
+}
+
+// Size retrieves the size of a file in the Azure container.
+func (b AzureBlobDataStore) Size(ctx context.Context, filePath string) (int64, error) {
+	props, err := b.GetProperties(ctx, filePath)
+	if err != nil {
+		return 0, err
+	}
+	return *props.ContentLength, nil
+}
+
+// Close does nothing for AzureBlobDataStore as it does not maintain a persistent connection.
+func (b AzureBlobDataStore) Close() error {
+	return nil
+}
+
+func (b AzureBlobDataStore) putFile(ctx context.Context, filePath string, in io.WriterTo, onlyIfFileDoesNotExist bool, metaData map[string]string) error {
+	filePath = path.Join(b.prefix, filePath)
+
+	buf := &bytes.Buffer{}
+	if _, err := in.WriteTo(buf); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", filePath, err)
+	}
+
+	// Storing the MD5 as a blob property lets the service validate the upload
+	// and lets GetFile validate downloads.
+	sum := md5.Sum(buf.Bytes())
+	options := &blockblob.UploadOptions{
+		Metadata:    toAzureMetadata(metaData),
+		HTTPHeaders: &blob.HTTPHeaders{BlobContentMD5: sum[:]},
+	}
+	if onlyIfFileDoesNotExist {
+		options.AccessConditions = &blob.AccessConditions{
+			ModifiedAccessConditions: &blob.ModifiedAccessConditions{IfNoneMatch: to.Ptr(azcore.ETagAny)},
+		}
+	}
+
+	_, err := b.container.NewBlockBlobClient(filePath).Upload(ctx, streaming.NopCloser(bytes.NewReader(buf.Bytes())), options)
+	return err
+}
+
+// ListFilePaths lists up to 'limit' file paths under the provided prefix.
+// Returned paths are absolute within the datastore (including the given prefix)
+// and ordered lexicographically ascending as provided by the backend.
+// If limit <= 0, implementations default to a cap of 1,000; values > 1,000 are capped to 1,000.
+func (b AzureBlobDataStore) ListFilePaths(ctx context.Context, prefix string, limit int) ([]string, error) {
+	var fullPrefix string
+
+	// When 'prefix' is empty, ensure the base prefix ends with a slash (e.g., "a/b/")
+	// so the query returns only objects within that directory, not similarly named paths like "a/b-1".
+	if prefix == "" {
+		fullPrefix = b.prefix
+		if fullPrefix != "" && !strings.HasSuffix(fullPrefix, "/") {
+			fullPrefix += "/"
+		}
+	} else {
+		// Join the caller-provided prefix with the datastore prefix
+		fullPrefix = path.Join(b.prefix, prefix)
+	}
+
+	remaining := limit
+	if remaining <= 0 || remaining > listFilePathsMaxLimit {
+		remaining = listFilePathsMaxLimit
+	}
+
+	// Azure returns blob names in lexicographic order
+	keys := make([]string, 0)
+	pager := b.container.NewListBlobsFlatPager(&container.ListBlobsFlatOptions{
+		Prefix:     to.Ptr(fullPrefix),
+		MaxResults: to.Ptr(int32(remaining)),
+	})
+	for remaining > 0 && pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range page.Segment.BlobItems {
+			// Return full path (including the configured prefix)
+			keys = append(keys, *item.Name)
+			remaining--
+			if remaining == 0 {
+				break
+			}
+		}
+	}
+	return keys, nil
+}
+
+// Azure metadata names must be valid C# identifiers, so the hyphens used in datastore
+// metadata keys (e.g. "start-ledger") are stored as underscores. Names also come back
+// from the service with HTTP header casing, so they are lower-cased when read.
+func toAzureMetadata(metaData map[string]string) map[string]*string {
+	if metaData == nil {
+		return nil
+	}
+	result := make(map[string]*string, len(metaData))
+	for k, v := range metaData {
+		result[strings.ReplaceAll(k, "-", "_")] = to.Ptr(v)
+	}
+	return result
+}
+
+func fromAzureMetadata(metaData map[string]*string) map[string]string {
+	result := make(map[string]string, len(metaData))
+	for k, v := range metaData {
+		if v == nil {
+			continue
+		}
+		result[strings.ReplaceAll(strings.ToLower(k), "_", "-")] = *v
+	}
+	return result
+}
+
+// md5ValidatingReader verifies the MD5 of the content once the underlying reader reaches EOF.
+type md5ValidatingReader struct {
+	io.ReadCloser
+	expected []byte
+	digest   hash.Hash
+	path     string
+}
+
+func (r *md5ValidatingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.digest.Write(p[:n])
+	if err == io.EOF {
+		if actual := r.digest.Sum(nil); !bytes.Equal(actual, r.expected) {
+			return n, fmt.Errorf("md5 mismatch for file %s: expected %x, got %x", r.path, r.expected, actual)
+		}
+	}
+	return n, err
+}
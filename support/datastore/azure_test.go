@@ -0,0 +1,405 @@
+package datastore
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/stretchr/testify/require"
+)
+
+const mockAzureAccount = "devstoreaccount1"
+
+// mockAzureBlob stores blob data and properties within the mock server.
+type mockAzureBlob struct {
+	body         []byte
+	metadata     map[string]string
+	contentMD5   []byte
+	lastModified time.Time
+}
+
+// mockAzureServer is a minimal Azurite-style stand-in for the Blob service, holding
+// a single in-memory container. Requests use path-style URLs: /<account>/<container>/<blob>.
+type mockAzureServer struct {
+	mu        sync.Mutex
+	container string
+	blobs     map[string]mockAzureBlob
+}
+
+func (s *mockAzureServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pathParts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 3)
+	if len(pathParts) < 2 || pathParts[0] != mockAzureAccount || pathParts[1] != s.container {
+		s.writeError(w, http.StatusNotFound, "ContainerNotFound")
+		return
+	}
+
+	if len(pathParts) == 2 {
+		// See https://learn.microsoft.com/en-us/rest/api/storageservices/list-blobs
+		if r.URL.Query().Get("comp") == "list" {
+			s.handleList(w, r)
+			return
+		}
+		// See https://learn.microsoft.com/en-us/rest/api/storageservices/get-container-properties
+		w.Header().Set("ETag", `"0x1"`)
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	name := pathParts[2]
+	switch r.Method {
+	case http.MethodHead:
+		// See https://learn.microsoft.com/en-us/rest/api/storageservices/get-blob-properties
+		blob, exists := s.blobs[name]
+		if !exists {
+			s.writeError(w, http.StatusNotFound, "BlobNotFound")
+			return
+		}
+		s.setBlobHeaders(w, blob)
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		// See https://learn.microsoft.com/en-us/rest/api/storageservices/get-blob
+		blob, exists := s.blobs[name]
+		if !exists {
+			s.writeError(w, http.StatusNotFound, "BlobNotFound")
+			return
+		}
+		s.setBlobHeaders(w, blob)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(blob.body)
+	case http.MethodPut:
+		// See https://learn.microsoft.com/en-us/rest/api/storageservices/put-blob
+		s.handlePut(w, r, name)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *mockAzureServer) handlePut(w http.ResponseWriter, r *http.Request, name string) {
+	if r.Header.Get("If-None-Match") == "*" {
+		if _, exists := s.blobs[name]; exists {
+			s.writeError(w, http.StatusConflict, "BlobAlreadyExists")
+			return
+		}
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	metadata := map[string]string{}
+	for k, v := range r.Header {
+		if strings.HasPrefix(strings.ToLower(k), "x-ms-meta-") {
+			metaName := k[len("x-ms-meta-"):]
+			if strings.Contains(metaName, "-") {
+				// metadata names must be valid C# identifiers
+				s.writeError(w, http.StatusBadRequest, "InvalidMetadata")
+				return
+			}
+			metadata[metaName] = v[0]
+		}
+	}
+
+	var contentMD5 []byte
+	if encoded := r.Header.Get("x-ms-blob-content-md5"); encoded != "" {
+		contentMD5, _ = base64.StdEncoding.DecodeString(encoded)
+		if sum := md5.Sum(body); !bytes.Equal(sum[:], contentMD5) {
+			s.writeError(w, http.StatusBadRequest, "Md5Mismatch")
+			return
+		}
+	}
+
+	s.blobs[name] = mockAzureBlob{
+		body:         body,
+		metadata:     metadata,
+		contentMD5:   contentMD5,
+		lastModified: time.Now(),
+	}
+	w.Header().Set("ETag", `"0x2"`)
+	w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+	w.WriteHeader(http.StatusCreated)
+}
+
+type mockAzureListResult struct {
+	XMLName    xml.Name            `xml:"EnumerationResults"`
+	Blobs      []mockAzureListItem `xml:"Blobs>Blob"`
+	NextMarker string              `xml:"NextMarker"`
+}
+
+type mockAzureListItem struct {
+	Name string `xml:"Name"`
+}
+
+func (s *mockAzureServer) handleList(w http.ResponseWriter, r *http.Request) {
+	prefix := r.URL.Query().Get("prefix")
+	marker := r.URL.Query().Get("marker")
+	maxResults := 5000
+	if v, err := strconv.Atoi(r.URL.Query().Get("maxresults")); err == nil {
+		maxResults = v
+	}
+
+	names := make([]string, 0)
+	for name := range s.blobs {
+		if strings.HasPrefix(name, prefix) && name >= marker {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	result := mockAzureListResult{}
+	if len(names) > maxResults {
+		result.NextMarker = names[maxResults]
+		names = names[:maxResults]
+	}
+	for _, name := range names {
+		result.Blobs = append(result.Blobs, mockAzureListItem{Name: name})
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(http.StatusOK)
+	_ = xml.NewEncoder(w).Encode(result)
+}
+
+func (s *mockAzureServer) setBlobHeaders(w http.ResponseWriter, blob mockAzureBlob) {
+	w.Header().Set("Content-Length", strconv.Itoa(len(blob.body)))
+	w.Header().Set("Last-Modified", blob.lastModified.UTC().Format(http.TimeFormat))
+	w.Header().Set("x-ms-blob-type", "BlockBlob")
+	for k, v := range blob.metadata {
+		// the real service returns metadata names with whatever casing net/http gives them
+		w.Header().Set("x-ms-meta-"+k, v)
+	}
+	if len(blob.contentMD5) > 0 {
+		w.Header().Set("Content-MD5", base64.StdEncoding.EncodeToString(blob.contentMD5))
+	}
+}
+
+func (s *mockAzureServer) writeError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("x-ms-error-code", code)
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_, _ = fmt.Fprintf(w, `<?xml version="1.0" encoding="utf-8"?><Error><Code>%s</Code><Message>%s</Message></Error>`, code, code)
+}
+
+// setupTestAzureDataStore is a helper function that initializes the mock server and an AzureBlobDataStore instance.
+func setupTestAzureDataStore(t *testing.T, ctx context.Context, bucketPath string, initBlobs map[string]mockAzureBlob) (DataStore, *mockAzureServer) {
+	t.Helper()
+	mockServer := &mockAzureServer{
+		container: "test-container",
+		blobs:     make(map[string]mockAzureBlob),
+	}
+	for name, blob := range initBlobs {
+		if blob.lastModified.IsZero() {
+			blob.lastModified = time.Now()
+		}
+		mockServer.blobs[name] = blob
+	}
+	server := httptest.NewServer(mockServer)
+	t.Cleanup(server.Close)
+
+	client, err := azblob.NewClientWithNoCredential(server.URL+"/"+mockAzureAccount, &azblob.ClientOptions{
+		ClientOptions: azcore.ClientOptions{Retry: policy.RetryOptions{MaxRetries: -1}},
+	})
+	require.NoError(t, err)
+
+	store, err := FromAzureBlobClient(ctx, client, bucketPath)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, store.Close())
+	})
+
+	return store, mockServer
+}
+
+func TestAzureMissingContainer(t *testing.T) {
+	server := httptest.NewServer(&mockAzureServer{container: "test-container", blobs: map[string]mockAzureBlob{}})
+	defer server.Close()
+
+	client, err := azblob.NewClientWithNoCredential(server.URL+"/"+mockAzureAccount, nil)
+	require.NoError(t, err)
+
+	_, err = FromAzureBlobClient(context.Background(), client, "other-container/objects")
+	require.ErrorContains(t, err, "ContainerNotFound")
+}
+
+func TestAzureExistsAndSize(t *testing.T) {
+	ctx := context.Background()
+	store, _ := setupTestAzureDataStore(t, ctx, "test-container/objects/testnet", nil)
+
+	content := []byte("inside the file")
+	require.NoError(t, store.PutFile(ctx, "file.txt", bytes.NewReader(content), nil))
+
+	exists, err := store.Exists(ctx, "file.txt")
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	exists, err = store.Exists(ctx, "missing-file.txt")
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	size, err := store.Size(ctx, "file.txt")
+	require.NoError(t, err)
+	require.Equal(t, int64(len(content)), size)
+
+	_, err = store.Size(ctx, "missing-file.txt")
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	lastModified, err := store.GetFileLastModified(ctx, "file.txt")
+	require.NoError(t, err)
+	require.NotZero(t, lastModified)
+}
+
+func TestAzurePutFileWithMetadata(t *testing.T) {
+	ctx := context.Background()
+	store, mockServer := setupTestAzureDataStore(t, ctx, "test-container/objects/testnet", nil)
+
+	metadata := MetaData{
+		StartLedger:          1234,
+		EndLedger:            1234,
+		StartLedgerCloseTime: 1234,
+		EndLedgerCloseTime:   1234,
+		ProtocolVersion:      21,
+		CoreVersion:          "v1.2.3",
+		NetworkPassPhrase:    "testnet",
+		CompressionType:      "zstd",
+		Version:              "1.0.0",
+	}
+	content := []byte("inside the file")
+	require.NoError(t, store.PutFile(ctx, "file.txt", bytes.NewReader(content), metadata.ToMap()))
+	require.Contains(t, mockServer.blobs, "objects/testnet/file.txt")
+
+	reader, err := store.GetFile(ctx, "file.txt")
+	require.NoError(t, err)
+	requireReaderContentEquals(t, reader, content)
+
+	stored, err := store.GetFileMetadata(ctx, "file.txt")
+	require.NoError(t, err)
+	require.Equal(t, metadata.ToMap(), stored)
+
+	_, err = store.GetFileMetadata(ctx, "missing-file.txt")
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestAzurePutFileIfNotExists(t *testing.T) {
+	ctx := context.Background()
+	store, _ := setupTestAzureDataStore(t, ctx, "test-container/objects/testnet", nil)
+
+	existingContent := []byte("inside the file")
+	require.NoError(t, store.PutFile(ctx, "file.txt", bytes.NewReader(existingContent), nil))
+
+	newContent := []byte("overwrite the file")
+	writerTo := &writerToRecorder{
+		WriterTo: bytes.NewReader(newContent),
+	}
+	ok, err := store.PutFileIfNotExists(ctx, "file.txt", writerTo, nil)
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Equal(t, int64(len(newContent)), writerTo.total)
+
+	reader, err := store.GetFile(ctx, "file.txt")
+	require.NoError(t, err)
+	requireReaderContentEquals(t, reader, existingContent)
+
+	ok, err = store.PutFileIfNotExists(ctx, "other-file.txt", bytes.NewReader(newContent), map[string]string{"start-ledger": "5"})
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	reader, err = store.GetFile(ctx, "other-file.txt")
+	require.NoError(t, err)
+	requireReaderContentEquals(t, reader, newContent)
+
+	metadata, err := store.GetFileMetadata(ctx, "other-file.txt")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"start-ledger": "5"}, metadata)
+}
+
+func TestAzureGetNonExistentFile(t *testing.T) {
+	ctx := context.Background()
+	store, _ := setupTestAzureDataStore(t, ctx, "test-container/objects/testnet", nil)
+
+	_, err := store.GetFile(ctx, "missing-file.txt")
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestAzureGetFileValidatesMD5(t *testing.T) {
+	ctx := context.Background()
+	store, mockServer := setupTestAzureDataStore(t, ctx, "test-container/objects/testnet", nil)
+
+	require.NoError(t, store.PutFile(ctx, "file.txt", bytes.NewReader([]byte("inside the file")), nil))
+
+	blob := mockServer.blobs["objects/testnet/file.txt"]
+	blob.body = []byte("corrupt the file")
+	mockServer.blobs["objects/testnet/file.txt"] = blob
+
+	reader, err := store.GetFile(ctx, "file.txt")
+	require.NoError(t, err)
+	_, err = io.ReadAll(reader)
+	require.ErrorContains(t, err, "md5 mismatch")
+	require.NoError(t, reader.Close())
+}
+
+func TestAzureListFilePaths(t *testing.T) {
+	ctx := context.Background()
+	store, _ := setupTestAzureDataStore(t, ctx, "test-container/objects/testnet", map[string]mockAzureBlob{
+		"objects/testnet-1/a": {body: []byte("1")},
+		"objects/testnet/a":   {body: []byte("1")},
+		"objects/testnet/b":   {body: []byte("1")},
+		"objects/testnet/c":   {body: []byte("1")},
+	})
+
+	paths, err := store.ListFilePaths(ctx, "", 2)
+	require.NoError(t, err)
+	require.Equal(t, []string{"objects/testnet/a", "objects/testnet/b"}, paths)
+}
+
+func TestAzureListFilePaths_WithPrefix(t *testing.T) {
+	ctx := context.Background()
+	store, _ := setupTestAzureDataStore(t, ctx, "test-container/objects/testnet", map[string]mockAzureBlob{
+		"objects/testnet/a/x": {body: []byte("1")},
+		"objects/testnet/a/y": {body: []byte("1")},
+		"objects/testnet/b/z": {body: []byte("1")},
+	})
+
+	paths, err := store.ListFilePaths(ctx, "a", 10)
+	require.NoError(t, err)
+	require.Equal(t, []string{"objects/testnet/a/x", "objects/testnet/a/y"}, paths)
+}
+
+func TestAzureListFilePaths_LimitDefaultAndCap(t *testing.T) {
+	ctx := context.Background()
+	init := map[string]mockAzureBlob{}
+	for i := 0; i < 1200; i++ {
+		init[fmt.Sprintf("objects/testnet/%04d", i)] = mockAzureBlob{body: []byte("1")}
+	}
+	store, _ := setupTestAzureDataStore(t, ctx, "test-container/objects/testnet", init)
+
+	// limit <= 0 defaults to 1000
+	paths, err := store.ListFilePaths(ctx, "", 0)
+	require.NoError(t, err)
+	require.Equal(t, 1000, len(paths))
+
+	// limit > 1000 is capped at 1000
+	paths, err = store.ListFilePaths(ctx, "", 5000)
+	require.NoError(t, err)
+	require.Equal(t, 1000, len(paths))
+}
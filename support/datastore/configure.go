@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -118,25 +119,34 @@ func compareManifests(expected, actual DatastoreManifest) error {
 	return nil
 }
 
-// PublishConfig ensures that a datastore manifest exists and matches the provided configuration.
-// If the manifest is missing, it creates one. Returns the manifest, whether it was created, and any error encountered.
-func PublishConfig(ctx context.Context, dataStore DataStore, cfg DataStoreConfig) (DatastoreManifest, bool, error) {
+// ValidateConfig checks that the datastore manifest, if any, matches the provided configuration,
+// without creating it. Returns the manifest, whether it exists, and any error encountered.
+func ValidateConfig(ctx context.Context, dataStore DataStore, cfg DataStoreConfig) (DatastoreManifest, bool, error) {
 	manifest, err := readManifest(ctx, dataStore, manifestFilename)
-	if err == nil {
-		// Validate that the existing manifest matches the provided config
-		if err = compareManifests(toDataStoreManifest(cfg), manifest); err != nil {
-			return manifest, false, fmt.Errorf(
-				"datastore config mismatch: %w. If the difference is in schema settings, "+
-					"either remove the schema section from your local config or update it to match the datastore", err)
-		}
-		return manifest, false, nil
+	if errors.Is(err, os.ErrNotExist) {
+		return DatastoreManifest{}, false, nil
 	}
-
-	// failed for a reason other than not existing
-	if !errors.Is(err, os.ErrNotExist) {
+	if err != nil {
 		return DatastoreManifest{}, false, fmt.Errorf("failed to read manifest: %w", err)
 	}
 
+	// Validate that the existing manifest matches the provided config
+	if err = compareManifests(toDataStoreManifest(cfg), manifest); err != nil {
+		return manifest, true, fmt.Errorf(
+			"datastore config mismatch: %w. If the difference is in schema settings, "+
+				"either remove the schema section from your local config or update it to match the datastore", err)
+	}
+	return manifest, true, nil
+}
+
+// PublishConfig ensures that a datastore manifest exists and matches the provided configuration.
+// If the manifest is missing, it creates one. Returns the manifest, whether it was created, and any error encountered.
+func PublishConfig(ctx context.Context, dataStore DataStore, cfg DataStoreConfig) (DatastoreManifest, bool, error) {
+	manifest, exists, err := ValidateConfig(ctx, dataStore, cfg)
+	if err != nil || exists {
+		return manifest, false, err
+	}
+
 	createdManifest, created, err := createManifest(ctx, dataStore, cfg)
 	if err != nil {
 		return DatastoreManifest{}, false, fmt.Errorf("failed to create manifest: %w", err)
@@ -207,3 +217,19 @@ func GetLedgerFileExtension(ctx context.Context, dataStore DataStore) (string, e
 
 	return "", ErrNoLedgerFiles
 }
+
+// FindLedgerObjectKey returns the object key of the file containing ledgerSeq, whichever
+// compression codec it was written with. It returns os.ErrNotExist if there is no such file.
+func FindLedgerObjectKey(ctx context.Context, dataStore DataStore, schema DataStoreSchema, ledgerSeq uint32) (string, error) {
+	prefix := schema.GetObjectKeyPrefixFromSequenceNumber(ledgerSeq)
+	files, err := dataStore.ListFilePaths(ctx, prefix, 1)
+	if err != nil {
+		return "", fmt.Errorf("failed to list ledger files with prefix %q: %w", prefix, err)
+	}
+	if len(files) == 0 {
+		return "", os.ErrNotExist
+	}
+
+	// Listed paths include the datastore's own prefix, keep only the file name.
+	return path.Join(path.Dir(prefix), path.Base(files[0])), nil
+}
@@ -67,6 +67,26 @@ func TestConfigureDatastore(t *testing.T) {
 		mockDataStore.AssertExpectations(t)
 	})
 
+	t.Run("validates without creating manifest", func(t *testing.T) {
+		mockDataStore := new(MockDataStore)
+		ctx := context.Background()
+
+		mockDataStore.On("GetFile", ctx, manifestFilename).Return(nil, os.ErrNotExist).Once()
+		_, ok, err := ValidateConfig(ctx, mockDataStore, defaultCfg)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		mismatchCfg := defaultCfg
+		mismatchCfg.Schema.LedgersPerFile = 1
+		mockDataStore.On("GetFile", ctx, manifestFilename).
+			Return(io.NopCloser(bytes.NewReader(configJSON)), nil).Once()
+		_, ok, err = ValidateConfig(ctx, mockDataStore, mismatchCfg)
+		require.ErrorContains(t, err, "datastore config mismatch")
+		require.True(t, ok)
+
+		mockDataStore.AssertExpectations(t)
+	})
+
 	t.Run("returns error if PutFile fails", func(t *testing.T) {
 		mockDataStore := new(MockDataStore)
 		ctx := context.Background()
@@ -329,3 +349,28 @@ func TestGetLedgerFileExtension(t *testing.T) {
 		})
 	}
 }
+
+func TestFindLedgerObjectKey(t *testing.T) {
+	ctx := context.Background()
+	schema := DataStoreSchema{LedgersPerFile: 1, FilesPerPartition: 2}
+
+	ds := new(MockDataStore)
+	ds.On("ListFilePaths", ctx, "FFFFFFFF--0-1/FFFFFFFE--1.xdr.", 1).
+		Return([]string{"bucket/prefix/FFFFFFFF--0-1/FFFFFFFE--1.xdr.gz"}, nil).Once()
+	key, err := FindLedgerObjectKey(ctx, ds, schema, 1)
+	require.NoError(t, err)
+	require.Equal(t, "FFFFFFFF--0-1/FFFFFFFE--1.xdr.gz", key)
+
+	ds.On("ListFilePaths", ctx, "FFFFFFFD--2-3/FFFFFFFD--2.xdr.", 1).Return([]string{}, nil).Once()
+	_, err = FindLedgerObjectKey(ctx, ds, schema, 2)
+	require.ErrorIs(t, err, os.ErrNotExist)
+	ds.AssertExpectations(t)
+
+	schema.FilesPerPartition = 1
+	ds = new(MockDataStore)
+	ds.On("ListFilePaths", ctx, "FFFFFFFE--1.xdr.", 1).Return([]string{"FFFFFFFE--1.xdr.lz4"}, nil).Once()
+	key, err = FindLedgerObjectKey(ctx, ds, schema, 1)
+	require.NoError(t, err)
+	require.Equal(t, "FFFFFFFE--1.xdr.lz4", key)
+	ds.AssertExpectations(t)
+}
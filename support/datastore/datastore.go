@@ -4,7 +4,10 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"os"
 	"time"
+
+	"github.com/stellar/go/support/compressxdr"
 )
 
 const (
@@ -18,7 +21,38 @@ type DataStoreConfig struct {
 	Params            map[string]string `toml:"params"`
 	Schema            DataStoreSchema   `toml:"schema"`
 	NetworkPassphrase string
-	Compression       string
+	// Compression is the name of the compressxdr codec used to write ledger files,
+	// zstd if empty. CompressionLevel and CompressionDictionaryPath are passed to the codec.
+	Compression               string `toml:"compression"`
+	CompressionLevel          int    `toml:"compression_level"`
+	CompressionDictionaryPath string `toml:"compression_dictionary_path"`
+}
+
+// NewCompressor creates the compressor configured by Compression, CompressionLevel
+// and CompressionDictionaryPath.
+func (cfg DataStoreConfig) NewCompressor() (compressxdr.Compressor, error) {
+	if cfg.CompressionDictionaryPath != "" && cfg.Compression != "" && cfg.Compression != compressxdr.Zstd {
+		return nil, fmt.Errorf("compression dictionaries are only supported by %s", compressxdr.Zstd)
+	}
+	options, err := cfg.CompressorOptions()
+	if err != nil {
+		return nil, err
+	}
+	return compressxdr.NewCompressor(cfg.Compression, options)
+}
+
+// CompressorOptions returns the codec options configured by CompressionLevel and
+// CompressionDictionaryPath, loading the dictionary file if one is set.
+func (cfg DataStoreConfig) CompressorOptions() (compressxdr.CompressorOptions, error) {
+	options := compressxdr.CompressorOptions{Level: cfg.CompressionLevel}
+	if cfg.CompressionDictionaryPath != "" {
+		dictionary, err := os.ReadFile(cfg.CompressionDictionaryPath)
+		if err != nil {
+			return options, fmt.Errorf("unable to read compression dictionary %q: %w", cfg.CompressionDictionaryPath, err)
+		}
+		options.Dictionary = dictionary
+	}
+	return options, nil
 }
 
 const listFilePathsMaxLimit = 1000
@@ -43,6 +77,10 @@ func NewDataStore(ctx context.Context, datastoreConfig DataStoreConfig) (DataSto
 		return NewGCSDataStore(ctx, datastoreConfig)
 	case "S3":
 		return NewS3DataStore(ctx, datastoreConfig)
+	case "Azure":
+		return NewAzureBlobDataStore(ctx, datastoreConfig)
+	case "Filesystem":
+		return NewFilesystemDataStore(ctx, datastoreConfig)
 
 	default:
 		return nil, fmt.Errorf("invalid datastore type %v, not supported", datastoreConfig.Type)
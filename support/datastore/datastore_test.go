@@ -11,3 +11,19 @@ func TestInvalidStore(t *testing.T) {
 	_, err := NewDataStore(context.Background(), DataStoreConfig{Type: "unknown"})
 	require.Error(t, err)
 }
+
+func TestDataStoreConfigNewCompressor(t *testing.T) {
+	compressor, err := DataStoreConfig{}.NewCompressor()
+	require.NoError(t, err)
+	require.Equal(t, "zst", compressor.Name())
+
+	compressor, err = DataStoreConfig{Compression: "gzip", CompressionLevel: 9}.NewCompressor()
+	require.NoError(t, err)
+	require.Equal(t, "gz", compressor.Name())
+
+	_, err = DataStoreConfig{Compression: "zstd", CompressionDictionaryPath: "/does/not/exist"}.NewCompressor()
+	require.ErrorContains(t, err, "unable to read compression dictionary")
+
+	_, err = DataStoreConfig{Compression: "lz4", CompressionDictionaryPath: "ledgers.dict"}.NewCompressor()
+	require.ErrorContains(t, err, "only supported by zstd")
+}
@@ -0,0 +1,333 @@
+package datastore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/stellar/go/support/log"
+)
+
+// filesystemInternalDir is the directory, relative to the datastore root, which holds
+// the metadata sidecar files and in-flight uploads. It is never returned by ListFilePaths.
+const filesystemInternalDir = ".datastore"
+
+// FilesystemDataStore implements DataStore on top of a local directory.
+//
+// Object metadata is kept in JSON sidecar files under <root>/.datastore/metadata and
+// uploads are staged under <root>/.datastore/tmp so that objects only ever become
+// visible once they have been fully written.
+type FilesystemDataStore struct {
+	root string
+}
+
+func NewFilesystemDataStore(ctx context.Context, datastoreConfig DataStoreConfig) (DataStore, error) {
+	destinationPath, ok := datastoreConfig.Params["destination_path"]
+	if !ok {
+		return nil, errors.New("invalid Filesystem config, no destination_path")
+	}
+
+	return FromFilesystemPath(destinationPath)
+}
+
+// FromFilesystemPath creates a FilesystemDataStore rooted at the given directory,
+// creating the directory if it does not exist yet.
+func FromFilesystemPath(root string) (DataStore, error) {
+	if root == "" {
+		return nil, errors.New("filesystem datastore root must not be empty")
+	}
+	root = filepath.Clean(root)
+
+	log.Infof("creating Filesystem datastore at: %s", root)
+	for _, dir := range []string{root, filepath.Join(root, filesystemInternalDir, "metadata"), filepath.Join(root, filesystemInternalDir, "tmp")} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+	}
+
+	return FilesystemDataStore{root: root}, nil
+}
+
+// objectPath maps a datastore key onto a path inside the root directory.
+// The key is cleaned as an absolute path first so that it can never escape the root.
+func (b FilesystemDataStore) objectPath(filePath string) string {
+	return filepath.Join(b.root, filepath.FromSlash(path.Clean("/"+filePath)))
+}
+
+func (b FilesystemDataStore) metadataPath(filePath string) string {
+	return filepath.Join(b.root, filesystemInternalDir, "metadata", filepath.FromSlash(path.Clean("/"+filePath))+".json")
+}
+
+func (b FilesystemDataStore) stat(filePath string) (os.FileInfo, error) {
+	info, err := os.Stat(b.objectPath(filePath))
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, os.ErrNotExist
+	}
+	return info, nil
+}
+
+// GetFileMetadata retrieves the metadata for the specified file in the local directory.
+func (b FilesystemDataStore) GetFileMetadata(ctx context.Context, filePath string) (map[string]string, error) {
+	if _, err := b.stat(filePath); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(b.metadataPath(filePath))
+	if errors.Is(err, os.ErrNotExist) {
+		// the object was written without metadata
+		return map[string]string{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading metadata for %s: %w", filePath, err)
+	}
+
+	metaData := map[string]string{}
+	if err := json.Unmarshal(data, &metaData); err != nil {
+		return nil, fmt.Errorf("invalid metadata for %s: %w", filePath, err)
+	}
+	return metaData, nil
+}
+
+// GetFileLastModified retrieves the last modified time of a file in the local directory.
+func (b FilesystemDataStore) GetFileLastModified(ctx context.Context, filePath string) (time.Time, error) {
+	info, err := b.stat(filePath)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
+
+// GetFile retrieves a file from the local directory.
+func (b FilesystemDataStore) GetFile(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	if _, err := b.stat(filePath); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, os.ErrNotExist
+		}
+		return nil, fmt.Errorf("error retrieving file %s: %w", filePath, err)
+	}
+
+	f, err := os.Open(b.objectPath(filePath))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, os.ErrNotExist
+		}
+		return nil, fmt.Errorf("error retrieving file %s: %w", filePath, err)
+	}
+	log.Debugf("File retrieved successfully: %s", filePath)
+	return f, nil
+}
+
+// PutFile writes a file to the local directory, replacing any existing file.
+func (b FilesystemDataStore) PutFile(ctx context.Context, filePath string, in io.WriterTo, metaData map[string]string) error {
+	if _, err := b.putFile(filePath, in, false, metaData); err != nil {
+		return fmt.Errorf("error uploading file %s: %w", filePath, err)
+	}
+	log.Infof("File uploaded successfully: %s", filePath)
+	return nil
+}
+
+// PutFileIfNotExists writes a file to the local directory only if it doesn't already exist.
+func (b FilesystemDataStore) PutFileIfNotExists(ctx context.Context, filePath string, in io.WriterTo, metaData map[string]string) (bool, error) {
+	ok, err := b.putFile(filePath, in, true, metaData)
+	if err != nil {
+		return false, fmt.Errorf("error uploading file %s: %w", filePath, err)
+	}
+	if !ok {
+		log.Infof("Precondition failed: %s already exists in the datastore", filePath)
+		return false, nil
+	}
+	log.Infof("File uploaded successfully: %s", filePath)
+	return true, nil
+}
+
+// Exists checks if a file exists in the local directory.
+func (b FilesystemDataStore) Exists(ctx context.Context, filePath string) (bool, error) {
+	_, err := b.stat(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Size retrieves the size of a file in the local directory.
+func (b FilesystemDataStore) Size(ctx context.Context, filePath string) (int64, error) {
+	info, err := b.stat(filePath)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// Close does nothing for FilesystemDataStore as it does not hold any resources.
+func (b FilesystemDataStore) Close() error {
+	return nil
+}
+
+// putFile stages the content and metadata in the tmp directory and then moves them into place.
+// When onlyIfFileDoesNotExist is set the object is published with a hard link, which fails
+// atomically if the destination already exists; in that case false is returned.
+func (b FilesystemDataStore) putFile(filePath string, in io.WriterTo, onlyIfFileDoesNotExist bool, metaData map[string]string) (bool, error) {
+	dest := b.objectPath(filePath)
+	if dest == b.root {
+		return false, errors.New("invalid empty file path")
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return false, err
+	}
+
+	tmpData, err := b.writeTemp(func(w io.Writer) error {
+		_, err := in.WriteTo(w)
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(tmpData)
+
+	encodedMetaData, err := json.Marshal(metaData)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	tmpMetaData, err := b.writeTemp(func(w io.Writer) error {
+		_, err := w.Write(encodedMetaData)
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(tmpMetaData)
+
+	metaDataDest := b.metadataPath(filePath)
+	if err := os.MkdirAll(filepath.Dir(metaDataDest), 0755); err != nil {
+		return false, err
+	}
+
+	if onlyIfFileDoesNotExist {
+		if err := os.Link(tmpData, dest); err != nil {
+			if errors.Is(err, os.ErrExist) {
+				return false, nil
+			}
+			return false, err
+		}
+	} else if err := os.Rename(tmpData, dest); err != nil {
+		return false, err
+	}
+
+	if err := os.Rename(tmpMetaData, metaDataDest); err != nil {
+		return false, fmt.Errorf("failed to write metadata: %w", err)
+	}
+	return true, nil
+}
+
+// writeTemp creates a new file in the tmp directory, fills it using write and syncs it to disk.
+func (b FilesystemDataStore) writeTemp(write func(io.Writer) error) (string, error) {
+	f, err := os.CreateTemp(filepath.Join(b.root, filesystemInternalDir, "tmp"), "upload-*")
+	if err != nil {
+		return "", err
+	}
+	name := f.Name()
+
+	if err = write(f); err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(name)
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+	return name, nil
+}
+
+// ListFilePaths lists up to 'limit' file paths under the provided prefix.
+// Returned paths are absolute within the datastore (including the given prefix)
+// and ordered lexicographically ascending, matching the GCS and S3 implementations.
+// If limit <= 0, implementations default to a cap of 1,000; values > 1,000 are capped to 1,000.
+func (b FilesystemDataStore) ListFilePaths(ctx context.Context, prefix string, limit int) ([]string, error) {
+	// Match the object store semantics where 'prefix' is a raw key prefix, not a directory.
+	keyPrefix := ""
+	if prefix != "" {
+		keyPrefix = strings.TrimPrefix(path.Clean("/"+prefix), "/")
+	}
+
+	remaining := limit
+	if remaining <= 0 || remaining > listFilePathsMaxLimit {
+		remaining = listFilePathsMaxLimit
+	}
+
+	basePath := filepath.ToSlash(b.root)
+	keys := make([]string, 0)
+	_, err := b.walkSorted("", keyPrefix, func(key string) bool {
+		// Return full path (including the configured root)
+		keys = append(keys, path.Join(basePath, key))
+		remaining--
+		return remaining > 0
+	})
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// walkSorted visits all files under dir whose key starts with keyPrefix, in lexicographic key order.
+// Object stores compare whole keys, so a directory "a" must sort as "a/" relative to its siblings
+// (e.g. "a-1" comes before "a/x"). visit returns false to stop the walk, in which case
+// walkSorted returns false as well.
+func (b FilesystemDataStore) walkSorted(dir, keyPrefix string, visit func(key string) bool) (bool, error) {
+	entries, err := os.ReadDir(filepath.Join(b.root, filepath.FromSlash(dir)))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return true, nil
+		}
+		return false, err
+	}
+
+	type entry struct {
+		key   string
+		isDir bool
+	}
+	sorted := make([]entry, 0, len(entries))
+	for _, e := range entries {
+		key := path.Join(dir, e.Name())
+		if key == filesystemInternalDir {
+			continue
+		}
+		if e.IsDir() {
+			key += "/"
+		}
+		sorted = append(sorted, entry{key: key, isDir: e.IsDir()})
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].key < sorted[j].key })
+
+	for _, e := range sorted {
+		if e.isDir {
+			// only descend if some key under this directory could match the prefix
+			if !strings.HasPrefix(e.key, keyPrefix) && !strings.HasPrefix(keyPrefix, e.key) {
+				continue
+			}
+			more, err := b.walkSorted(strings.TrimSuffix(e.key, "/"), keyPrefix, visit)
+			if err != nil || !more {
+				return more, err
+			}
+			continue
+		}
+		if !strings.HasPrefix(e.key, keyPrefix) {
+			continue
+		}
+		if !visit(e.key) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,206 @@
+package datastore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newFilesystemTestStore(t *testing.T) (DataStore, string) {
+	root := t.TempDir()
+	store, err := NewDataStore(context.Background(), DataStoreConfig{
+		Type:   "Filesystem",
+		Params: map[string]string{"destination_path": root},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, store.Close())
+	})
+	return store, filepath.ToSlash(root)
+}
+
+func TestFilesystemMissingConfig(t *testing.T) {
+	_, err := NewDataStore(context.Background(), DataStoreConfig{Type: "Filesystem"})
+	require.ErrorContains(t, err, "no destination_path")
+}
+
+func TestFilesystemExistsAndSize(t *testing.T) {
+	store, _ := newFilesystemTestStore(t)
+	ctx := context.Background()
+	content := []byte("inside the file")
+
+	require.NoError(t, store.PutFile(ctx, "dir/file.txt", bytes.NewReader(content), nil))
+
+	exists, err := store.Exists(ctx, "dir/file.txt")
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	exists, err = store.Exists(ctx, "missing-file.txt")
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	// directories are not objects
+	exists, err = store.Exists(ctx, "dir")
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	size, err := store.Size(ctx, "dir/file.txt")
+	require.NoError(t, err)
+	require.Equal(t, int64(len(content)), size)
+
+	_, err = store.Size(ctx, "missing-file.txt")
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestFilesystemPutFileWithMetadata(t *testing.T) {
+	store, _ := newFilesystemTestStore(t)
+	ctx := context.Background()
+
+	metaData := MetaData{
+		StartLedger:          1234,
+		EndLedger:            1234,
+		StartLedgerCloseTime: 1234,
+		EndLedgerCloseTime:   1234,
+		ProtocolVersion:      21,
+		CoreVersion:          "v1.2.3",
+		NetworkPassPhrase:    "testnet",
+		CompressionType:      "zstd",
+		Version:              "1.0.0",
+	}
+	require.NoError(t, store.PutFile(ctx, "file.txt", bytes.NewReader([]byte("v1")), metaData.ToMap()))
+
+	reader, err := store.GetFile(ctx, "file.txt")
+	require.NoError(t, err)
+	requireReaderContentEquals(t, reader, []byte("v1"))
+
+	stored, err := store.GetFileMetadata(ctx, "file.txt")
+	require.NoError(t, err)
+	require.Equal(t, metaData.ToMap(), stored)
+
+	// PutFile overwrites both the content and the metadata
+	require.NoError(t, store.PutFile(ctx, "file.txt", bytes.NewReader([]byte("v2")), map[string]string{"a": "b"}))
+	reader, err = store.GetFile(ctx, "file.txt")
+	require.NoError(t, err)
+	requireReaderContentEquals(t, reader, []byte("v2"))
+
+	stored, err = store.GetFileMetadata(ctx, "file.txt")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"a": "b"}, stored)
+
+	_, err = store.GetFileMetadata(ctx, "missing-file.txt")
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = store.GetFileLastModified(ctx, "file.txt")
+	require.NoError(t, err)
+	_, err = store.GetFileLastModified(ctx, "missing-file.txt")
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestFilesystemPutFileIfNotExists(t *testing.T) {
+	store, _ := newFilesystemTestStore(t)
+	ctx := context.Background()
+
+	ok, err := store.PutFileIfNotExists(ctx, "a/b/file.txt", bytes.NewReader([]byte("first")), map[string]string{"n": "1"})
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = store.PutFileIfNotExists(ctx, "a/b/file.txt", bytes.NewReader([]byte("second")), map[string]string{"n": "2"})
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	reader, err := store.GetFile(ctx, "a/b/file.txt")
+	require.NoError(t, err)
+	requireReaderContentEquals(t, reader, []byte("first"))
+
+	stored, err := store.GetFileMetadata(ctx, "a/b/file.txt")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"n": "1"}, stored)
+}
+
+func TestFilesystemGetNonExistentFile(t *testing.T) {
+	store, _ := newFilesystemTestStore(t)
+
+	_, err := store.GetFile(context.Background(), "missing-file.txt")
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestFilesystemPathsCannotEscapeRoot(t *testing.T) {
+	store, root := newFilesystemTestStore(t)
+	ctx := context.Background()
+
+	require.NoError(t, store.PutFile(ctx, "../../escaped.txt", bytes.NewReader([]byte("1")), nil))
+	_, err := os.Stat(filepath.Join(filepath.FromSlash(root), "escaped.txt"))
+	require.NoError(t, err)
+}
+
+func TestFilesystemListFilePaths(t *testing.T) {
+	store, root := newFilesystemTestStore(t)
+	ctx := context.Background()
+
+	for _, name := range []string{"c", "a/y", "a/x", "a-1", "b", ".config.json"} {
+		require.NoError(t, store.PutFile(ctx, name, bytes.NewReader([]byte("1")), map[string]string{"k": "v"}))
+	}
+
+	// keys are ordered as full strings, like GCS and S3, so "a-1" sorts before "a/x"
+	paths, err := store.ListFilePaths(ctx, "", 0)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		path.Join(root, ".config.json"),
+		path.Join(root, "a-1"),
+		path.Join(root, "a/x"),
+		path.Join(root, "a/y"),
+		path.Join(root, "b"),
+		path.Join(root, "c"),
+	}, paths)
+
+	paths, err = store.ListFilePaths(ctx, "", 2)
+	require.NoError(t, err)
+	require.Equal(t, []string{path.Join(root, ".config.json"), path.Join(root, "a-1")}, paths)
+
+	paths, err = store.ListFilePaths(ctx, "a", 3)
+	require.NoError(t, err)
+	require.Equal(t, []string{path.Join(root, "a-1"), path.Join(root, "a/x"), path.Join(root, "a/y")}, paths)
+
+	paths, err = store.ListFilePaths(ctx, "a/x", 10)
+	require.NoError(t, err)
+	require.Equal(t, []string{path.Join(root, "a/x")}, paths)
+
+	paths, err = store.ListFilePaths(ctx, "missing", 10)
+	require.NoError(t, err)
+	require.Empty(t, paths)
+}
+
+func TestFilesystemListFilePaths_LimitDefaultAndCap(t *testing.T) {
+	store, _ := newFilesystemTestStore(t)
+	ctx := context.Background()
+
+	for i := 0; i < 1200; i++ {
+		require.NoError(t, store.PutFile(ctx, fmt.Sprintf("%04d", i), bytes.NewReader([]byte("1")), nil))
+	}
+
+	paths, err := store.ListFilePaths(ctx, "", 0)
+	require.NoError(t, err)
+	require.Equal(t, 1000, len(paths))
+
+	paths, err = store.ListFilePaths(ctx, "", 5000)
+	require.NoError(t, err)
+	require.Equal(t, 1000, len(paths))
+}
+
+func TestFilesystemLedgerFileExtension(t *testing.T) {
+	store, _ := newFilesystemTestStore(t)
+	ctx := context.Background()
+
+	schema := DataStoreSchema{LedgersPerFile: 1, FilesPerPartition: 10}
+	require.NoError(t, store.PutFile(ctx, schema.GetObjectKeyFromSequenceNumber(5), bytes.NewReader([]byte("1")), nil))
+
+	ext, err := GetLedgerFileExtension(ctx, store)
+	require.NoError(t, err)
+	require.Equal(t, "zst", ext)
+}
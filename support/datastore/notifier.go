@@ -0,0 +1,174 @@
+package datastore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/stellar/go/support/log"
+)
+
+// latestLedgerFilename is the pointer object holding the latest ledger written to a data store.
+const latestLedgerFilename = ".latest-ledger.json"
+
+// ledgerFileRangeRe captures the ledger range of a ledger file name, e.g. "FFFFFFC0--64-127.xdr.zst".
+var ledgerFileRangeRe = regexp.MustCompile(`^[0-9A-F]{8}--([0-9]+)(?:-([0-9]+))?\.xdr\.`)
+
+// LedgerNotifier notifies the readers of a DataStore when new ledger files are written to it,
+// so they don't need to poll the data store for the next file.
+type LedgerNotifier interface {
+	// Subscribe calls notify with the sequence of the latest ledger written to the data store
+	// whenever it advances, until ctx is done. Notifications may be missed, e.g. while the
+	// underlying notification service is unavailable, so readers must keep polling as a fallback.
+	Subscribe(ctx context.Context, notify func(latestLedger uint32))
+}
+
+type latestLedgerPointer struct {
+	Ledger uint32 `json:"ledger"`
+}
+
+// WriteLatestLedger updates the pointer object of the data store to the latest ledger written to it.
+func WriteLatestLedger(ctx context.Context, dataStore DataStore, ledgerSeq uint32) error {
+	content, err := json.Marshal(latestLedgerPointer{Ledger: ledgerSeq})
+	if err != nil {
+		return err
+	}
+	if err = dataStore.PutFile(ctx, latestLedgerFilename, bytes.NewReader(content), nil); err != nil {
+		return fmt.Errorf("failed to write latest ledger pointer: %w", err)
+	}
+	return nil
+}
+
+// ReadLatestLedger returns the ledger of the data store's pointer object,
+// os.ErrNotExist is returned if the pointer has never been written.
+func ReadLatestLedger(ctx context.Context, dataStore DataStore) (uint32, error) {
+	reader, err := dataStore.GetFile(ctx, latestLedgerFilename)
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read latest ledger pointer: %w", err)
+	}
+	var pointer latestLedgerPointer
+	if err = json.Unmarshal(content, &pointer); err != nil {
+		return 0, fmt.Errorf("failed to decode latest ledger pointer: %w", err)
+	}
+	return pointer.Ledger, nil
+}
+
+// LatestLedgerPointerNotifier is a LedgerNotifier which polls the small pointer object
+// updated with WriteLatestLedger by the writer of the data store, it is much cheaper to poll
+// frequently than the ledger files themselves.
+type LatestLedgerPointerNotifier struct {
+	dataStore    DataStore
+	pollInterval time.Duration
+}
+
+// NewLatestLedgerPointerNotifier returns a LatestLedgerPointerNotifier reading the
+// pointer object of dataStore every pollInterval.
+func NewLatestLedgerPointerNotifier(dataStore DataStore, pollInterval time.Duration) *LatestLedgerPointerNotifier {
+	return &LatestLedgerPointerNotifier{
+		dataStore:    dataStore,
+		pollInterval: pollInterval,
+	}
+}
+
+func (n *LatestLedgerPointerNotifier) Subscribe(ctx context.Context, notify func(latestLedger uint32)) {
+	ticker := time.NewTicker(n.pollInterval)
+	defer ticker.Stop()
+
+	var latest uint32
+	for {
+		ledgerSeq, err := ReadLatestLedger(ctx, n.dataStore)
+		switch {
+		case err == nil:
+			if ledgerSeq > latest {
+				latest = ledgerSeq
+				notify(latest)
+			}
+		case errors.Is(err, os.ErrNotExist) || ctx.Err() != nil:
+		default:
+			log.Warnf("Unable to read latest ledger pointer: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ObjectCreatedNotifier is a LedgerNotifier relaying object created events, e.g. from
+// Google Cloud Storage Pub/Sub notifications, S3 event notifications or Azure Event Grid,
+// to the readers of a data store. The event subscription calls ObjectCreated.
+type ObjectCreatedNotifier struct {
+	lock        sync.Mutex
+	latest      uint32
+	nextID      int
+	subscribers map[int]func(latestLedger uint32)
+}
+
+// NewObjectCreatedNotifier returns an ObjectCreatedNotifier without subscribers.
+func NewObjectCreatedNotifier() *ObjectCreatedNotifier {
+	return &ObjectCreatedNotifier{
+		subscribers: map[int]func(latestLedger uint32){},
+	}
+}
+
+// ObjectCreated notifies the subscribers if objectKey is a ledger file ending after the
+// latest ledger notified so far, other objects are ignored.
+func (n *ObjectCreatedNotifier) ObjectCreated(objectKey string) {
+	matches := ledgerFileRangeRe.FindStringSubmatch(path.Base(objectKey))
+	if matches == nil {
+		return
+	}
+	end := matches[2]
+	if end == "" {
+		end = matches[1]
+	}
+	ledgerSeq, err := strconv.ParseUint(end, 10, 32)
+	if err != nil {
+		return
+	}
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if uint32(ledgerSeq) <= n.latest {
+		return
+	}
+	n.latest = uint32(ledgerSeq)
+	for _, notify := range n.subscribers {
+		notify(n.latest)
+	}
+}
+
+func (n *ObjectCreatedNotifier) Subscribe(ctx context.Context, notify func(latestLedger uint32)) {
+	n.lock.Lock()
+	id := n.nextID
+	n.nextID++
+	n.subscribers[id] = notify
+	// catch up with the objects created before subscribing
+	if n.latest > 0 {
+		notify(n.latest)
+	}
+	n.lock.Unlock()
+
+	<-ctx.Done()
+
+	n.lock.Lock()
+	delete(n.subscribers, id)
+	n.lock.Unlock()
+}
@@ -0,0 +1,91 @@
+package datastore
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatestLedgerPointer(t *testing.T) {
+	store, _ := newFilesystemTestStore(t)
+	ctx := context.Background()
+
+	_, err := ReadLatestLedger(ctx, store)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	require.NoError(t, WriteLatestLedger(ctx, store, 127))
+	latest, err := ReadLatestLedger(ctx, store)
+	require.NoError(t, err)
+	require.Equal(t, uint32(127), latest)
+
+	require.NoError(t, WriteLatestLedger(ctx, store, 191))
+	latest, err = ReadLatestLedger(ctx, store)
+	require.NoError(t, err)
+	require.Equal(t, uint32(191), latest)
+}
+
+func TestLatestLedgerPointerNotifier(t *testing.T) {
+	store, _ := newFilesystemTestStore(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	notifications := make(chan uint32, 10)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		NewLatestLedgerPointerNotifier(store, time.Millisecond).Subscribe(ctx, func(latestLedger uint32) {
+			notifications <- latestLedger
+		})
+	}()
+
+	require.NoError(t, WriteLatestLedger(ctx, store, 63))
+	require.Equal(t, uint32(63), <-notifications)
+	require.NoError(t, WriteLatestLedger(ctx, store, 127))
+	require.Equal(t, uint32(127), <-notifications)
+
+	cancel()
+	<-done
+	require.Empty(t, notifications)
+}
+
+func TestObjectCreatedNotifier(t *testing.T) {
+	notifier := NewObjectCreatedNotifier()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var notifications []uint32
+	done := make(chan struct{})
+	subscribed := make(chan struct{})
+	go func() {
+		defer close(done)
+		notifier.Subscribe(ctx, func(latestLedger uint32) {
+			if latestLedger == 1 {
+				close(subscribed)
+				return
+			}
+			notifications = append(notifications, latestLedger)
+		})
+	}()
+	// the subscriber is caught up with the objects created before subscribing
+	notifier.ObjectCreated("FFFFFFFF--0-63999/FFFFFFFE--1.xdr.zst")
+	<-subscribed
+
+	notifier.ObjectCreated("FFFFFFFF--0-63999/FFFFFFBF--64-127.xdr.zst")
+	notifier.ObjectCreated("bucket/FFFFFFFF--0-63999/FFFFFF7F--128-191.xdr.gz")
+	// older files, files of the manifest and of other tools are ignored
+	notifier.ObjectCreated("FFFFFFFF--0-63999/FFFFFFFF--0-63.xdr.zst")
+	notifier.ObjectCreated(".config.json")
+	notifier.ObjectCreated(".latest-ledger.json")
+	notifier.ObjectCreated("FFFFFFFF--0-63999/not-a-ledger-file.txt")
+	notifier.ObjectCreated("FFFFFFFF--0-63999/FFFFFF3F--192.xdr.zst")
+
+	cancel()
+	<-done
+	require.Equal(t, []uint32{127, 191, 192}, notifications)
+
+	// unsubscribed once ctx is done
+	notifier.ObjectCreated("FFFFFFFF--0-63999/FFFFFF3E--193.xdr.zst")
+	require.Equal(t, []uint32{127, 191, 192}, notifications)
+}
@@ -12,6 +12,12 @@ type MetaData struct {
 	NetworkPassPhrase    string
 	CompressionType      string
 	Version              string
+	// BatchSHA256 is the hex encoded SHA-256 of the uncompressed LedgerCloseMetaBatch XDR.
+	BatchSHA256 string
+	// StartLedgerPreviousHash and EndLedgerHash are the hex encoded hash chain
+	// endpoints of the batch, used to check continuity between consecutive files.
+	StartLedgerPreviousHash string
+	EndLedgerHash           string
 }
 
 func (m MetaData) ToMap() map[string]string {
@@ -25,6 +31,9 @@ func (m MetaData) ToMap() map[string]string {
 		"network-passphrase":      m.NetworkPassPhrase,
 		"compression-type":        m.CompressionType,
 		"version":                 m.Version,
+		"batch-sha256":            m.BatchSHA256,
+		"start-ledger-prev-hash":  m.StartLedgerPreviousHash,
+		"end-ledger-hash":         m.EndLedgerHash,
 	}
 }
 func NewMetaDataFromMap(data map[string]string) (MetaData, error) {
@@ -74,6 +83,9 @@ func NewMetaDataFromMap(data map[string]string) (MetaData, error) {
 	metaData.NetworkPassPhrase = data["network-passphrase"]
 	metaData.CompressionType = data["compression-type"]
 	metaData.Version = data["version"]
+	metaData.BatchSHA256 = data["batch-sha256"]
+	metaData.StartLedgerPreviousHash = data["start-ledger-prev-hash"]
+	metaData.EndLedgerHash = data["end-ledger-hash"]
 
 	return metaData, nil
 }
@@ -25,6 +25,8 @@ func TestMetaDataToMap(t *testing.T) {
 				NetworkPassPhrase:    "testnet passphrase",
 				CompressionType:      "gzip",
 				Version:              "1.0.0",
+				BatchSHA256:          "c0ffee",
+				EndLedgerHash:        "beef",
 			},
 			expected: map[string]string{
 				"start-ledger":            "100",
@@ -36,6 +38,9 @@ func TestMetaDataToMap(t *testing.T) {
 				"network-passphrase":      "testnet passphrase",
 				"compression-type":        "gzip",
 				"version":                 "1.0.0",
+				"batch-sha256":            "c0ffee",
+				"start-ledger-prev-hash":  "",
+				"end-ledger-hash":         "beef",
 			},
 		},
 	}
@@ -51,6 +56,8 @@ func TestMetaDataToMap(t *testing.T) {
 				NetworkPassPhrase:    tt.metaData.NetworkPassPhrase,
 				CompressionType:      tt.metaData.CompressionType,
 				Version:              tt.metaData.Version,
+				BatchSHA256:          tt.metaData.BatchSHA256,
+				EndLedgerHash:        tt.metaData.EndLedgerHash,
 			}
 			got := m.ToMap()
 			require.Equal(t, got, tt.expected)
@@ -69,18 +76,24 @@ func TestNewMetaDataFromMap(t *testing.T) {
 		"network-passphrase":      "testnet passphrase",
 		"compression-type":        "gzip",
 		"version":                 "1.0.0",
+		"batch-sha256":            "c0ffee",
+		"start-ledger-prev-hash":  "abcd",
+		"end-ledger-hash":         "beef",
 	}
 
 	expected := MetaData{
-		StartLedger:          100,
-		EndLedger:            200,
-		StartLedgerCloseTime: 123456789,
-		EndLedgerCloseTime:   987654321,
-		ProtocolVersion:      3,
-		CoreVersion:          "v1.2.3",
-		NetworkPassPhrase:    "testnet passphrase",
-		CompressionType:      "gzip",
-		Version:              "1.0.0",
+		StartLedger:             100,
+		EndLedger:               200,
+		StartLedgerCloseTime:    123456789,
+		EndLedgerCloseTime:      987654321,
+		ProtocolVersion:         3,
+		CoreVersion:             "v1.2.3",
+		NetworkPassPhrase:       "testnet passphrase",
+		CompressionType:         "gzip",
+		Version:                 "1.0.0",
+		BatchSHA256:             "c0ffee",
+		StartLedgerPreviousHash: "abcd",
+		EndLedgerHash:           "beef",
 	}
 
 	got, err := NewMetaDataFromMap(data)
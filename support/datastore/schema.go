@@ -3,6 +3,7 @@ package datastore
 import (
 	"fmt"
 	"math"
+	"strings"
 
 	"github.com/stellar/go/support/compressxdr"
 )
@@ -24,6 +25,14 @@ func (ec DataStoreSchema) GetSequenceNumberEndBoundary(ledgerSeq uint32) uint32
 	return ec.GetSequenceNumberStartBoundary(ledgerSeq) + ec.LedgersPerFile - 1
 }
 
+// GetObjectKeyPrefixFromSequenceNumber returns the object key of the file containing ledgerSeq
+// without the compression extension, e.g. "FFFFFFFE--1.xdr.". Listing this prefix finds the
+// file regardless of the codec it was written with.
+func (ec DataStoreSchema) GetObjectKeyPrefixFromSequenceNumber(ledgerSeq uint32) string {
+	objectKey := ec.GetObjectKeyFromSequenceNumber(ledgerSeq)
+	return objectKey[:strings.LastIndex(objectKey, ".xdr.")+len(".xdr.")]
+}
+
 // GetObjectKeyFromSequenceNumber generates the object key name from the ledger sequence number based on configuration.
 func (ec DataStoreSchema) GetObjectKeyFromSequenceNumber(ledgerSeq uint32) string {
 	var objectKey string
@@ -38,6 +38,14 @@ func TestGetObjectKeyFromSequenceNumber(t *testing.T) {
 	}
 }
 
+func TestGetObjectKeyPrefixFromSequenceNumber(t *testing.T) {
+	config := DataStoreSchema{FilesPerPartition: 2, LedgersPerFile: 1, FileExtension: "gz"}
+	require.Equal(t, "FFFFFFFF--0-1/FFFFFFFE--1.xdr.", config.GetObjectKeyPrefixFromSequenceNumber(1))
+
+	config = DataStoreSchema{FilesPerPartition: 1, LedgersPerFile: 10}
+	require.Equal(t, "FFFFFFFF--0-9.xdr.", config.GetObjectKeyPrefixFromSequenceNumber(5))
+}
+
 func TestGetObjectKeyFromSequenceNumber_ObjectKeyDescOrder(t *testing.T) {
 	config := DataStoreSchema{
 		LedgersPerFile:    1,
@@ -0,0 +1,177 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/streaming"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/stellar/go/support/errors"
+)
+
+type AzureBlobStorage struct {
+	ctx       context.Context
+	container *container.Client
+	prefix    string
+}
+
+// NewAzureBlobClient creates an Azure Blob Storage client for the given account. If endpoint
+// is empty the public Azure endpoint for accountName is used, otherwise endpoint is the
+// service URL, e.g. "http://127.0.0.1:10000/devstoreaccount1" for a local Azurite.
+//
+// Credentials are taken from the environment: AZURE_STORAGE_CONNECTION_STRING is used if set,
+// otherwise AZURE_STORAGE_ACCOUNT_KEY together with accountName. If neither is set the client
+// falls back to anonymous access, which works for public containers and for an endpoint
+// carrying a SAS token.
+func NewAzureBlobClient(accountName, endpoint string) (*azblob.Client, error) {
+	if connectionString := os.Getenv("AZURE_STORAGE_CONNECTION_STRING"); connectionString != "" {
+		return azblob.NewClientFromConnectionString(connectionString, nil)
+	}
+
+	if endpoint == "" {
+		if accountName == "" {
+			return nil, errors.New("azure account name or endpoint is required")
+		}
+		endpoint = fmt.Sprintf("https://%s.blob.core.windows.net/", accountName)
+	}
+
+	if accountKey := os.Getenv("AZURE_STORAGE_ACCOUNT_KEY"); accountKey != "" {
+		if accountName == "" {
+			return nil, errors.New("azure account name is required when AZURE_STORAGE_ACCOUNT_KEY is set")
+		}
+		cred, err := azblob.NewSharedKeyCredential(accountName, accountKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid azure shared key credential")
+		}
+		return azblob.NewClientWithSharedKeyCredential(endpoint, cred, nil)
+	}
+
+	log.Info("azure: no credentials found, using anonymous access")
+	return azblob.NewClientWithNoCredential(endpoint, nil)
+}
+
+func NewAzureBlobBackend(
+	ctx context.Context,
+	accountName string,
+	containerName string,
+	prefix string,
+	endpoint string,
+) (Storage, error) {
+	log.WithFields(log.Fields{
+		"account":   accountName,
+		"container": containerName,
+		"prefix":    prefix,
+		"endpoint":  endpoint,
+	}).Debug("azure: making backend")
+
+	client, err := NewAzureBlobClient(accountName, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the container exists
+	containerClient := client.ServiceClient().NewContainerClient(containerName)
+	if _, err := containerClient.GetProperties(ctx, nil); err != nil {
+		return nil, err
+	}
+
+	backend := AzureBlobStorage{
+		ctx:       ctx,
+		container: containerClient,
+		prefix:    prefix,
+	}
+	return &backend, nil
+}
+
+func (b *AzureBlobStorage) Exists(pth string) (bool, error) {
+	log.WithField("path", path.Join(b.prefix, pth)).Trace("azure: check exists")
+	_, err := b.Size(pth)
+	if err == os.ErrNotExist {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (b *AzureBlobStorage) Size(pth string) (int64, error) {
+	pth = path.Join(b.prefix, pth)
+	log.WithField("path", pth).Trace("azure: get size")
+	props, err := b.container.NewBlobClient(pth).GetProperties(b.ctx, nil)
+	if bloberror.HasCode(err, bloberror.BlobNotFound) {
+		err = os.ErrNotExist
+	}
+	if err != nil {
+		return 0, err
+	}
+	return *props.ContentLength, nil
+}
+
+func (b *AzureBlobStorage) GetFile(pth string) (io.ReadCloser, error) {
+	pth = path.Join(b.prefix, pth)
+	log.WithField("path", pth).Trace("azure: get file")
+	resp, err := b.container.NewBlobClient(pth).DownloadStream(b.ctx, nil)
+	if bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return nil, os.ErrNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (b *AzureBlobStorage) PutFile(pth string, in io.ReadCloser) error {
+	pth = path.Join(b.prefix, pth)
+	log.WithField("path", pth).Trace("azure: put file")
+	var buf bytes.Buffer
+	_, err := buf.ReadFrom(in)
+	in.Close()
+	if err != nil {
+		return err
+	}
+	_, err = b.container.NewBlockBlobClient(pth).Upload(b.ctx, streaming.NopCloser(bytes.NewReader(buf.Bytes())), nil)
+	return err
+}
+
+func (b *AzureBlobStorage) ListFiles(pth string) (chan string, chan error) {
+	prefix := path.Join(b.prefix, pth)
+	ch := make(chan string)
+	errs := make(chan error)
+
+	go func() {
+		log.WithField("path", pth).Trace("azure: list files")
+		defer close(ch)
+		defer close(errs)
+
+		pager := b.container.NewListBlobsFlatPager(&container.ListBlobsFlatOptions{Prefix: to.Ptr(prefix)})
+		for pager.More() {
+			page, err := pager.NextPage(b.ctx)
+			if err != nil {
+				errs <- err
+				return
+			}
+			for _, item := range page.Segment.BlobItems {
+				ch <- *item.Name
+			}
+		}
+	}()
+
+	return ch, errs
+}
+
+func (b *AzureBlobStorage) CanListFiles() bool {
+	log.Trace("azure: can list files")
+	return true
+}
+
+func (b *AzureBlobStorage) Close() error {
+	log.Trace("azure: close")
+	return nil
+}
@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockAzureServer is a minimal stand-in for the Blob service serving the
+// "devstoreaccount1/archive" container with path-style URLs.
+type mockAzureServer struct {
+	mu    sync.Mutex
+	blobs map[string][]byte
+}
+
+func (s *mockAzureServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pathParts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 3)
+	if len(pathParts) < 2 || pathParts[0] != "devstoreaccount1" || pathParts[1] != "archive" {
+		w.Header().Set("x-ms-error-code", "ContainerNotFound")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if len(pathParts) == 2 {
+		if r.URL.Query().Get("comp") == "list" {
+			type item struct {
+				Name string `xml:"Name"`
+			}
+			var result struct {
+				XMLName xml.Name `xml:"EnumerationResults"`
+				Blobs   []item   `xml:"Blobs>Blob"`
+			}
+			for name := range s.blobs {
+				if strings.HasPrefix(name, r.URL.Query().Get("prefix")) {
+					result.Blobs = append(result.Blobs, item{Name: name})
+				}
+			}
+			sort.Slice(result.Blobs, func(i, j int) bool { return result.Blobs[i].Name < result.Blobs[j].Name })
+			w.Header().Set("Content-Type", "application/xml")
+			_ = xml.NewEncoder(w).Encode(result)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	name := pathParts[2]
+	switch r.Method {
+	case http.MethodHead, http.MethodGet:
+		body, exists := s.blobs[name]
+		if !exists {
+			w.Header().Set("x-ms-error-code", "BlobNotFound")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(body)
+		}
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		s.blobs[name] = body
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestAzureBlobBackend(t *testing.T) {
+	server := httptest.NewServer(&mockAzureServer{blobs: map[string][]byte{
+		"history/.well-known/stellar-history.json": []byte("{}"),
+	}})
+	defer server.Close()
+
+	backend, err := ConnectBackend("azure://devstoreaccount1/archive/history", ConnectOptions{
+		AzureEndpoint: server.URL + "/devstoreaccount1",
+	})
+	require.NoError(t, err)
+	defer backend.Close()
+
+	exists, err := backend.Exists(".well-known/stellar-history.json")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = backend.Exists("missing.json")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	require.NoError(t, backend.PutFile("ledger/00/00/00/ledger-0000003f.xdr.gz", io.NopCloser(bytes.NewReader([]byte("ledger")))))
+
+	size, err := backend.Size("ledger/00/00/00/ledger-0000003f.xdr.gz")
+	require.NoError(t, err)
+	assert.Equal(t, int64(len("ledger")), size)
+
+	r, err := backend.GetFile("ledger/00/00/00/ledger-0000003f.xdr.gz")
+	require.NoError(t, err)
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, []byte("ledger"), content)
+
+	files, errs := backend.ListFiles("ledger")
+	var listed []string
+	for f := range files {
+		listed = append(listed, f)
+	}
+	require.NoError(t, <-errs)
+	assert.Equal(t, []string{"history/ledger/00/00/00/ledger-0000003f.xdr.gz"}, listed)
+}
+
+func TestAzureBlobBackendRequiresContainer(t *testing.T) {
+	_, err := ConnectBackend("azure://devstoreaccount1", ConnectOptions{})
+	require.ErrorContains(t, err, "must include a container")
+}
@@ -29,6 +29,10 @@ type ConnectOptions struct {
 	UnsignedRequests bool
 	GCSEndpoint      string
 
+	// AzureEndpoint overrides the Blob service URL derived from the account
+	// name, e.g. to point at a local Azurite instance.
+	AzureEndpoint string
+
 	// When putting file object to s3 bucket, specify the ACL for the object.
 	S3WriteACL string
 
@@ -81,6 +85,25 @@ func ConnectBackend(u string, opts ConnectOptions) (Storage, error) {
 			opts.GCSEndpoint,
 		)
 
+	case "azure":
+		// azure://<account>/<container>/<prefix>, blob names start _without_ the leading /
+		parts := strings.SplitN(strings.TrimPrefix(pth, "/"), "/", 2)
+		if parts[0] == "" {
+			err = errors.New("azure URL must include a container: azure://<account>/<container>/<prefix>")
+			break
+		}
+		prefix := ""
+		if len(parts) == 2 {
+			prefix = parts[1]
+		}
+		backend, err = NewAzureBlobBackend(
+			opts.Context,
+			parsed.Host,
+			parts[0],
+			prefix,
+			opts.AzureEndpoint,
+		)
+
 	case "file":
 		pth = path.Join(parsed.Host, pth)
 		backend = NewFilesystemStorage(pth)
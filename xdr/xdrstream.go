@@ -166,43 +166,55 @@ func (x *Stream) closeReaders() error {
 }
 
 func (x *Stream) ReadOne(in DecoderFrom) error {
+	_, err := x.ReadOneIf(in, nil)
+	return err
+}
+
+// ReadOneIf reads the next frame of the stream and decodes it into in only if
+// accept returns true for the raw XDR bytes of the frame, which allows
+// skipping the frames that are not needed without decoding them. The frame is
+// always decoded if accept is nil. Returns whether the frame was decoded.
+func (x *Stream) ReadOneIf(in DecoderFrom, accept func(frame []byte) bool) (bool, error) {
 	var nbytes uint32
 	err := binary.Read(x.reader, binary.BigEndian, &nbytes)
 	if err != nil {
 		x.reader.Close()
 		if err == io.EOF {
 			// Do not wrap io.EOF
-			return err
+			return false, err
 		}
-		return errors.Wrap(err, "binary.Read error")
+		return false, errors.Wrap(err, "binary.Read error")
 	}
 	nbytes &= 0x7fffffff
 	x.buf.Reset()
 	if nbytes == 0 {
 		x.reader.Close()
-		return io.EOF
+		return false, io.EOF
 	}
 	x.buf.Grow(int(nbytes))
 	read, err := x.buf.ReadFrom(io.LimitReader(x.reader, int64(nbytes)))
 	if err != nil {
 		x.reader.Close()
-		return err
+		return false, err
 	}
 	if read != int64(nbytes) {
 		x.reader.Close()
-		return errors.New("Read wrong number of bytes from XDR")
+		return false, errors.New("Read wrong number of bytes from XDR")
+	}
+	if accept != nil && !accept(x.buf.Bytes()) {
+		return false, nil
 	}
 
 	readi, err := x.xdrDecoder.DecodeBytes(in, x.buf.Bytes())
 	if err != nil {
 		x.reader.Close()
-		return err
+		return false, err
 	}
 	if int64(readi) != int64(nbytes) {
-		return fmt.Errorf("Unmarshalled %d bytes from XDR, expected %d)",
+		return false, fmt.Errorf("Unmarshalled %d bytes from XDR, expected %d)",
 			readi, nbytes)
 	}
-	return nil
+	return true, nil
 }
 
 // BytesRead returns the number of bytes read in the stream
@@ -115,3 +115,42 @@ func TestXdrStreamDiscard(t *testing.T) {
 	assert.NoError(t, discardStream.Close())
 	assert.NoError(t, fullStream.Close())
 }
+
+func TestXdrStreamReadOneIf(t *testing.T) {
+	account := func(balance Int64) BucketEntry {
+		return BucketEntry{
+			Type: BucketEntryTypeLiveentry,
+			LiveEntry: &LedgerEntry{
+				Data: LedgerEntryData{
+					Type: LedgerEntryTypeAccount,
+					Account: &AccountEntry{
+						AccountId: MustAddress("GC3C4AKRBQLHOJ45U4XG35ESVWRDECWO5XLDGYADO6DPR3L7KIDVUMML"),
+						Balance:   balance,
+					},
+				},
+			},
+		}
+	}
+	stream := CreateXdrStream(account(1), account(2))
+
+	var entry BucketEntry
+	var frames [][]byte
+	decoded, err := stream.ReadOneIf(&entry, func(frame []byte) bool {
+		frames = append(frames, append([]byte{}, frame...))
+		return false
+	})
+	require.NoError(t, err)
+	assert.False(t, decoded)
+	assert.Equal(t, BucketEntry{}, entry)
+	expected, err := account(1).MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{expected}, frames)
+
+	decoded, err = stream.ReadOneIf(&entry, func(frame []byte) bool { return true })
+	require.NoError(t, err)
+	assert.True(t, decoded)
+	assert.Equal(t, account(2), entry)
+
+	_, err = stream.ReadOneIf(&entry, nil)
+	assert.Equal(t, io.EOF, err)
+}